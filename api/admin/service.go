@@ -9,7 +9,6 @@ import (
 	"path"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
 
 	"github.com/luxfi/database"
@@ -62,7 +61,7 @@ type Admin struct {
 // NewService returns a new admin API service.
 // All of the fields in [config] must be set.
 func NewService(config Config) (http.Handler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	codec := json.NewCodec()
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -16,8 +16,6 @@ import (
 
 	jwt "github.com/golang-jwt/jwt/v4"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/luxfi/log"
 	"github.com/luxfi/node/utils/json"
 	"github.com/luxfi/node/utils/password"
@@ -84,7 +82,8 @@ type Auth interface {
 	CreateHandler() (http.Handler, error)
 
 	// WrapHandler wraps an http.Handler. Before passing a request to the
-	// provided handler, the auth token is authenticated.
+	// provided handler, the auth token is authenticated and its ID is
+	// recorded in the request's context. See TokenIDFromContext.
 	WrapHandler(h http.Handler) http.Handler
 }
 
@@ -199,12 +198,19 @@ func (a *auth) RevokeToken(tokenStr, pw string) error {
 }
 
 func (a *auth) AuthenticateToken(tokenStr, url string) error {
+	_, err := a.authenticateToken(tokenStr, url)
+	return err
+}
+
+// authenticateToken returns the claims of [tokenStr] if it allows access to
+// [url].
+func (a *auth) authenticateToken(tokenStr, url string) (*endpointClaims, error) {
 	a.lock.RLock()
 	defer a.lock.RUnlock()
 
 	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
 	if err != nil { // Probably because signature wrong
-		return err
+		return nil, err
 	}
 
 	// Make sure this token gives access to the requested endpoint
@@ -212,20 +218,15 @@ func (a *auth) AuthenticateToken(tokenStr, url string) error {
 	if !ok {
 		// Error is intentionally dropped here as there is nothing left to do
 		// with it.
-		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+		return nil, fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
 	}
 
 	_, revoked := a.revoked[claims.ID]
 	if revoked {
-		return errTokenRevoked
+		return nil, errTokenRevoked
 	}
 
-	for _, endpoint := range claims.Endpoints {
-		if endpoint == "*" || strings.HasSuffix(url, endpoint) {
-			return nil
-		}
-	}
-	return errTokenInsufficientPermission
+	return claims, authorizeEndpoints(claims.Endpoints, url)
 }
 
 func (a *auth) ChangePassword(oldPW, newPW string) error {
@@ -253,7 +254,7 @@ func (a *auth) ChangePassword(oldPW, newPW string) error {
 }
 
 func (a *auth) CreateHandler() (http.Handler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	codec := json.NewCodec()
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -271,6 +272,13 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 			return
 		}
 
+		// Requests authorized as a role were already authenticated, e.g. by
+		// their client certificate
+		if _, ok := RoleFromContext(r.Context()); ok {
+			h.ServeHTTP(w, r)
+			return
+		}
+
 		// Should be "Bearer AUTH.TOKEN.HERE"
 		rawHeader := r.Header.Get(headerKey)
 		if rawHeader == "" {
@@ -286,12 +294,13 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 		// Returns actual auth token. Slice guaranteed to not go OOB
 		tokenStr := rawHeader[len(headerValStart):]
 
-		if err := a.AuthenticateToken(tokenStr, r.URL.Path); err != nil {
+		claims, err := a.authenticateToken(tokenStr, r.URL.Path)
+		if err != nil {
 			writeUnauthorizedResponse(w, err)
 			return
 		}
 
-		h.ServeHTTP(w, r)
+		h.ServeHTTP(w, r.WithContext(WithTokenID(r.Context(), claims.ID)))
 	})
 }
 
@@ -159,7 +159,13 @@ func TestWrapHandlerHappyPath(t *testing.T) {
 	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
 	require.NoError(err)
 
-	wrappedHandler := auth.WrapHandler(dummyHandler)
+	var tokenIDs []string
+	wrappedHandler := auth.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenID, ok := TokenIDFromContext(r.Context())
+		require.True(ok)
+		tokenIDs = append(tokenIDs, tokenID)
+		dummyHandler.ServeHTTP(w, r)
+	}))
 
 	for _, endpoint := range endpoints {
 		req := httptest.NewRequest(http.MethodPost, hostName+endpoint, strings.NewReader(""))
@@ -168,6 +174,13 @@ func TestWrapHandlerHappyPath(t *testing.T) {
 		wrappedHandler.ServeHTTP(rr, req)
 		require.Equal(http.StatusOK, rr.Code)
 	}
+
+	// Every request is recorded with the ID of the same token
+	require.Len(tokenIDs, len(endpoints))
+	require.NotEmpty(tokenIDs[0])
+	for _, tokenID := range tokenIDs {
+		require.Equal(tokenIDs[0], tokenID)
+	}
 }
 
 func TestWrapHandlerRevokedToken(t *testing.T) {
@@ -366,3 +379,17 @@ func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
 		require.Regexp(unAuthorizedResponseRegex, rr.Body.String())
 	}
 }
+
+func TestWrapHandlerRole(t *testing.T) {
+	require := require.New(t)
+
+	auth := NewFromHash(log.NewNoOpLogger(), "auth", hashedPassword)
+	wrappedHandler := auth.WrapHandler(dummyHandler)
+
+	// Requests authorized as a role don't need a token
+	req := httptest.NewRequest(http.MethodPost, hostName+"/ext/admin", strings.NewReader(""))
+	req = req.WithContext(WithRole(req.Context(), "sidecar"))
+	rr := httptest.NewRecorder()
+	wrappedHandler.ServeHTTP(rr, req)
+	require.Equal(http.StatusOK, rr.Code)
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errUnknownRole = errors.New("unknown role")
+
+type (
+	roleKey    struct{}
+	tokenIDKey struct{}
+)
+
+// Roles maps the name of a role to the API endpoints it allows access to. As
+// with tokens, a role allows access to each API whose path ends with one of
+// its endpoints, and "*" allows access to all APIs.
+type Roles map[string][]string
+
+// Authorize returns nil if [role] allows access to [url].
+func (r Roles) Authorize(role, url string) error {
+	endpoints, ok := r[role]
+	if !ok {
+		return fmt.Errorf("%w: %q", errUnknownRole, role)
+	}
+	return authorizeEndpoints(endpoints, url)
+}
+
+// WithRole returns a copy of [ctx] recording that the request it belongs to was
+// authenticated, e.g. by a client certificate, and authorized as [role].
+// Requests with a role don't need an auth token.
+func WithRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, roleKey{}, role)
+}
+
+// RoleFromContext returns the role recorded in [ctx] by WithRole, if any.
+func RoleFromContext(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(roleKey{}).(string)
+	return role, ok
+}
+
+// WithTokenID returns a copy of [ctx] recording that the request it belongs to
+// was authenticated with the auth token whose ID is [tokenID].
+func WithTokenID(ctx context.Context, tokenID string) context.Context {
+	return context.WithValue(ctx, tokenIDKey{}, tokenID)
+}
+
+// TokenIDFromContext returns the token ID recorded in [ctx] by WithTokenID, if
+// any.
+func TokenIDFromContext(ctx context.Context) (string, bool) {
+	tokenID, ok := ctx.Value(tokenIDKey{}).(string)
+	return tokenID, ok
+}
+
+// authorizeEndpoints returns nil if one of [endpoints] allows access to [url].
+func authorizeEndpoints(endpoints []string, url string) error {
+	for _, endpoint := range endpoints {
+		if endpoint == "*" || strings.HasSuffix(url, endpoint) {
+			return nil
+		}
+	}
+	return errTokenInsufficientPermission
+}
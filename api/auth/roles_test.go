@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolesAuthorize(t *testing.T) {
+	roles := Roles{
+		"admin":   {"*"},
+		"sidecar": {"/ext/admin", "/ext/keystore"},
+	}
+
+	tests := []struct {
+		name        string
+		role        string
+		url         string
+		expectedErr error
+	}{
+		{
+			name: "wildcard",
+			role: "admin",
+			url:  "/ext/bc/X",
+		},
+		{
+			name: "allowed endpoint",
+			role: "sidecar",
+			url:  "/ext/keystore",
+		},
+		{
+			name:        "disallowed endpoint",
+			role:        "sidecar",
+			url:         "/ext/info",
+			expectedErr: errTokenInsufficientPermission,
+		},
+		{
+			name:        "unknown role",
+			role:        "unknown",
+			url:         "/ext/admin",
+			expectedErr: errUnknownRole,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := roles.Authorize(test.role, test.url)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRoleFromContext(t *testing.T) {
+	require := require.New(t)
+
+	_, ok := RoleFromContext(context.Background())
+	require.False(ok)
+
+	role, ok := RoleFromContext(WithRole(context.Background(), "sidecar"))
+	require.True(ok)
+	require.Equal("sidecar", role)
+}
+
+func TestTokenIDFromContext(t *testing.T) {
+	require := require.New(t)
+
+	_, ok := TokenIDFromContext(context.Background())
+	require.False(ok)
+
+	tokenID, ok := TokenIDFromContext(WithTokenID(context.Background(), "id"))
+	require.True(ok)
+	require.Equal("id", tokenID)
+}
@@ -28,6 +28,7 @@ type Client interface {
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Uptime(context.Context, ids.ID, ...rpc.Option) (*UptimeResponse, error)
+	GetUptimeReport(context.Context, ids.NodeID, ids.ID, ...rpc.Option) (*GetUptimeReportReply, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
 }
 
@@ -109,6 +110,15 @@ func (c *client) Uptime(ctx context.Context, subnetID ids.ID, options ...rpc.Opt
 	return res, err
 }
 
+func (c *client) GetUptimeReport(ctx context.Context, nodeID ids.NodeID, subnetID ids.ID, options ...rpc.Option) (*GetUptimeReportReply, error) {
+	res := &GetUptimeReportReply{}
+	err := c.requester.SendRequest(ctx, "info.getUptimeReport", &GetUptimeReportArgs{
+		NodeID:   nodeID,
+		SubnetID: subnetID,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID][]string, error) {
 	res := &GetVMsReply{}
 	err := c.requester.SendRequest(ctx, "info.getVMs", struct{}{}, res, options...)
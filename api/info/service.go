@@ -8,8 +8,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/netip"
+	"time"
 
-	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
 
 	"github.com/luxfi/node/chains"
@@ -72,7 +72,7 @@ func NewService(
 	network network.Network,
 	// benchlist benchlist.Manager, // benchlist package doesn't exist
 ) (http.Handler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	codec := json.NewCodec()
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -328,6 +328,76 @@ func (i *Info) Uptime(_ *http.Request, args *UptimeRequest, reply *UptimeRespons
 	return nil
 }
 
+// GetUptimeReportArgs are the arguments for calling GetUptimeReport
+type GetUptimeReportArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// if omitted, defaults to primary network
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// ConnectionInterval is a period of time during which this node was connected
+// to a validator.
+type ConnectionInterval struct {
+	Connected time.Time `json:"connected"`
+	// Omitted if this node is still connected to the validator
+	Disconnected *time.Time `json:"disconnected,omitempty"`
+}
+
+// GetUptimeReportReply are the results from calling GetUptimeReport
+type GetUptimeReportReply struct {
+	// UptimePercentage is the percent of the validator's staking period that
+	// this node observed it to be online.
+	UptimePercentage json.Float64 `json:"uptimePercentage"`
+	// UpDuration is the number of seconds that this node observed the
+	// validator to be online, as of LastUpdated.
+	UpDuration  json.Uint64 `json:"upDuration"`
+	LastUpdated time.Time   `json:"lastUpdated"`
+	// Connected is true if this node is currently connected to the validator.
+	Connected bool `json:"connected"`
+	// ConnectionIntervals are the most recent periods during which this node
+	// was connected to the validator, oldest first. They are persisted across
+	// restarts.
+	ConnectionIntervals []ConnectionInterval `json:"connectionIntervals"`
+	// UptimeRequirement is the percent of time a validator must be online to
+	// be rewarded.
+	UptimeRequirement json.Float64 `json:"uptimeRequirement"`
+	// RewardEligible is true if, based on this node's observations, the
+	// validator currently meets the uptime requirement.
+	RewardEligible bool `json:"rewardEligible"`
+}
+
+// GetUptimeReport returns this node's observations of the uptime of a
+// validator.
+func (i *Info) GetUptimeReport(_ *http.Request, args *GetUptimeReportArgs, reply *GetUptimeReportReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getUptimeReport"),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	report, err := i.networking.UptimeReport(args.NodeID, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get uptime report: %w", err)
+	}
+
+	reply.UptimePercentage = json.Float64(100 * report.UptimePercentage)
+	reply.UpDuration = json.Uint64(report.UpDuration / time.Second)
+	reply.LastUpdated = report.LastUpdated
+	reply.Connected = report.Connected
+	reply.ConnectionIntervals = make([]ConnectionInterval, len(report.ConnectionIntervals))
+	for j, interval := range report.ConnectionIntervals {
+		reply.ConnectionIntervals[j].Connected = interval.Connected
+		if !interval.Disconnected.IsZero() {
+			disconnected := interval.Disconnected
+			reply.ConnectionIntervals[j].Disconnected = &disconnected
+		}
+	}
+	reply.UptimeRequirement = json.Float64(100 * report.UptimeRequirement)
+	reply.RewardEligible = report.RewardEligible
+	return nil
+}
+
 type LP struct {
 	SupportWeight json.Uint64         `json:"supportWeight"`
 	Supporters    set.Set[ids.NodeID] `json:"supporters"`
@@ -6,12 +6,15 @@ package info
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/luxfi/mock/gomock"
 
 	"github.com/luxfi/ids"
 	"github.com/luxfi/log"
+	"github.com/luxfi/node/network"
+	"github.com/luxfi/node/utils/constants"
 	"github.com/luxfi/node/vms"
 )
 
@@ -94,3 +97,104 @@ func TestGetVMsGetAliasesFails(t *testing.T) {
 	err := resources.info.GetVMs(nil, nil, &reply)
 	require.ErrorIs(t, err, errTest)
 }
+
+type testNetwork struct {
+	network.Network
+	report network.UptimeReport
+	err    error
+}
+
+func (n *testNetwork) UptimeReport(ids.NodeID, ids.ID) (network.UptimeReport, error) {
+	return n.report, n.err
+}
+
+func TestGetUptimeReport(t *testing.T) {
+	var (
+		start        = time.Unix(1_000, 0)
+		disconnected = start.Add(time.Minute)
+		lastUpdated  = start.Add(time.Hour)
+	)
+
+	tests := []struct {
+		name          string
+		network       *testNetwork
+		expectedReply GetUptimeReportReply
+		expectedErr   error
+	}{
+		{
+			name: "network error",
+			network: &testNetwork{
+				err: errTest,
+			},
+			expectedErr: errTest,
+		},
+		{
+			name: "report",
+			network: &testNetwork{
+				report: network.UptimeReport{
+					UptimePercentage: .9,
+					UpDuration:       90 * time.Second,
+					LastUpdated:      lastUpdated,
+					Connected:        true,
+					ConnectionIntervals: []network.ConnectionInterval{
+						{Connected: start, Disconnected: disconnected},
+						{Connected: start.Add(2 * time.Minute)},
+					},
+					UptimeRequirement: .8,
+					RewardEligible:    true,
+				},
+			},
+			expectedReply: GetUptimeReportReply{
+				UptimePercentage: 90,
+				UpDuration:       90,
+				LastUpdated:      lastUpdated,
+				Connected:        true,
+				ConnectionIntervals: []ConnectionInterval{
+					{Connected: start, Disconnected: &disconnected},
+					{Connected: start.Add(2 * time.Minute)},
+				},
+				UptimeRequirement: 80,
+				RewardEligible:    true,
+			},
+		},
+		{
+			name: "no connection intervals",
+			network: &testNetwork{
+				report: network.UptimeReport{
+					LastUpdated:       lastUpdated,
+					UptimeRequirement: .8,
+				},
+			},
+			expectedReply: GetUptimeReportReply{
+				LastUpdated:         lastUpdated,
+				ConnectionIntervals: []ConnectionInterval{},
+				UptimeRequirement:   80,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			info := &Info{
+				log:        log.NewNoOpLogger(),
+				networking: test.network,
+			}
+
+			reply := GetUptimeReportReply{}
+			err := info.GetUptimeReport(
+				nil,
+				&GetUptimeReportArgs{
+					NodeID:   ids.GenerateTestNodeID(),
+					SubnetID: constants.PrimaryNetworkID,
+				},
+				&reply,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expectedReply, reply)
+		})
+	}
+}
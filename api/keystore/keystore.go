@@ -9,8 +9,6 @@ import (
 	"net/http"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/luxfi/database"
 	"github.com/luxfi/database/encdb"
 	"github.com/luxfi/database/prefixdb"
@@ -114,7 +112,7 @@ func New(log log.Logger, db database.Database) Keystore {
 }
 
 func (ks *keystore) CreateHandler() (http.Handler, error) {
-	newServer := rpc.NewServer()
+	newServer := json.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -0,0 +1,214 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package openrpc generates OpenRPC documents describing JSON-RPC services.
+//
+// See https://spec.open-rpc.org.
+package openrpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	avajson "github.com/luxfi/node/utils/json"
+)
+
+const (
+	Version = "1.2.6"
+
+	// DiscoverMethod is the method clients call to get the OpenRPC document
+	// of an endpoint.
+	DiscoverMethod = "rpc.discover"
+
+	paramStructureByName = "by-name"
+)
+
+var (
+	typeOfJSONMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	typeOfTextMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+type Document struct {
+	OpenRPC string    `json:"openrpc"`
+	Info    Info      `json:"info"`
+	Methods []*Method `json:"methods"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Method struct {
+	Name           string               `json:"name"`
+	ParamStructure string               `json:"paramStructure"`
+	Params         []*ContentDescriptor `json:"params"`
+	Result         *ContentDescriptor   `json:"result"`
+}
+
+type ContentDescriptor struct {
+	Name   string  `json:"name"`
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is the subset of JSON Schema used to describe the arguments and
+// replies of methods.
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// NewDocument returns the OpenRPC document describing [methods].
+//
+// Gorilla's JSON-RPC codec accepts params as a single object, so the params
+// of each method are the fields of its arguments.
+func NewDocument(title, version string, methods []avajson.Method) *Document {
+	doc := &Document{
+		OpenRPC: Version,
+		Info: Info{
+			Title:   title,
+			Version: version,
+		},
+		Methods: make([]*Method, len(methods)),
+	}
+	for i, method := range methods {
+		argsSchema := schemaOf(method.Args, nil)
+
+		params := make([]*ContentDescriptor, 0, len(argsSchema.Properties))
+		for _, field := range fields(method.Args) {
+			params = append(params, &ContentDescriptor{
+				Name:   field.name,
+				Schema: argsSchema.Properties[field.name],
+			})
+		}
+		doc.Methods[i] = &Method{
+			Name:           method.Name,
+			ParamStructure: paramStructureByName,
+			Params:         params,
+			Result: &ContentDescriptor{
+				Name:   "reply",
+				Schema: schemaOf(method.Reply, nil),
+			},
+		}
+	}
+	return doc
+}
+
+// schemaOf returns the schema of the JSON encoding of [t]. [visiting] contains
+// the types currently being described, so that recursive types terminate.
+func schemaOf(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// Text marshalers, such as IDs, are encoded as strings. Other types with
+	// custom encodings are described as any value.
+	ptr := reflect.PointerTo(t)
+	switch {
+	case t.Implements(typeOfTextMarshaler) || ptr.Implements(typeOfTextMarshaler):
+		return &Schema{Type: "string"}
+	case t.Implements(typeOfJSONMarshaler) || ptr.Implements(typeOfJSONMarshaler):
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are base64 encoded.
+			return &Schema{Type: "string"}
+		}
+		return &Schema{
+			Type:  "array",
+			Items: schemaOf(t.Elem(), visiting),
+		}
+	case reflect.Array:
+		return &Schema{
+			Type:  "array",
+			Items: schemaOf(t.Elem(), visiting),
+		}
+	case reflect.Map:
+		return &Schema{
+			Type:                 "object",
+			AdditionalProperties: schemaOf(t.Elem(), visiting),
+		}
+	case reflect.Struct:
+		if visiting[t] {
+			return &Schema{Type: "object"}
+		}
+		if visiting == nil {
+			visiting = make(map[reflect.Type]bool)
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		schema := &Schema{
+			Type:       "object",
+			Properties: make(map[string]*Schema),
+		}
+		for _, field := range fields(t) {
+			schema.Properties[field.name] = schemaOf(field.typ, visiting)
+		}
+		return schema
+	default:
+		return &Schema{}
+	}
+}
+
+type field struct {
+	name string
+	typ  reflect.Type
+}
+
+// fields returns the fields of the JSON encoding of [t], in order, following
+// the rules of encoding/json for tags and embedded structs.
+func fields(t reflect.Type) []field {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fs []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		fieldType := f.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if f.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			fs = append(fs, fields(fieldType)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fs = append(fs, field{
+			name: name,
+			typ:  f.Type,
+		})
+	}
+	return fs
+}
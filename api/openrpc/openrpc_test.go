@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+
+	avajson "github.com/luxfi/node/utils/json"
+)
+
+type Embedded struct {
+	Embedded string `json:"embedded"`
+}
+
+type Node struct {
+	Children []*Node `json:"children"`
+}
+
+type Args struct {
+	Embedded
+
+	ID      ids.ID            `json:"id"`
+	Amount  avajson.Uint64    `json:"amount"`
+	Bytes   []byte            `json:"bytes"`
+	Count   int               `json:"count,omitempty"`
+	Enabled bool              `json:"enabled"`
+	Labels  map[string]string `json:"labels"`
+	Ratio   float64           `json:"ratio"`
+	Time    time.Time         `json:"time"`
+	NoTag   string
+	Ignored string `json:"-"`
+	private string
+}
+
+type Reply struct {
+	Nodes []Node `json:"nodes"`
+}
+
+type service struct{}
+
+func (*service) Get(*http.Request, *Args, *Reply) error {
+	return nil
+}
+
+func TestNewDocument(t *testing.T) {
+	require := require.New(t)
+
+	server := avajson.NewServer()
+	require.NoError(server.RegisterService(&service{}, "test"))
+
+	doc := NewDocument("title", "v1.0.0", server.Methods())
+	require.Equal(Version, doc.OpenRPC)
+	require.Equal(Info{Title: "title", Version: "v1.0.0"}, doc.Info)
+	require.Len(doc.Methods, 1)
+
+	method := doc.Methods[0]
+	require.Equal("test.get", method.Name)
+	require.Equal(paramStructureByName, method.ParamStructure)
+
+	params := make(map[string]*Schema)
+	var names []string
+	for _, param := range method.Params {
+		names = append(names, param.Name)
+		params[param.Name] = param.Schema
+	}
+	require.Equal(
+		[]string{"embedded", "id", "amount", "bytes", "count", "enabled", "labels", "ratio", "time", "NoTag"},
+		names,
+	)
+	require.Equal(&Schema{Type: "string"}, params["embedded"])
+	require.Equal(&Schema{Type: "string"}, params["id"])
+	require.Equal(&Schema{}, params["amount"])
+	require.Equal(&Schema{Type: "string"}, params["bytes"])
+	require.Equal(&Schema{Type: "integer"}, params["count"])
+	require.Equal(&Schema{Type: "boolean"}, params["enabled"])
+	require.Equal(&Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, params["labels"])
+	require.Equal(&Schema{Type: "number"}, params["ratio"])
+	require.Equal(&Schema{Type: "string"}, params["time"])
+	require.Equal(&Schema{Type: "string"}, params["NoTag"])
+
+	// Recursive types terminate.
+	require.Equal("reply", method.Result.Name)
+	require.Equal(
+		&Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"nodes": {
+					Type: "array",
+					Items: &Schema{
+						Type: "object",
+						Properties: map[string]*Schema{
+							"children": {
+								Type:  "array",
+								Items: &Schema{Type: "object"},
+							},
+						},
+					},
+				},
+			},
+		},
+		method.Result.Schema,
+	)
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/luxfi/node/api/openrpc"
+	"github.com/luxfi/node/utils/units"
+	"github.com/luxfi/node/version"
+
+	avajson "github.com/luxfi/node/utils/json"
+)
+
+const (
+	jsonRPCVersion = "2.0"
+
+	// See https://www.jsonrpc.org/specification#error_object
+	parseErrorCode     = -32700
+	invalidRequestCode = -32600
+	internalErrorCode  = -32603
+
+	emptyBatchMessage = "batch must contain at least one request"
+
+	// maxRequestBodySize is the maximum size of a JSON-RPC request body,
+	// including batch requests, that is read.
+	maxRequestBodySize = 16 * units.MiB
+)
+
+type jsonRPCRequest struct {
+	Method string          `json:"method"`
+	ID     json.RawMessage `json:"id"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// wrapJSONRPC adds support for JSON-RPC batch requests of up to
+// [maxBatchSize] requests to [handler] and serves an OpenRPC document titled
+// [title] on rpc.discover. Handlers other than [avajson.Server] aren't
+// necessarily JSON-RPC handlers, so they are returned unchanged.
+func wrapJSONRPC(handler http.Handler, title string, maxBatchSize int) http.Handler {
+	server, ok := handler.(*avajson.Server)
+	if !ok {
+		return handler
+	}
+	return batchHandler(discoverHandler(server, title), maxBatchSize)
+}
+
+// discoverHandler responds to rpc.discover with the OpenRPC document of the
+// methods of [server]. Other requests are passed to [server].
+func discoverHandler(server *avajson.Server, title string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			server.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := readBody(w, r)
+		if err != nil {
+			writeJSONRPCError(w, nil, parseErrorCode, err.Error())
+			return
+		}
+
+		var req jsonRPCRequest
+		if err := json.Unmarshal(body, &req); err != nil || req.Method != openrpc.DiscoverMethod {
+			server.ServeHTTP(w, r)
+			return
+		}
+
+		// Services may be registered after the handler is added, so the
+		// document is generated on every request.
+		writeJSON(w, &jsonRPCResponse{
+			JSONRPC: jsonRPCVersion,
+			Result:  openrpc.NewDocument(title, version.Current.String(), server.Methods()),
+			ID:      req.ID,
+		})
+	})
+}
+
+// batchHandler splits JSON-RPC batch requests into individual requests, which
+// are passed to [handler] in order, and responds with an array of their
+// responses. Responses to notifications, which don't have an ID, are omitted.
+func batchHandler(handler http.Handler, maxBatchSize int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := readBody(w, r)
+		if err != nil {
+			writeJSONRPCError(w, nil, parseErrorCode, err.Error())
+			return
+		}
+		trimmed := bytes.TrimLeft(body, " \t\r\n")
+		if len(trimmed) == 0 || trimmed[0] != '[' {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		var reqs []json.RawMessage
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			writeJSONRPCError(w, nil, parseErrorCode, err.Error())
+			return
+		}
+		switch {
+		case len(reqs) == 0:
+			writeJSONRPCError(w, nil, invalidRequestCode, emptyBatchMessage)
+			return
+		case len(reqs) > maxBatchSize:
+			writeJSONRPCError(
+				w,
+				nil,
+				invalidRequestCode,
+				fmt.Sprintf("batch of %d requests exceeds the maximum of %d", len(reqs), maxBatchSize),
+			)
+			return
+		}
+
+		responses := make([]json.RawMessage, 0, len(reqs))
+		for _, req := range reqs {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(req, &fields); err != nil {
+				responses = append(responses, newJSONRPCError(nil, invalidRequestCode, err.Error()))
+				continue
+			}
+			id, hasID := fields["id"]
+
+			subReq := r.Clone(r.Context())
+			subReq.Body = io.NopCloser(bytes.NewReader(req))
+			subReq.ContentLength = int64(len(req))
+
+			recorder := newResponseRecorder()
+			handler.ServeHTTP(recorder, subReq)
+			if !hasID {
+				continue
+			}
+
+			// Errors from the handler, such as requests being rejected
+			// while the chain is bootstrapping, may not be JSON-RPC responses.
+			resp := bytes.TrimSpace(recorder.body.Bytes())
+			if !json.Valid(resp) {
+				message := fmt.Sprintf("%d %s", recorder.status, http.StatusText(recorder.status))
+				resp = newJSONRPCError(id, internalErrorCode, message)
+			}
+			responses = append(responses, resp)
+		}
+
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, responses)
+	})
+}
+
+// readBody reads the body of [r], of at most [maxRequestBodySize] bytes, and
+// replaces it, so that it can be read again.
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func newJSONRPCError(id json.RawMessage, code int, message string) json.RawMessage {
+	resp, _ := json.Marshal(&jsonRPCResponse{
+		JSONRPC: jsonRPCVersion,
+		Error: &jsonRPCError{
+			Code:    code,
+			Message: message,
+		},
+		ID: id,
+	})
+	return resp
+}
+
+func writeJSONRPCError(w http.ResponseWriter, id json.RawMessage, code int, message string) {
+	writeJSON(w, newJSONRPCError(id, code, message))
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// responseRecorder buffers the response to a request in a batch.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/api/openrpc"
+
+	avajson "github.com/luxfi/node/utils/json"
+)
+
+type EchoArgs struct {
+	Value string `json:"value"`
+}
+
+type EchoReply struct {
+	Value string `json:"value"`
+}
+
+type echoService struct{}
+
+func (*echoService) Echo(_ *http.Request, args *EchoArgs, reply *EchoReply) error {
+	reply.Value = args.Value
+	return nil
+}
+
+func newTestJSONRPCHandler(t *testing.T, maxBatchSize int) http.Handler {
+	server := avajson.NewServer()
+	server.RegisterCodec(avajson.NewCodec(), "application/json")
+	require.NoError(t, server.RegisterService(&echoService{}, "echo"))
+	return wrapJSONRPC(server, "test", maxBatchSize)
+}
+
+func serveJSONRPC(handler http.Handler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+type testResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *jsonRPCError   `json:"error"`
+	ID     json.RawMessage `json:"id"`
+}
+
+func TestJSONRPCBatch(t *testing.T) {
+	require := require.New(t)
+
+	handler := newTestJSONRPCHandler(t, 4)
+
+	// Single requests are passed through.
+	w := serveJSONRPC(handler, `{"jsonrpc":"2.0","method":"echo.echo","params":{"value":"a"},"id":1}`)
+	require.Equal(http.StatusOK, w.Code)
+	var single testResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &single))
+	require.JSONEq(`{"value":"a"}`, string(single.Result))
+
+	w = serveJSONRPC(handler, `[
+		{"jsonrpc":"2.0","method":"echo.echo","params":{"value":"a"},"id":1},
+		{"jsonrpc":"2.0","method":"echo.echo","params":{"value":"notification"}},
+		{"jsonrpc":"2.0","method":"echo.missing","params":{},"id":"b"},
+		{"jsonrpc":"2.0","method":"echo.echo","params":{"value":"c"},"id":3}
+	]`)
+	require.Equal(http.StatusOK, w.Code)
+
+	var batch []testResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &batch))
+	require.Len(batch, 3)
+
+	require.JSONEq(`1`, string(batch[0].ID))
+	require.JSONEq(`{"value":"a"}`, string(batch[0].Result))
+	require.Nil(batch[0].Error)
+
+	require.JSONEq(`"b"`, string(batch[1].ID))
+	require.NotNil(batch[1].Error)
+
+	require.JSONEq(`3`, string(batch[2].ID))
+	require.JSONEq(`{"value":"c"}`, string(batch[2].Result))
+
+	// A batch of only notifications has no response.
+	w = serveJSONRPC(handler, `[{"jsonrpc":"2.0","method":"echo.echo","params":{"value":"a"}}]`)
+	require.Equal(http.StatusNoContent, w.Code)
+	require.Empty(w.Body.Bytes())
+}
+
+func TestJSONRPCBatchInvalid(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "empty batch",
+			body:         `[]`,
+			expectedCode: invalidRequestCode,
+		},
+		{
+			name:         "too many requests",
+			body:         `[{"id":1},{"id":2},{"id":3}]`,
+			expectedCode: invalidRequestCode,
+		},
+		{
+			name:         "malformed batch",
+			body:         `[{"id":1}`,
+			expectedCode: parseErrorCode,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			handler := newTestJSONRPCHandler(t, 2)
+			w := serveJSONRPC(handler, test.body)
+
+			var resp testResponse
+			require.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+			require.NotNil(resp.Error)
+			require.Equal(test.expectedCode, resp.Error.Code)
+		})
+	}
+}
+
+func TestJSONRPCBatchNonJSONResponse(t *testing.T) {
+	require := require.New(t)
+
+	handler := batchHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}), 10)
+	w := serveJSONRPC(handler, `[{"jsonrpc":"2.0","method":"echo.echo","id":1}]`)
+
+	var batch []testResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &batch))
+	require.Len(batch, 1)
+	require.JSONEq(`1`, string(batch[0].ID))
+	require.Equal(internalErrorCode, batch[0].Error.Code)
+}
+
+func TestJSONRPCDiscover(t *testing.T) {
+	require := require.New(t)
+
+	handler := newTestJSONRPCHandler(t, 10)
+
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","method":"rpc.discover","id":1}`,
+		`[{"jsonrpc":"2.0","method":"rpc.discover","id":1}]`,
+	} {
+		w := serveJSONRPC(handler, body)
+		require.Equal(http.StatusOK, w.Code)
+
+		var resp testResponse
+		if strings.HasPrefix(body, "[") {
+			var batch []testResponse
+			require.NoError(json.Unmarshal(w.Body.Bytes(), &batch))
+			require.Len(batch, 1)
+			resp = batch[0]
+		} else {
+			require.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		}
+		require.JSONEq(`1`, string(resp.ID))
+
+		var doc openrpc.Document
+		require.NoError(json.Unmarshal(resp.Result, &doc))
+		require.Equal("test", doc.Info.Title)
+		require.Len(doc.Methods, 1)
+		require.Equal("echo.echo", doc.Methods[0].Name)
+		require.Equal("value", doc.Methods[0].Params[0].Name)
+	}
+
+}
+
+func TestJSONRPCOnlyWrapsServers(t *testing.T) {
+	require := require.New(t)
+
+	// Other handlers receive batch and rpc.discover requests unchanged.
+	handler := wrapJSONRPC(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, r.Body)
+	}), "test", 1)
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","method":"rpc.discover","id":1}`,
+		`[{"jsonrpc":"2.0","method":"echo.echo","id":1},{"jsonrpc":"2.0","method":"echo.echo","id":2}]`,
+	} {
+		w := serveJSONRPC(handler, body)
+		require.Equal(http.StatusOK, w.Code)
+		require.Equal(body, w.Body.String())
+	}
+}
+
+func TestJSONRPCMaxRequestBodySize(t *testing.T) {
+	require := require.New(t)
+
+	handler := newTestJSONRPCHandler(t, 10)
+	body := `[{"jsonrpc":"2.0","method":"echo.echo","params":{"value":"` + strings.Repeat("a", maxRequestBodySize) + `"},"id":1}]`
+	w := serveJSONRPC(handler, body)
+
+	var resp testResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(parseErrorCode, resp.Error.Code)
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/luxfi/node/api/auth"
+)
+
+var (
+	errNoClientCACerts      = errors.New("no client CA certificates provided")
+	errNotSocket            = errors.New("file exists and isn't a socket")
+	errUnknownClientRole    = errors.New("client certificate subject is mapped to an unknown role")
+	errUnknownClientSubject = errors.New("client certificate subject isn't mapped to a role")
+)
+
+// ClientAuthConfig configures mutual TLS on the HTTPS listener.
+type ClientAuthConfig struct {
+	// CACerts is a PEM bundle of the CAs that client certificates must be
+	// issued by. If empty, clients aren't asked for certificates.
+	CACerts []byte `json:"-"`
+	// Subjects maps the common name of a verified client certificate's subject
+	// to the role it is authorized as. If empty, verified clients aren't
+	// authorized as a role, so their requests are authorized like requests
+	// made without a client certificate. Otherwise, clients whose subject
+	// isn't mapped are rejected.
+	Subjects map[string]string `json:"subjects"`
+	// Roles maps the name of a role to the API endpoints it allows access to.
+	Roles auth.Roles `json:"roles"`
+}
+
+// Verify returns an error if a subject is mapped to a role that doesn't exist.
+func (c *ClientAuthConfig) Verify() error {
+	roles := make(map[string]struct{}, len(c.Roles))
+	for role := range c.Roles {
+		roles[strings.ToLower(role)] = struct{}{}
+	}
+	for subject, role := range c.Subjects {
+		if _, ok := roles[strings.ToLower(role)]; !ok {
+			return fmt.Errorf("%w: %q is mapped to %q", errUnknownClientRole, subject, role)
+		}
+	}
+	return nil
+}
+
+// NewUnixListener listens on a Unix domain socket at [path] whose file mode is
+// [perm]. A socket left at [path] by a previous run is removed first.
+func NewUnixListener(path string, perm os.FileMode) (net.Listener, error) {
+	info, err := os.Lstat(path)
+	switch {
+	case err == nil && info.Mode().Type() != fs.ModeSocket:
+		return nil, fmt.Errorf("%w: %s", errNotSocket, path)
+	case err == nil:
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// NewTLSConfig returns the config of an HTTPS listener serving [cert]. If
+// [clientAuth] has CA certificates, clients must present a certificate issued
+// by one of them.
+func NewTLSConfig(cert tls.Certificate, clientAuth ClientAuthConfig) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if len(clientAuth.CACerts) == 0 {
+		return config, nil
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(clientAuth.CACerts) {
+		return nil, errNoClientCACerts
+	}
+	config.ClientCAs = clientCAs
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+	return config, nil
+}
+
+// wrapClientAuth authorizes requests made with a verified client certificate
+// as the role its subject is mapped to. Requests made without one, e.g. over a
+// Unix domain socket, are passed through.
+func wrapClientAuth(handler http.Handler, config ClientAuthConfig) http.Handler {
+	if len(config.Subjects) == 0 {
+		return handler
+	}
+
+	// Subjects and roles are matched case-insensitively, because config files
+	// may be parsed into lowercase keys.
+	subjects := make(map[string]string, len(config.Subjects))
+	for subject, role := range config.Subjects {
+		subjects[strings.ToLower(subject)] = strings.ToLower(role)
+	}
+	roles := make(auth.Roles, len(config.Roles))
+	for role, endpoints := range config.Roles {
+		roles[strings.ToLower(role)] = endpoints
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		subject := r.TLS.VerifiedChains[0][0].Subject.CommonName
+		role, ok := subjects[strings.ToLower(subject)]
+		if !ok {
+			http.Error(w, fmt.Sprintf("%s: %q", errUnknownClientSubject, subject), http.StatusForbidden)
+			return
+		}
+		if err := roles.Authorize(role, r.URL.Path); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		handler.ServeHTTP(w, r.WithContext(auth.WithRole(r.Context(), role)))
+	})
+}
@@ -0,0 +1,210 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/api/auth"
+)
+
+func TestUnixListener(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "api.sock")
+
+	// Sockets left behind by a previous run are replaced.
+	stale, err := net.Listen("unix", path)
+	require.NoError(err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(stale.Close())
+
+	listener, err := NewUnixListener(path, 0o600)
+	require.NoError(err)
+	defer listener.Close()
+
+	info, err := os.Stat(path)
+	require.NoError(err)
+	require.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	go func() {
+		_ = http.Serve(listener, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	}()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+	resp, err := client.Get("http://unix/ext/admin")
+	require.NoError(err)
+	require.NoError(resp.Body.Close())
+	require.Equal(http.StatusOK, resp.StatusCode)
+}
+
+func TestUnixListenerNotSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.sock")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	_, err := NewUnixListener(path, 0o600)
+	require.ErrorIs(t, err, errNotSocket)
+}
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func (ca *testCA) issue(t *testing.T, commonName string, usage x509.ExtKeyUsage) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}
+}
+
+func TestClientAuth(t *testing.T) {
+	require := require.New(t)
+
+	ca := newTestCA(t)
+	config := ClientAuthConfig{
+		CACerts: ca.pem,
+		Subjects: map[string]string{
+			"sidecar": "sidecar",
+		},
+		Roles: auth.Roles{
+			"sidecar": {"/ext/admin", "/ext/keystore"},
+		},
+	}
+	require.NoError(config.Verify())
+
+	tlsConfig, err := NewTLSConfig(ca.issue(t, "127.0.0.1", x509.ExtKeyUsageServerAuth), config)
+	require.NoError(err)
+
+	handler := wrapClientAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, _ := auth.RoleFromContext(r.Context())
+		_, _ = w.Write([]byte(role))
+	}), config)
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = tlsConfig
+	server.StartTLS()
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					MinVersion:   tls.VersionTLS12,
+					RootCAs:      rootCAs,
+					Certificates: certs,
+				},
+			},
+		}
+	}
+	get := func(client *http.Client, endpoint string) (int, string) {
+		resp, err := client.Get(server.URL + endpoint)
+		require.NoError(err)
+		defer resp.Body.Close()
+
+		body := make([]byte, 64)
+		n, _ := resp.Body.Read(body)
+		return resp.StatusCode, string(body[:n])
+	}
+
+	// Clients are authorized as the role of their certificate's subject.
+	sidecar := newClient(ca.issue(t, "sidecar", x509.ExtKeyUsageClientAuth))
+	code, role := get(sidecar, "/ext/keystore")
+	require.Equal(http.StatusOK, code)
+	require.Equal("sidecar", role)
+
+	code, _ = get(sidecar, "/ext/info")
+	require.Equal(http.StatusForbidden, code)
+
+	// Clients whose subject isn't mapped to a role are rejected.
+	stranger := newClient(ca.issue(t, "stranger", x509.ExtKeyUsageClientAuth))
+	code, _ = get(stranger, "/ext/keystore")
+	require.Equal(http.StatusForbidden, code)
+
+	// Clients without a certificate can't connect.
+	_, err = newClient().Get(server.URL + "/ext/keystore")
+	require.Error(err) //nolint:forbidigo // the handshake error isn't exported
+}
+
+func TestClientAuthConfigVerify(t *testing.T) {
+	config := ClientAuthConfig{
+		Subjects: map[string]string{
+			"sidecar": "missing",
+		},
+	}
+	require.ErrorIs(t, config.Verify(), errUnknownClientRole)
+}
+
+func TestNewTLSConfigInvalidClientCAs(t *testing.T) {
+	_, err := NewTLSConfig(tls.Certificate{}, ClientAuthConfig{
+		CACerts: []byte("not a certificate"),
+	})
+	require.ErrorIs(t, err, errNoClientCACerts)
+}
@@ -15,6 +15,7 @@ type metrics struct {
 	numProcessing *prometheus.GaugeVec
 	numCalls      *prometheus.CounterVec
 	totalDuration *prometheus.GaugeVec
+	numThrottled  *prometheus.CounterVec
 }
 
 func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
@@ -40,12 +41,20 @@ func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
 			},
 			[]string{"base"},
 		),
+		numThrottled: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "calls_throttled",
+				Help: "The number of calls this API has rejected because the client exceeded its rate limit",
+			},
+			[]string{"base"},
+		),
 	}
 
 	err := errors.Join(
 		registerer.Register(m.numProcessing),
 		registerer.Register(m.numCalls),
 		registerer.Register(m.totalDuration),
+		registerer.Register(m.numThrottled),
 	)
 	return m, err
 }
@@ -67,3 +76,8 @@ func (m *metrics) wrapHandler(chainName string, handler http.Handler) http.Handl
 		handler.ServeHTTP(w, r)
 	})
 }
+
+// throttled returns a function that counts a throttled call to [base].
+func (m *metrics) throttled(base string) func() {
+	return m.numThrottled.WithLabelValues(base).Inc
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/luxfi/node/api/auth"
+	"github.com/luxfi/node/cache"
+)
+
+const defaultMaxRateLimitedClients = 10_000
+
+var errRequestTooHeavy = errors.New("request consumes more tokens than the rate limit allows")
+
+// RateLimit is a token bucket that is refilled at Rate tokens per second, up
+// to Burst tokens.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// RateLimitConfig limits the rate of requests each client can make to each
+// endpoint. Clients are identified by the role their client certificate is
+// mapped to or by the auth token they were authenticated with, and by their IP
+// otherwise.
+type RateLimitConfig struct {
+	// Default is the limit of every endpoint that isn't in Endpoints. If its
+	// rate is 0, those endpoints aren't rate limited.
+	Default RateLimit `json:"default"`
+	// Endpoints maps the path of an endpoint, e.g. "/ext/index/X/tx", to its
+	// limit. An endpoint with a rate of 0 isn't rate limited.
+	Endpoints map[string]RateLimit `json:"endpoints"`
+	// MethodWeights maps JSON-RPC methods, e.g. "platform.getUTXOs", to the
+	// number of tokens a call consumes. Other methods consume 1 token. Each
+	// request in a batch consumes tokens, and requests that consume more
+	// tokens than the burst of their endpoint are rejected.
+	MethodWeights map[string]int `json:"methodWeights"`
+	// MaxClients is the number of clients whose buckets are kept per endpoint.
+	// The least recently seen client is forgotten when it is exceeded.
+	MaxClients int `json:"maxClients"`
+}
+
+type rateLimiter struct {
+	config RateLimitConfig
+	now    func() time.Time
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	if config.MaxClients <= 0 {
+		config.MaxClients = defaultMaxRateLimitedClients
+	}
+
+	// Keys are matched case-insensitively, because config files may be
+	// parsed into lowercase keys.
+	endpoints := make(map[string]RateLimit, len(config.Endpoints))
+	for endpoint, limit := range config.Endpoints {
+		endpoints[strings.ToLower(endpoint)] = limit
+	}
+	config.Endpoints = endpoints
+	methodWeights := make(map[string]int, len(config.MethodWeights))
+	for method, weight := range config.MethodWeights {
+		methodWeights[strings.ToLower(method)] = weight
+	}
+	config.MethodWeights = methodWeights
+
+	return &rateLimiter{
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// wrapHandler limits the rate of requests to [handler], which serves
+// [endpoint]. Throttled requests are counted in [throttled].
+func (l *rateLimiter) wrapHandler(endpoint string, handler http.Handler, throttled func()) http.Handler {
+	limit, ok := l.config.Endpoints[strings.ToLower(endpoint)]
+	if !ok {
+		limit = l.config.Default
+	}
+	if limit.Rate <= 0 {
+		return handler
+	}
+	burst := max(limit.Burst, 1)
+
+	var (
+		lock     sync.Mutex
+		limiters = &cache.LRU[string, *rate.Limiter]{Size: l.config.MaxClients}
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		weight, err := l.weight(w, r)
+		if err != nil {
+			writeJSONRPCError(w, nil, parseErrorCode, err.Error())
+			return
+		}
+		// Requests heavier than the bucket would never be allowed, so they
+		// are rejected without a Retry-After header.
+		if weight > burst {
+			throttled()
+			http.Error(
+				w,
+				fmt.Sprintf("%s: %d > %d", errRequestTooHeavy, weight, burst),
+				http.StatusTooManyRequests,
+			)
+			return
+		}
+
+		client := clientID(r)
+		lock.Lock()
+		limiter, ok := limiters.Get(client)
+		if !ok {
+			limiter = rate.NewLimiter(rate.Limit(limit.Rate), burst)
+			limiters.Put(client, limiter)
+		}
+		lock.Unlock()
+
+		now := l.now()
+		reservation := limiter.ReserveN(now, weight)
+		delay := reservation.DelayFrom(now)
+		if !reservation.OK() || delay > 0 {
+			reservation.CancelAt(now)
+			throttled()
+
+			retryAfter := int(math.Ceil(delay.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// weight returns the number of tokens [r] consumes.
+func (l *rateLimiter) weight(w http.ResponseWriter, r *http.Request) (int, error) {
+	if len(l.config.MethodWeights) == 0 || r.Method != http.MethodPost {
+		return 1, nil
+	}
+
+	body, err := readBody(w, r)
+	if err != nil {
+		return 0, err
+	}
+
+	var reqs []jsonRPCRequest
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		// Invalid requests are rejected by the handler.
+		_ = json.Unmarshal(body, &reqs)
+	} else {
+		var req jsonRPCRequest
+		_ = json.Unmarshal(body, &req)
+		reqs = []jsonRPCRequest{req}
+	}
+
+	weight := 0
+	for _, req := range reqs {
+		if methodWeight, ok := l.config.MethodWeights[strings.ToLower(req.Method)]; ok {
+			weight += methodWeight
+		} else {
+			weight++
+		}
+	}
+	return max(weight, 1), nil
+}
+
+// clientID returns the role [r] was authenticated as if it has one, the ID of
+// the auth token [r] was authenticated with if it has one, and the IP [r] was
+// sent from otherwise. Only tokens that were verified before the request is
+// rate limited identify the client, so clients can't evade their limit by
+// sending made up tokens.
+func clientID(r *http.Request) string {
+	ctx := r.Context()
+	if role, ok := auth.RoleFromContext(ctx); ok {
+		return "role:" + role
+	}
+	if tokenID, ok := auth.TokenIDFromContext(ctx); ok {
+		return "token:" + tokenID
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "ip:" + r.RemoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/api/auth"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestRateLimitedHandler(config RateLimitConfig, endpoint string) (http.Handler, *testClock, *int) {
+	clock := &testClock{now: time.Unix(0, 0)}
+	limiter := newRateLimiter(config)
+	limiter.now = clock.Now
+
+	numThrottled := 0
+	handler := limiter.wrapHandler(
+		endpoint,
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+		func() { numThrottled++ },
+	)
+	return handler, clock, &numThrottled
+}
+
+func serveRateLimited(handler http.Handler, remoteAddr, role, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	if role != "" {
+		req = req.WithContext(auth.WithRole(req.Context(), role))
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	handler, clock, numThrottled := newTestRateLimitedHandler(RateLimitConfig{
+		Default: RateLimit{
+			Rate:  1,
+			Burst: 2,
+		},
+	}, "/ext/info")
+
+	// Clients are limited independently.
+	for i := 0; i < 2; i++ {
+		require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", "{}").Code)
+	}
+	w := serveRateLimited(handler, "1.1.1.1:1001", "", "{}")
+	require.Equal(http.StatusTooManyRequests, w.Code)
+	require.Equal("1", w.Header().Get("Retry-After"))
+	require.Equal(1, *numThrottled)
+
+	require.Equal(http.StatusOK, serveRateLimited(handler, "2.2.2.2:1000", "", "{}").Code)
+
+	// Authenticated clients are identified by their role rather than their IP.
+	require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "admin", "{}").Code)
+	require.Equal(http.StatusOK, serveRateLimited(handler, "3.3.3.3:1000", "admin", "{}").Code)
+	require.Equal(http.StatusTooManyRequests, serveRateLimited(handler, "4.4.4.4:1000", "admin", "{}").Code)
+	require.Equal(2, *numThrottled)
+
+	// Clients authenticated with a token are identified by the token.
+	serveWithToken := func(remoteAddr, tokenID string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+		req.RemoteAddr = remoteAddr
+		req = req.WithContext(auth.WithTokenID(req.Context(), tokenID))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+	require.Equal(http.StatusOK, serveWithToken("5.5.5.5:1000", "token"))
+	require.Equal(http.StatusOK, serveWithToken("6.6.6.6:1000", "token"))
+	require.Equal(http.StatusTooManyRequests, serveWithToken("7.7.7.7:1000", "token"))
+	require.Equal(http.StatusOK, serveWithToken("7.7.7.7:1000", "other token"))
+	require.Equal(3, *numThrottled)
+
+	// Unauthenticated bearer tokens don't change the identity of a client.
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	req.RemoteAddr = "2.2.2.2:1000"
+	req.Header.Set("Authorization", "Bearer token")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(http.StatusOK, w.Code)
+	require.Equal(http.StatusTooManyRequests, serveRateLimited(handler, "2.2.2.2:1000", "", "{}").Code)
+	require.Equal(4, *numThrottled)
+
+	// Tokens are replenished over time.
+	clock.now = clock.now.Add(time.Second)
+	require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", "{}").Code)
+	require.Equal(http.StatusTooManyRequests, serveRateLimited(handler, "1.1.1.1:1000", "", "{}").Code)
+}
+
+func TestRateLimiterMethodWeights(t *testing.T) {
+	require := require.New(t)
+
+	handler, clock, _ := newTestRateLimitedHandler(RateLimitConfig{
+		Default: RateLimit{
+			Rate:  1,
+			Burst: 10,
+		},
+		MethodWeights: map[string]int{
+			"platform.getutxos": 4,
+		},
+	}, "/ext/bc/P")
+
+	const (
+		getUTXOs  = `{"jsonrpc":"2.0","method":"platform.getUTXOs","id":1}`
+		getHeight = `{"jsonrpc":"2.0","method":"platform.getHeight","id":1}`
+	)
+
+	// 4 + 4 tokens, leaving 2.
+	require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", getUTXOs).Code)
+	require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", getUTXOs).Code)
+
+	w := serveRateLimited(handler, "1.1.1.1:1000", "", getUTXOs)
+	require.Equal(http.StatusTooManyRequests, w.Code)
+	require.Equal("2", w.Header().Get("Retry-After"))
+
+	// Throttled requests don't consume tokens.
+	require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", getHeight).Code)
+	require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", getHeight).Code)
+	require.Equal(http.StatusTooManyRequests, serveRateLimited(handler, "1.1.1.1:1000", "", getHeight).Code)
+
+	// Each request in a batch consumes tokens.
+	clock.now = clock.now.Add(5 * time.Second)
+	batch := "[" + getUTXOs + "," + getHeight + "]"
+	require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", batch).Code)
+	require.Equal(http.StatusTooManyRequests, serveRateLimited(handler, "1.1.1.1:1000", "", batch).Code)
+
+	// Requests heavier than the bucket are rejected without consuming tokens.
+	clock.now = clock.now.Add(10 * time.Second)
+	heavy := "[" + strings.Repeat(getUTXOs+",", 5) + getUTXOs + "]"
+	w = serveRateLimited(handler, "1.1.1.1:1000", "", heavy)
+	require.Equal(http.StatusTooManyRequests, w.Code)
+	require.Empty(w.Header().Get("Retry-After"))
+	require.Contains(w.Body.String(), errRequestTooHeavy.Error())
+	require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", batch).Code)
+}
+
+func TestRateLimiterEndpoints(t *testing.T) {
+	require := require.New(t)
+
+	config := RateLimitConfig{
+		Endpoints: map[string]RateLimit{
+			"/ext/index/X/tx": {
+				Rate:  1,
+				Burst: 1,
+			},
+		},
+	}
+
+	// Endpoints without a limit aren't rate limited.
+	handler, _, _ := newTestRateLimitedHandler(config, "/ext/info")
+	for i := 0; i < 10; i++ {
+		require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", "{}").Code)
+	}
+
+	handler, _, _ = newTestRateLimitedHandler(config, "/ext/index/X/tx")
+	require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", "{}").Code)
+	require.Equal(http.StatusTooManyRequests, serveRateLimited(handler, "1.1.1.1:1000", "", "{}").Code)
+}
+
+func TestRateLimiterMaxClients(t *testing.T) {
+	require := require.New(t)
+
+	handler, _, _ := newTestRateLimitedHandler(RateLimitConfig{
+		Default: RateLimit{
+			Rate:  1,
+			Burst: 1,
+		},
+		MaxClients: 1,
+	}, "/ext/info")
+
+	require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", "{}").Code)
+	require.Equal(http.StatusTooManyRequests, serveRateLimited(handler, "1.1.1.1:1000", "", "{}").Code)
+
+	// Once another client is seen, the first client is forgotten.
+	require.Equal(http.StatusOK, serveRateLimited(handler, "2.2.2.2:1000", "", "{}").Code)
+	require.Equal(http.StatusOK, serveRateLimited(handler, "1.1.1.1:1000", "", "{}").Code)
+}
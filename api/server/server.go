@@ -25,6 +25,7 @@ import (
 	"github.com/luxfi/ids"
 	"github.com/luxfi/log"
 	"github.com/luxfi/node/api"
+	"github.com/luxfi/node/api/auth"
 	"github.com/luxfi/node/utils/constants"
 	"github.com/luxfi/trace"
 )
@@ -77,6 +78,11 @@ type HTTPConfig struct {
 	ReadHeaderTimeout time.Duration `json:"readHeaderTimeout"`
 	WriteTimeout      time.Duration `json:"writeHeaderTimeout"`
 	IdleTimeout       time.Duration `json:"idleTimeout"`
+	// MaxBatchSize is the maximum number of requests in a JSON-RPC batch
+	// request.
+	MaxBatchSize int              `json:"maxBatchSize"`
+	RateLimit    RateLimitConfig  `json:"rateLimit"`
+	ClientAuth   ClientAuthConfig `json:"clientAuth"`
 }
 
 type server struct {
@@ -92,6 +98,9 @@ type server struct {
 
 	metrics *metrics
 
+	maxBatchSize int
+	rateLimiter  *rateLimiter
+
 	// Maps endpoints to handlers
 	router *router
 
@@ -100,15 +109,19 @@ type server struct {
 
 	srv *http.Server
 
-	// Listener used to serve traffic
-	listener net.Listener
+	// Listeners used to serve traffic
+	listeners []net.Listener
 }
 
 // New returns an instance of a Server.
+//
+// If [tokenAuth] isn't nil, requests that weren't authorized by a client
+// certificate must carry an auth token. Tokens are verified before requests are
+// routed, so that rate limits can be keyed by the token.
 func New(
 	log log.Logger,
 	factory log.Factory,
-	listener net.Listener,
+	listeners []net.Listener,
 	allowedOrigins []string,
 	shutdownTimeout time.Duration,
 	nodeID ids.NodeID,
@@ -117,6 +130,7 @@ func New(
 	registerer prometheus.Registerer,
 	httpConfig HTTPConfig,
 	allowedHosts []string,
+	tokenAuth auth.Auth,
 ) (Server, error) {
 	m, err := newMetrics(registerer)
 	if err != nil {
@@ -124,12 +138,16 @@ func New(
 	}
 
 	router := newRouter()
-	allowedHostsHandler := filterInvalidHosts(router, allowedHosts)
+	var routerHandler http.Handler = router
+	if tokenAuth != nil {
+		routerHandler = tokenAuth.WrapHandler(router)
+	}
+	allowedHostsHandler := filterInvalidHosts(routerHandler, allowedHosts)
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   allowedOrigins,
 		AllowCredentials: true,
 	}).Handler(allowedHostsHandler)
-	gzipHandler := gziphandler.GzipHandler(corsHandler)
+	gzipHandler := gziphandler.GzipHandler(wrapClientAuth(corsHandler, httpConfig.ClientAuth))
 	var handler http.Handler = http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			// Attach this node's ID as a header
@@ -163,14 +181,26 @@ func New(
 		tracingEnabled:  tracingEnabled,
 		tracer:          tracer,
 		metrics:         m,
+		maxBatchSize:    httpConfig.MaxBatchSize,
+		rateLimiter:     newRateLimiter(httpConfig.RateLimit),
 		router:          router,
 		srv:             httpServer,
-		listener:        listener,
+		listeners:       listeners,
 	}, nil
 }
 
 func (s *server) Dispatch() error {
-	return s.srv.Serve(s.listener)
+	errs := make(chan error, len(s.listeners))
+	for _, listener := range s.listeners {
+		go func(listener net.Listener) {
+			errs <- s.srv.Serve(listener)
+		}(listener)
+	}
+
+	// If any listener fails, stop serving on all of them.
+	err := <-errs
+	_ = s.srv.Close()
+	return err
 }
 
 func (s *server) RegisterChain(chainName string, ctx context.Context, vm core.VM) {
@@ -224,6 +254,8 @@ func (s *server) addChainRoute(chainName string, handler http.Handler, ctx conte
 		zap.String("url", url),
 		zap.String("endpoint", endpoint),
 	)
+	handler = wrapJSONRPC(handler, chainName+endpoint, s.maxBatchSize)
+	handler = s.rateLimiter.wrapHandler(url+endpoint, handler, s.metrics.throttled(chainName))
 	if s.tracingEnabled {
 		handler = api.TraceHandler(handler, chainName, s.tracer)
 	}
@@ -250,6 +282,8 @@ func (s *server) addRoute(handler http.Handler, base, endpoint string) error {
 		zap.String("endpoint", endpoint),
 	)
 
+	handler = wrapJSONRPC(handler, base+endpoint, s.maxBatchSize)
+	handler = s.rateLimiter.wrapHandler(url+endpoint, handler, s.metrics.throttled(base))
 	if s.tracingEnabled {
 		handler = api.TraceHandler(handler, url, s.tracer)
 	}
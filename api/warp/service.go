@@ -7,8 +7,6 @@ import (
 	"net/http"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
-
 	"go.uber.org/zap"
 
 	"github.com/luxfi/ids"
@@ -27,7 +25,7 @@ type Service struct {
 }
 
 func NewService(log log.Logger, chainManager chains.Manager, ipcs *warp.ChainIPCs) (http.Handler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	codec := json.NewCodec()
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
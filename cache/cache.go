@@ -41,3 +41,11 @@ type Deduplicator[K comparable, V Evictable[K]] interface {
 	// Flush removes all entries from the cache
 	Flush()
 }
+
+// AdmissionController is implemented by caches that may refuse to insert an
+// entry, to avoid evicting entries that are more likely to be accessed.
+type AdmissionController interface {
+	// Rejected returns the number of entries that were not admitted into the
+	// cache.
+	Rejected() uint64
+}
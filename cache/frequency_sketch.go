@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"hash/maphash"
+	"math/bits"
+)
+
+const (
+	sketchDepth      = 4
+	maxSketchCounter = 15
+
+	// Every row of the sketch has [sketchWidthFactor] counters per expected
+	// key, which keeps keys that are only accessed once from colliding into
+	// frequently accessed keys.
+	sketchWidthFactor = 4
+
+	minSketchWidth = 64
+	maxSketchWidth = 1 << 18
+
+	// The counters are halved once the number of increments reaches
+	// [sketchSampleFactor] times the width, so that old accesses age out.
+	sketchSampleFactor = 10
+)
+
+// frequencySketch is a count-min sketch that estimates how often keys were
+// accessed recently.
+type frequencySketch[K comparable] struct {
+	seed       maphash.Seed
+	counters   [sketchDepth][]uint8
+	mask       uint64
+	increments int
+	sampleSize int
+}
+
+// newFrequencySketch returns a sketch that accurately estimates the access
+// frequency of approximately [numKeys] keys.
+func newFrequencySketch[K comparable](numKeys int) *frequencySketch[K] {
+	width := min(max(sketchWidthFactor*numKeys, minSketchWidth), maxSketchWidth)
+	width = 1 << bits.Len(uint(width-1)) // round up to a power of 2
+
+	s := &frequencySketch[K]{
+		seed:       maphash.MakeSeed(),
+		mask:       uint64(width - 1),
+		sampleSize: sketchSampleFactor * width,
+	}
+	for i := range s.counters {
+		s.counters[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// increment records an access of [key].
+func (s *frequencySketch[K]) increment(key K) {
+	hash := maphash.Comparable(s.seed, key)
+	for i := range s.counters {
+		index := s.index(hash, i)
+		if s.counters[i][index] < maxSketchCounter {
+			s.counters[i][index]++
+		}
+	}
+
+	s.increments++
+	if s.increments >= s.sampleSize {
+		s.age()
+	}
+}
+
+// estimate returns the approximate number of recent accesses of [key].
+func (s *frequencySketch[K]) estimate(key K) uint8 {
+	hash := maphash.Comparable(s.seed, key)
+	estimate := uint8(maxSketchCounter)
+	for i := range s.counters {
+		estimate = min(estimate, s.counters[i][s.index(hash, i)])
+	}
+	return estimate
+}
+
+// reset forgets all recorded accesses.
+func (s *frequencySketch[_]) reset() {
+	for i := range s.counters {
+		clear(s.counters[i])
+	}
+	s.increments = 0
+}
+
+// age halves every counter.
+func (s *frequencySketch[_]) age() {
+	for i := range s.counters {
+		for j := range s.counters[i] {
+			s.counters[i][j] /= 2
+		}
+	}
+	s.increments /= 2
+}
+
+// sketchSeeds are added to the hash of a key to derive an independent index
+// for every row.
+var sketchSeeds = [sketchDepth]uint64{
+	0x9e3779b97f4a7c15,
+	0xc3a5c85c97cb3127,
+	0xb492b66fbe98f273,
+	0x9ae16a3b2f90404f,
+}
+
+// index returns the counter of the [row] that [hash] maps to.
+//
+// Deriving the indices of all rows from a single pair of hashes would cause
+// keys that collide in one row to collide in every row, so the hash is
+// remixed for every row.
+func (s *frequencySketch[_]) index(hash uint64, row int) uint64 {
+	// splitmix64 finalizer
+	z := hash + sketchSeeds[row]
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return z & s.mask
+}
@@ -4,6 +4,7 @@
 package metercacher
 
 import (
+	"errors"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -22,11 +23,14 @@ type Cache[K comparable, V any] struct {
 func New[K comparable, V any](
 	namespace string,
 	registerer prometheus.Registerer,
-	cache cache.Cacher[K, V],
+	cacher cache.Cacher[K, V],
 ) (*Cache[K, V], error) {
 	metrics, err := newMetrics(namespace, registerer)
+	if controller, ok := cacher.(cache.AdmissionController); ok {
+		err = errors.Join(err, registerAdmissionMetrics(namespace, registerer, controller))
+	}
 	return &Cache[K, V]{
-		Cacher:  cache,
+		Cacher:  cacher,
 		metrics: metrics,
 	}, err
 }
@@ -45,3 +45,42 @@ func TestInterface(t *testing.T) {
 		}
 	}
 }
+
+func TestAdmissionRejectedMetric(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	c, err := New("", registry, cache.NewTinyLFU[int, int](1))
+	require.NoError(err)
+
+	// With room for a single entry, the cache is entirely used by the window,
+	// so every entry pushed out of the window by a new entry is rejected.
+	for key := 0; key < 3; key++ {
+		c.Put(key, key)
+	}
+
+	metrics, err := registry.Gather()
+	require.NoError(err)
+
+	var rejected float64
+	for _, metric := range metrics {
+		if metric.GetName() == "rejected_count" {
+			rejected = metric.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	require.InDelta(2, rejected, 0)
+}
+
+func TestNoAdmissionRejectedMetric(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	_, err := New("", registry, &cache.LRU[int, int]{Size: 1})
+	require.NoError(err)
+
+	metrics, err := registry.Gather()
+	require.NoError(err)
+	for _, metric := range metrics {
+		require.NotEqual("rejected_count", metric.GetName())
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/luxfi/node/cache"
 )
 
 const (
@@ -87,3 +89,22 @@ func newMetrics(
 		reg.Register(m.portionFilled),
 	)
 }
+
+// registerAdmissionMetrics reports the number of entries that [controller]
+// refused to insert.
+func registerAdmissionMetrics(
+	namespace string,
+	reg prometheus.Registerer,
+	controller cache.AdmissionController,
+) error {
+	return reg.Register(prometheus.NewCounterFunc(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rejected_count",
+			Help:      "number of entries that were not admitted",
+		},
+		func() float64 {
+			return float64(controller.Rejected())
+		},
+	))
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// LRUPolicy evicts the least recently used entries.
+	LRUPolicy Policy = "lru"
+	// TinyLFUPolicy evicts entries using W-TinyLFU, which only admits entries
+	// that are accessed more frequently than the entries they would replace.
+	// This prevents scans from flushing frequently accessed entries.
+	TinyLFUPolicy Policy = "tinylfu"
+)
+
+var ErrUnknownPolicy = errors.New("unknown cache policy")
+
+// Policy selects the eviction policy of a cache. The empty policy is treated
+// as [LRUPolicy].
+type Policy string
+
+func (p Policy) Verify() error {
+	switch p {
+	case "", LRUPolicy, TinyLFUPolicy:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownPolicy, p)
+	}
+}
+
+// NewCacher returns a cache with [policy] that holds at most [size] entries.
+func NewCacher[K comparable, V any](policy Policy, size int) (Cacher[K, V], error) {
+	switch policy {
+	case "", LRUPolicy:
+		return &LRU[K, V]{Size: size}, nil
+	case TinyLFUPolicy:
+		return NewTinyLFU[K, V](size), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, policy)
+	}
+}
+
+// NewSizedCacher returns a cache with [policy] that holds entries with a total
+// size of at most [maxSize], as reported by [size].
+func NewSizedCacher[K comparable, V any](policy Policy, maxSize int, size func(K, V) int) (Cacher[K, V], error) {
+	switch policy {
+	case "", LRUPolicy:
+		return NewSizedLRU(maxSize, size), nil
+	case TinyLFUPolicy:
+		return NewSizedTinyLFU(maxSize, size), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, policy)
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/luxfi/node/utils"
+	"github.com/luxfi/node/utils/linked"
+)
+
+const (
+	// windowPercentage is the percentage of the cache that is used to hold
+	// recently inserted entries before they are considered for admission.
+	windowPercentage = 1
+	// protectedPercentage is the percentage of the main cache that is used to
+	// hold entries that were accessed after their admission.
+	protectedPercentage = 80
+
+	// expectedSizedEntrySize is the assumed average size of an entry in a
+	// sized cache, which is used to size its frequency sketch.
+	expectedSizedEntrySize = 256
+)
+
+var (
+	_ Cacher[struct{}, any] = (*tinyLFU[struct{}, any])(nil)
+	_ AdmissionController   = (*tinyLFU[struct{}, any])(nil)
+)
+
+type tinyLFUEntry[V any] struct {
+	value V
+	size  int
+}
+
+// tinyLFU is a key value store with bounded size that implements the W-TinyLFU
+// eviction policy.
+//
+// New entries are inserted into a small LRU window. Entries evicted from the
+// window are only admitted into the main cache if they were accessed more
+// frequently than the entries they would replace. This prevents a single scan
+// over many keys, each accessed only once, from evicting the frequently
+// accessed entries.
+//
+// The main cache is a segmented LRU. Entries are admitted into the probation
+// segment and are moved into the protected segment once they are accessed
+// again.
+type tinyLFU[K comparable, V any] struct {
+	lock   sync.Mutex
+	sketch *frequencySketch[K]
+	size   func(K, V) int
+
+	window    *linked.Hashmap[K, tinyLFUEntry[V]]
+	probation *linked.Hashmap[K, tinyLFUEntry[V]]
+	protected *linked.Hashmap[K, tinyLFUEntry[V]]
+
+	windowSize    int
+	probationSize int
+	protectedSize int
+
+	maxSize          int
+	maxWindowSize    int
+	maxProtectedSize int
+
+	rejected uint64
+}
+
+// NewTinyLFU returns a W-TinyLFU cache that holds at most [size] entries.
+func NewTinyLFU[K comparable, V any](size int) Cacher[K, V] {
+	size = max(size, 1)
+	return newTinyLFU(size, size, func(K, V) int {
+		return 1
+	})
+}
+
+// NewSizedTinyLFU returns a W-TinyLFU cache that holds entries with a total size
+// of at most [maxSize], as reported by [size].
+func NewSizedTinyLFU[K comparable, V any](maxSize int, size func(K, V) int) Cacher[K, V] {
+	maxSize = max(maxSize, 1)
+	return newTinyLFU(maxSize, maxSize/expectedSizedEntrySize, size)
+}
+
+func newTinyLFU[K comparable, V any](maxSize int, expectedLen int, size func(K, V) int) *tinyLFU[K, V] {
+	maxWindowSize := max(maxSize*windowPercentage/100, 1)
+	return &tinyLFU[K, V]{
+		sketch:           newFrequencySketch[K](expectedLen),
+		size:             size,
+		window:           linked.NewHashmap[K, tinyLFUEntry[V]](),
+		probation:        linked.NewHashmap[K, tinyLFUEntry[V]](),
+		protected:        linked.NewHashmap[K, tinyLFUEntry[V]](),
+		maxSize:          maxSize,
+		maxWindowSize:    maxWindowSize,
+		maxProtectedSize: (maxSize - maxWindowSize) * protectedPercentage / 100,
+	}
+}
+
+func (c *tinyLFU[K, V]) Put(key K, value V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value)
+}
+
+func (c *tinyLFU[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+func (c *tinyLFU[K, _]) Evict(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+func (c *tinyLFU[_, _]) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+func (c *tinyLFU[_, _]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.len()
+}
+
+func (c *tinyLFU[_, _]) PortionFilled() float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.portionFilled()
+}
+
+func (c *tinyLFU[_, _]) Rejected() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.rejected
+}
+
+func (c *tinyLFU[K, V]) put(key K, value V) {
+	c.sketch.increment(key)
+
+	newEntry := tinyLFUEntry[V]{
+		value: value,
+		size:  c.size(key, value),
+	}
+	if newEntry.size > c.maxSize {
+		c.evict(key)
+		c.rejected++
+		return
+	}
+
+	switch {
+	case c.update(c.window, &c.windowSize, key, newEntry):
+	case c.update(c.probation, &c.probationSize, key, newEntry):
+	case c.update(c.protected, &c.protectedSize, key, newEntry):
+		c.demoteProtected()
+	default:
+		c.window.Put(key, newEntry)
+		c.windowSize += newEntry.size
+	}
+
+	c.shrinkWindow()
+	c.shrinkMain()
+}
+
+// update replaces the entry of [key] in [segment], if it exists, and marks it
+// as the most recently used entry of the segment.
+func (*tinyLFU[K, V]) update(
+	segment *linked.Hashmap[K, tinyLFUEntry[V]],
+	segmentSize *int,
+	key K,
+	newEntry tinyLFUEntry[V],
+) bool {
+	oldEntry, ok := segment.Get(key)
+	if !ok {
+		return false
+	}
+	segment.Put(key, newEntry)
+	*segmentSize += newEntry.size - oldEntry.size
+	return true
+}
+
+func (c *tinyLFU[K, V]) get(key K) (V, bool) {
+	c.sketch.increment(key)
+
+	if entry, ok := c.window.Get(key); ok {
+		c.window.Put(key, entry) // Mark [key] as MRU.
+		return entry.value, true
+	}
+	if entry, ok := c.protected.Get(key); ok {
+		c.protected.Put(key, entry) // Mark [key] as MRU.
+		return entry.value, true
+	}
+	if entry, ok := c.probation.Get(key); ok {
+		// Entries accessed while on probation are protected from eviction.
+		c.probation.Delete(key)
+		c.probationSize -= entry.size
+		c.protected.Put(key, entry)
+		c.protectedSize += entry.size
+		c.demoteProtected()
+		return entry.value, true
+	}
+	return utils.Zero[V](), false
+}
+
+func (c *tinyLFU[K, _]) evict(key K) {
+	if entry, ok := c.window.Get(key); ok {
+		c.window.Delete(key)
+		c.windowSize -= entry.size
+	}
+	if entry, ok := c.probation.Get(key); ok {
+		c.probation.Delete(key)
+		c.probationSize -= entry.size
+	}
+	if entry, ok := c.protected.Get(key); ok {
+		c.protected.Delete(key)
+		c.protectedSize -= entry.size
+	}
+}
+
+func (c *tinyLFU[_, _]) flush() {
+	c.sketch.reset()
+	c.window.Clear()
+	c.probation.Clear()
+	c.protected.Clear()
+	c.windowSize = 0
+	c.probationSize = 0
+	c.protectedSize = 0
+}
+
+func (c *tinyLFU[_, _]) len() int {
+	return c.window.Len() + c.probation.Len() + c.protected.Len()
+}
+
+func (c *tinyLFU[_, _]) portionFilled() float64 {
+	return float64(c.windowSize+c.probationSize+c.protectedSize) / float64(c.maxSize)
+}
+
+// demoteProtected moves the least recently used protected entries onto
+// probation until the protected segment fits.
+func (c *tinyLFU[_, _]) demoteProtected() {
+	for c.protectedSize > c.maxProtectedSize {
+		key, entry, _ := c.protected.Oldest()
+		c.protected.Delete(key)
+		c.protectedSize -= entry.size
+		c.probation.Put(key, entry)
+		c.probationSize += entry.size
+	}
+}
+
+// mainCapacity returns the size available to the main cache, which is the
+// space not used by the window.
+func (c *tinyLFU[_, _]) mainCapacity() int {
+	return c.maxSize - c.windowSize
+}
+
+// shrinkMain evicts the least recently used main entries until the main cache
+// fits. This is only needed when an entry in the main cache grows, or when the
+// window grows into space previously used by the main cache.
+func (c *tinyLFU[_, _]) shrinkMain() {
+	for c.probationSize+c.protectedSize > c.mainCapacity() {
+		if key, entry, ok := c.probation.Oldest(); ok {
+			c.probation.Delete(key)
+			c.probationSize -= entry.size
+			continue
+		}
+		key, entry, _ := c.protected.Oldest()
+		c.protected.Delete(key)
+		c.protectedSize -= entry.size
+	}
+}
+
+// shrinkWindow evicts the least recently used window entries until the window
+// fits, admitting them into the main cache if they are accessed more
+// frequently than the entries they would replace.
+//
+// The most recently inserted entry is always kept, even if it is larger than
+// the window, so that a new entry is never rejected before it can be accessed.
+func (c *tinyLFU[_, _]) shrinkWindow() {
+	for c.windowSize > c.maxWindowSize && c.window.Len() > 1 {
+		key, entry, _ := c.window.Oldest()
+		c.window.Delete(key)
+		c.windowSize -= entry.size
+		c.admit(key, entry)
+	}
+}
+
+func (c *tinyLFU[K, V]) admit(candidateKey K, candidate tinyLFUEntry[V]) {
+	mainCapacity := c.mainCapacity()
+	if candidate.size > mainCapacity {
+		c.rejected++
+		return
+	}
+
+	// Find the least recently used entries that must be evicted to make room
+	// for the candidate. The candidate is only admitted if it was accessed
+	// more frequently than each of them.
+	var (
+		requiredSize  = c.probationSize + c.protectedSize + candidate.size - mainCapacity
+		candidateFreq = c.sketch.estimate(candidateKey)
+		victims       []K
+	)
+	for _, segment := range []*linked.Hashmap[K, tinyLFUEntry[V]]{c.probation, c.protected} {
+		it := segment.NewIterator()
+		for requiredSize > 0 && it.Next() {
+			victimKey := it.Key()
+			if c.sketch.estimate(victimKey) >= candidateFreq {
+				c.rejected++
+				return
+			}
+			victims = append(victims, victimKey)
+			requiredSize -= it.Value().size
+		}
+	}
+
+	for _, victimKey := range victims {
+		c.evict(victimKey)
+	}
+	c.probation.Put(candidateKey, candidate)
+	c.probationSize += candidate.size
+}
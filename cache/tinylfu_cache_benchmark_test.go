@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+)
+
+func BenchmarkTinyLFUCachePutSmall(b *testing.B) {
+	smallLen := 5
+	cache := NewTinyLFU[ids.ID, int](smallLen)
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < smallLen; i++ {
+			var id ids.ID
+			_, err := rand.Read(id[:])
+			require.NoError(b, err)
+			cache.Put(id, n)
+		}
+		b.StopTimer()
+		cache.Flush()
+		b.StartTimer()
+	}
+}
+
+func BenchmarkTinyLFUCachePutMedium(b *testing.B) {
+	mediumLen := 250
+	cache := NewTinyLFU[ids.ID, int](mediumLen)
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < mediumLen; i++ {
+			var id ids.ID
+			_, err := rand.Read(id[:])
+			require.NoError(b, err)
+			cache.Put(id, n)
+		}
+		b.StopTimer()
+		cache.Flush()
+		b.StartTimer()
+	}
+}
+
+func BenchmarkTinyLFUCachePutLarge(b *testing.B) {
+	largeLen := 10000
+	cache := NewTinyLFU[ids.ID, int](largeLen)
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < largeLen; i++ {
+			var id ids.ID
+			_, err := rand.Read(id[:])
+			require.NoError(b, err)
+			cache.Put(id, n)
+		}
+		b.StopTimer()
+		cache.Flush()
+		b.StartTimer()
+	}
+}
+
+// BenchmarkScanResistance measures the hit ratio of a hot set of keys that is
+// accessed while the cache is being scanned, as happens during UTXO pagination
+// or bootstrapping.
+func BenchmarkScanResistance(b *testing.B) {
+	const (
+		size       = 1024
+		numHotKeys = size / 2
+		// For every access of a hot key, [scanRate] keys are scanned, so the
+		// hot keys are pushed out of an LRU before they are accessed again.
+		scanRate = 4
+	)
+	caches := []struct {
+		name  string
+		cache func() Cacher[ids.ID, int]
+	}{
+		{
+			name: "lru",
+			cache: func() Cacher[ids.ID, int] {
+				return &LRU[ids.ID, int]{Size: size}
+			},
+		},
+		{
+			name: "tinylfu",
+			cache: func() Cacher[ids.ID, int] {
+				return NewTinyLFU[ids.ID, int](size)
+			},
+		},
+	}
+
+	hotKeys := make([]ids.ID, numHotKeys)
+	for i := range hotKeys {
+		_, err := rand.Read(hotKeys[i][:])
+		require.NoError(b, err)
+	}
+
+	for _, c := range caches {
+		b.Run(c.name, func(b *testing.B) {
+			var (
+				cache   = c.cache()
+				hotHits int
+			)
+			get := func(key ids.ID) bool {
+				if _, ok := cache.Get(key); ok {
+					return true
+				}
+				cache.Put(key, 0)
+				return false
+			}
+
+			for n := 0; n < b.N; n++ {
+				if get(hotKeys[n%numHotKeys]) {
+					hotHits++
+				}
+
+				for i := 0; i < scanRate; i++ {
+					var scannedKey ids.ID
+					_, err := rand.Read(scannedKey[:])
+					require.NoError(b, err)
+					get(scannedKey)
+				}
+			}
+			b.ReportMetric(float64(hotHits)/float64(b.N), "hot-hits/op")
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build test
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+)
+
+func TestTinyLFU(t *testing.T) {
+	cache := NewTinyLFU[ids.ID, int64](1)
+
+	TestBasic(t, cache)
+}
+
+func TestSizedTinyLFU(t *testing.T) {
+	cache := NewSizedTinyLFU[ids.ID, int64](TestIntSize, TestIntSizeFunc)
+
+	TestBasic(t, cache)
+}
+
+func TestTinyLFUScanResistance(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		size           = 100
+		numHotKeys     = 50
+		numHotAccesses = 10
+		numScanned     = 10 * size
+	)
+	cache := NewTinyLFU[int, int](size)
+
+	for i := 0; i < numHotAccesses; i++ {
+		for key := 0; key < numHotKeys; key++ {
+			if _, ok := cache.Get(key); !ok {
+				cache.Put(key, key)
+			}
+		}
+	}
+
+	// Scan over many keys that are each only accessed once, while the hot keys
+	// continue to be accessed.
+	for i := 0; i < numScanned; i++ {
+		hotKey := i % numHotKeys
+		value, ok := cache.Get(hotKey)
+		require.True(ok)
+		require.Equal(hotKey, value)
+
+		scannedKey := numHotKeys + i
+		_, ok = cache.Get(scannedKey)
+		require.False(ok)
+		cache.Put(scannedKey, scannedKey)
+	}
+
+	for key := 0; key < numHotKeys; key++ {
+		value, ok := cache.Get(key)
+		require.True(ok)
+		require.Equal(key, value)
+	}
+	require.LessOrEqual(cache.Len(), size)
+	require.Positive(cache.(AdmissionController).Rejected())
+}
+
+func TestTinyLFUAdmitsFrequentKeys(t *testing.T) {
+	require := require.New(t)
+
+	const size = 100
+	cache := NewTinyLFU[int, int](size)
+	for key := 0; key < size; key++ {
+		cache.Put(key, key)
+	}
+
+	// Once [newKey] is accessed more frequently than the cached keys, it
+	// should be admitted.
+	const newKey = size
+	for i := 0; i < 3; i++ {
+		cache.Put(newKey, newKey)
+		cache.Put(newKey+1, newKey+1) // Push [newKey] out of the window.
+	}
+
+	value, ok := cache.Get(newKey)
+	require.True(ok)
+	require.Equal(newKey, value)
+	require.Equal(size, cache.Len())
+}
+
+func TestTinyLFUEvictAndFlush(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewTinyLFU[int, int](10)
+	for key := 0; key < 10; key++ {
+		cache.Put(key, key)
+	}
+	require.Equal(10, cache.Len())
+	require.InDelta(1, cache.PortionFilled(), 0)
+
+	// Move some keys into the protected segment.
+	for key := 0; key < 5; key++ {
+		_, ok := cache.Get(key)
+		require.True(ok)
+	}
+
+	for key := 0; key < 10; key += 2 {
+		cache.Evict(key)
+	}
+	require.Equal(5, cache.Len())
+	require.InDelta(.5, cache.PortionFilled(), 0)
+	for key := 0; key < 10; key++ {
+		_, ok := cache.Get(key)
+		require.Equal(key%2 == 1, ok)
+	}
+
+	cache.Flush()
+	require.Zero(cache.Len())
+	require.Zero(cache.PortionFilled())
+	for key := 0; key < 10; key++ {
+		_, ok := cache.Get(key)
+		require.False(ok)
+	}
+}
+
+func TestSizedTinyLFUUpdate(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewSizedTinyLFU[string, string](
+		100,
+		func(key string, value string) int {
+			return len(key) + len(value)
+		},
+	)
+	for _, key := range []string{"a", "b", "c"} {
+		cache.Put(key, "")
+		_, ok := cache.Get(key)
+		require.True(ok)
+	}
+	require.InDelta(.03, cache.PortionFilled(), 0)
+
+	// Growing an entry beyond the space available to the main cache should
+	// evict the least recently used entries.
+	cache.Put("b", string(make([]byte, 98)))
+	require.InDelta(1, cache.PortionFilled(), 0)
+
+	_, ok := cache.Get("a")
+	require.False(ok)
+
+	value, ok := cache.Get("b")
+	require.True(ok)
+	require.Len(value, 98)
+
+	_, ok = cache.Get("c")
+	require.True(ok)
+
+	// Entries that are larger than the cache are never admitted.
+	cache.Put("b", string(make([]byte, 100)))
+	_, ok = cache.Get("b")
+	require.False(ok)
+	require.Equal(uint64(1), cache.(AdmissionController).Rejected())
+}
+
+func TestNewCacherUnknownPolicy(t *testing.T) {
+	require := require.New(t)
+
+	const policy Policy = "unknown"
+	require.ErrorIs(policy.Verify(), ErrUnknownPolicy)
+
+	_, err := NewCacher[int, int](policy, 1)
+	require.ErrorIs(err, ErrUnknownPolicy)
+
+	_, err = NewSizedCacher[int, int](policy, 1, func(int, int) int { return 1 })
+	require.ErrorIs(err, ErrUnknownPolicy)
+}
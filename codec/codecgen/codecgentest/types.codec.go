@@ -0,0 +1,581 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package codecgentest
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*Basic)(nil)
+	_ codec.Unmarshaler = (*Basic)(nil)
+	_ codec.Marshaler   = (*Bytes)(nil)
+	_ codec.Unmarshaler = (*Bytes)(nil)
+	_ codec.Marshaler   = (*Slices)(nil)
+	_ codec.Unmarshaler = (*Slices)(nil)
+	_ codec.Marshaler   = (*Embedded)(nil)
+	_ codec.Unmarshaler = (*Embedded)(nil)
+	_ codec.Marshaler   = (*Leaf)(nil)
+	_ codec.Unmarshaler = (*Leaf)(nil)
+	_ codec.Marshaler   = (*Node)(nil)
+	_ codec.Unmarshaler = (*Node)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*Basic) CodecType() any {
+	return (*Basic)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Basic) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.BoolLen
+	size += wrappers.ByteLen
+	size += wrappers.ByteLen
+	size += wrappers.ByteLen
+	size += wrappers.ShortLen
+	size += wrappers.ShortLen
+	size += wrappers.IntLen
+	size += wrappers.IntLen
+	size += wrappers.LongLen
+	size += wrappers.LongLen
+	size += wrappers.StringLen(v.Str)
+	size += wrappers.ShortLen
+	size += wrappers.ShortLen
+	fieldSize, err := c.Size(&v.Kind)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Basic) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackBool(v.Bool)
+	p.PackByte(v.Uint8)
+	p.PackByte(v.Byte)
+	p.PackByte(uint8(v.Int8))
+	p.PackShort(v.Uint16)
+	p.PackShort(uint16(v.Int16))
+	p.PackInt(v.Uint32)
+	p.PackInt(uint32(v.Int32))
+	p.PackLong(v.Uint64)
+	p.PackLong(uint64(v.Int64))
+	p.PackStr(v.Str)
+	p.PackShort(v.A)
+	p.PackShort(v.B)
+	if err := c.MarshalInto(&v.Kind, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Basic) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.Bool = p.UnpackBool()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Uint8 = p.UnpackByte()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Byte = p.UnpackByte()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Int8 = int8(p.UnpackByte())
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Uint16 = p.UnpackShort()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Int16 = int16(p.UnpackShort())
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Uint32 = p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Int32 = int32(p.UnpackInt())
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Uint64 = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Int64 = int64(p.UnpackLong())
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Str = p.UnpackStr()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.A = p.UnpackShort()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.B = p.UnpackShort()
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.Kind); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CodecType implements [codec.Marshaler].
+func (*Bytes) CodecType() any {
+	return (*Bytes)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Bytes) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.IntLen + len(v.Bytes)
+	size += wrappers.IntLen + len(v.JSONBytes)
+	size += len(v.Fixed)
+	size += len(v.ID)
+	size += len(v.ShortID)
+	size += len(v.NodeID)
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Bytes) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if len(v.Bytes) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Bytes), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Bytes)))
+	p.PackFixedBytes(v.Bytes)
+	if len(v.JSONBytes) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.JSONBytes), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.JSONBytes)))
+	p.PackFixedBytes(v.JSONBytes)
+	p.PackFixedBytes(v.Fixed[:])
+	p.PackFixedBytes(v.ID[:])
+	p.PackFixedBytes(v.ShortID[:])
+	p.PackFixedBytes(v.NodeID[:])
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Bytes) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	numBytes := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numBytes > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numBytes, math.MaxInt32)
+	}
+	v.Bytes = p.UnpackFixedBytes(int(numBytes))
+	if p.Err != nil {
+		return p.Err
+	}
+	numJSONBytes := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numJSONBytes > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numJSONBytes, math.MaxInt32)
+	}
+	v.JSONBytes = p.UnpackFixedBytes(int(numJSONBytes))
+	if p.Err != nil {
+		return p.Err
+	}
+	copy(v.Fixed[:], p.UnpackFixedBytes(len(v.Fixed)))
+	if p.Err != nil {
+		return p.Err
+	}
+	copy(v.ID[:], p.UnpackFixedBytes(len(v.ID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	copy(v.ShortID[:], p.UnpackFixedBytes(len(v.ShortID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	copy(v.NodeID[:], p.UnpackFixedBytes(len(v.NodeID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+// CodecType implements [codec.Marshaler].
+func (*Slices) CodecType() any {
+	return (*Slices)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Slices) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.IntLen + len(v.Uint32s)*wrappers.IntLen
+	size += wrappers.IntLen + len(v.Int64s)*wrappers.LongLen
+	size += wrappers.IntLen
+	for _, elem := range v.Strs {
+		size += wrappers.StringLen(elem)
+	}
+	size += wrappers.IntLen + len(v.Bools)*wrappers.BoolLen
+	size += wrappers.IntLen + len(v.Sigs)*len([65]byte{})
+	size += wrappers.IntLen + len(v.IDs)*len(ids.ID{})
+	fieldSize, err := c.Size(&v.ByteSlcs)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Kinds)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Basics)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Ptrs)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Verifs)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Array)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Empty)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Map)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Slices) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if len(v.Uint32s) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Uint32s), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Uint32s)))
+	for _, elem := range v.Uint32s {
+		p.PackInt(elem)
+	}
+	if len(v.Int64s) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Int64s), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Int64s)))
+	for _, elem := range v.Int64s {
+		p.PackLong(uint64(elem))
+	}
+	if len(v.Strs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Strs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Strs)))
+	for _, elem := range v.Strs {
+		p.PackStr(elem)
+	}
+	if len(v.Bools) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Bools), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Bools)))
+	for _, elem := range v.Bools {
+		p.PackBool(elem)
+	}
+	if len(v.Sigs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Sigs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Sigs)))
+	for _, elem := range v.Sigs {
+		p.PackFixedBytes(elem[:])
+	}
+	if len(v.IDs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.IDs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.IDs)))
+	for _, elem := range v.IDs {
+		p.PackFixedBytes(elem[:])
+	}
+	if err := c.MarshalInto(&v.ByteSlcs, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Kinds, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Basics, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Ptrs, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Verifs, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Array, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Empty, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Map, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Slices) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	numUint32s := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numUint32s > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numUint32s, math.MaxInt32)
+	}
+	v.Uint32s = make([]uint32, 0, min(int(numUint32s), len(p.Bytes)))
+	for i := uint32(0); i < numUint32s; i++ {
+		elem := p.UnpackInt()
+		if p.Err != nil {
+			return p.Err
+		}
+		v.Uint32s = append(v.Uint32s, elem)
+	}
+	numInt64s := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numInt64s > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numInt64s, math.MaxInt32)
+	}
+	v.Int64s = make([]int64, 0, min(int(numInt64s), len(p.Bytes)))
+	for i := uint32(0); i < numInt64s; i++ {
+		elem := int64(p.UnpackLong())
+		if p.Err != nil {
+			return p.Err
+		}
+		v.Int64s = append(v.Int64s, elem)
+	}
+	numStrs := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numStrs > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numStrs, math.MaxInt32)
+	}
+	v.Strs = make([]string, 0, min(int(numStrs), len(p.Bytes)))
+	for i := uint32(0); i < numStrs; i++ {
+		elem := p.UnpackStr()
+		if p.Err != nil {
+			return p.Err
+		}
+		v.Strs = append(v.Strs, elem)
+	}
+	numBools := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numBools > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numBools, math.MaxInt32)
+	}
+	v.Bools = make([]bool, 0, min(int(numBools), len(p.Bytes)))
+	for i := uint32(0); i < numBools; i++ {
+		elem := p.UnpackBool()
+		if p.Err != nil {
+			return p.Err
+		}
+		v.Bools = append(v.Bools, elem)
+	}
+	numSigs := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numSigs > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numSigs, math.MaxInt32)
+	}
+	v.Sigs = make([][65]byte, 0, min(int(numSigs), len(p.Bytes)))
+	for i := uint32(0); i < numSigs; i++ {
+		var elem [65]byte
+		copy(elem[:], p.UnpackFixedBytes(len(elem)))
+		if p.Err != nil {
+			return p.Err
+		}
+		v.Sigs = append(v.Sigs, elem)
+	}
+	numIDs := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numIDs > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numIDs, math.MaxInt32)
+	}
+	v.IDs = make([]ids.ID, 0, min(int(numIDs), len(p.Bytes)))
+	for i := uint32(0); i < numIDs; i++ {
+		var elem ids.ID
+		copy(elem[:], p.UnpackFixedBytes(len(elem)))
+		if p.Err != nil {
+			return p.Err
+		}
+		v.IDs = append(v.IDs, elem)
+	}
+	if err := c.UnmarshalFrom(p, &v.ByteSlcs); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Kinds); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Basics); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Ptrs); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Verifs); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Array); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Empty); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Map); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CodecType implements [codec.Marshaler].
+func (*Embedded) CodecType() any {
+	return (*Embedded)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Embedded) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.Basic)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Bytes)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Verifiable)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Embedded) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.Basic, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Bytes, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Verifiable, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Embedded) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.Basic); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Bytes); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Verifiable); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CodecType implements [codec.Marshaler].
+func (*Leaf) CodecType() any {
+	return (*Leaf)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Leaf) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.LongLen
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Leaf) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackLong(v.Value)
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Leaf) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.Value = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+// CodecType implements [codec.Marshaler].
+func (*Node) CodecType() any {
+	return (*Node)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Node) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.Children)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Node) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.Children, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Node) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.Children); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package codecgentest contains types that exercise every kind of field
+// supported by codecgen.
+package codecgentest
+
+import (
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/vms/types"
+)
+
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
+type Kind uint32
+
+type Verifiable interface {
+	Verify() error
+}
+
+type Basic struct {
+	Bool      bool   `serialize:"true"`
+	Uint8     uint8  `serialize:"true"`
+	Byte      byte   `serialize:"true"`
+	Int8      int8   `serialize:"true"`
+	Uint16    uint16 `serialize:"true"`
+	Int16     int16  `serialize:"true"`
+	Uint32    uint32 `serialize:"true"`
+	Int32     int32  `serialize:"true"`
+	Uint64    uint64 `serialize:"true"`
+	Int64     int64  `serialize:"true"`
+	Str       string `serialize:"true"`
+	A, B      uint16 `serialize:"true"`
+	Kind      Kind   `serialize:"true"`
+	Ignored   uint64
+	Unexposed uint64 `serialize:"false"`
+}
+
+type Bytes struct {
+	Bytes     []byte              `serialize:"true"`
+	JSONBytes types.JSONByteSlice `serialize:"true"`
+	Fixed     [4]byte             `serialize:"true"`
+	ID        ids.ID              `serialize:"true"`
+	ShortID   ids.ShortID         `serialize:"true"`
+	NodeID    ids.NodeID          `serialize:"true"`
+}
+
+type Slices struct {
+	Uint32s  []uint32         `serialize:"true"`
+	Int64s   []int64          `serialize:"true"`
+	Strs     []string         `serialize:"true"`
+	Bools    []bool           `serialize:"true"`
+	Sigs     [][65]byte       `serialize:"true"`
+	IDs      []ids.ID         `serialize:"true"`
+	ByteSlcs [][]byte         `serialize:"true"`
+	Kinds    []Kind           `serialize:"true"`
+	Basics   []Basic          `serialize:"true"`
+	Ptrs     []*Bytes         `serialize:"true"`
+	Verifs   []Verifiable     `serialize:"true"`
+	Array    [2]uint16        `serialize:"true"`
+	Nested   [][]codec.Codec  `serialize:"false"`
+	Empty    [0]byte          `serialize:"true"`
+	Map      map[uint32]Basic `serialize:"true"`
+}
+
+type Embedded struct {
+	Basic  `serialize:"true"`
+	*Bytes `serialize:"true"`
+
+	Verifiable Verifiable `serialize:"true"`
+}
+
+type Leaf struct {
+	Value uint64 `serialize:"true"`
+}
+
+func (*Leaf) Verify() error {
+	return nil
+}
+
+type Node struct {
+	Children []Verifiable `serialize:"true"`
+}
+
+func (*Node) Verify() error {
+	return nil
+}
+
+// RegisterTypes registers the implementations of [Verifiable].
+func RegisterTypes(r codec.Registry) error {
+	if err := r.RegisterType(&Leaf{}); err != nil {
+		return err
+	}
+	return r.RegisterType(&Node{})
+}
@@ -0,0 +1,208 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codecgentest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/codec/codectest"
+	"github.com/luxfi/node/codec/linearcodec"
+	"github.com/luxfi/node/codec/reflectcodec"
+)
+
+// promoted embeds a type with generated code, without having its own.
+type promoted struct {
+	Leaf `serialize:"true"`
+
+	Extra uint32 `serialize:"true"`
+}
+
+func newBasic() *Basic {
+	return &Basic{
+		Bool:    true,
+		Uint8:   1,
+		Byte:    2,
+		Int8:    -3,
+		Uint16:  4,
+		Int16:   -5,
+		Uint32:  6,
+		Int32:   -7,
+		Uint64:  8,
+		Int64:   -9,
+		Str:     "basic",
+		A:       10,
+		B:       11,
+		Kind:    12,
+		Ignored: 13,
+	}
+}
+
+func newBytes() *Bytes {
+	return &Bytes{
+		Bytes:     []byte{1, 2, 3},
+		JSONBytes: []byte{4, 5},
+		Fixed:     [4]byte{6, 7, 8, 9},
+		ID:        ids.GenerateTestID(),
+		ShortID:   ids.GenerateTestShortID(),
+		NodeID:    ids.GenerateTestNodeID(),
+	}
+}
+
+func newSlices() *Slices {
+	return &Slices{
+		Uint32s:  []uint32{1, 2},
+		Int64s:   []int64{-1, 1},
+		Strs:     []string{"", "slices"},
+		Bools:    []bool{true, false},
+		Sigs:     [][65]byte{{1}, {2}},
+		IDs:      []ids.ID{ids.GenerateTestID()},
+		ByteSlcs: [][]byte{{1}, {}},
+		Kinds:    []Kind{3, 4},
+		Basics:   []Basic{*newBasic()},
+		Ptrs:     []*Bytes{newBytes()},
+		Verifs: []Verifiable{
+			&Leaf{Value: 5},
+			&Node{Children: []Verifiable{&Leaf{Value: 6}}},
+		},
+		Array: [2]uint16{7, 8},
+		Map: map[uint32]Basic{
+			9: *newBasic(),
+		},
+	}
+}
+
+func newEmbedded() *Embedded {
+	return &Embedded{
+		Basic:      *newBasic(),
+		Bytes:      newBytes(),
+		Verifiable: &Leaf{Value: 1},
+	}
+}
+
+func newCodecs(t testing.TB) (codec.Manager, codec.Manager) {
+	require := require.New(t)
+
+	generatedCodec := linearcodec.NewDefault()
+	require.NoError(RegisterTypes(generatedCodec))
+	generatedManager := codec.NewDefaultManager()
+	require.NoError(generatedManager.RegisterCodec(0, generatedCodec))
+
+	reflectCodec := linearcodec.NewReflectOnly([]string{reflectcodec.DefaultTagName})
+	require.NoError(RegisterTypes(reflectCodec))
+	reflectManager := codec.NewDefaultManager()
+	require.NoError(reflectManager.RegisterCodec(0, reflectCodec))
+	return generatedManager, reflectManager
+}
+
+func TestGeneratedMatchesReflection(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		dest  func() interface{}
+	}{
+		{
+			name:  "basic",
+			value: newBasic(),
+			dest:  func() interface{} { return &Basic{} },
+		},
+		{
+			name:  "empty bytes",
+			value: &Bytes{},
+			dest:  func() interface{} { return &Bytes{} },
+		},
+		{
+			name:  "bytes",
+			value: newBytes(),
+			dest:  func() interface{} { return &Bytes{} },
+		},
+		{
+			name:  "slices",
+			value: newSlices(),
+			dest:  func() interface{} { return &Slices{} },
+		},
+		{
+			name:  "embedded",
+			value: newEmbedded(),
+			dest:  func() interface{} { return &Embedded{} },
+		},
+		{
+			name:  "promoted",
+			value: &promoted{Leaf: Leaf{Value: 1}, Extra: 2},
+			dest:  func() interface{} { return &promoted{} },
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			generatedManager, reflectManager := newCodecs(t)
+
+			reflectBytes, err := reflectManager.Marshal(0, test.value)
+			require.NoError(err)
+			generatedBytes, err := generatedManager.Marshal(0, test.value)
+			require.NoError(err)
+			require.Equal(reflectBytes, generatedBytes)
+
+			size, err := generatedManager.Size(0, test.value)
+			require.NoError(err)
+			require.Len(generatedBytes, size)
+
+			reflectValue := test.dest()
+			_, err = reflectManager.Unmarshal(reflectBytes, reflectValue)
+			require.NoError(err)
+			generatedValue := test.dest()
+			_, err = generatedManager.Unmarshal(generatedBytes, generatedValue)
+			require.NoError(err)
+			require.Equal(reflectValue, generatedValue)
+		})
+	}
+}
+
+func TestGeneratedRejectsRecursiveInterfaces(t *testing.T) {
+	require := require.New(t)
+
+	generatedManager, reflectManager := newCodecs(t)
+
+	value := &Node{
+		Children: []Verifiable{
+			&Node{},
+		},
+	}
+	_, err := reflectManager.Marshal(0, value)
+	require.NoError(err)
+	_, err = generatedManager.Marshal(0, value)
+	require.NoError(err)
+
+	value = &Node{
+		Children: []Verifiable{
+			&Node{
+				Children: []Verifiable{
+					&Node{},
+				},
+			},
+		},
+	}
+	_, reflectErr := reflectManager.Marshal(0, value)
+	require.Error(reflectErr) //nolint:forbidigo // the error is unexported
+	_, generatedErr := generatedManager.Marshal(0, value)
+	require.EqualError(generatedErr, reflectErr.Error())
+}
+
+func FuzzGenerated(f *testing.F) {
+	codectest.FuzzGenerated(
+		f,
+		func(c linearcodec.Codec) error {
+			return RegisterTypes(c)
+		},
+		newBasic(),
+		newBytes(),
+		newSlices(),
+		newEmbedded(),
+		&promoted{},
+	)
+}
@@ -0,0 +1,610 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	goExtension    = ".go"
+	codecExtension = ".codec.go"
+
+	tagName  = "serialize"
+	tagValue = "true"
+
+	codecImport    = "github.com/luxfi/node/codec"
+	wrappersImport = "github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	errNonGoFile           = errors.New("file must be a non-test go file")
+	errUnexportedField     = errors.New("serialized field is unexported")
+	errUnknownPackage      = errors.New("unknown package")
+	errUnsupportedEmbedded = errors.New("unsupported embedded field")
+
+	// basicTypes maps the supported basic types to the [wrappers.Packer]
+	// method suffix and the size constant used to serialize them.
+	basicTypes = map[string]basicType{
+		"bool":   {method: "Bool", size: "wrappers.BoolLen", unsigned: "bool"},
+		"uint8":  {method: "Byte", size: "wrappers.ByteLen", unsigned: "uint8"},
+		"byte":   {method: "Byte", size: "wrappers.ByteLen", unsigned: "uint8"},
+		"int8":   {method: "Byte", size: "wrappers.ByteLen", unsigned: "uint8"},
+		"uint16": {method: "Short", size: "wrappers.ShortLen", unsigned: "uint16"},
+		"int16":  {method: "Short", size: "wrappers.ShortLen", unsigned: "uint16"},
+		"uint32": {method: "Int", size: "wrappers.IntLen", unsigned: "uint32"},
+		"int32":  {method: "Int", size: "wrappers.IntLen", unsigned: "uint32"},
+		"uint64": {method: "Long", size: "wrappers.LongLen", unsigned: "uint64"},
+		"int64":  {method: "Long", size: "wrappers.LongLen", unsigned: "uint64"},
+		"string": {method: "Str", unsigned: "string"},
+	}
+
+	// knownFixedBytes are types, declared in other packages, whose underlying
+	// type is a byte array.
+	knownFixedBytes = map[string]map[string]bool{
+		"github.com/luxfi/ids": {
+			"ID":      true,
+			"ShortID": true,
+			"NodeID":  true,
+		},
+	}
+
+	// knownBytes are types, declared in other packages, whose underlying type
+	// is a byte slice.
+	knownBytes = map[string]map[string]bool{
+		"github.com/luxfi/node/vms/types": {
+			"JSONByteSlice": true,
+		},
+	}
+)
+
+type basicType struct {
+	// method is the suffix of the Pack and Unpack methods
+	method string
+	// size is the constant size of the type, or empty if it isn't constant
+	size string
+	// unsigned is the type the Pack and Unpack methods operate on
+	unsigned string
+}
+
+type fieldKind int
+
+const (
+	// kindBasic is a basic type
+	kindBasic fieldKind = iota
+	// kindBytes is a byte slice
+	kindBytes
+	// kindFixedBytes is a byte array
+	kindFixedBytes
+	// kindSlice is a slice of basic types or byte arrays
+	kindSlice
+	// kindCodec is any other type, which is serialized by the codec
+	kindCodec
+)
+
+type field struct {
+	name string
+	kind fieldKind
+	// goType is the go type of the field
+	goType string
+	// basic is the type of the field, or of its elements if it is a slice of
+	// basic types
+	basic basicType
+	// elemType is the go type of the elements of a slice
+	elemType string
+	// elemFixedBytes is true if the elements of a slice are byte arrays
+	elemFixedBytes bool
+}
+
+type message struct {
+	name   string
+	fields []field
+}
+
+type fileParser struct {
+	fs      *token.FileSet
+	imports map[string]string // package name -> import path
+	used    map[string]bool   // import paths used by the generated code
+}
+
+// generate writes the generated codec implementation of every struct declared
+// in [inputFilePath] that has serialized fields.
+func generate(inputFilePath string) error {
+	if !strings.HasSuffix(inputFilePath, goExtension) ||
+		strings.HasSuffix(inputFilePath, "_test"+goExtension) ||
+		strings.HasSuffix(inputFilePath, codecExtension) {
+		return fmt.Errorf("%w: %s", errNonGoFile, inputFilePath)
+	}
+
+	fs := token.NewFileSet()
+	f, err := parser.ParseFile(fs, inputFilePath, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	p := &fileParser{
+		fs:      fs,
+		imports: make(map[string]string),
+		used:    make(map[string]bool),
+	}
+	for _, spec := range f.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return err
+		}
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		p.imports[name] = importPath
+	}
+
+	messages, err := p.parseMessages(f)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	source, err := p.generate(f.Name.Name, messages)
+	if err != nil {
+		return err
+	}
+
+	outputFilePath := strings.TrimSuffix(inputFilePath, goExtension) + codecExtension
+	return os.WriteFile(outputFilePath, source, 0o600)
+}
+
+func (p *fileParser) parseMessages(f *ast.File) ([]message, error) {
+	var messages []message
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || typeSpec.TypeParams != nil {
+				continue
+			}
+
+			m, err := p.parseMessage(typeSpec.Name.Name, structType)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", typeSpec.Name.Name, err)
+			}
+			if len(m.fields) != 0 {
+				messages = append(messages, m)
+			}
+		}
+	}
+	return messages, nil
+}
+
+func (p *fileParser) parseMessage(name string, structType *ast.StructType) (message, error) {
+	m := message{name: name}
+	for _, astField := range structType.Fields.List {
+		if !isSerialized(astField) {
+			continue
+		}
+
+		names := make([]string, 0, len(astField.Names))
+		for _, ident := range astField.Names {
+			names = append(names, ident.Name)
+		}
+		if len(names) == 0 {
+			embeddedName, err := embeddedFieldName(astField.Type)
+			if err != nil {
+				return message{}, err
+			}
+			names = append(names, embeddedName)
+		}
+
+		for _, fieldName := range names {
+			if !ast.IsExported(fieldName) {
+				return message{}, fmt.Errorf("%w: %s", errUnexportedField, fieldName)
+			}
+
+			f, err := p.parseField(fieldName, astField.Type)
+			if err != nil {
+				return message{}, err
+			}
+			m.fields = append(m.fields, f)
+		}
+	}
+	return m, nil
+}
+
+func isSerialized(astField *ast.Field) bool {
+	if astField.Tag == nil {
+		return false
+	}
+	tag, err := strconv.Unquote(astField.Tag.Value)
+	if err != nil {
+		return false
+	}
+	return reflect.StructTag(tag).Get(tagName) == tagValue
+}
+
+func embeddedFieldName(expr ast.Expr) (string, error) {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name, nil
+	case *ast.SelectorExpr:
+		return expr.Sel.Name, nil
+	case *ast.StarExpr:
+		return embeddedFieldName(expr.X)
+	default:
+		return "", fmt.Errorf("%w: %T", errUnsupportedEmbedded, expr)
+	}
+}
+
+func (p *fileParser) parseField(name string, expr ast.Expr) (field, error) {
+	goType, err := p.typeString(expr)
+	if err != nil {
+		return field{}, err
+	}
+
+	f := field{
+		name:   name,
+		kind:   kindCodec,
+		goType: goType,
+	}
+	switch {
+	case p.isBasic(expr):
+		f.kind = kindBasic
+		f.basic = basicTypes[expr.(*ast.Ident).Name]
+	case p.isBytes(expr):
+		f.kind = kindBytes
+	case p.isFixedBytes(expr):
+		f.kind = kindFixedBytes
+	default:
+		arrayType, ok := expr.(*ast.ArrayType)
+		if !ok || arrayType.Len != nil {
+			break
+		}
+
+		elemType, err := p.typeString(arrayType.Elt)
+		if err != nil {
+			return field{}, err
+		}
+		switch {
+		case p.isBasic(arrayType.Elt):
+			f.kind = kindSlice
+			f.basic = basicTypes[arrayType.Elt.(*ast.Ident).Name]
+			f.elemType = elemType
+		case p.isFixedBytes(arrayType.Elt):
+			f.kind = kindSlice
+			f.elemType = elemType
+			f.elemFixedBytes = true
+		}
+	}
+
+	if f.kind == kindSlice {
+		p.markUsed(expr)
+	}
+	return f, nil
+}
+
+func (*fileParser) isBasic(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	_, ok = basicTypes[ident.Name]
+	return ok
+}
+
+func (p *fileParser) isBytes(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.ArrayType:
+		return expr.Len == nil && isByte(expr.Elt)
+	case *ast.SelectorExpr:
+		return p.isKnown(knownBytes, expr)
+	default:
+		return false
+	}
+}
+
+func (p *fileParser) isFixedBytes(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.ArrayType:
+		if expr.Len == nil || !isByte(expr.Elt) {
+			return false
+		}
+		// Arrays of zero length values are rejected by the codec.
+		lit, ok := expr.Len.(*ast.BasicLit)
+		return !ok || lit.Value != "0"
+	case *ast.SelectorExpr:
+		return p.isKnown(knownFixedBytes, expr)
+	default:
+		return false
+	}
+}
+
+func (p *fileParser) isKnown(known map[string]map[string]bool, expr *ast.SelectorExpr) bool {
+	pkg, ok := expr.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	return known[p.imports[pkg.Name]][expr.Sel.Name]
+}
+
+func isByte(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && (ident.Name == "byte" || ident.Name == "uint8")
+}
+
+// typeString returns the go source of [expr].
+func (p *fileParser) typeString(expr ast.Expr) (string, error) {
+	var b strings.Builder
+	if err := printer.Fprint(&b, p.fs, expr); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// markUsed records the packages referenced by [expr], which is referenced by
+// the generated code.
+func (p *fileParser) markUsed(expr ast.Expr) {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		selector, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if pkg, ok := selector.X.(*ast.Ident); ok {
+			p.used[pkg.Name] = true
+		}
+		return false
+	})
+}
+
+func (p *fileParser) generate(packageName string, messages []message) ([]byte, error) {
+	var (
+		body     bytes.Buffer
+		useMath  bool
+		usedPkgs = map[string]string{
+			codecImport:    "",
+			wrappersImport: "",
+		}
+	)
+	for _, m := range messages {
+		for _, f := range m.fields {
+			if f.kind == kindBytes || f.kind == kindSlice {
+				useMath = true
+			}
+		}
+		writeMessage(&body, m)
+	}
+	for name := range p.used {
+		importPath, ok := p.imports[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errUnknownPackage, name)
+		}
+		alias := ""
+		if path.Base(importPath) != name {
+			alias = name
+		}
+		usedPkgs[importPath] = alias
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by codecgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", packageName)
+	out.WriteString("import (\n")
+	if useMath {
+		out.WriteString("\t\"fmt\"\n\t\"math\"\n\n")
+	}
+	importPaths := make([]string, 0, len(usedPkgs))
+	for importPath := range usedPkgs {
+		importPaths = append(importPaths, importPath)
+	}
+	slices.Sort(importPaths)
+	for _, importPath := range importPaths {
+		if alias := usedPkgs[importPath]; alias != "" {
+			fmt.Fprintf(&out, "\t%s %q\n", alias, importPath)
+			continue
+		}
+		fmt.Fprintf(&out, "\t%q\n", importPath)
+	}
+	out.WriteString(")\n\n")
+
+	out.WriteString("var (\n")
+	for _, m := range messages {
+		fmt.Fprintf(&out, "\t_ codec.Marshaler   = (*%s)(nil)\n", m.name)
+		fmt.Fprintf(&out, "\t_ codec.Unmarshaler = (*%s)(nil)\n", m.name)
+	}
+	out.WriteString(")\n")
+	out.Write(body.Bytes())
+
+	return format.Source(out.Bytes())
+}
+
+func writeMessage(w *bytes.Buffer, m message) {
+	writeType(w, m)
+	writeSize(w, m)
+	writeMarshal(w, m)
+	writeUnmarshal(w, m)
+}
+
+func writeType(w *bytes.Buffer, m message) {
+	fmt.Fprintf(w, "\n// CodecType implements [codec.Marshaler].\n")
+	fmt.Fprintf(w, "func (*%s) CodecType() any {\n", m.name)
+	fmt.Fprintf(w, "return (*%s)(nil)\n", m.name)
+	w.WriteString("}\n")
+}
+
+func writeSize(w *bytes.Buffer, m message) {
+	fmt.Fprintf(w, "\n// CodecSize implements [codec.Marshaler].\n")
+	fmt.Fprintf(w, "func (v *%s) CodecSize(c codec.Codec) (int, error) {\n", m.name)
+	w.WriteString("size := 0\n")
+	declaredErr := false
+	for _, f := range m.fields {
+		switch f.kind {
+		case kindBasic:
+			if f.basic.size != "" {
+				fmt.Fprintf(w, "size += %s\n", f.basic.size)
+			} else {
+				fmt.Fprintf(w, "size += wrappers.StringLen(v.%s)\n", f.name)
+			}
+		case kindBytes:
+			fmt.Fprintf(w, "size += wrappers.IntLen + len(v.%s)\n", f.name)
+		case kindFixedBytes:
+			fmt.Fprintf(w, "size += len(v.%s)\n", f.name)
+		case kindSlice:
+			switch {
+			case f.elemFixedBytes:
+				fmt.Fprintf(w, "size += wrappers.IntLen + len(v.%s)*len(%s{})\n", f.name, f.elemType)
+			case f.basic.size != "":
+				fmt.Fprintf(w, "size += wrappers.IntLen + len(v.%s)*%s\n", f.name, f.basic.size)
+			default:
+				w.WriteString("size += wrappers.IntLen\n")
+				fmt.Fprintf(w, "for _, elem := range v.%s {\n", f.name)
+				w.WriteString("size += wrappers.StringLen(elem)\n")
+				w.WriteString("}\n")
+			}
+		case kindCodec:
+			assign := "="
+			if !declaredErr {
+				assign = ":="
+				declaredErr = true
+			}
+			fmt.Fprintf(w, "fieldSize, err %s c.Size(&v.%s)\n", assign, f.name)
+			w.WriteString("if err != nil {\n")
+			w.WriteString("return 0, err\n")
+			w.WriteString("}\n")
+			w.WriteString("size += fieldSize\n")
+		}
+	}
+	w.WriteString("return size, nil\n")
+	w.WriteString("}\n")
+}
+
+func writeMarshal(w *bytes.Buffer, m message) {
+	fmt.Fprintf(w, "\n// MarshalCodec implements [codec.Marshaler].\n")
+	fmt.Fprintf(w, "func (v *%s) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {\n", m.name)
+	for _, f := range m.fields {
+		switch f.kind {
+		case kindBasic:
+			fmt.Fprintf(w, "p.Pack%s(%s)\n", f.basic.method, convert(f.basic, "v."+f.name, f.goType))
+		case kindBytes:
+			writeMarshalLen(w, f.name)
+			fmt.Fprintf(w, "p.PackFixedBytes(v.%s)\n", f.name)
+		case kindFixedBytes:
+			fmt.Fprintf(w, "p.PackFixedBytes(v.%s[:])\n", f.name)
+		case kindSlice:
+			writeMarshalLen(w, f.name)
+			fmt.Fprintf(w, "for _, elem := range v.%s {\n", f.name)
+			if f.elemFixedBytes {
+				w.WriteString("p.PackFixedBytes(elem[:])\n")
+			} else {
+				fmt.Fprintf(w, "p.Pack%s(%s)\n", f.basic.method, convert(f.basic, "elem", f.elemType))
+			}
+			w.WriteString("}\n")
+		case kindCodec:
+			fmt.Fprintf(w, "if err := c.MarshalInto(&v.%s, p); err != nil {\n", f.name)
+			w.WriteString("return err\n")
+			w.WriteString("}\n")
+		}
+	}
+	w.WriteString("return p.Err\n")
+	w.WriteString("}\n")
+}
+
+func writeMarshalLen(w *bytes.Buffer, name string) {
+	fmt.Fprintf(w, "if len(v.%s) > math.MaxInt32 {\n", name)
+	fmt.Fprintf(w, "return fmt.Errorf(\"%%w; slice length, %%d, exceeds maximum length, %%d\", codec.ErrMaxSliceLenExceeded, len(v.%s), math.MaxInt32)\n", name)
+	w.WriteString("}\n")
+	fmt.Fprintf(w, "p.PackInt(uint32(len(v.%s)))\n", name)
+}
+
+func writeUnmarshal(w *bytes.Buffer, m message) {
+	fmt.Fprintf(w, "\n// UnmarshalCodec implements [codec.Unmarshaler].\n")
+	fmt.Fprintf(w, "func (v *%s) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {\n", m.name)
+	for _, f := range m.fields {
+		switch f.kind {
+		case kindBasic:
+			fmt.Fprintf(w, "v.%s = %s\n", f.name, unpack(f.basic, f.goType))
+			writeCheckErr(w)
+		case kindBytes:
+			lenName := writeUnmarshalLen(w, f.name)
+			fmt.Fprintf(w, "v.%s = p.UnpackFixedBytes(int(%s))\n", f.name, lenName)
+			writeCheckErr(w)
+		case kindFixedBytes:
+			fmt.Fprintf(w, "copy(v.%s[:], p.UnpackFixedBytes(len(v.%s)))\n", f.name, f.name)
+			writeCheckErr(w)
+		case kindSlice:
+			lenName := writeUnmarshalLen(w, f.name)
+			// Every element is at least one byte, so the capacity is bounded
+			// by the size of the input.
+			fmt.Fprintf(w, "v.%s = make([]%s, 0, min(int(%s), len(p.Bytes)))\n", f.name, f.elemType, lenName)
+			fmt.Fprintf(w, "for i := uint32(0); i < %s; i++ {\n", lenName)
+			if f.elemFixedBytes {
+				fmt.Fprintf(w, "var elem %s\n", f.elemType)
+				w.WriteString("copy(elem[:], p.UnpackFixedBytes(len(elem)))\n")
+			} else {
+				fmt.Fprintf(w, "elem := %s\n", unpack(f.basic, f.elemType))
+			}
+			writeCheckErr(w)
+			fmt.Fprintf(w, "v.%s = append(v.%s, elem)\n", f.name, f.name)
+			w.WriteString("}\n")
+		case kindCodec:
+			fmt.Fprintf(w, "if err := c.UnmarshalFrom(p, &v.%s); err != nil {\n", f.name)
+			w.WriteString("return err\n")
+			w.WriteString("}\n")
+		}
+	}
+	w.WriteString("return nil\n")
+	w.WriteString("}\n")
+}
+
+// writeUnmarshalLen unpacks the length of the slice [name] and returns the
+// name of the variable it was unpacked into.
+func writeUnmarshalLen(w *bytes.Buffer, name string) string {
+	lenName := "num" + name
+	fmt.Fprintf(w, "%s := p.UnpackInt()\n", lenName)
+	writeCheckErr(w)
+	fmt.Fprintf(w, "if %s > math.MaxInt32 {\n", lenName)
+	fmt.Fprintf(w, "return fmt.Errorf(\"%%w; array length, %%d, exceeds maximum length, %%d\", codec.ErrMaxSliceLenExceeded, %s, math.MaxInt32)\n", lenName)
+	w.WriteString("}\n")
+	return lenName
+}
+
+func writeCheckErr(w *bytes.Buffer) {
+	w.WriteString("if p.Err != nil {\n")
+	w.WriteString("return p.Err\n")
+	w.WriteString("}\n")
+}
+
+// convert returns [value], of type [goType], converted to the type packed by
+// [basic].
+func convert(basic basicType, value string, goType string) string {
+	if goType == basic.unsigned || (goType == "byte" && basic.unsigned == "uint8") {
+		return value
+	}
+	return fmt.Sprintf("%s(%s)", basic.unsigned, value)
+}
+
+// unpack returns the expression that unpacks a value of [goType].
+func unpack(basic basicType, goType string) string {
+	value := fmt.Sprintf("p.Unpack%s()", basic.method)
+	if goType == basic.unsigned || (goType == "byte" && basic.unsigned == "uint8") {
+		return value
+	}
+	return fmt.Sprintf("%s(%s)", goType, value)
+}
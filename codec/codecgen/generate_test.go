@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateUpToDate verifies that the generated code of the test types is
+// up to date.
+func TestGenerateUpToDate(t *testing.T) {
+	require := require.New(t)
+
+	source, err := os.ReadFile(filepath.Join("codecgentest", "types.go"))
+	require.NoError(err)
+
+	inputFilePath := filepath.Join(t.TempDir(), "types.go")
+	require.NoError(os.WriteFile(inputFilePath, source, 0o600))
+	require.NoError(generate(inputFilePath))
+
+	expected, err := os.ReadFile(filepath.Join("codecgentest", "types.codec.go"))
+	require.NoError(err)
+	generated, err := os.ReadFile(filepath.Join(filepath.Dir(inputFilePath), "types.codec.go"))
+	require.NoError(err)
+	require.Equal(string(expected), string(generated))
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name          string
+		fileName      string
+		source        string
+		expectedErr   error
+		expectedFiles []string
+	}{
+		{
+			name:        "test file",
+			fileName:    "types_test.go",
+			source:      "package test\n",
+			expectedErr: errNonGoFile,
+		},
+		{
+			name:        "generated file",
+			fileName:    "types.codec.go",
+			source:      "package test\n",
+			expectedErr: errNonGoFile,
+		},
+		{
+			name:     "no serialized fields",
+			fileName: "types.go",
+			source: `package test
+
+type Foo struct {
+	Bar uint64
+}
+`,
+			expectedFiles: []string{"types.go"},
+		},
+		{
+			name:     "unexported field",
+			fileName: "types.go",
+			source: `package test
+
+type Foo struct {
+	bar uint64 ` + "`serialize:\"true\"`" + `
+}
+`,
+			expectedErr: errUnexportedField,
+		},
+		{
+			name:     "unsupported embedded field",
+			fileName: "types.go",
+			source: `package test
+
+type Bar[T any] struct{}
+
+type Foo struct {
+	Bar[uint64] ` + "`serialize:\"true\"`" + `
+}
+`,
+			expectedErr: errUnsupportedEmbedded,
+		},
+		{
+			name:     "generic type",
+			fileName: "types.go",
+			source: `package test
+
+type Foo[T any] struct {
+	Bar T ` + "`serialize:\"true\"`" + `
+}
+`,
+			expectedFiles: []string{"types.go"},
+		},
+		{
+			name:     "serialized fields",
+			fileName: "types.go",
+			source: `package test
+
+type Foo struct {
+	Bar uint64 ` + "`serialize:\"true\"`" + `
+}
+`,
+			expectedFiles: []string{"types.codec.go", "types.go"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			dir := t.TempDir()
+			inputFilePath := filepath.Join(dir, test.fileName)
+			require.NoError(os.WriteFile(inputFilePath, []byte(test.source), 0o600))
+
+			err := generate(inputFilePath)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(err)
+			files := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				files = append(files, entry.Name())
+			}
+			require.Equal(test.expectedFiles, files)
+		})
+	}
+}
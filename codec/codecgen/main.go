@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// codecgen generates reflection-free implementations of [codec.Marshaler] and
+// [codec.Unmarshaler] for the structs declared in the provided go files.
+//
+// For every file, a sibling file with the .codec.go extension is written that
+// serializes every struct with at least one field tagged with
+// `serialize:"true"`. Fields with basic types, byte slices, byte arrays, and
+// slices of those are serialized directly. All other fields are serialized by
+// the codec, so that interfaces and nested types are handled exactly as they
+// would be by reflection.
+//
+// Usage:
+//
+//	//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+//
+// [codec.Marshaler]: https://pkg.go.dev/github.com/luxfi/node/codec#Marshaler
+// [codec.Unmarshaler]: https://pkg.go.dev/github.com/luxfi/node/codec#Unmarshaler
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s <file.go>...\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	for _, inputFilePath := range os.Args[1:] {
+		if err := generate(inputFilePath); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to generate code for %q: %v\n", inputFilePath, err)
+			os.Exit(1)
+		}
+	}
+}
@@ -69,6 +69,25 @@ func (mr *ManagerMockRecorder) RegisterCodec(version, codec any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterCodec", reflect.TypeOf((*Manager)(nil).RegisterCodec), version, codec)
 }
 
+// Schema mocks base method.
+func (m *Manager) Schema(roots ...any) (codec.Schema, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range roots {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Schema", varargs...)
+	ret0, _ := ret[0].(codec.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schema indicates an expected call of Schema.
+func (mr *ManagerMockRecorder) Schema(roots ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schema", reflect.TypeOf((*Manager)(nil).Schema), roots...)
+}
+
 // Size mocks base method.
 func (m *Manager) Size(version uint16, value any) (int, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codectest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/codec/linearcodec"
+	"github.com/luxfi/node/codec/reflectcodec"
+
+	codecpkg "github.com/luxfi/node/codec"
+)
+
+// typeRecorder records the types registered into a codec.
+type typeRecorder struct {
+	linearcodec.Codec
+	types []reflect.Type
+}
+
+func (r *typeRecorder) RegisterType(val interface{}) error {
+	r.types = append(r.types, reflect.TypeOf(val))
+	return r.Codec.RegisterType(val)
+}
+
+// FuzzGenerated verifies that every type registered by [registerTypes], and
+// the type of every value in [values], is deserialized and serialized
+// identically by the default codec, which uses generated code, and by a codec
+// that only uses reflection. Every value in [values] must be a pointer, and
+// its serialization is used to seed the corpus.
+func FuzzGenerated(
+	f *testing.F,
+	registerTypes func(linearcodec.Codec) error,
+	values ...interface{},
+) {
+	generatedCodec := &typeRecorder{Codec: linearcodec.NewDefault()}
+	require.NoError(f, registerTypes(generatedCodec))
+	reflectCodec := linearcodec.NewReflectOnly([]string{reflectcodec.DefaultTagName})
+	require.NoError(f, registerTypes(reflectCodec))
+
+	generatedManager := codecpkg.NewDefaultManager()
+	require.NoError(f, generatedManager.RegisterCodec(0, generatedCodec))
+	reflectManager := codecpkg.NewDefaultManager()
+	require.NoError(f, reflectManager.RegisterCodec(0, reflectCodec))
+
+	types := generatedCodec.types
+	for i := range types {
+		f.Add(uint(i), []byte{0, 0})
+	}
+	for _, value := range values {
+		bytes, err := reflectManager.Marshal(0, value)
+		require.NoError(f, err)
+
+		f.Add(uint(len(types)), bytes)
+		types = append(types, reflect.TypeOf(value).Elem())
+	}
+	require.NotEmpty(f, types)
+
+	f.Fuzz(func(t *testing.T, typeIndex uint, bytes []byte) {
+		require := require.New(t)
+
+		typ := types[typeIndex%uint(len(types))]
+		generatedValue := reflect.New(typ).Interface()
+		reflectValue := reflect.New(typ).Interface()
+
+		_, generatedErr := generatedManager.Unmarshal(bytes, generatedValue)
+		_, reflectErr := reflectManager.Unmarshal(bytes, reflectValue)
+		if reflectErr != nil {
+			require.Error(generatedErr, "generated code accepted bytes rejected by reflection for %s", typ)
+			return
+		}
+		require.NoError(generatedErr, "generated code rejected bytes accepted by reflection for %s", typ)
+		require.Equal(reflectValue, generatedValue)
+
+		generatedBytes, err := generatedManager.Marshal(0, generatedValue)
+		require.NoError(err)
+		reflectBytes, err := reflectManager.Marshal(0, reflectValue)
+		require.NoError(err)
+		require.Equal(reflectBytes, generatedBytes)
+		require.Equal(bytes, generatedBytes)
+
+		generatedSize, err := generatedManager.Size(0, generatedValue)
+		require.NoError(err)
+		require.Len(generatedBytes, generatedSize)
+	})
+}
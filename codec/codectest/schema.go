@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codectest
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/utils/perms"
+
+	codecpkg "github.com/luxfi/node/codec"
+)
+
+// UpdateSchemaEnv is the environment variable that, when set, causes
+// [RequireCompatibleSchema] to overwrite the snapshots with the current
+// schemas.
+const UpdateSchemaEnv = "UPDATE_CODEC_SCHEMA"
+
+// RequireCompatibleSchema requires that the schema of [manager], including the
+// types of [roots], doesn't break the wire format described by the snapshot at
+// [snapshotPath].
+//
+// If [UpdateSchemaEnv] is set, the snapshot is written instead. A missing
+// snapshot fails the test, so that snapshots are always committed alongside
+// the codecs they describe. Snapshots should only be updated to record changes
+// that don't break the wire format, such as newly registered types, or changes
+// that are intentionally gated behind a new codec version.
+func RequireCompatibleSchema(
+	t testing.TB,
+	manager codecpkg.Manager,
+	snapshotPath string,
+	roots ...interface{},
+) {
+	t.Helper()
+	require := require.New(t)
+
+	current, err := manager.Schema(roots...)
+	require.NoError(err)
+
+	if os.Getenv(UpdateSchemaEnv) != "" {
+		currentBytes, err := json.MarshalIndent(current, "", "\t")
+		require.NoError(err)
+
+		require.NoError(os.MkdirAll(filepath.Dir(snapshotPath), perms.ReadWriteExecute))
+		require.NoError(os.WriteFile(snapshotPath, append(currentBytes, '\n'), perms.ReadWrite))
+		t.Logf("wrote codec schema snapshot to %s", snapshotPath)
+		return
+	}
+
+	snapshotBytes, err := os.ReadFile(snapshotPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("missing codec schema snapshot %s, run with %s=1 to write it", snapshotPath, UpdateSchemaEnv)
+	}
+	require.NoError(err)
+
+	var previous codecpkg.Schema
+	require.NoError(json.Unmarshal(snapshotBytes, &previous))
+
+	changes := codecpkg.CompareSchemas(previous, current)
+	for _, change := range changes {
+		t.Errorf("breaking codec change: %s", change)
+	}
+	if len(changes) != 0 {
+		t.FailNow()
+	}
+}
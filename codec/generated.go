@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import "github.com/luxfi/node/utils/wrappers"
+
+// Marshaler is implemented by types with generated, reflection-free
+// serialization code. Codecs call it in preference to reflection, so it is
+// also used by every [Manager].
+//
+// The generated code must produce exactly the bytes that reflection would
+// produce for the type. [c] must be used to serialize any fields that aren't
+// generated, such as interfaces, so that their type IDs are resolved by the
+// codec serializing the value.
+type Marshaler interface {
+	// CodecType returns a nil pointer to the type the code was generated for.
+	// It prevents the code generated for an embedded struct from being used
+	// to serialize the struct that embeds it.
+	CodecType() any
+	// CodecSize returns the number of bytes MarshalCodec will pack.
+	CodecSize(c Codec) (int, error)
+	// MarshalCodec packs the value into [p].
+	MarshalCodec(c Codec, p *wrappers.Packer) error
+}
+
+// Unmarshaler is implemented by types with generated, reflection-free
+// deserialization code. It is the inverse of [Marshaler].
+type Unmarshaler interface {
+	// UnmarshalCodec unpacks the value from [p].
+	UnmarshalCodec(c Codec, p *wrappers.Packer) error
+}
@@ -4,8 +4,10 @@
 package hierarchycodec
 
 import (
+	"cmp"
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
 
 	"github.com/luxfi/node/codec"
@@ -15,10 +17,11 @@ import (
 )
 
 var (
-	_ Codec              = (*hierarchyCodec)(nil)
-	_ codec.Codec        = (*hierarchyCodec)(nil)
-	_ codec.Registry     = (*hierarchyCodec)(nil)
-	_ codec.GeneralCodec = (*hierarchyCodec)(nil)
+	_ Codec                 = (*hierarchyCodec)(nil)
+	_ codec.Codec           = (*hierarchyCodec)(nil)
+	_ codec.Registry        = (*hierarchyCodec)(nil)
+	_ codec.GeneralCodec    = (*hierarchyCodec)(nil)
+	_ codec.SchemaDescriber = (*hierarchyCodec)(nil)
 )
 
 // Codec marshals and unmarshals
@@ -38,6 +41,7 @@ type typeID struct {
 type hierarchyCodec struct {
 	codec.Codec
 
+	tagNames        []string
 	lock            sync.RWMutex
 	currentGroupID  uint16
 	nextTypeID      uint16
@@ -47,6 +51,7 @@ type hierarchyCodec struct {
 // New returns a new, concurrency-safe codec
 func New(tagNames []string) Codec {
 	hCodec := &hierarchyCodec{
+		tagNames:        tagNames,
 		currentGroupID:  0,
 		nextTypeID:      0,
 		registeredTypes: bimap.New[typeID, reflect.Type](),
@@ -143,3 +148,40 @@ func (c *hierarchyCodec) UnpackPrefix(p *wrappers.Packer, valueType reflect.Type
 	}
 	return reflect.New(implementingType).Elem(), nil // instance of the proper type
 }
+
+// Schema describes the registered types, with type IDs formed from the group
+// ID in the upper 16 bits and the type ID within the group in the lower 16
+// bits.
+func (c *hierarchyCodec) Schema(roots ...reflect.Type) (codec.CodecSchema, error) {
+	c.lock.RLock()
+	typeIDs := c.registeredTypes.Keys()
+	registeredTypes := make([]codec.RegisteredType, len(typeIDs))
+	types := make([]reflect.Type, len(typeIDs), len(typeIDs)+len(roots))
+	for i, typeID := range typeIDs {
+		types[i], _ = c.registeredTypes.GetValue(typeID)
+		registeredTypes[i] = codec.RegisteredType{
+			ID:   uint32(typeID.groupID)<<16 | uint32(typeID.typeID),
+			Type: reflectcodec.TypeName(types[i]),
+		}
+	}
+	c.lock.RUnlock()
+
+	slices.SortFunc(registeredTypes, func(a, b codec.RegisteredType) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	rootNames := make([]string, len(roots))
+	for i, root := range roots {
+		rootNames[i] = reflectcodec.TypeName(root)
+	}
+
+	described, err := reflectcodec.Describe(c.tagNames, append(types, roots...)...)
+	if err != nil {
+		return codec.CodecSchema{}, err
+	}
+	return codec.CodecSchema{
+		RegisteredTypes: registeredTypes,
+		Roots:           rootNames,
+		Types:           described,
+	}, nil
+}
@@ -6,6 +6,7 @@ package linearcodec
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
 
 	"github.com/luxfi/node/codec"
@@ -15,10 +16,11 @@ import (
 )
 
 var (
-	_ Codec              = (*linearCodec)(nil)
-	_ codec.Codec        = (*linearCodec)(nil)
-	_ codec.Registry     = (*linearCodec)(nil)
-	_ codec.GeneralCodec = (*linearCodec)(nil)
+	_ Codec                 = (*linearCodec)(nil)
+	_ codec.Codec           = (*linearCodec)(nil)
+	_ codec.Registry        = (*linearCodec)(nil)
+	_ codec.GeneralCodec    = (*linearCodec)(nil)
+	_ codec.SchemaDescriber = (*linearCodec)(nil)
 )
 
 // Codec marshals and unmarshals
@@ -32,6 +34,7 @@ type Codec interface {
 type linearCodec struct {
 	codec.Codec
 
+	tagNames        []string
 	lock            sync.RWMutex
 	nextTypeID      uint32
 	registeredTypes *bimap.BiMap[uint32, reflect.Type]
@@ -40,6 +43,7 @@ type linearCodec struct {
 // New returns a new, concurrency-safe codec; it allow to specify tagNames.
 func New(tagNames []string) Codec {
 	hCodec := &linearCodec{
+		tagNames:        tagNames,
 		nextTypeID:      0,
 		registeredTypes: bimap.New[uint32, reflect.Type](),
 	}
@@ -47,6 +51,19 @@ func New(tagNames []string) Codec {
 	return hCodec
 }
 
+// NewReflectOnly returns a new, concurrency-safe codec that never uses
+// generated code. It is used to verify that generated code matches
+// reflection.
+func NewReflectOnly(tagNames []string) Codec {
+	hCodec := &linearCodec{
+		tagNames:        tagNames,
+		nextTypeID:      0,
+		registeredTypes: bimap.New[uint32, reflect.Type](),
+	}
+	hCodec.Codec = reflectcodec.NewReflectOnly(hCodec, tagNames)
+	return hCodec
+}
+
 // NewDefault is a convenience constructor; it returns a new codec with default
 // tagNames.
 func NewDefault() Codec {
@@ -116,3 +133,34 @@ func (c *linearCodec) UnpackPrefix(p *wrappers.Packer, valueType reflect.Type) (
 	}
 	return reflect.New(implementingType).Elem(), nil // instance of the proper type
 }
+
+func (c *linearCodec) Schema(roots ...reflect.Type) (codec.CodecSchema, error) {
+	c.lock.RLock()
+	typeIDs := c.registeredTypes.Keys()
+	slices.Sort(typeIDs)
+	registeredTypes := make([]codec.RegisteredType, len(typeIDs))
+	types := make([]reflect.Type, len(typeIDs), len(typeIDs)+len(roots))
+	for i, typeID := range typeIDs {
+		types[i], _ = c.registeredTypes.GetValue(typeID)
+		registeredTypes[i] = codec.RegisteredType{
+			ID:   typeID,
+			Type: reflectcodec.TypeName(types[i]),
+		}
+	}
+	c.lock.RUnlock()
+
+	rootNames := make([]string, len(roots))
+	for i, root := range roots {
+		rootNames[i] = reflectcodec.TypeName(root)
+	}
+
+	described, err := reflectcodec.Describe(c.tagNames, append(types, roots...)...)
+	if err != nil {
+		return codec.CodecSchema{}, err
+	}
+	return codec.CodecSchema{
+		RegisteredTypes: registeredTypes,
+		Roots:           rootNames,
+		Types:           described,
+	}, nil
+}
@@ -6,6 +6,7 @@ package codec
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 
 	"github.com/luxfi/node/utils/units"
@@ -55,6 +56,12 @@ type Manager interface {
 	// be a pointer or an interface. Returns the version of the codec that
 	// produces the given bytes.
 	Unmarshal(source []byte, destination interface{}) (version uint16, err error)
+
+	// Schema describes the wire format of every registered codec version. The
+	// types of [roots], which are typically the types passed to Marshal and
+	// Unmarshal, are described in addition to the registered types. Every
+	// registered codec must implement [SchemaDescriber].
+	Schema(roots ...interface{}) (Schema, error)
 }
 
 // NewManager returns a new codec manager.
@@ -159,3 +166,30 @@ func (m *manager) Unmarshal(bytes []byte, dest interface{}) (uint16, error) {
 	}
 	return version, c.Unmarshal(p.Bytes[p.Offset:], dest)
 }
+
+func (m *manager) Schema(roots ...interface{}) (Schema, error) {
+	rootTypes := make([]reflect.Type, len(roots))
+	for i, root := range roots {
+		rootTypes[i] = reflect.TypeOf(root)
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	schema := Schema{
+		Versions: make(map[uint16]CodecSchema, len(m.codecs)),
+	}
+	for version, c := range m.codecs {
+		describer, ok := c.(SchemaDescriber)
+		if !ok {
+			return Schema{}, fmt.Errorf("%w: version %d", ErrSchemaNotSupported, version)
+		}
+
+		codecSchema, err := describer.Schema(rootTypes...)
+		if err != nil {
+			return Schema{}, fmt.Errorf("couldn't describe codec version %d: %w", version, err)
+		}
+		schema.Versions[version] = codecSchema
+	}
+	return schema, nil
+}
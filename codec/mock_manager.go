@@ -67,6 +67,25 @@ func (mr *MockManagerMockRecorder) RegisterCodec(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterCodec", reflect.TypeOf((*MockManager)(nil).RegisterCodec), arg0, arg1)
 }
 
+// Schema mocks base method.
+func (m *MockManager) Schema(roots ...any) (Schema, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range roots {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Schema", varargs...)
+	ret0, _ := ret[0].(Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schema indicates an expected call of Schema.
+func (mr *MockManagerMockRecorder) Schema(roots ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schema", reflect.TypeOf((*MockManager)(nil).Schema), roots...)
+}
+
 // Size mocks base method.
 func (m *MockManager) Size(arg0 uint16, arg1 any) (int, error) {
 	m.ctrl.T.Helper()
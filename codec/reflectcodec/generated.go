@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reflectcodec
+
+import (
+	"reflect"
+
+	"github.com/luxfi/math/set"
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Codec = (*stackCodec)(nil)
+
+	unmarshalerType = reflect.TypeOf((*codec.Unmarshaler)(nil)).Elem()
+)
+
+// marshaler returns the generated serialization code of the struct [value],
+// if there is any and it should be used.
+func (c *genericCodec) marshaler(value reflect.Value) (codec.Marshaler, bool) {
+	if !c.useGenerated || !value.CanAddr() || !c.isGenerated(value.Type()) {
+		return nil, false
+	}
+	m, ok := value.Addr().Interface().(codec.Marshaler)
+	return m, ok
+}
+
+// unmarshaler returns the generated deserialization code of the struct
+// [value], if there is any and it should be used.
+func (c *genericCodec) unmarshaler(value reflect.Value) (codec.Unmarshaler, bool) {
+	if !c.useGenerated || !value.CanAddr() || !c.isGenerated(value.Type()) {
+		return nil, false
+	}
+	u, ok := value.Addr().Interface().(codec.Unmarshaler)
+	return u, ok
+}
+
+// isGenerated returns true if the struct type [t] has its own generated
+// serialization and deserialization code, rather than code promoted from an
+// embedded field.
+func (c *genericCodec) isGenerated(t reflect.Type) bool {
+	c.generatedLock.RLock()
+	generated, ok := c.generated[t]
+	c.generatedLock.RUnlock()
+	if ok {
+		return generated
+	}
+
+	ptrType := reflect.PointerTo(t)
+	m, isMarshaler := reflect.New(t).Interface().(codec.Marshaler)
+	generated = isMarshaler &&
+		ptrType.Implements(unmarshalerType) &&
+		reflect.TypeOf(m.CodecType()) == ptrType
+
+	c.generatedLock.Lock()
+	c.generated[t] = generated
+	c.generatedLock.Unlock()
+	return generated
+}
+
+// withTypeStack returns the codec that is passed to generated code.
+func (c *genericCodec) withTypeStack(typeStack set.Set[reflect.Type]) codec.Codec {
+	return &stackCodec{
+		c:         c,
+		typeStack: typeStack,
+	}
+}
+
+// stackCodec is passed to generated code to serialize the fields that aren't
+// generated. It keeps track of the interface types that are already being
+// serialized, so that recursive interface types are rejected exactly as they
+// would be by reflection.
+type stackCodec struct {
+	c         *genericCodec
+	typeStack set.Set[reflect.Type]
+}
+
+func (s *stackCodec) Size(value interface{}) (int, error) {
+	if value == nil {
+		return 0, codec.ErrMarshalNil
+	}
+
+	size, _, err := s.c.size(reflect.ValueOf(value), s.typeStack)
+	return size, err
+}
+
+func (s *stackCodec) MarshalInto(value interface{}, p *wrappers.Packer) error {
+	if value == nil {
+		return codec.ErrMarshalNil
+	}
+
+	return s.c.marshal(reflect.ValueOf(value), p, s.typeStack)
+}
+
+func (s *stackCodec) Unmarshal(bytes []byte, dest interface{}) error {
+	return s.c.Unmarshal(bytes, dest)
+}
+
+func (s *stackCodec) UnmarshalFrom(p *wrappers.Packer, dest interface{}) error {
+	if dest == nil {
+		return codec.ErrUnmarshalNil
+	}
+
+	destPtr := reflect.ValueOf(dest)
+	if destPtr.Kind() != reflect.Ptr {
+		return errNeedPointer
+	}
+	return s.c.unmarshal(p, destPtr.Elem(), s.typeStack)
+}
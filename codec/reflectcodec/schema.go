@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reflectcodec
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/luxfi/node/codec"
+)
+
+// Describe returns the schema of [types], and of every type reachable from
+// them, as serialized by a codec with [tagNames]. The returned types are keyed
+// by [TypeName].
+func Describe(tagNames []string, types ...reflect.Type) (map[string]codec.TypeSchema, error) {
+	var (
+		fielder   = NewStructFielder(tagNames)
+		described = make(map[string]codec.TypeSchema)
+		toVisit   = types
+	)
+	for len(toVisit) > 0 {
+		t := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+
+		name := TypeName(t)
+		if _, ok := described[name]; ok {
+			continue
+		}
+
+		schema := codec.TypeSchema{
+			Kind: t.Kind().String(),
+		}
+		switch t.Kind() {
+		case reflect.Array:
+			schema.Len = t.Len()
+			fallthrough
+		case reflect.Ptr, reflect.Slice:
+			schema.Elem = TypeName(t.Elem())
+			toVisit = append(toVisit, t.Elem())
+		case reflect.Map:
+			schema.Key = TypeName(t.Key())
+			schema.Elem = TypeName(t.Elem())
+			toVisit = append(toVisit, t.Key(), t.Elem())
+		case reflect.Struct:
+			serializedFields, err := fielder.GetSerializedFields(t)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't describe %s: %w", name, err)
+			}
+
+			schema.Fields = make([]codec.FieldSchema, len(serializedFields))
+			for i, fieldIndex := range serializedFields {
+				field := t.Field(fieldIndex)
+				fieldSchema := codec.FieldSchema{
+					Name: field.Name,
+					Type: TypeName(field.Type),
+					Tags: []string{},
+				}
+				for _, tag := range tagNames {
+					if field.Tag.Get(tag) == TagValue {
+						fieldSchema.Tags = append(fieldSchema.Tags, tag)
+					}
+				}
+				schema.Fields[i] = fieldSchema
+				toVisit = append(toVisit, field.Type)
+			}
+		}
+		described[name] = schema
+	}
+	return described, nil
+}
+
+// TypeName returns a name of [t] that is unique across packages.
+func TypeName(t reflect.Type) string {
+	if name := t.Name(); name != "" {
+		if pkgPath := t.PkgPath(); pkgPath != "" {
+			return pkgPath + "." + name
+		}
+		return name
+	}
+
+	switch t.Kind() {
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), TypeName(t.Elem()))
+	case reflect.Ptr:
+		return "*" + TypeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + TypeName(t.Elem())
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", TypeName(t.Key()), TypeName(t.Elem()))
+	default:
+		return t.String()
+	}
+}
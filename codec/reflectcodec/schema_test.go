@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reflectcodec
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/codec"
+)
+
+type schemaInterface interface{}
+
+type schemaStruct struct {
+	Uint64    uint64             `serialize:"true"`
+	Nested    *schemaStruct      `serialize:"true" v1:"true"`
+	Interface schemaInterface    `v1:"true"`
+	Map       map[uint32][4]byte `serialize:"true"`
+	Ignored   uint64
+}
+
+type schemaUnexported struct {
+	unexported uint64 `serialize:"true"` //nolint:unused
+}
+
+func TestDescribe(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		structName    = "github.com/luxfi/node/codec/reflectcodec.schemaStruct"
+		interfaceName = "github.com/luxfi/node/codec/reflectcodec.schemaInterface"
+	)
+
+	described, err := Describe(
+		[]string{DefaultTagName, "v1"},
+		reflect.TypeOf(&schemaStruct{}),
+	)
+	require.NoError(err)
+	require.Equal(
+		map[string]codec.TypeSchema{
+			"*" + structName: {
+				Kind: "ptr",
+				Elem: structName,
+			},
+			structName: {
+				Kind: "struct",
+				Fields: []codec.FieldSchema{
+					{
+						Name: "Uint64",
+						Type: "uint64",
+						Tags: []string{DefaultTagName},
+					},
+					{
+						Name: "Nested",
+						Type: "*" + structName,
+						Tags: []string{DefaultTagName, "v1"},
+					},
+					{
+						Name: "Interface",
+						Type: interfaceName,
+						Tags: []string{"v1"},
+					},
+					{
+						Name: "Map",
+						Type: "map[uint32][4]uint8",
+						Tags: []string{DefaultTagName},
+					},
+				},
+			},
+			"uint64": {
+				Kind: "uint64",
+			},
+			interfaceName: {
+				Kind: "interface",
+			},
+			"map[uint32][4]uint8": {
+				Kind: "map",
+				Key:  "uint32",
+				Elem: "[4]uint8",
+			},
+			"uint32": {
+				Kind: "uint32",
+			},
+			"[4]uint8": {
+				Kind: "array",
+				Elem: "uint8",
+				Len:  4,
+			},
+			"uint8": {
+				Kind: "uint8",
+			},
+		},
+		described,
+	)
+
+	_, err = Describe([]string{DefaultTagName}, reflect.TypeOf(schemaUnexported{}))
+	require.ErrorIs(err, codec.ErrUnexportedField)
+}
@@ -10,6 +10,7 @@ import (
 	"math"
 	"reflect"
 	"slices"
+	"sync"
 
 	"github.com/luxfi/node/codec"
 	"github.com/luxfi/math/set"
@@ -67,13 +68,35 @@ type TypeCodec interface {
 //     codec.RegisterType([instance of the type that fulfills the interface]).
 //  6. Serialized fields must be exported
 //  7. nil slices are marshaled as empty slices
+//  8. structs implementing [codec.Marshaler] and [codec.Unmarshaler] through a
+//     pointer are serialized with their generated code, if the codec only
+//     uses the default tag name
 type genericCodec struct {
-	typer   TypeCodec
-	fielder StructFielder
+	typer        TypeCodec
+	fielder      StructFielder
+	useGenerated bool
+
+	generatedLock sync.RWMutex
+	// Key: a struct type
+	// Value: true if the type has its own generated code
+	generated map[reflect.Type]bool
 }
 
 // New returns a new, concurrency-safe codec
 func New(typer TypeCodec, tagNames []string) codec.Codec {
+	return &genericCodec{
+		typer:   typer,
+		fielder: NewStructFielder(tagNames),
+		// Generated code only serializes fields with the default tag name.
+		useGenerated: len(tagNames) == 1 && tagNames[0] == DefaultTagName,
+		generated:    make(map[reflect.Type]bool),
+	}
+}
+
+// NewReflectOnly returns a new, concurrency-safe codec that never uses
+// generated code. It is used to verify that generated code matches
+// reflection.
+func NewReflectOnly(typer TypeCodec, tagNames []string) codec.Codec {
 	return &genericCodec{
 		typer:   typer,
 		fielder: NewStructFielder(tagNames),
@@ -198,6 +221,11 @@ func (c *genericCodec) size(
 		return size, false, nil
 
 	case reflect.Struct:
+		if m, ok := c.marshaler(value); ok {
+			size, err := m.CodecSize(c.withTypeStack(typeStack))
+			return size, false, err
+		}
+
 		serializedFields, err := c.fielder.GetSerializedFields(value.Type())
 		if err != nil {
 			return 0, false, err
@@ -411,6 +439,10 @@ func (c *genericCodec) marshal(
 		}
 		return nil
 	case reflect.Struct:
+		if m, ok := c.marshaler(value); ok {
+			return m.MarshalCodec(c.withTypeStack(typeStack), p)
+		}
+
 		serializedFields, err := c.fielder.GetSerializedFields(value.Type())
 		if err != nil {
 			return err
@@ -681,6 +713,10 @@ func (c *genericCodec) unmarshal(
 		value.Set(intfImplementor)
 		return nil
 	case reflect.Struct:
+		if u, ok := c.unmarshaler(value); ok {
+			return u.UnmarshalCodec(c.withTypeStack(typeStack), p)
+		}
+
 		// Get indices of fields that will be unmarshaled into
 		serializedFieldIndices, err := c.fielder.GetSerializedFields(value.Type())
 		if err != nil {
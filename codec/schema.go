@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+var ErrSchemaNotSupported = errors.New("codec doesn't support describing its schema")
+
+// SchemaDescriber is implemented by codecs that can describe the wire format of
+// the types they serialize.
+type SchemaDescriber interface {
+	// Schema describes the registered types, the types in [roots], and every
+	// type reachable from them.
+	Schema(roots ...reflect.Type) (CodecSchema, error)
+}
+
+// Schema describes the wire format of every codec version of a [Manager].
+//
+// The JSON form of a Schema is stable, so that it can be checked in and
+// compared against with [CompareSchemas].
+type Schema struct {
+	Versions map[uint16]CodecSchema `json:"versions"`
+}
+
+// CodecSchema describes the wire format of a single codec version.
+type CodecSchema struct {
+	// RegisteredTypes are the types that can be serialized into interfaces,
+	// sorted by type ID.
+	RegisteredTypes []RegisteredType `json:"registeredTypes"`
+	// Roots are the names of the top-level types that were described.
+	Roots []string `json:"roots,omitempty"`
+	// Types describes every type reachable from the registered types and the
+	// roots, keyed by name.
+	Types map[string]TypeSchema `json:"types"`
+}
+
+// RegisteredType is a type that was registered into a codec.
+type RegisteredType struct {
+	ID   uint32 `json:"id"`
+	Type string `json:"type"`
+}
+
+// TypeSchema describes how a type is serialized.
+type TypeSchema struct {
+	// Kind is the [reflect.Kind] of the type.
+	Kind string `json:"kind"`
+	// Elem is the name of the element type of arrays, maps, pointers, and
+	// slices.
+	Elem string `json:"elem,omitempty"`
+	// Key is the name of the key type of maps.
+	Key string `json:"key,omitempty"`
+	// Len is the length of arrays.
+	Len int `json:"len,omitempty"`
+	// Fields are the serialized fields of structs, in serialization order.
+	Fields []FieldSchema `json:"fields,omitempty"`
+}
+
+// FieldSchema describes a serialized struct field.
+type FieldSchema struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Tags are the tags of the codec that cause the field to be serialized.
+	Tags []string `json:"tags"`
+}
+
+// BreakingChange is a change between two schemas that modifies the wire
+// format.
+type BreakingChange struct {
+	Version uint16 `json:"version"`
+	// Path identifies the type that changed.
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+func (c BreakingChange) String() string {
+	return fmt.Sprintf("codec version %d: %s: %s", c.Version, c.Path, c.Reason)
+}
+
+// CompareSchemas returns every change from [previous] to [current] that
+// modifies the serialization of a type described by [previous].
+//
+// Adding codec versions, registering new types after the previously registered
+// types, and renaming fields or nested types are not breaking changes.
+// Removing codec versions, changing the type registered with a type ID, and
+// changing the serialized fields of a reachable type are.
+func CompareSchemas(previous, current Schema) []BreakingChange {
+	versions := make([]uint16, 0, len(previous.Versions))
+	for version := range previous.Versions {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+
+	var changes []BreakingChange
+	for _, version := range versions {
+		currentSchema, ok := current.Versions[version]
+		if !ok {
+			changes = append(changes, BreakingChange{
+				Version: version,
+				Path:    "codec",
+				Reason:  "codec version was removed",
+			})
+			continue
+		}
+
+		c := &schemaComparer{
+			version:  version,
+			previous: previous.Versions[version],
+			current:  currentSchema,
+			compared: make(map[[2]string]bool),
+		}
+		c.compareRegisteredTypes()
+		c.compareRoots()
+		changes = append(changes, c.changes...)
+	}
+	return changes
+}
+
+type schemaComparer struct {
+	version  uint16
+	previous CodecSchema
+	current  CodecSchema
+	// compared are the pairs of previous and current type names that have
+	// already been compared, which allows recursive types to be compared.
+	compared map[[2]string]bool
+	changes  []BreakingChange
+}
+
+func (c *schemaComparer) compareRegisteredTypes() {
+	currentTypes := make(map[uint32]string, len(c.current.RegisteredTypes))
+	for _, registeredType := range c.current.RegisteredTypes {
+		currentTypes[registeredType.ID] = registeredType.Type
+	}
+
+	for _, registeredType := range c.previous.RegisteredTypes {
+		path := fmt.Sprintf("type ID %d", registeredType.ID)
+		currentType, ok := currentTypes[registeredType.ID]
+		switch {
+		case !ok:
+			c.report(path, fmt.Sprintf("%s is no longer registered", registeredType.Type))
+		case currentType != registeredType.Type:
+			c.report(path, fmt.Sprintf("registered type changed from %s to %s", registeredType.Type, currentType))
+		default:
+			c.compareTypes(fmt.Sprintf("%s (%s)", path, registeredType.Type), registeredType.Type, currentType)
+		}
+	}
+}
+
+func (c *schemaComparer) compareRoots() {
+	for _, root := range c.previous.Roots {
+		if !slices.Contains(c.current.Roots, root) {
+			c.report(root, "root type was removed")
+			continue
+		}
+		c.compareTypes(root, root, root)
+	}
+}
+
+func (c *schemaComparer) compareTypes(path string, previousName, currentName string) {
+	key := [2]string{previousName, currentName}
+	if c.compared[key] {
+		return
+	}
+	c.compared[key] = true
+
+	previous, ok := c.previous.Types[previousName]
+	if !ok {
+		// The previous schema is incomplete, so there is nothing to compare
+		// against.
+		return
+	}
+	current, ok := c.current.Types[currentName]
+	if !ok {
+		c.report(path, fmt.Sprintf("type %s is not described", currentName))
+		return
+	}
+
+	if previous.Kind != current.Kind {
+		c.report(path, fmt.Sprintf("kind changed from %s to %s", previous.Kind, current.Kind))
+		return
+	}
+	if previous.Len != current.Len {
+		c.report(path, fmt.Sprintf("length changed from %d to %d", previous.Len, current.Len))
+	}
+	if previous.Key != "" {
+		c.compareTypes(path+".key", previous.Key, current.Key)
+	}
+	if previous.Elem != "" {
+		c.compareTypes(path+".elem", previous.Elem, current.Elem)
+	}
+
+	for i, previousField := range previous.Fields {
+		if i >= len(current.Fields) {
+			c.report(path, fmt.Sprintf("serialized field %s was removed", previousField.Name))
+			continue
+		}
+		currentField := current.Fields[i]
+		c.compareTypes(path+"."+previousField.Name, previousField.Type, currentField.Type)
+	}
+	for _, currentField := range current.Fields[min(len(previous.Fields), len(current.Fields)):] {
+		c.report(path, fmt.Sprintf("serialized field %s was added", currentField.Name))
+	}
+}
+
+func (c *schemaComparer) report(path string, reason string) {
+	c.changes = append(c.changes, BreakingChange{
+		Version: c.version,
+		Path:    path,
+		Reason:  reason,
+	})
+}
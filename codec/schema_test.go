@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSchema() Schema {
+	return Schema{
+		Versions: map[uint16]CodecSchema{
+			0: {
+				RegisteredTypes: []RegisteredType{
+					{ID: 0, Type: "*pkg.Tx"},
+					{ID: 2, Type: "*pkg.Output"},
+				},
+				Roots: []string{"*pkg.Block"},
+				Types: map[string]TypeSchema{
+					"*pkg.Block": {Kind: "ptr", Elem: "pkg.Block"},
+					"pkg.Block": {
+						Kind: "struct",
+						Fields: []FieldSchema{
+							{Name: "Parent", Type: "*pkg.Block", Tags: []string{"serialize"}},
+							{Name: "Txs", Type: "[]pkg.Tx", Tags: []string{"serialize"}},
+						},
+					},
+					"[]pkg.Tx": {Kind: "slice", Elem: "pkg.Tx"},
+					"*pkg.Tx":  {Kind: "ptr", Elem: "pkg.Tx"},
+					"pkg.Tx": {
+						Kind: "struct",
+						Fields: []FieldSchema{
+							{Name: "ID", Type: "[32]uint8", Tags: []string{"serialize"}},
+							{Name: "Amount", Type: "uint64", Tags: []string{"serialize"}},
+						},
+					},
+					"*pkg.Output": {Kind: "ptr", Elem: "pkg.Output"},
+					"pkg.Output": {
+						Kind: "struct",
+						Fields: []FieldSchema{
+							{Name: "Amount", Type: "uint64", Tags: []string{"serialize"}},
+						},
+					},
+					"[32]uint8": {Kind: "array", Elem: "uint8", Len: 32},
+					"uint8":     {Kind: "uint8"},
+					"uint64":    {Kind: "uint64"},
+				},
+			},
+		},
+	}
+}
+
+func TestCompareSchemas(t *testing.T) {
+	tests := []struct {
+		name            string
+		modify          func(*Schema)
+		expectedChanges []BreakingChange
+	}{
+		{
+			name:   "unchanged",
+			modify: func(*Schema) {},
+		},
+		{
+			name: "new version",
+			modify: func(s *Schema) {
+				s.Versions[1] = CodecSchema{}
+			},
+		},
+		{
+			name: "new registered type",
+			modify: func(s *Schema) {
+				v := s.Versions[0]
+				v.RegisteredTypes = append(v.RegisteredTypes, RegisteredType{ID: 3, Type: "*pkg.Output2"})
+				s.Versions[0] = v
+			},
+		},
+		{
+			name: "renamed field",
+			modify: func(s *Schema) {
+				s.Versions[0].Types["pkg.Output"].Fields[0].Name = "Amt"
+			},
+		},
+		{
+			name: "removed version",
+			modify: func(s *Schema) {
+				delete(s.Versions, 0)
+			},
+			expectedChanges: []BreakingChange{
+				{Version: 0, Path: "codec", Reason: "codec version was removed"},
+			},
+		},
+		{
+			name: "unregistered type",
+			modify: func(s *Schema) {
+				v := s.Versions[0]
+				v.RegisteredTypes = v.RegisteredTypes[:1]
+				s.Versions[0] = v
+			},
+			expectedChanges: []BreakingChange{
+				{Version: 0, Path: "type ID 2", Reason: "*pkg.Output is no longer registered"},
+			},
+		},
+		{
+			name: "reordered registered types",
+			modify: func(s *Schema) {
+				v := s.Versions[0]
+				v.RegisteredTypes = []RegisteredType{
+					{ID: 0, Type: "*pkg.Output"},
+					{ID: 2, Type: "*pkg.Tx"},
+				}
+				s.Versions[0] = v
+			},
+			expectedChanges: []BreakingChange{
+				{Version: 0, Path: "type ID 0", Reason: "registered type changed from *pkg.Tx to *pkg.Output"},
+				{Version: 0, Path: "type ID 2", Reason: "registered type changed from *pkg.Output to *pkg.Tx"},
+			},
+		},
+		{
+			name: "added field",
+			modify: func(s *Schema) {
+				output := s.Versions[0].Types["pkg.Output"]
+				output.Fields = append(output.Fields, FieldSchema{Name: "Locktime", Type: "uint64"})
+				s.Versions[0].Types["pkg.Output"] = output
+			},
+			expectedChanges: []BreakingChange{
+				{Version: 0, Path: "type ID 2 (*pkg.Output).elem", Reason: "serialized field Locktime was added"},
+			},
+		},
+		{
+			name: "removed field",
+			modify: func(s *Schema) {
+				tx := s.Versions[0].Types["pkg.Tx"]
+				tx.Fields = tx.Fields[:1]
+				s.Versions[0].Types["pkg.Tx"] = tx
+			},
+			expectedChanges: []BreakingChange{
+				{Version: 0, Path: "type ID 0 (*pkg.Tx).elem", Reason: "serialized field Amount was removed"},
+			},
+		},
+		{
+			name: "changed field type",
+			modify: func(s *Schema) {
+				s.Versions[0].Types["pkg.Tx"].Fields[1].Type = "uint32"
+				s.Versions[0].Types["uint32"] = TypeSchema{Kind: "uint32"}
+			},
+			expectedChanges: []BreakingChange{
+				{Version: 0, Path: "type ID 0 (*pkg.Tx).elem.Amount", Reason: "kind changed from uint64 to uint32"},
+			},
+		},
+		{
+			name: "changed array length",
+			modify: func(s *Schema) {
+				s.Versions[0].Types["[32]uint8"] = TypeSchema{Kind: "array", Elem: "uint8", Len: 20}
+			},
+			expectedChanges: []BreakingChange{
+				{Version: 0, Path: "type ID 0 (*pkg.Tx).elem.ID", Reason: "length changed from 32 to 20"},
+			},
+		},
+		{
+			name: "removed root",
+			modify: func(s *Schema) {
+				v := s.Versions[0]
+				v.Roots = nil
+				s.Versions[0] = v
+			},
+			expectedChanges: []BreakingChange{
+				{Version: 0, Path: "*pkg.Block", Reason: "root type was removed"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current := newTestSchema()
+			test.modify(&current)
+			require.Equal(t, test.expectedChanges, CompareSchemas(newTestSchema(), current))
+		})
+	}
+}
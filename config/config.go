@@ -13,6 +13,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/luxfi/crypto/bls"
 	"github.com/luxfi/ids"
 	"github.com/luxfi/log"
+	"github.com/luxfi/node/api/auth"
 	"github.com/luxfi/node/api/server"
 	"github.com/luxfi/node/chains"
 	"github.com/luxfi/consensus/prism"
@@ -35,6 +37,7 @@ import (
 	"github.com/luxfi/node/node"
 	"github.com/luxfi/node/staking"
 	"github.com/luxfi/node/subnets"
+	"github.com/luxfi/node/upgrade"
 	"github.com/luxfi/node/utils/compression"
 	"github.com/luxfi/node/utils/constants"
 	"github.com/luxfi/node/utils/ips"
@@ -85,6 +88,9 @@ var (
 	errCannotReadDirectory                    = errors.New("cannot read directory")
 	errUnmarshalling                          = errors.New("unmarshalling failed")
 	errFileDoesNotExist                       = errors.New("file does not exist")
+	errInvalidRateLimit                       = errors.New("invalid rate limit")
+	errClientCAWithoutTLS                     = fmt.Errorf("%s requires %s", HTTPSClientCAFileKey, HTTPSEnabledKey)
+	errUpgradeOnPublicNetwork                 = fmt.Errorf("%s can't be specified on a public network", UpgradeFileContentKey)
 )
 
 func getConsensusConfig(v *viper.Viper) consensusconfig.Parameters {
@@ -138,6 +144,102 @@ func getConsensusConfig(v *viper.Viper) consensusconfig.Parameters {
 // 	return loggingConfig, err
 // }
 
+func getRateLimitConfig(v *viper.Viper) (server.RateLimitConfig, error) {
+	config := server.RateLimitConfig{
+		Default: server.RateLimit{
+			Rate:  v.GetFloat64(HTTPRateLimitKey),
+			Burst: int(v.GetUint(HTTPRateLimitBurstKey)),
+		},
+		Endpoints:     make(map[string]server.RateLimit),
+		MethodWeights: make(map[string]int),
+		MaxClients:    int(v.GetUint(HTTPRateLimitMaxClientsKey)),
+	}
+	for endpoint, limit := range v.GetStringMapString(HTTPRateLimitEndpointsKey) {
+		rateStr, burstStr, ok := strings.Cut(limit, ":")
+		if !ok {
+			return server.RateLimitConfig{}, fmt.Errorf("%w for %s: %q isn't <rate>:<burst>", errInvalidRateLimit, endpoint, limit)
+		}
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil {
+			return server.RateLimitConfig{}, fmt.Errorf("%w for %s: %w", errInvalidRateLimit, endpoint, err)
+		}
+		burst, err := strconv.Atoi(burstStr)
+		if err != nil {
+			return server.RateLimitConfig{}, fmt.Errorf("%w for %s: %w", errInvalidRateLimit, endpoint, err)
+		}
+		config.Endpoints[endpoint] = server.RateLimit{
+			Rate:  rate,
+			Burst: burst,
+		}
+	}
+	for method, weightStr := range v.GetStringMapString(HTTPRateLimitMethodWeightsKey) {
+		weight, err := strconv.Atoi(weightStr)
+		if err != nil || weight < 0 {
+			return server.RateLimitConfig{}, fmt.Errorf("%w: invalid weight %q for %s", errInvalidRateLimit, weightStr, method)
+		}
+		config.MethodWeights[method] = weight
+	}
+	return config, nil
+}
+
+// resolveRateLimitAliases rewrites the endpoints of [config] that address a
+// chain by one of its [chainAliases], e.g. "/ext/bc/X/rpc", to address it by
+// its ID, because chain routes are rate limited under their chain ID.
+func resolveRateLimitAliases(config *server.RateLimitConfig, chainAliases map[ids.ID][]string) {
+	// Aliases are matched case-insensitively, because config files may be
+	// parsed into lowercase keys.
+	chainIDs := make(map[string]ids.ID)
+	for chainID, aliases := range chainAliases {
+		for _, alias := range aliases {
+			chainIDs[strings.ToLower(alias)] = chainID
+		}
+	}
+
+	prefix := "/ext/" + constants.ChainAliasPrefix + "/"
+	endpoints := make(map[string]server.RateLimit, len(config.Endpoints))
+	for endpoint, limit := range config.Endpoints {
+		if suffix, ok := strings.CutPrefix(strings.ToLower(endpoint), prefix); ok {
+			alias, route, _ := strings.Cut(suffix, "/")
+			if chainID, ok := chainIDs[alias]; ok {
+				endpoint = prefix + chainID.String() + strings.TrimSuffix("/"+route, "/")
+			}
+		}
+		endpoints[endpoint] = limit
+	}
+	config.Endpoints = endpoints
+}
+
+func getClientAuthConfig(v *viper.Viper) (server.ClientAuthConfig, error) {
+	config := server.ClientAuthConfig{
+		Subjects: v.GetStringMapString(HTTPSClientSubjectsKey),
+		Roles:    make(auth.Roles),
+	}
+
+	var err error
+	switch {
+	case v.IsSet(HTTPSClientCAContentKey):
+		rawContent := v.GetString(HTTPSClientCAContentKey)
+		config.CACerts, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return server.ClientAuthConfig{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(HTTPSClientCAFileKey):
+		clientCAFilepath := GetExpandedArg(v, HTTPSClientCAFileKey)
+		config.CACerts, err = os.ReadFile(filepath.Clean(clientCAFilepath))
+		if err != nil {
+			return server.ClientAuthConfig{}, err
+		}
+	}
+	if len(config.CACerts) > 0 && !v.GetBool(HTTPSEnabledKey) {
+		return server.ClientAuthConfig{}, errClientCAWithoutTLS
+	}
+
+	for role, endpoints := range v.GetStringMapString(HTTPSClientRolesKey) {
+		config.Roles[role] = strings.Split(endpoints, ":")
+	}
+	return config, config.Verify()
+}
+
 func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 	var (
 		httpsKey  []byte
@@ -174,12 +276,30 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		}
 	}
 
+	rateLimitConfig, err := getRateLimitConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+
+	clientAuthConfig, err := getClientAuthConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+
+	unixSocketPerms, err := strconv.ParseUint(v.GetString(HTTPUnixSocketPermsKey), 8, 32)
+	if err != nil {
+		return node.HTTPConfig{}, fmt.Errorf("invalid %s: %w", HTTPUnixSocketPermsKey, err)
+	}
+
 	return node.HTTPConfig{
 		HTTPConfig: server.HTTPConfig{
 			ReadTimeout:       v.GetDuration(HTTPReadTimeoutKey),
 			ReadHeaderTimeout: v.GetDuration(HTTPReadHeaderTimeoutKey),
 			WriteTimeout:      v.GetDuration(HTTPWriteTimeoutKey),
 			IdleTimeout:       v.GetDuration(HTTPIdleTimeoutKey),
+			MaxBatchSize:      int(v.GetUint(HTTPMaxBatchSizeKey)),
+			RateLimit:         rateLimitConfig,
+			ClientAuth:        clientAuthConfig,
 		},
 		APIConfig: node.APIConfig{
 			APIIndexerConfig: node.APIIndexerConfig{
@@ -192,15 +312,17 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 			MetricsAPIEnabled:  v.GetBool(MetricsAPIEnabledKey),
 			HealthAPIEnabled:   v.GetBool(HealthAPIEnabledKey),
 		},
-		HTTPHost:           v.GetString(HTTPHostKey),
-		HTTPPort:           uint16(v.GetUint(HTTPPortKey)),
-		HTTPSEnabled:       v.GetBool(HTTPSEnabledKey),
-		HTTPSKey:           httpsKey,
-		HTTPSCert:          httpsCert,
-		HTTPAllowedOrigins: v.GetStringSlice(HTTPAllowedOrigins),
-		HTTPAllowedHosts:   v.GetStringSlice(HTTPAllowedHostsKey),
-		ShutdownTimeout:    v.GetDuration(HTTPShutdownTimeoutKey),
-		ShutdownWait:       v.GetDuration(HTTPShutdownWaitKey),
+		HTTPHost:                  v.GetString(HTTPHostKey),
+		HTTPPort:                  uint16(v.GetUint(HTTPPortKey)),
+		HTTPSEnabled:              v.GetBool(HTTPSEnabledKey),
+		HTTPSKey:                  httpsKey,
+		HTTPSCert:                 httpsCert,
+		HTTPUnixSocketPath:        GetExpandedArg(v, HTTPUnixSocketPathKey),
+		HTTPUnixSocketPermissions: os.FileMode(unixSocketPerms),
+		HTTPAllowedOrigins:        v.GetStringSlice(HTTPAllowedOrigins),
+		HTTPAllowedHosts:          v.GetStringSlice(HTTPAllowedHostsKey),
+		ShutdownTimeout:           v.GetDuration(HTTPShutdownTimeoutKey),
+		ShutdownWait:              v.GetDuration(HTTPShutdownWaitKey),
 	}, nil
 }
 
@@ -821,6 +943,30 @@ func getGenesisData(v *viper.Viper, networkID uint32, stakingCfg *genesis.Stakin
 	return genesis.FromConfig(config)
 }
 
+func getUpgradeConfig(v *viper.Viper, networkID uint32) (upgrade.Config, error) {
+	upgradeConfig := version.GetUpgradeConfig(networkID)
+	if !v.IsSet(UpgradeFileContentKey) {
+		return upgradeConfig, nil
+	}
+
+	if networkID == constants.MainnetID || networkID == constants.TestnetID {
+		return upgrade.Config{}, errUpgradeOnPublicNetwork
+	}
+
+	upgradeContent, err := base64.StdEncoding.DecodeString(v.GetString(UpgradeFileContentKey))
+	if err != nil {
+		return upgrade.Config{}, fmt.Errorf("unable to decode base64 content: %w", err)
+	}
+	// Upgrades omitted from the content keep the network's default schedule.
+	if err := json.Unmarshal(upgradeContent, &upgradeConfig); err != nil {
+		return upgrade.Config{}, fmt.Errorf("%w: %w", errUnmarshalling, err)
+	}
+	if err := upgradeConfig.Validate(); err != nil {
+		return upgrade.Config{}, err
+	}
+	return upgradeConfig, nil
+}
+
 func getTrackedSubnets(v *viper.Viper) (set.Set[ids.ID], error) {
 	trackSubnetsStr := v.GetString(TrackSubnetsKey)
 	trackSubnetsStrs := strings.Split(trackSubnetsStr, ",")
@@ -1362,6 +1508,13 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	// Upgrade Config
+	nodeConfig.UpgradeConfig, err = getUpgradeConfig(v, nodeConfig.NetworkID)
+	if err != nil {
+		return node.Config{}, fmt.Errorf("couldn't read upgrade config: %w", err)
+	}
+	nodeConfig.NetworkConfig.UpgradeScheduleHash = nodeConfig.UpgradeConfig.Hash()
+
 	// Subnet Configs
 	subnetConfigs, err := getSubnetConfigs(v, nodeConfig.TrackedSubnets.List())
 	if err != nil {
@@ -1448,6 +1601,12 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	if err != nil {
 		return node.Config{}, err
 	}
+	_, genesisChainAliases, err := genesis.Aliases(nodeConfig.GenesisBytes)
+	if err != nil {
+		return node.Config{}, err
+	}
+	resolveRateLimitAliases(&nodeConfig.HTTPConfig.HTTPConfig.RateLimit, genesisChainAliases)
+	resolveRateLimitAliases(&nodeConfig.HTTPConfig.HTTPConfig.RateLimit, nodeConfig.ChainAliases)
 
 	nodeConfig.SystemTrackerFrequency = v.GetDuration(SystemTrackerFrequencyKey)
 	nodeConfig.SystemTrackerProcessingHalflife = v.GetDuration(SystemTrackerProcessingHalflifeKey)
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -18,8 +19,12 @@ import (
 
 	consensusconfig "github.com/luxfi/consensus/config"
 	"github.com/luxfi/ids"
+	"github.com/luxfi/node/api/server"
 	"github.com/luxfi/node/chains"
 	"github.com/luxfi/node/subnets"
+	"github.com/luxfi/node/upgrade"
+	"github.com/luxfi/node/utils/constants"
+	"github.com/luxfi/node/version"
 )
 
 const chainConfigFilenameExtention = ".ex"
@@ -323,6 +328,66 @@ func TestGetVMAliasesFromFlag(t *testing.T) {
 	}
 }
 
+func TestGetUpgradeConfigFromFlag(t *testing.T) {
+	etnaTime := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		networkID        uint32
+		givenJSON        string
+		expectedEtnaTime time.Time
+		expectedDurango  time.Time
+		expectedErr      error
+	}{
+		"no content": {
+			networkID:        constants.LocalID,
+			givenJSON:        "",
+			expectedEtnaTime: version.GetEtnaTime(constants.LocalID),
+			expectedDurango:  version.GetDurangoTime(constants.LocalID),
+			expectedErr:      nil,
+		},
+		"scheduled upgrade": {
+			networkID:        constants.LocalID,
+			givenJSON:        `{"etnaTime": "2030-01-01T00:00:00Z"}`,
+			expectedEtnaTime: etnaTime,
+			expectedDurango:  version.GetDurangoTime(constants.LocalID),
+			expectedErr:      nil,
+		},
+		"invalid json": {
+			networkID:   constants.LocalID,
+			givenJSON:   `{"etnaTime": 5}`,
+			expectedErr: errUnmarshalling,
+		},
+		"out of order upgrades": {
+			networkID:   constants.LocalID,
+			givenJSON:   `{"durangoTime": "2031-01-01T00:00:00Z", "etnaTime": "2030-01-01T00:00:00Z"}`,
+			expectedErr: upgrade.ErrInvalidUpgradeTimes,
+		},
+		"public network": {
+			networkID:   constants.MainnetID,
+			givenJSON:   `{"etnaTime": "2030-01-01T00:00:00Z"}`,
+			expectedErr: errUpgradeOnPublicNetwork,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			if test.givenJSON != "" {
+				v.Set(UpgradeFileContentKey, base64.StdEncoding.EncodeToString([]byte(test.givenJSON)))
+			}
+
+			upgradeConfig, err := getUpgradeConfig(v, test.networkID)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expectedEtnaTime, upgradeConfig.EtnaTime)
+			require.Equal(test.expectedDurango, upgradeConfig.DurangoTime)
+		})
+	}
+}
+
 func TestGetVMAliasesDefaultDir(t *testing.T) {
 	require := require.New(t)
 	root := t.TempDir()
@@ -661,3 +726,26 @@ func TestDevModeFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveRateLimitAliases(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	config := server.RateLimitConfig{
+		Endpoints: map[string]server.RateLimit{
+			"/ext/bc/x/rpc":   {Rate: 1, Burst: 1},
+			"/ext/bc/xvm":     {Rate: 2, Burst: 2},
+			"/ext/bc/unknown": {Rate: 3, Burst: 3},
+			"/ext/index/X/tx": {Rate: 4, Burst: 4},
+		},
+	}
+	resolveRateLimitAliases(&config, map[ids.ID][]string{
+		chainID: {"X", "xvm"},
+	})
+	require.Equal(map[string]server.RateLimit{
+		"/ext/bc/" + chainID.String() + "/rpc": {Rate: 1, Burst: 1},
+		"/ext/bc/" + chainID.String():          {Rate: 2, Burst: 2},
+		"/ext/bc/unknown":                      {Rate: 3, Burst: 3},
+		"/ext/index/X/tx":                      {Rate: 4, Burst: 4},
+	}, config.Endpoints)
+}
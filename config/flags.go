@@ -94,6 +94,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(GenesisDBTypeKey, "pebbledb", "Database type to use for genesis database. Must be one of {pebbledb, badgerdb}")
 	fs.Uint64(GenesisBlockLimitKey, 0, "Limit number of blocks to replay during genesis (0 = all blocks)")
 
+	// Upgrade
+	fs.String(UpgradeFileContentKey, "", "Specifies base64 encoded network upgrade schedule. Not allowed on Mainnet or Testnet")
+
 	// Network ID
 	fs.String(NetworkNameKey, constants.MainnetName, "Network ID this node will connect to")
 
@@ -216,6 +219,12 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(HTTPSKeyContentKey, "", "Specifies base64 encoded TLS private key for the HTTPs server")
 	fs.String(HTTPSCertFileKey, "", fmt.Sprintf("TLS certificate file for the HTTPs server. Ignored if %s is specified", HTTPSCertContentKey))
 	fs.String(HTTPSCertContentKey, "", "Specifies base64 encoded TLS certificate for the HTTPs server")
+	fs.String(HTTPSClientCAFileKey, "", fmt.Sprintf("PEM bundle of the CAs that client certificates must be issued by. If set, the HTTPs server requires clients to present a certificate. Ignored if %s is specified", HTTPSClientCAContentKey))
+	fs.String(HTTPSClientCAContentKey, "", "Specifies base64 encoded PEM bundle of the CAs that client certificates must be issued by")
+	fs.StringToString(HTTPSClientRolesKey, map[string]string{}, "Roles client certificates can be authorized as, mapped to the colon-separated API endpoints they allow access to. Example: sidecar=/ext/admin:/ext/keystore")
+	fs.StringToString(HTTPSClientSubjectsKey, map[string]string{}, fmt.Sprintf("Common names of client certificate subjects, mapped to the role in %s they are authorized as. Clients whose subject isn't mapped are rejected. If empty, verified clients aren't authorized as a role and their requests are authorized like requests made without a client certificate. Example: sidecar.local=sidecar", HTTPSClientRolesKey))
+	fs.String(HTTPUnixSocketPathKey, "", "Path of a Unix domain socket to also serve the HTTP API on. If empty, the API is only served on the HTTP host and port")
+	fs.String(HTTPUnixSocketPermsKey, "0660", "Octal file permissions of the HTTP Unix domain socket")
 	fs.String(HTTPAllowedOrigins, "*", "Origins to allow on the HTTP port. Defaults to * which allows all origins. Example: https://*.lux.network https://*.lux-test.network")
 	fs.StringSlice(HTTPAllowedHostsKey, []string{"localhost"}, "List of acceptable host names in API requests. Provide the wildcard ('*') to accept requests from all hosts. API requests where the Host field is empty or an IP address will always be accepted. An API call whose HTTP Host field isn't acceptable will receive a 403 error code")
 	fs.Duration(HTTPShutdownWaitKey, 0, "Duration to wait after receiving SIGTERM or SIGINT before initiating shutdown. The /health endpoint will return unhealthy during this duration")
@@ -224,6 +233,16 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(HTTPReadHeaderTimeoutKey, 30*time.Second, fmt.Sprintf("Maximum duration to read request headers. The connection's read deadline is reset after reading the headers. If %s is zero, the value of %s is used. If both are zero, there is no timeout.", HTTPReadHeaderTimeoutKey, HTTPReadTimeoutKey))
 	fs.Duration(HTTPWriteTimeoutKey, 30*time.Second, "Maximum duration before timing out writes of the response. It is reset whenever a new request's header is read. A zero or negative value means there will be no timeout.")
 	fs.Duration(HTTPIdleTimeoutKey, 120*time.Second, fmt.Sprintf("Maximum duration to wait for the next request when keep-alives are enabled. If %s is zero, the value of %s is used. If both are zero, there is no timeout.", HTTPIdleTimeoutKey, HTTPReadTimeoutKey))
+	fs.Uint(HTTPMaxBatchSizeKey, 100, "Maximum number of requests in a JSON-RPC batch request")
+	fs.Float64(HTTPRateLimitKey, 0, "Number of API request tokens each client is given per second, per endpoint. Clients are identified by their client certificate role or IP. If 0, API requests aren't rate limited")
+	fs.Uint(HTTPRateLimitBurstKey, 100, "Maximum number of API request tokens each client can accumulate, per endpoint")
+	fs.StringToString(HTTPRateLimitEndpointsKey, map[string]string{}, "Rate limits of specific endpoints, as <rate>:<burst>. Example: /ext/index/X/tx=5:50")
+	fs.StringToString(HTTPRateLimitMethodWeightsKey, map[string]string{
+		"index.getContainerRange": "10",
+		"platform.getUTXOs":       "10",
+		"xvm.getUTXOs":            "10",
+	}, "Number of tokens a call to a JSON-RPC method consumes. Methods that aren't specified consume 1 token. Requests that consume more tokens than the burst are rejected")
+	fs.Uint(HTTPRateLimitMaxClientsKey, 10_000, "Maximum number of clients whose rate limits are tracked per endpoint")
 
 	// Enable/Disable APIs
 	fs.Bool(AdminAPIEnabledKey, false, "If true, this node exposes the Admin API")
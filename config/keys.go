@@ -63,6 +63,12 @@ const (
 	HTTPSKeyContentKey           = "http-tls-key-file-content"
 	HTTPSCertFileKey             = "http-tls-cert-file"
 	HTTPSCertContentKey          = "http-tls-cert-file-content"
+	HTTPSClientCAFileKey         = "http-tls-client-ca-file"
+	HTTPSClientCAContentKey      = "http-tls-client-ca-file-content"
+	HTTPSClientRolesKey          = "http-tls-client-roles"
+	HTTPSClientSubjectsKey       = "http-tls-client-subjects"
+	HTTPUnixSocketPathKey        = "http-unix-socket-path"
+	HTTPUnixSocketPermsKey       = "http-unix-socket-permissions"
 
 	HTTPAllowedOrigins       = "http-allowed-origins"
 	HTTPAllowedHostsKey      = "http-allowed-hosts"
@@ -70,6 +76,13 @@ const (
 	HTTPShutdownWaitKey      = "http-shutdown-wait"
 	HTTPReadTimeoutKey       = "http-read-timeout"
 	HTTPReadHeaderTimeoutKey = "http-read-header-timeout"
+	HTTPMaxBatchSizeKey      = "http-max-batch-size"
+
+	HTTPRateLimitKey              = "http-rate-limit"
+	HTTPRateLimitBurstKey         = "http-rate-limit-burst"
+	HTTPRateLimitEndpointsKey     = "http-rate-limit-endpoints"
+	HTTPRateLimitMethodWeightsKey = "http-rate-limit-method-weights"
+	HTTPRateLimitMaxClientsKey    = "http-rate-limit-max-clients"
 
 	HTTPIdleTimeoutKey                                 = "http-idle-timeout"
 	StateSyncIPsKey                                    = "state-sync-ips"
@@ -5,81 +5,217 @@ package consensus
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/luxfi/consensus"
 	"github.com/luxfi/ids"
 	"github.com/luxfi/log"
 )
 
+const (
+	chainLabel    = "chain"
+	acceptorLabel = "acceptor"
+)
+
+var (
+	_ AcceptorGroup = (*acceptorGroup)(nil)
+
+	acceptorLabels = []string{chainLabel, acceptorLabel}
+)
+
+// AcceptorGroup is a [consensus.AcceptorGroup] whose acceptors can also be
+// called asynchronously.
+type AcceptorGroup interface {
+	consensus.AcceptorGroup
+
+	// RegisterAsyncAcceptor registers an acceptor that is called from a
+	// durable queue rather than while the container is being accepted.
+	RegisterAsyncAcceptor(chainID ids.ID, acceptorName string, acceptor consensus.Acceptor, config AsyncAcceptorConfig) error
+}
+
 type acceptorWrapper struct {
+	name       string
 	acceptor   consensus.Acceptor
 	dieOnError bool
+	// If non-nil, containers are delivered to [acceptor] asynchronously.
+	async *asyncAcceptor
 }
 
 type acceptorGroup struct {
 	log log.Logger
 
+	lag      *prometheus.GaugeVec
+	failures *prometheus.CounterVec
+
 	lock      sync.RWMutex
 	acceptors map[ids.ID]map[string]acceptorWrapper
 }
 
-// NewAcceptorGroup creates a new AcceptorGroup
-func NewAcceptorGroup(log log.Logger) *acceptorGroup {
-	return &acceptorGroup{
-		log:       log,
+// NewAcceptorGroup creates a new AcceptorGroup that reports the lag of its
+// async acceptors to [reg].
+func NewAcceptorGroup(log log.Logger, reg prometheus.Registerer) (*acceptorGroup, error) {
+	a := &acceptorGroup{
+		log: log,
+		lag: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "acceptor_lag",
+				Help: "number of accepted containers that haven't been delivered to an async acceptor",
+			},
+			acceptorLabels,
+		),
+		failures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "acceptor_delivery_failures",
+				Help: "number of times an async acceptor failed to accept a container",
+			},
+			acceptorLabels,
+		),
 		acceptors: make(map[ids.ID]map[string]acceptorWrapper),
 	}
+	return a, errors.Join(
+		reg.Register(a.lag),
+		reg.Register(a.failures),
+	)
 }
 
 func (a *acceptorGroup) RegisterAcceptor(chainID ids.ID, acceptorName string, acceptor consensus.Acceptor, dieOnError bool) error {
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
+	return a.register(chainID, acceptorWrapper{
+		name:       acceptorName,
+		acceptor:   acceptor,
+		dieOnError: dieOnError,
+	})
+}
+
+// RegisterAsyncAcceptor registers an acceptor that is called from a durable
+// queue rather than while the container is being accepted, so that a slow
+// acceptor doesn't delay acceptance unless its queue is full.
+//
+// Containers are delivered in the order they were accepted, at least once.
+// If the acceptor returns an error, the container is delivered again after
+// [config.RetryDelay].
+func (a *acceptorGroup) RegisterAsyncAcceptor(chainID ids.ID, acceptorName string, acceptor consensus.Acceptor, config AsyncAcceptorConfig) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if _, ok := a.acceptors[chainID][acceptorName]; ok {
+		return fmt.Errorf("acceptor %s already registered for chain %s", acceptorName, chainID)
+	}
+
+	labels := prometheus.Labels{
+		chainLabel:    chainID.String(),
+		acceptorLabel: acceptorName,
+	}
+	async, err := newAsyncAcceptor(
+		a.log.With("chain", chainID, "acceptor", acceptorName),
+		acceptor,
+		config,
+		a.lag.With(labels),
+		a.failures.With(labels),
+	)
+	if err != nil {
+		return err
+	}
+
+	return a.register(chainID, acceptorWrapper{
+		name:     acceptorName,
+		acceptor: acceptor,
+		async:    async,
+	})
+}
+
+// register assumes [a.lock] is held.
+func (a *acceptorGroup) register(chainID ids.ID, wrapper acceptorWrapper) error {
 	chainAcceptors, ok := a.acceptors[chainID]
 	if !ok {
 		chainAcceptors = make(map[string]acceptorWrapper)
 		a.acceptors[chainID] = chainAcceptors
 	}
 
-	if _, ok := chainAcceptors[acceptorName]; ok {
-		return fmt.Errorf("acceptor %s already registered for chain %s", acceptorName, chainID)
+	if _, ok := chainAcceptors[wrapper.name]; ok {
+		return fmt.Errorf("acceptor %s already registered for chain %s", wrapper.name, chainID)
 	}
 
-	chainAcceptors[acceptorName] = acceptorWrapper{
-		acceptor:   acceptor,
-		dieOnError: dieOnError,
-	}
+	chainAcceptors[wrapper.name] = wrapper
 	return nil
 }
 
 func (a *acceptorGroup) DeregisterAcceptor(chainID ids.ID, acceptorName string) error {
 	a.lock.Lock()
-	defer a.lock.Unlock()
-
 	chainAcceptors, ok := a.acceptors[chainID]
 	if !ok {
+		a.lock.Unlock()
 		return nil
 	}
 
+	wrapper, ok := chainAcceptors[acceptorName]
 	delete(chainAcceptors, acceptorName)
 	if len(chainAcceptors) == 0 {
 		delete(a.acceptors, chainID)
 	}
+	a.lock.Unlock()
+
+	if ok && wrapper.async != nil {
+		// Stopping waits for an in-progress delivery, so it is done without
+		// holding the lock.
+		wrapper.async.stop()
+		labels := prometheus.Labels{
+			chainLabel:    chainID.String(),
+			acceptorLabel: acceptorName,
+		}
+		a.lag.Delete(labels)
+		a.failures.Delete(labels)
+	}
 	return nil
 }
 
+// Accept calls the synchronous acceptors of [chainID] and enqueues the
+// container for its async acceptors.
+//
+// If a synchronous acceptor registered with dieOnError fails, or the
+// container can't be persisted to the queue of an async acceptor, an error is
+// returned.
 func (a *acceptorGroup) Accept(ctx context.Context, chainID ids.ID, containerID ids.ID, container []byte) error {
 	a.lock.RLock()
-	chainAcceptors := a.acceptors[chainID]
+	chainAcceptors := make([]acceptorWrapper, 0, len(a.acceptors[chainID]))
+	for _, wrapper := range a.acceptors[chainID] {
+		chainAcceptors = append(chainAcceptors, wrapper)
+	}
 	a.lock.RUnlock()
 
-	for name, wrapper := range chainAcceptors {
+	for _, wrapper := range chainAcceptors {
+		if wrapper.async != nil {
+			err := wrapper.async.enqueue(ctx, containerID, container)
+			if errors.Is(err, errAcceptorStopped) {
+				// The acceptor was deregistered concurrently.
+				continue
+			}
+			if err != nil {
+				a.log.Error("failed to enqueue accepted container",
+					"chain", chainID,
+					"acceptor", wrapper.name,
+					"containerID", containerID,
+					"error", err,
+				)
+				return err
+			}
+			continue
+		}
+
 		if err := wrapper.acceptor.Accept(ctx, containerID, container); err != nil {
 			a.log.Error("acceptor failed",
 				"chain", chainID,
-				"acceptor", name,
+				"acceptor", wrapper.name,
 				"containerID", containerID,
 				"error", err,
 			)
@@ -89,4 +225,4 @@ func (a *acceptorGroup) Accept(ctx context.Context, chainID ids.ID, containerID
 		}
 	}
 	return nil
-}
\ No newline at end of file
+}
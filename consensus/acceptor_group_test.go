@@ -0,0 +1,242 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database/memdb"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+)
+
+var errTestAcceptor = errors.New("test acceptor failed")
+
+type testAcceptor struct {
+	AcceptF func(ctx context.Context, containerID ids.ID, container []byte) error
+}
+
+func (a *testAcceptor) Accept(ctx context.Context, containerID ids.ID, container []byte) error {
+	return a.AcceptF(ctx, containerID, container)
+}
+
+// recordingAcceptor records the containers it accepts, and fails while
+// [fail] is set.
+type recordingAcceptor struct {
+	lock     sync.Mutex
+	accepted []ids.ID
+	fail     bool
+}
+
+func (a *recordingAcceptor) Accept(_ context.Context, containerID ids.ID, _ []byte) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.fail {
+		return errTestAcceptor
+	}
+	a.accepted = append(a.accepted, containerID)
+	return nil
+}
+
+func (a *recordingAcceptor) setFail(fail bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.fail = fail
+}
+
+func (a *recordingAcceptor) Accepted() []ids.ID {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return append([]ids.ID(nil), a.accepted...)
+}
+
+func newTestContainerIDs(n int) []ids.ID {
+	containerIDs := make([]ids.ID, n)
+	for i := range containerIDs {
+		containerIDs[i] = ids.ID{byte(i + 1)}
+	}
+	return containerIDs
+}
+
+func TestAcceptorGroupSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	a, err := NewAcceptorGroup(log.NewNoOpLogger(), prometheus.NewRegistry())
+	require.NoError(err)
+	chainID := ids.GenerateTestID()
+
+	failing := &testAcceptor{
+		AcceptF: func(context.Context, ids.ID, []byte) error {
+			return errTestAcceptor
+		},
+	}
+	require.NoError(a.RegisterAcceptor(chainID, "ignored", failing, false))
+	require.NoError(a.Accept(ctx, chainID, ids.GenerateTestID(), nil))
+
+	require.NoError(a.RegisterAcceptor(chainID, "fatal", failing, true))
+	err = a.Accept(ctx, chainID, ids.GenerateTestID(), nil)
+	require.ErrorIs(err, errTestAcceptor)
+
+	require.Error(a.RegisterAcceptor(chainID, "fatal", failing, true))
+	require.NoError(a.DeregisterAcceptor(chainID, "fatal"))
+	require.NoError(a.Accept(ctx, chainID, ids.GenerateTestID(), nil))
+}
+
+func TestAcceptorGroupAsync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	reg := prometheus.NewRegistry()
+	a, err := NewAcceptorGroup(log.NewNoOpLogger(), reg)
+	require.NoError(err)
+	chainID := ids.GenerateTestID()
+
+	// The acceptor is blocked until [unblock] is closed.
+	var (
+		unblock  = make(chan struct{})
+		lock     sync.Mutex
+		accepted []ids.ID
+	)
+	acceptor := &testAcceptor{
+		AcceptF: func(ctx context.Context, containerID ids.ID, container []byte) error {
+			select {
+			case <-unblock:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			accepted = append(accepted, ids.ID(container))
+			return nil
+		},
+	}
+	require.NoError(a.RegisterAsyncAcceptor(chainID, "async", acceptor, AsyncAcceptorConfig{
+		DB:         memdb.New(),
+		MaxPending: 3,
+	}))
+	require.ErrorIs(a.RegisterAsyncAcceptor(chainID, "invalid", acceptor, AsyncAcceptorConfig{
+		MaxPending: 3,
+	}), errNoQueueDB)
+
+	// A blocked async acceptor doesn't block acceptance until its queue is
+	// full.
+	containerIDs := newTestContainerIDs(4)
+	for _, containerID := range containerIDs[:3] {
+		require.NoError(a.Accept(ctx, chainID, containerID, containerID[:]))
+	}
+	lag := a.lag.WithLabelValues(chainID.String(), "async")
+	require.Equal(3.0, testutil.ToFloat64(lag))
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err = a.Accept(timeoutCtx, chainID, containerIDs[3], containerIDs[3][:])
+	require.ErrorIs(err, context.DeadlineExceeded)
+
+	// Once the acceptor catches up, there is space in the queue.
+	close(unblock)
+	require.NoError(a.Accept(ctx, chainID, containerIDs[3], containerIDs[3][:]))
+	require.Eventually(func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(accepted) == len(containerIDs)
+	}, time.Second, time.Millisecond)
+	require.Equal(containerIDs, accepted)
+	require.Zero(testutil.ToFloat64(lag))
+
+	require.NoError(a.DeregisterAcceptor(chainID, "async"))
+	require.NoError(a.Accept(ctx, chainID, ids.GenerateTestID(), nil))
+}
+
+func TestAcceptorGroupAsyncRetry(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	a, err := NewAcceptorGroup(log.NewNoOpLogger(), prometheus.NewRegistry())
+	require.NoError(err)
+	chainID := ids.GenerateTestID()
+
+	acceptor := &recordingAcceptor{fail: true}
+	require.NoError(a.RegisterAsyncAcceptor(chainID, "async", acceptor, AsyncAcceptorConfig{
+		DB:         memdb.New(),
+		MaxPending: 10,
+		RetryDelay: time.Millisecond,
+	}))
+
+	// Failures of async acceptors aren't reported to the caller.
+	containerIDs := newTestContainerIDs(2)
+	for _, containerID := range containerIDs {
+		require.NoError(a.Accept(ctx, chainID, containerID, nil))
+	}
+
+	failures := a.failures.WithLabelValues(chainID.String(), "async")
+	require.Eventually(func() bool {
+		return testutil.ToFloat64(failures) >= 2
+	}, time.Second, time.Millisecond)
+	require.Empty(acceptor.Accepted())
+
+	// Failed containers are delivered again, in order.
+	acceptor.setFail(false)
+	require.Eventually(func() bool {
+		return len(acceptor.Accepted()) == len(containerIDs)
+	}, time.Second, time.Millisecond)
+	require.Equal(containerIDs, acceptor.Accepted())
+}
+
+func TestAcceptorGroupAsyncDurable(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	a, err := NewAcceptorGroup(log.NewNoOpLogger(), prometheus.NewRegistry())
+	require.NoError(err)
+	chainID := ids.GenerateTestID()
+	db := memdb.New()
+	config := AsyncAcceptorConfig{
+		DB:         db,
+		MaxPending: 10,
+		RetryDelay: time.Millisecond,
+	}
+
+	// Deliver the first container, then stop delivering.
+	containerIDs := newTestContainerIDs(3)
+	acceptor := &recordingAcceptor{}
+	require.NoError(a.RegisterAsyncAcceptor(chainID, "async", acceptor, config))
+	require.NoError(a.Accept(ctx, chainID, containerIDs[0], nil))
+	require.Eventually(func() bool {
+		return len(acceptor.Accepted()) == 1
+	}, time.Second, time.Millisecond)
+
+	acceptor.setFail(true)
+	for _, containerID := range containerIDs[1:] {
+		require.NoError(a.Accept(ctx, chainID, containerID, nil))
+	}
+	require.NoError(a.DeregisterAcceptor(chainID, "async"))
+
+	// A new acceptor with the same database resumes delivery after the last
+	// committed container.
+	acceptor = &recordingAcceptor{}
+	require.NoError(a.RegisterAsyncAcceptor(chainID, "async", acceptor, config))
+	require.Eventually(func() bool {
+		return len(acceptor.Accepted()) == 2
+	}, time.Second, time.Millisecond)
+	require.Equal(containerIDs[1:], acceptor.Accepted())
+	require.NoError(a.DeregisterAcceptor(chainID, "async"))
+
+	// Delivered containers are removed from the queue.
+	it := db.NewIteratorWithPrefix(entryPrefix)
+	defer it.Release()
+	require.False(it.Next())
+	require.NoError(it.Error())
+}
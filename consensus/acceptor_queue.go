@@ -0,0 +1,273 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/luxfi/consensus"
+	"github.com/luxfi/database"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+)
+
+const defaultRetryDelay = time.Second
+
+var (
+	entryPrefix = []byte{0x00}
+	nextKey     = []byte{0x01}
+	cursorKey   = []byte{0x02}
+
+	errNoQueueDB         = errors.New("async acceptor requires a database")
+	errZeroMaxPending    = errors.New("async acceptor max pending must be positive")
+	errAcceptorStopped   = errors.New("acceptor stopped")
+	errCorruptQueueEntry = errors.New("corrupt queue entry")
+)
+
+// AsyncAcceptorConfig configures an acceptor that is called asynchronously
+// from a durable queue, rather than while the container is being accepted.
+type AsyncAcceptorConfig struct {
+	// DB persists the queue. It must not be shared with any other acceptor.
+	// Registering an acceptor with the DB of a previously registered acceptor
+	// resumes delivery where the previous acceptor left off.
+	DB database.Database
+	// MaxPending is the maximum number of accepted containers that haven't
+	// been delivered. Once it is reached, accepting containers blocks until
+	// the acceptor catches up.
+	MaxPending uint64
+	// RetryDelay is the time to wait after the acceptor fails before
+	// delivering the container again. Defaults to 1s.
+	RetryDelay time.Duration
+}
+
+func (c *AsyncAcceptorConfig) Verify() error {
+	switch {
+	case c.DB == nil:
+		return errNoQueueDB
+	case c.MaxPending == 0:
+		return errZeroMaxPending
+	default:
+		return nil
+	}
+}
+
+// asyncAcceptor delivers accepted containers to an acceptor from a durable
+// queue. Delivery is at-least-once: the cursor is only committed after the
+// acceptor returns successfully, so a container may be delivered again if the
+// node stops after it was delivered but before the cursor was committed.
+type asyncAcceptor struct {
+	log        log.Logger
+	acceptor   consensus.Acceptor
+	db         database.Database
+	maxPending uint64
+	retryDelay time.Duration
+
+	lag      prometheus.Gauge
+	failures prometheus.Counter
+
+	lock sync.Mutex
+	// The index the next accepted container will be written to.
+	next uint64
+	// The index of the next container to deliver.
+	cursor uint64
+	// Closed and replaced whenever a container is delivered, to wake up
+	// callers waiting for space in the queue.
+	delivered chan struct{}
+	stopped   bool
+
+	// Signaled whenever a container is added to the queue.
+	enqueued chan struct{}
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func newAsyncAcceptor(
+	log log.Logger,
+	acceptor consensus.Acceptor,
+	config AsyncAcceptorConfig,
+	lag prometheus.Gauge,
+	failures prometheus.Counter,
+) (*asyncAcceptor, error) {
+	next, err := getUInt64(config.DB, nextKey)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := getUInt64(config.DB, cursorKey)
+	if err != nil {
+		return nil, err
+	}
+
+	retryDelay := config.RetryDelay
+	if retryDelay <= 0 {
+		retryDelay = defaultRetryDelay
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a := &asyncAcceptor{
+		log:        log,
+		acceptor:   acceptor,
+		db:         config.DB,
+		maxPending: config.MaxPending,
+		retryDelay: retryDelay,
+		lag:        lag,
+		failures:   failures,
+		next:       next,
+		cursor:     cursor,
+		delivered:  make(chan struct{}),
+		enqueued:   make(chan struct{}, 1),
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	a.lag.Set(float64(next - cursor))
+	go a.run(ctx)
+	return a, nil
+}
+
+// enqueue persists the container to be delivered. If the queue is full,
+// enqueue blocks until there is space or [ctx] is cancelled.
+func (a *asyncAcceptor) enqueue(ctx context.Context, containerID ids.ID, container []byte) error {
+	a.lock.Lock()
+	for !a.stopped && a.next-a.cursor >= a.maxPending {
+		delivered := a.delivered
+		a.lock.Unlock()
+
+		select {
+		case <-delivered:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		a.lock.Lock()
+	}
+	defer a.lock.Unlock()
+
+	if a.stopped {
+		return errAcceptorStopped
+	}
+
+	entry := make([]byte, ids.IDLen+len(container))
+	copy(entry, containerID[:])
+	copy(entry[ids.IDLen:], container)
+
+	batch := a.db.NewBatch()
+	if err := batch.Put(entryKey(a.next), entry); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(batch, nextKey, a.next+1); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	a.next++
+	a.lag.Set(float64(a.next - a.cursor))
+	select {
+	case a.enqueued <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (a *asyncAcceptor) run(ctx context.Context) {
+	defer close(a.done)
+
+	for {
+		a.lock.Lock()
+		cursor, next := a.cursor, a.next
+		a.lock.Unlock()
+
+		if cursor == next {
+			select {
+			case <-a.enqueued:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := a.deliver(ctx, cursor); err != nil {
+			a.failures.Inc()
+			a.log.Warn("failed to deliver accepted container",
+				"index", cursor,
+				"error", err,
+			)
+
+			select {
+			case <-time.After(a.retryDelay):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// deliver calls the acceptor with the container at [index] and commits the
+// cursor past it.
+func (a *asyncAcceptor) deliver(ctx context.Context, index uint64) error {
+	key := entryKey(index)
+	entry, err := a.db.Get(key)
+	if err != nil {
+		return err
+	}
+	if len(entry) < ids.IDLen {
+		return fmt.Errorf("%w: %d bytes at index %d", errCorruptQueueEntry, len(entry), index)
+	}
+
+	containerID := ids.ID(entry[:ids.IDLen])
+	if err := a.acceptor.Accept(ctx, containerID, entry[ids.IDLen:]); err != nil {
+		return err
+	}
+
+	batch := a.db.NewBatch()
+	if err := batch.Delete(key); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(batch, cursorKey, index+1); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.cursor = index + 1
+	a.lag.Set(float64(a.next - a.cursor))
+	close(a.delivered)
+	a.delivered = make(chan struct{})
+	return nil
+}
+
+// stop waits for the in-progress delivery, if any, to finish. Containers that
+// haven't been delivered remain in the queue.
+func (a *asyncAcceptor) stop() {
+	a.lock.Lock()
+	a.stopped = true
+	close(a.delivered)
+	a.delivered = make(chan struct{})
+	a.lock.Unlock()
+
+	a.cancel()
+	<-a.done
+}
+
+func entryKey(index uint64) []byte {
+	return append(entryPrefix, database.PackUInt64(index)...)
+}
+
+// getUInt64 returns 0 if [key] isn't in [db].
+func getUInt64(db database.KeyValueReader, key []byte) (uint64, error) {
+	value, err := database.GetUInt64(db, key)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	return value, err
+}
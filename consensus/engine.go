@@ -3,48 +3,481 @@ package consensus
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"gonum.org/v1/gonum/mathext/prng"
 
 	"github.com/luxfi/ids"
 	"github.com/luxfi/log"
+	"github.com/luxfi/node/utils/hashing"
+	"github.com/luxfi/node/utils/sampler"
+)
+
+var (
+	ErrNotFinalized = errors.New("not finalized")
+	ErrUnknownTx    = errors.New("unknown transaction")
+
+	errInvalidSampleSize          = errors.New("sample size must be positive")
+	errInvalidFirstRoundThreshold = errors.New("first round threshold must be in (0, 1)")
+	errInvalidThresholdBound      = errors.New("threshold bound must be in [0, 0.5)")
+	errInvalidFinalizationRounds  = errors.New("finalization rounds must be positive")
+	errZeroWeight                 = errors.New("validator weight must be positive")
+	errAlreadyStarted             = errors.New("engine already started")
 )
 
+// FPCConfig configures Fast Probabilistic Consensus.
+//
+// Every round, the engine samples [SampleSize] validators, weighted by stake,
+// and asks them for their opinions on every undecided transaction. A
+// transaction is liked in the next round if the fraction of sampled opinions
+// that like it exceeds the round's threshold. The threshold is
+// [FirstRoundThreshold] in the first round of a transaction, and is otherwise
+// drawn uniformly from [ThresholdBound, 1-ThresholdBound] using randomness
+// revealed by a [Beacon] after the opinions of the round have been collected,
+// so that an adversary can't predict it when choosing its opinions. A
+// transaction is finalized once its opinion has been
+// unchanged for [FinalizationRounds] consecutive rounds.
+type FPCConfig struct {
+	SampleSize          int     `json:"sampleSize"`
+	FirstRoundThreshold float64 `json:"firstRoundThreshold"`
+	ThresholdBound      float64 `json:"thresholdBound"`
+	FinalizationRounds  int     `json:"finalizationRounds"`
+	// RoundInterval is the time between rounds after the engine is started.
+	// If 0, rounds are only run by calling Round.
+	RoundInterval time.Duration `json:"roundInterval"`
+}
+
+// DefaultFPCConfig returns the default configuration for an engine that
+// tolerates [f] Byzantine validators in each sample.
+func DefaultFPCConfig(f int) FPCConfig {
+	return FPCConfig{
+		SampleSize:          max(2*f+1, 1),
+		FirstRoundThreshold: .5,
+		ThresholdBound:      .3,
+		FinalizationRounds:  10,
+	}
+}
+
+func (c *FPCConfig) Verify() error {
+	switch {
+	case c.SampleSize <= 0:
+		return errInvalidSampleSize
+	case c.FirstRoundThreshold <= 0 || c.FirstRoundThreshold >= 1:
+		return errInvalidFirstRoundThreshold
+	case c.ThresholdBound < 0 || c.ThresholdBound >= .5:
+		return errInvalidThresholdBound
+	case c.FinalizationRounds <= 0:
+		return errInvalidFinalizationRounds
+	default:
+		return nil
+	}
+}
+
+// Validator is a node that is sampled with probability proportional to its
+// weight.
+type Validator struct {
+	NodeID ids.NodeID
+	Weight uint64
+}
+
+// Beacon provides randomness that every node observes identically but that
+// can't be predicted before it is revealed, such as a VRF output or the
+// randomness beacon of the last accepted block.
+type Beacon interface {
+	// Randomness returns the most recently revealed randomness.
+	Randomness(ctx context.Context) (ids.ID, error)
+}
+
+// localBeacon draws fresh randomness every time it is queried. It isn't shared
+// with other nodes, so it is only suitable for an engine without validators.
+type localBeacon struct{}
+
+func (localBeacon) Randomness(context.Context) (ids.ID, error) {
+	var randomness ids.ID
+	_, err := rand.Read(randomness[:])
+	return randomness, err
+}
+
+// Querier requests the opinions of other validators.
+type Querier interface {
+	// Opinions returns whether [nodeID] likes each of [txIDs].
+	Opinions(ctx context.Context, nodeID ids.NodeID, txIDs []ids.ID) ([]bool, error)
+}
+
+type txState struct {
+	liked bool
+	// The number of rounds this transaction has been voted on.
+	rounds int
+	// The number of consecutive rounds this transaction's opinion hasn't
+	// changed.
+	stableRounds int
+	finalized    bool
+}
+
 // FPCEngine provides fast-path consensus for the Lux node
 type FPCEngine struct {
-	log log.Logger
+	log     log.Logger
+	config  FPCConfig
+	querier Querier
+	beacon  Beacon
+
+	// Held for the duration of a round, so that concurrent calls to Round
+	// don't interleave. Must be acquired before [lock].
+	roundLock sync.Mutex
+
+	lock sync.Mutex
+	// Draws sample values in [0, totalWeight). Not safe for concurrent use.
+	uniform sampler.Uniform
+
+	validators  []Validator
+	weighted    sampler.Weighted
+	totalWeight uint64
+
+	txs map[ids.ID]*txState
+	// Undecided transactions, in the order they were proposed.
+	undecided []ids.ID
+	// Transactions that were finalized as liked but haven't been returned by
+	// Executable, in the order they were finalized.
+	executable []ids.ID
+
+	running bool
+	stop    chan struct{}
+	done    chan struct{}
 }
 
-// NewFPCEngine creates a new FPC consensus engine
+// NewFPCEngine creates a new FPC consensus engine that tolerates [f]
+// Byzantine validators in each sample.
+//
+// Until validators are set, the engine only samples its own opinions. As its
+// thresholds are drawn locally rather than from a shared [Beacon], it should
+// only be used without validators.
 func NewFPCEngine(f int) *FPCEngine {
+	source := prng.NewMT19937()
+	source.Seed(uint64(time.Now().UnixNano()))
+
+	// The default config is always valid.
+	engine, _ := NewFPCEngineWithConfig(
+		log.NewLogger("fpc"),
+		DefaultFPCConfig(f),
+		nil,
+		localBeacon{},
+		source,
+	)
+	return engine
+}
+
+// NewFPCEngineWithConfig creates a new FPC consensus engine that queries
+// sampled validators with [querier], derives thresholds from [beacon] and
+// samples validators using [source].
+func NewFPCEngineWithConfig(
+	log log.Logger,
+	config FPCConfig,
+	querier Querier,
+	beacon Beacon,
+	source sampler.Source,
+) (*FPCEngine, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
 	return &FPCEngine{
-		log: log.NewLogger("fpc"),
+		log:      log,
+		config:   config,
+		querier:  querier,
+		beacon:   beacon,
+		uniform:  sampler.NewDeterministicUniform(source),
+		weighted: sampler.NewWeighted(),
+		txs:      make(map[ids.ID]*txState),
+	}, nil
+}
+
+// SetValidators sets the validators that are sampled in future rounds.
+func (e *FPCEngine) SetValidators(validators []Validator) error {
+	weights := make([]uint64, len(validators))
+	var totalWeight uint64
+	for i, vdr := range validators {
+		if vdr.Weight == 0 {
+			return fmt.Errorf("%w: %s", errZeroWeight, vdr.NodeID)
+		}
+		weights[i] = vdr.Weight
+		totalWeight += vdr.Weight
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if err := e.weighted.Initialize(weights); err != nil {
+		return err
 	}
+	e.uniform.Initialize(totalWeight)
+	e.validators = validators
+	e.totalWeight = totalWeight
+	return nil
 }
 
-// Start initializes the consensus engine
+// Start initializes the consensus engine. If a round interval is configured,
+// rounds are run periodically until the engine is stopped or [ctx] is
+// cancelled.
 func (e *FPCEngine) Start(ctx context.Context) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.running {
+		return errAlreadyStarted
+	}
 	e.log.Info("Starting FPC consensus engine")
+	e.running = true
+	if e.config.RoundInterval <= 0 {
+		return nil
+	}
+
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+	go e.run(ctx, e.stop, e.done)
 	return nil
 }
 
+func (e *FPCEngine) run(ctx context.Context, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(e.config.RoundInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.Round(ctx); err != nil {
+				e.log.Warn("FPC round failed", "error", err)
+			}
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // Stop shuts down the consensus engine
 func (e *FPCEngine) Stop() error {
+	e.lock.Lock()
+	stop, done := e.stop, e.done
+	e.running = false
+	e.stop, e.done = nil, nil
+	e.lock.Unlock()
+
 	e.log.Info("Stopping FPC consensus engine")
+	if stop != nil {
+		close(stop)
+		<-done
+	}
 	return nil
 }
 
-// Propose adds a transaction to consensus
+// Propose adds a transaction to consensus that this node initially likes.
 func (e *FPCEngine) Propose(txID ids.ID) error {
-	e.log.Debug("Proposed transaction", "txID", txID)
+	return e.ProposeWithOpinion(txID, true)
+}
+
+// ProposeWithOpinion adds a transaction to consensus with this node's initial
+// opinion of it. Proposing a transaction that is already known is a no-op.
+func (e *FPCEngine) ProposeWithOpinion(txID ids.ID, liked bool) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if _, ok := e.txs[txID]; ok {
+		return nil
+	}
+	e.txs[txID] = &txState{liked: liked}
+	e.undecided = append(e.undecided, txID)
+	e.log.Debug("Proposed transaction", "txID", txID, "liked", liked)
 	return nil
 }
 
-// Query checks if a transaction is finalized
+// Opinions returns whether this node currently likes each of [txIDs].
+// Transactions this node doesn't know about are reported as disliked.
+func (e *FPCEngine) Opinions(txIDs []ids.ID) []bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	opinions := make([]bool, len(txIDs))
+	for i, txID := range txIDs {
+		if tx, ok := e.txs[txID]; ok {
+			opinions[i] = tx.liked
+		}
+	}
+	return opinions
+}
+
+// Query checks if a transaction is finalized. Returns whether the transaction
+// was accepted if it was finalized, and [ErrNotFinalized] otherwise.
 func (e *FPCEngine) Query(txID ids.ID) (bool, error) {
-	return false, fmt.Errorf("not finalized: %s", txID)
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	tx, ok := e.txs[txID]
+	switch {
+	case !ok:
+		return false, fmt.Errorf("%w: %s", ErrUnknownTx, txID)
+	case !tx.finalized:
+		return false, fmt.Errorf("%w: %s", ErrNotFinalized, txID)
+	default:
+		return tx.liked, nil
+	}
 }
 
-// Executable returns transactions ready for execution
+// Executable returns the transactions that were accepted since the last call,
+// in the order they were finalized.
 func (e *FPCEngine) Executable() []ids.ID {
-	return []ids.ID{} // Return empty slice instead of nil
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	executable := e.executable
+	e.executable = nil
+	if executable == nil {
+		return []ids.ID{}
+	}
+	return executable
+}
+
+// Round runs a single round of voting on every undecided transaction.
+// Concurrent calls are run one after another.
+func (e *FPCEngine) Round(ctx context.Context) error {
+	e.roundLock.Lock()
+	defer e.roundLock.Unlock()
+
+	e.lock.Lock()
+	txIDs := append([]ids.ID(nil), e.undecided...)
+	sampled, err := e.sample()
+	e.lock.Unlock()
+	if err != nil || len(txIDs) == 0 {
+		return err
+	}
+
+	var (
+		likes     = make([]int, len(txIDs))
+		responses int
+	)
+	if len(sampled) == 0 {
+		// Without validators, this node only samples itself.
+		for i, liked := range e.Opinions(txIDs) {
+			if liked {
+				likes[i]++
+			}
+		}
+		responses = 1
+	}
+	for _, nodeID := range sampled {
+		opinions, err := e.querier.Opinions(ctx, nodeID, txIDs)
+		if err != nil || len(opinions) != len(txIDs) {
+			// Unresponsive validators aren't counted.
+			e.log.Debug("failed to query opinions",
+				"nodeID", nodeID,
+				"numOpinions", len(opinions),
+				"error", err,
+			)
+			continue
+		}
+		for i, liked := range opinions {
+			if liked {
+				likes[i]++
+			}
+		}
+		responses++
+	}
+
+	if responses == 0 {
+		return nil
+	}
+
+	// The randomness is only fetched once the opinions have been collected, so
+	// that they can't depend on the threshold.
+	randomness, err := e.beacon.Randomness(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch randomness: %w", err)
+	}
+	randomThreshold := e.randomThreshold(randomness)
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for i, txID := range txIDs {
+		tx := e.txs[txID]
+		threshold := randomThreshold
+		if tx.rounds == 0 {
+			threshold = e.config.FirstRoundThreshold
+		}
+		tx.rounds++
+
+		liked := float64(likes[i])/float64(responses) > threshold
+		if liked == tx.liked {
+			tx.stableRounds++
+		} else {
+			tx.liked = liked
+			tx.stableRounds = 0
+		}
+		if tx.stableRounds < e.config.FinalizationRounds {
+			continue
+		}
+
+		tx.finalized = true
+		if tx.liked {
+			e.executable = append(e.executable, txID)
+		}
+		e.log.Debug("Finalized transaction",
+			"txID", txID,
+			"accepted", tx.liked,
+			"rounds", tx.rounds,
+		)
+	}
+
+	undecided := e.undecided[:0]
+	for _, txID := range e.undecided {
+		if !e.txs[txID].finalized {
+			undecided = append(undecided, txID)
+		}
+	}
+	e.undecided = undecided
+	return nil
+}
+
+// sample returns [e.config.SampleSize] validators sampled with replacement,
+// weighted by stake. Returns no validators if there aren't any validators.
+//
+// Assumes [e.lock] is held.
+func (e *FPCEngine) sample() ([]ids.NodeID, error) {
+	if e.totalWeight == 0 {
+		return nil, nil
+	}
+	sampled := make([]ids.NodeID, e.config.SampleSize)
+	for i := range sampled {
+		// Values are drawn with replacement, so every draw starts over.
+		e.uniform.Reset()
+		value, ok := e.uniform.Next()
+		if !ok {
+			return nil, fmt.Errorf("failed to draw a weight below %d", e.totalWeight)
+		}
+		index, ok := e.weighted.Sample(value)
+		if !ok {
+			return nil, fmt.Errorf("failed to sample weight %d of %d", value, e.totalWeight)
+		}
+		sampled[i] = e.validators[index].NodeID
+	}
+	return sampled, nil
+}
+
+// randomThreshold returns the threshold used by transactions that have already
+// been voted on. The threshold is derived from the beacon's [randomness], so
+// every node that observes the same randomness uses the same threshold.
+func (e *FPCEngine) randomThreshold(randomness ids.ID) float64 {
+	hash := hashing.ComputeHash256(randomness[:])
+
+	// Uniform in [0, 1].
+	uniform := float64(binary.BigEndian.Uint64(hash)) / math.MaxUint64
+	bound := e.config.ThresholdBound
+	return bound + (1-2*bound)*uniform
 }
@@ -2,10 +2,17 @@ package consensus
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
-	"github.com/luxfi/ids"
 	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mathext/prng"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
 )
 
 func TestFPCEngine(t *testing.T) {
@@ -42,24 +49,218 @@ func TestFPCEngine(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestVerkleIntegration(t *testing.T) {
-	engine := NewFPCEngine(3)
-	require.NotNil(t, engine)
+func TestFPCEngineSingleNode(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
 
-	// Test witness validation
-	// payload := make([]byte, 1000)
-	// payload[0] = 0x01 // varint length indicator
+	config := DefaultFPCConfig(3)
+	engine, err := NewFPCEngineWithConfig(log.NewNoOpLogger(), config, nil, localBeacon{}, prng.NewMT19937())
+	require.NoError(err)
 
-	// header := mockHeader{
-	// 	id: [32]byte{1, 2, 3},
-	// }
+	liked := ids.GenerateTestID()
+	disliked := ids.GenerateTestID()
+	require.NoError(engine.Propose(liked))
+	require.NoError(engine.ProposeWithOpinion(disliked, false))
+	require.Equal([]bool{true, false, false}, engine.Opinions([]ids.ID{liked, disliked, ids.GenerateTestID()}))
+
+	_, err = engine.Query(ids.GenerateTestID())
+	require.ErrorIs(err, ErrUnknownTx)
+
+	// Without validators, the engine only samples its own opinions.
+	for i := 0; i < config.FinalizationRounds-1; i++ {
+		require.NoError(engine.Round(ctx))
+		_, err := engine.Query(liked)
+		require.ErrorIs(err, ErrNotFinalized)
+	}
+	require.NoError(engine.Round(ctx))
+
+	accepted, err := engine.Query(liked)
+	require.NoError(err)
+	require.True(accepted)
+
+	accepted, err = engine.Query(disliked)
+	require.NoError(err)
+	require.False(accepted)
+
+	require.Equal([]ids.ID{liked}, engine.Executable())
+	require.Empty(engine.Executable())
+}
+
+func TestFPCEngineRoundInterval(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultFPCConfig(0)
+	config.FinalizationRounds = 1
+	config.RoundInterval = time.Millisecond
+	engine, err := NewFPCEngineWithConfig(log.NewNoOpLogger(), config, nil, localBeacon{}, prng.NewMT19937())
+	require.NoError(err)
+
+	txID := ids.GenerateTestID()
+	require.NoError(engine.Propose(txID))
+	require.NoError(engine.Start(context.Background()))
+	require.ErrorIs(engine.Start(context.Background()), errAlreadyStarted)
+
+	require.Eventually(func() bool {
+		accepted, err := engine.Query(txID)
+		return err == nil && accepted
+	}, time.Second, time.Millisecond)
+	require.NoError(engine.Stop())
+}
+
+func TestFPCConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*FPCConfig)
+		expectedErr error
+	}{
+		{
+			name:   "default",
+			modify: func(*FPCConfig) {},
+		},
+		{
+			name:        "zero sample size",
+			modify:      func(c *FPCConfig) { c.SampleSize = 0 },
+			expectedErr: errInvalidSampleSize,
+		},
+		{
+			name:        "first round threshold of 1",
+			modify:      func(c *FPCConfig) { c.FirstRoundThreshold = 1 },
+			expectedErr: errInvalidFirstRoundThreshold,
+		},
+		{
+			name:        "threshold bound of .5",
+			modify:      func(c *FPCConfig) { c.ThresholdBound = .5 },
+			expectedErr: errInvalidThresholdBound,
+		},
+		{
+			name:        "zero finalization rounds",
+			modify:      func(c *FPCConfig) { c.FinalizationRounds = 0 },
+			expectedErr: errInvalidFinalizationRounds,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultFPCConfig(3)
+			test.modify(&config)
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestFPCEngineSetValidators(t *testing.T) {
+	require := require.New(t)
+
+	engine := NewFPCEngine(1)
+	err := engine.SetValidators([]Validator{
+		{NodeID: ids.GenerateTestNodeID(), Weight: 1},
+		{NodeID: ids.GenerateTestNodeID()},
+	})
+	require.ErrorIs(err, errZeroWeight)
+}
+
+func TestRandomThreshold(t *testing.T) {
+	require := require.New(t)
 
-	// valid, size, root := engine.ValidateWitness(header, payload)
+	config := DefaultFPCConfig(3)
+	engine, err := NewFPCEngineWithConfig(log.NewNoOpLogger(), config, nil, localBeacon{}, prng.NewMT19937())
+	require.NoError(err)
+
+	thresholds := make(map[float64]struct{})
+	for i := 0; i < 100; i++ {
+		randomness := ids.GenerateTestID()
+		threshold := engine.randomThreshold(randomness)
+		require.GreaterOrEqual(threshold, config.ThresholdBound)
+		require.LessOrEqual(threshold, 1-config.ThresholdBound)
+		thresholds[threshold] = struct{}{}
+
+		// Every node that observes the same randomness derives the same
+		// threshold.
+		require.Equal(threshold, engine.randomThreshold(randomness))
+	}
+	require.Len(thresholds, 100)
+}
+
+type testBeacon struct {
+	err error
+}
+
+func (b *testBeacon) Randomness(context.Context) (ids.ID, error) {
+	return ids.Empty, b.err
+}
+
+func TestFPCEngineBeaconError(t *testing.T) {
+	require := require.New(t)
+
+	errBeacon := errors.New("beacon unavailable")
+	config := DefaultFPCConfig(3)
+	engine, err := NewFPCEngineWithConfig(log.NewNoOpLogger(), config, nil, &testBeacon{err: errBeacon}, prng.NewMT19937())
+	require.NoError(err)
+
+	txID := ids.GenerateTestID()
+	require.NoError(engine.Propose(txID))
+	require.ErrorIs(engine.Round(context.Background()), errBeacon)
+
+	// The failed round wasn't counted.
+	engine.lock.Lock()
+	rounds := engine.txs[txID].rounds
+	engine.lock.Unlock()
+	require.Zero(rounds)
+}
+
+// overlapQuerier records whether queries of different rounds overlapped.
+type overlapQuerier struct {
+	inFlight   atomic.Int32
+	overlapped atomic.Bool
+}
+
+func (q *overlapQuerier) Opinions(_ context.Context, _ ids.NodeID, txIDs []ids.ID) ([]bool, error) {
+	if q.inFlight.Add(1) > 1 {
+		q.overlapped.Store(true)
+	}
+	defer q.inFlight.Add(-1)
+
+	time.Sleep(time.Millisecond)
+	return make([]bool, len(txIDs)), nil
+}
+
+func TestFPCEngineConcurrentRounds(t *testing.T) {
+	require := require.New(t)
+
+	const numRounds = 10
+	config := DefaultFPCConfig(0)
+	config.FinalizationRounds = 2 * numRounds
+	querier := &overlapQuerier{}
+	engine, err := NewFPCEngineWithConfig(log.NewNoOpLogger(), config, querier, localBeacon{}, prng.NewMT19937())
+	require.NoError(err)
+	require.NoError(engine.SetValidators([]Validator{
+		{NodeID: ids.GenerateTestNodeID(), Weight: 1},
+	}))
+
+	txID := ids.GenerateTestID()
+	require.NoError(engine.Propose(txID))
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, numRounds)
+	)
+	for i := 0; i < numRounds; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- engine.Round(context.Background())
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(err)
+	}
 
-	// // Should validate (soft mode accepts everything)
-	// require.True(t, valid)
-	// require.Greater(t, size, 0)
-	// require.NotEqual(t, [32]byte{}, root)
+	require.False(querier.overlapped.Load())
+	engine.lock.Lock()
+	rounds := engine.txs[txID].rounds
+	engine.lock.Unlock()
+	require.Equal(numRounds, rounds)
 }
 
 func BenchmarkFPCEngine(b *testing.B) {
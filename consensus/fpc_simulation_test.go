@@ -0,0 +1,314 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mathext/prng"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+	"github.com/luxfi/node/utils/hashing"
+	"github.com/luxfi/node/utils/sampler"
+)
+
+var errUnknownNode = errors.New("unknown node")
+
+// simulation runs FPC on a network of engines that query each other in
+// memory. Given the same seed, every simulation runs identically.
+type simulation struct {
+	nodeIDs []ids.NodeID
+	engines map[ids.NodeID]*FPCEngine
+	// Byzantine nodes answer every query with the opposite of the querier's
+	// current opinion, to delay agreement as much as possible.
+	byzantine map[ids.NodeID]bool
+	// Crashed nodes don't answer queries.
+	crashed map[ids.NodeID]bool
+
+	// The node currently running a round.
+	querier *FPCEngine
+	beacon  *simulationBeacon
+}
+
+// simulationBeacon reveals the same randomness to every node within a
+// simulated round.
+type simulationBeacon struct {
+	seed  uint64
+	round uint64
+}
+
+func (b *simulationBeacon) Randomness(context.Context) (ids.ID, error) {
+	var preimage [16]byte
+	binary.BigEndian.PutUint64(preimage[:8], b.seed)
+	binary.BigEndian.PutUint64(preimage[8:], b.round)
+	return hashing.ComputeHash256Array(preimage[:]), nil
+}
+
+func newSimulation(t *testing.T, seed uint64, config FPCConfig, weights []uint64) *simulation {
+	s := &simulation{
+		engines:   make(map[ids.NodeID]*FPCEngine),
+		byzantine: make(map[ids.NodeID]bool),
+		crashed:   make(map[ids.NodeID]bool),
+		beacon:    &simulationBeacon{seed: seed},
+	}
+
+	validators := make([]Validator, len(weights))
+	for i, weight := range weights {
+		nodeID := ids.BuildTestNodeID([]byte{byte(i), byte(i >> 8)})
+		validators[i] = Validator{
+			NodeID: nodeID,
+			Weight: weight,
+		}
+		s.nodeIDs = append(s.nodeIDs, nodeID)
+	}
+
+	for i, nodeID := range s.nodeIDs {
+		source := prng.NewMT19937()
+		source.Seed(seed + uint64(i))
+
+		engine, err := NewFPCEngineWithConfig(log.NewNoOpLogger(), config, s, s.beacon, source)
+		require.NoError(t, err)
+		// The best weighted sampler is chosen by benchmarking, which would
+		// make the simulation depend on timing.
+		engine.weighted = sampler.NewDeterministicWeighted()
+		require.NoError(t, engine.SetValidators(validators))
+		s.engines[nodeID] = engine
+	}
+	return s
+}
+
+func (s *simulation) Opinions(_ context.Context, nodeID ids.NodeID, txIDs []ids.ID) ([]bool, error) {
+	engine, ok := s.engines[nodeID]
+	switch {
+	case !ok || s.crashed[nodeID]:
+		return nil, errUnknownNode
+	case s.byzantine[nodeID]:
+		opinions := s.querier.Opinions(txIDs)
+		for i := range opinions {
+			opinions[i] = !opinions[i]
+		}
+		return opinions, nil
+	default:
+		return engine.Opinions(txIDs), nil
+	}
+}
+
+// honest returns the engines of nodes that are neither Byzantine nor crashed.
+func (s *simulation) honest() []*FPCEngine {
+	var engines []*FPCEngine
+	for _, nodeID := range s.nodeIDs {
+		if !s.byzantine[nodeID] && !s.crashed[nodeID] {
+			engines = append(engines, s.engines[nodeID])
+		}
+	}
+	return engines
+}
+
+// propose proposes [txID] to every node, where the first [numLikes] nodes
+// initially like it.
+func (s *simulation) propose(t *testing.T, txID ids.ID, numLikes int) {
+	for i, nodeID := range s.nodeIDs {
+		require.NoError(t, s.engines[nodeID].ProposeWithOpinion(txID, i < numLikes))
+	}
+}
+
+// run runs rounds on every honest node until they have all finalized [txIDs]
+// or [maxRounds] rounds have been run. Returns the number of rounds run.
+func (s *simulation) run(t *testing.T, txIDs []ids.ID, maxRounds int) int {
+	ctx := context.Background()
+	for round := 1; round <= maxRounds; round++ {
+		s.beacon.round++
+		for _, engine := range s.honest() {
+			s.querier = engine
+			require.NoError(t, engine.Round(ctx))
+		}
+		if s.finalized(txIDs) {
+			return round
+		}
+	}
+	return maxRounds
+}
+
+func (s *simulation) finalized(txIDs []ids.ID) bool {
+	for _, engine := range s.honest() {
+		for _, txID := range txIDs {
+			if _, err := engine.Query(txID); err != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// requireAgreement requires every honest node to have finalized [txID] with
+// the same outcome, and returns that outcome.
+func (s *simulation) requireAgreement(t *testing.T, txID ids.ID) bool {
+	require := require.New(t)
+
+	engines := s.honest()
+	expected, err := engines[0].Query(txID)
+	require.NoError(err)
+	for _, engine := range engines[1:] {
+		accepted, err := engine.Query(txID)
+		require.NoError(err)
+		require.Equal(expected, accepted)
+	}
+	return expected
+}
+
+func equalWeights(n int) []uint64 {
+	weights := make([]uint64, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return weights
+}
+
+func TestFPCSimulationUnanimous(t *testing.T) {
+	tests := []struct {
+		name     string
+		liked    bool
+		expected bool
+	}{
+		{
+			name:     "all like",
+			liked:    true,
+			expected: true,
+		},
+		{
+			name:     "all dislike",
+			liked:    false,
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			const numNodes = 10
+			config := DefaultFPCConfig(3)
+			s := newSimulation(t, 1, config, equalWeights(numNodes))
+
+			txID := ids.GenerateTestID()
+			numLikes := 0
+			if test.liked {
+				numLikes = numNodes
+			}
+			s.propose(t, txID, numLikes)
+
+			// Opinions never change, so every node finalizes as soon as it
+			// has seen enough stable rounds.
+			rounds := s.run(t, []ids.ID{txID}, 100)
+			require.Equal(config.FinalizationRounds, rounds)
+			require.Equal(test.expected, s.requireAgreement(t, txID))
+
+			for _, engine := range s.honest() {
+				executable := engine.Executable()
+				if test.expected {
+					require.Equal([]ids.ID{txID}, executable)
+				} else {
+					require.Empty(executable)
+				}
+			}
+		})
+	}
+}
+
+func TestFPCSimulationAgreement(t *testing.T) {
+	tests := []struct {
+		name         string
+		weights      []uint64
+		numLikes     int
+		numByzantine int
+		numCrashed   int
+	}{
+		{
+			name:     "even split",
+			weights:  equalWeights(20),
+			numLikes: 10,
+		},
+		{
+			name:     "minority likes",
+			weights:  equalWeights(20),
+			numLikes: 6,
+		},
+		{
+			name:         "byzantine nodes",
+			weights:      equalWeights(20),
+			numLikes:     10,
+			numByzantine: 3,
+		},
+		{
+			name:       "crashed nodes",
+			weights:    equalWeights(20),
+			numLikes:   10,
+			numCrashed: 4,
+		},
+		{
+			name:     "stake weighted",
+			weights:  []uint64{50, 10, 10, 10, 5, 5, 5, 3, 1, 1},
+			numLikes: 5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for seed := uint64(0); seed < 10; seed++ {
+				s := newSimulation(t, seed, DefaultFPCConfig(3), test.weights)
+				// Byzantine and crashed nodes are taken from the end, so
+				// honest nodes keep the opinions they were proposed with.
+				for i := 0; i < test.numByzantine; i++ {
+					s.byzantine[s.nodeIDs[len(s.nodeIDs)-1-i]] = true
+				}
+				for i := 0; i < test.numCrashed; i++ {
+					s.crashed[s.nodeIDs[len(s.nodeIDs)-1-test.numByzantine-i]] = true
+				}
+
+				txIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+				s.propose(t, txIDs[0], test.numLikes)
+				s.propose(t, txIDs[1], len(s.nodeIDs)-test.numLikes)
+				s.run(t, txIDs, 200)
+
+				require.True(t, s.finalized(txIDs), "seed %d", seed)
+				for _, txID := range txIDs {
+					s.requireAgreement(t, txID)
+				}
+			}
+		})
+	}
+}
+
+func TestFPCSimulationDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	runSimulation := func() ([]int, []bool) {
+		s := newSimulation(t, 42, DefaultFPCConfig(3), equalWeights(20))
+		txIDs := make([]ids.ID, 5)
+		for i := range txIDs {
+			txIDs[i] = ids.ID{byte(i + 1)}
+			s.propose(t, txIDs[i], 8+i)
+		}
+
+		var (
+			rounds   []int
+			outcomes []bool
+		)
+		for _, txID := range txIDs {
+			rounds = append(rounds, s.run(t, []ids.ID{txID}, 200))
+			outcomes = append(outcomes, s.requireAgreement(t, txID))
+		}
+		return rounds, outcomes
+	}
+
+	rounds, outcomes := runSimulation()
+	for i := 0; i < 3; i++ {
+		nextRounds, nextOutcomes := runSimulation()
+		require.Equal(rounds, nextRounds)
+		require.Equal(outcomes, nextOutcomes)
+	}
+}
@@ -2,13 +2,40 @@
 package consensus
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 
+	"github.com/ethereum/go-verkle"
+
+	"github.com/luxfi/geth/common"
 	"github.com/luxfi/geth/trie/utils"
 	"github.com/luxfi/geth/triedb/database"
 	"github.com/luxfi/ids"
 )
 
+var (
+	ErrInvalidWitness = errors.New("invalid witness")
+
+	errMissingProof       = errors.New("witness is missing a proof")
+	errMissingStem        = errors.New("witness doesn't cover address")
+	errUnavailablePreRoot = errors.New("pre-state root is unavailable")
+)
+
+// Witness proves the state a transaction reads and writes. It is the JSON
+// encoding of a Witness that is returned by [Transaction.Witness].
+type Witness struct {
+	PreStateRoot  common.Hash `json:"preStateRoot"`
+	PostStateRoot common.Hash `json:"postStateRoot"`
+	// Addresses are the accounts the transaction touches. The state diff must
+	// include the stem of each address's account header.
+	Addresses []common.Address    `json:"addresses"`
+	StateDiff verkle.StateDiff    `json:"stateDiff"`
+	Proof     *verkle.VerkleProof `json:"proof"`
+}
+
 // VerkleIntegration bridges VMs with Verkle+FPC consensus
 type VerkleIntegration struct {
 	engine *FPCEngine
@@ -25,23 +52,68 @@ func NewVerkleIntegration(engine *FPCEngine, db database.NodeDatabase) *VerkleIn
 	}
 }
 
-// ProcessTransactions processes transactions through consensus
+// ProcessTransactions validates the witnesses of [txs] and proposes them to
+// consensus. If any witness is invalid, no transactions are proposed.
+//
+// A transaction's pre-state root must be available in the database, or be the
+// post-state root of an earlier transaction in [txs].
 func (v *VerkleIntegration) ProcessTransactions(ctx context.Context, txs []Transaction) error {
-	// Process each transaction through consensus
+	postStateRoots := make(map[common.Hash]struct{})
 	for _, tx := range txs {
+		witnessBytes := tx.Witness()
+		if witnessBytes == nil {
+			continue
+		}
+
 		txID := ids.ID(tx.Hash())
+		witness, err := v.ValidateWitness(witnessBytes, postStateRoots)
+		if err != nil {
+			return fmt.Errorf("%w of %s: %w", ErrInvalidWitness, txID, err)
+		}
+		postStateRoots[witness.PostStateRoot] = struct{}{}
+	}
 
-		// Propose to consensus
-		if err := v.engine.Propose(txID); err != nil {
+	for _, tx := range txs {
+		if err := v.engine.Propose(ids.ID(tx.Hash())); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		// if witness := tx.Witness(); witness != nil {
-		//     // Validate witness
-		// }
+// ValidateWitness parses and verifies [witnessBytes]. The witness's pre-state
+// root must be in [knownRoots] or be available in the database.
+func (v *VerkleIntegration) ValidateWitness(witnessBytes []byte, knownRoots map[common.Hash]struct{}) (*Witness, error) {
+	witness := &Witness{}
+	if err := json.Unmarshal(witnessBytes, witness); err != nil {
+		return nil, err
+	}
+	if witness.Proof == nil {
+		return nil, errMissingProof
 	}
 
-	return nil
+	if _, ok := knownRoots[witness.PreStateRoot]; !ok && v.db != nil {
+		if _, err := v.db.NodeReader(witness.PreStateRoot); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", errUnavailablePreRoot, witness.PreStateRoot, err)
+		}
+	}
+
+	for _, addr := range witness.Addresses {
+		stem := v.cache.GetStem(addr[:])
+		if !containsStem(witness.StateDiff, stem) {
+			return nil, fmt.Errorf("%w: %s", errMissingStem, addr)
+		}
+	}
+
+	if err := verkle.Verify(
+		witness.Proof,
+		witness.PreStateRoot[:],
+		witness.PostStateRoot[:],
+		witness.StateDiff,
+	); err != nil {
+		return nil, err
+	}
+	return witness, nil
 }
 
 // GetExecutable returns transactions ready for execution
@@ -49,8 +121,19 @@ func (v *VerkleIntegration) GetExecutable() []ids.ID {
 	return v.engine.Executable()
 }
 
+func containsStem(stateDiff verkle.StateDiff, stem []byte) bool {
+	for _, stemDiff := range stateDiff {
+		if bytes.Equal(stemDiff.Stem[:], stem) {
+			return true
+		}
+	}
+	return false
+}
+
 // Transaction interface for VM transactions
 type Transaction interface {
 	Hash() [32]byte
+	// Witness returns the JSON encoding of the transaction's [Witness], or nil
+	// if the transaction has no witness.
 	Witness() []byte
 }
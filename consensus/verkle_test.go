@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-verkle"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mathext/prng"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/trie/utils"
+	"github.com/luxfi/geth/triedb/database"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+)
+
+var errMissingState = errors.New("missing state")
+
+// testNodeDatabase has the state of the roots it contains.
+type testNodeDatabase map[common.Hash]struct{}
+
+func (db testNodeDatabase) NodeReader(stateRoot common.Hash) (database.NodeReader, error) {
+	if _, ok := db[stateRoot]; !ok {
+		return nil, errMissingState
+	}
+	return nil, nil
+}
+
+type testTransaction struct {
+	hash    [32]byte
+	witness []byte
+}
+
+func (tx *testTransaction) Hash() [32]byte {
+	return tx.hash
+}
+
+func (tx *testTransaction) Witness() []byte {
+	return tx.witness
+}
+
+// accountKey returns the key of the account header of [addr].
+func accountKey(cache *utils.PointCache, addr common.Address) []byte {
+	return append(cache.GetStem(addr[:]), 0)
+}
+
+// newTestWitness returns a witness for a transaction that writes [value] to the
+// account headers of [addrs] in [tree], and the tree after the write.
+func newTestWitness(
+	t *testing.T,
+	cache *utils.PointCache,
+	tree verkle.VerkleNode,
+	addrs []common.Address,
+	value byte,
+) (*Witness, verkle.VerkleNode) {
+	require := require.New(t)
+
+	preStateRoot := tree.Commit().Bytes()
+	postTree := tree.Copy()
+	keys := make([][]byte, len(addrs))
+	for i, addr := range addrs {
+		keys[i] = accountKey(cache, addr)
+		newValue := make([]byte, 32)
+		newValue[0] = value
+		require.NoError(postTree.Insert(keys[i], newValue, nil))
+	}
+	postStateRoot := postTree.Commit().Bytes()
+
+	proof, _, _, _, err := verkle.MakeVerkleMultiProof(tree, postTree, keys, nil)
+	require.NoError(err)
+	verkleProof, stateDiff, err := verkle.SerializeProof(proof)
+	require.NoError(err)
+
+	return &Witness{
+		PreStateRoot:  preStateRoot,
+		PostStateRoot: postStateRoot,
+		Addresses:     addrs,
+		StateDiff:     stateDiff,
+		Proof:         verkleProof,
+	}, postTree
+}
+
+func newTestTransaction(t *testing.T, witness *Witness) *testTransaction {
+	tx := &testTransaction{
+		hash: ids.GenerateTestID(),
+	}
+	if witness != nil {
+		witnessBytes, err := json.Marshal(witness)
+		require.NoError(t, err)
+		tx.witness = witnessBytes
+	}
+	return tx
+}
+
+func newTestGenesisTree(t *testing.T, cache *utils.PointCache, addrs []common.Address) verkle.VerkleNode {
+	tree := verkle.New()
+	for _, addr := range addrs {
+		require.NoError(t, tree.Insert(accountKey(cache, addr), make([]byte, 32), nil))
+	}
+	tree.Commit()
+	return tree
+}
+
+func newTestVerkleIntegration(t *testing.T, db database.NodeDatabase) (*VerkleIntegration, *FPCEngine) {
+	config := DefaultFPCConfig(0)
+	config.FinalizationRounds = 1
+	engine, err := NewFPCEngineWithConfig(log.NewNoOpLogger(), config, nil, localBeacon{}, prng.NewMT19937())
+	require.NoError(t, err)
+	return NewVerkleIntegration(engine, db), engine
+}
+
+func TestVerkleIntegration(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cache := utils.NewPointCache(100)
+	addrs := []common.Address{{1}, {2}, {3}}
+	genesis := newTestGenesisTree(t, cache, addrs)
+	genesisRoot := common.Hash(genesis.Commit().Bytes())
+
+	verkleIntegration, engine := newTestVerkleIntegration(t, testNodeDatabase{genesisRoot: {}})
+
+	// The second transaction builds on the post-state of the first.
+	witness0, postTree := newTestWitness(t, cache, genesis, addrs[:2], 1)
+	witness1, _ := newTestWitness(t, cache, postTree, addrs[2:], 2)
+	txs := []Transaction{
+		newTestTransaction(t, witness0),
+		newTestTransaction(t, witness1),
+		newTestTransaction(t, nil),
+	}
+	require.NoError(verkleIntegration.ProcessTransactions(ctx, txs))
+
+	require.NoError(engine.Round(ctx))
+	executable := verkleIntegration.GetExecutable()
+	require.Len(executable, len(txs))
+	for i, tx := range txs {
+		require.Equal(ids.ID(tx.Hash()), executable[i])
+	}
+}
+
+func TestVerkleIntegrationInvalidWitness(t *testing.T) {
+	cache := utils.NewPointCache(100)
+	addrs := []common.Address{{1}, {2}}
+	genesis := newTestGenesisTree(t, cache, addrs)
+	genesisRoot := common.Hash(genesis.Commit().Bytes())
+
+	tests := []struct {
+		name        string
+		modify      func(*Witness)
+		expectedErr error
+	}{
+		{
+			name:        "missing proof",
+			modify:      func(w *Witness) { w.Proof = nil },
+			expectedErr: errMissingProof,
+		},
+		{
+			name:        "unavailable pre-state root",
+			modify:      func(w *Witness) { w.PreStateRoot = common.Hash{1} },
+			expectedErr: errUnavailablePreRoot,
+		},
+		{
+			name: "address not covered",
+			modify: func(w *Witness) {
+				w.Addresses = append(w.Addresses, common.Address{3})
+			},
+			expectedErr: errMissingStem,
+		},
+		{
+			name: "wrong post-state root",
+			modify: func(w *Witness) {
+				w.PostStateRoot = w.PreStateRoot
+			},
+		},
+		{
+			name: "tampered post-state value",
+			modify: func(w *Witness) {
+				w.StateDiff[0].SuffixDiffs[0].NewValue[0]++
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			verkleIntegration, engine := newTestVerkleIntegration(t, testNodeDatabase{genesisRoot: {}})
+
+			witness, _ := newTestWitness(t, cache, genesis, addrs, 1)
+			test.modify(witness)
+			txs := []Transaction{
+				newTestTransaction(t, nil),
+				newTestTransaction(t, witness),
+			}
+
+			err := verkleIntegration.ProcessTransactions(ctx, txs)
+			require.ErrorIs(err, ErrInvalidWitness)
+			if test.expectedErr != nil {
+				require.ErrorIs(err, test.expectedErr)
+			}
+
+			// No transactions are proposed if any witness is invalid.
+			for _, tx := range txs {
+				_, err := engine.Query(tx.Hash())
+				require.ErrorIs(err, ErrUnknownTx)
+			}
+		})
+	}
+}
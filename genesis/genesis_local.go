@@ -12,6 +12,7 @@ import (
 	"github.com/luxfi/node/utils/cb58"
 	"github.com/luxfi/node/utils/units"
 	"github.com/luxfi/node/utils/wrappers"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/platformvm/reward"
 	"github.com/luxfi/node/vms/platformvm/txs/fee"
 )
@@ -50,6 +51,32 @@ var (
 			AddSubnetValidatorFee:         units.MilliLux,
 			AddSubnetDelegatorFee:         units.MilliLux,
 		},
+		DynamicFeeConfig: gas.Config{
+			Weights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    1_000,
+				gas.DBWrite:   1_000,
+				gas.Compute:   4,
+			},
+			MaxCapacity:              1_000_000,
+			MaxPerSecond:             100_000,
+			TargetPerSecond:          50_000,
+			MinPrice:                 1,
+			ExcessConversionConstant: 2_164_043, // Double every 30s
+		},
+		XChainDynamicFeeConfig: gas.Config{
+			Weights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    1_000,
+				gas.DBWrite:   1_000,
+				gas.Compute:   4,
+			},
+			MaxCapacity:              1_000_000,
+			MaxPerSecond:             100_000,
+			TargetPerSecond:          50_000,
+			MinPrice:                 1,
+			ExcessConversionConstant: 2_164_043, // Double every 30s
+		},
 		StakingConfig: StakingConfig{
 			UptimeRequirement: .8, // 80%
 			MinValidatorStake: 2 * units.KiloLux,
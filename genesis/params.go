@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/luxfi/node/utils/constants"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/platformvm/reward"
 	"github.com/luxfi/node/vms/platformvm/txs/fee"
 )
@@ -42,6 +43,10 @@ type StakingConfig struct {
 type Params struct {
 	StakingConfig
 	fee.StaticConfig
+	// DynamicFeeConfig is the config for the P-Chain dynamic fee state.
+	DynamicFeeConfig gas.Config
+	// XChainDynamicFeeConfig is the config for the X-Chain dynamic fee state.
+	XChainDynamicFeeConfig gas.Config
 }
 
 func GetTxFeeConfig(networkID uint32) fee.StaticConfig {
@@ -57,6 +62,32 @@ func GetTxFeeConfig(networkID uint32) fee.StaticConfig {
 	}
 }
 
+func GetDynamicFeeConfig(networkID uint32) gas.Config {
+	switch networkID {
+	case constants.MainnetID:
+		return MainnetParams.DynamicFeeConfig
+	case constants.TestnetID:
+		return TestnetParams.DynamicFeeConfig
+	case constants.LocalID:
+		return LocalParams.DynamicFeeConfig
+	default:
+		return LocalParams.DynamicFeeConfig
+	}
+}
+
+func GetXChainDynamicFeeConfig(networkID uint32) gas.Config {
+	switch networkID {
+	case constants.MainnetID:
+		return MainnetParams.XChainDynamicFeeConfig
+	case constants.TestnetID:
+		return TestnetParams.XChainDynamicFeeConfig
+	case constants.LocalID:
+		return LocalParams.XChainDynamicFeeConfig
+	default:
+		return LocalParams.XChainDynamicFeeConfig
+	}
+}
+
 func GetStakingConfig(networkID uint32) StakingConfig {
 	switch networkID {
 	case constants.MainnetID:
@@ -14,8 +14,10 @@ exclude github.com/ethereum/go-ethereum v1.16.2
 require (
 	connectrpc.com/connect v1.18.1
 	github.com/StephenButtolph/canoto v0.17.2
+	github.com/cockroachdb/pebble v1.1.5
 	github.com/dgraph-io/badger/v4 v4.8.0
 	github.com/ethereum/go-ethereum v1.16.1
+	github.com/ethereum/go-verkle v0.2.2
 	github.com/golang/mock v1.5.0
 	github.com/holiman/uint256 v1.3.2
 	github.com/klauspost/compress v1.18.0
@@ -44,7 +46,6 @@ require (
 	github.com/NYTimes/gziphandler v1.1.1
 	github.com/antithesishq/antithesis-sdk-go v0.4.4
 	github.com/btcsuite/btcd/btcutil v1.1.6
-	github.com/cockroachdb/pebble v1.1.5 // indirect
 	github.com/compose-spec/compose-go v1.20.2
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.0 // indirect
 	github.com/golang-jwt/jwt/v4 v4.5.2
@@ -127,7 +128,6 @@ require (
 	github.com/emicklei/dot v1.9.0 // indirect
 	github.com/emicklei/go-restful/v3 v3.13.0 // indirect
 	github.com/ethereum/c-kzg-4844/v2 v2.1.1 // indirect
-	github.com/ethereum/go-verkle v0.2.2 // indirect
 	github.com/fatih/color v1.18.0 // indirect
 	github.com/fatih/structtag v1.2.0 // indirect
 	github.com/ferranbt/fastssz v1.0.0 // indirect
@@ -9,7 +9,6 @@ import (
 	"io"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
 
 	"github.com/luxfi/consensus"
@@ -25,6 +24,8 @@ import (
 	"github.com/luxfi/node/utils/json"
 	"github.com/luxfi/node/utils/timer/mockable"
 	"github.com/luxfi/node/utils/wrappers"
+
+	nodeconsensus "github.com/luxfi/node/consensus"
 )
 
 const (
@@ -34,6 +35,13 @@ const (
 	blockPrefix             = 0x03
 	isIncompletePrefix      = 0x04
 	previouslyIndexedPrefix = 0x05
+	txQueuePrefix           = 0x08
+	vtxQueuePrefix          = 0x09
+	blockQueuePrefix        = 0x0a
+
+	// maxPendingAccepted is the number of accepted containers that can be
+	// waiting to be indexed before accepting containers blocks on the indexer.
+	maxPendingAccepted = 1024
 )
 
 var (
@@ -48,9 +56,9 @@ type Config struct {
 	Log                  log.Logger
 	IndexingEnabled      bool
 	AllowIncompleteIndex bool
-	BlockAcceptorGroup   consensus.AcceptorGroup
-	TxAcceptorGroup      consensus.AcceptorGroup
-	VertexAcceptorGroup  consensus.AcceptorGroup
+	BlockAcceptorGroup   nodeconsensus.AcceptorGroup
+	TxAcceptorGroup      nodeconsensus.AcceptorGroup
+	VertexAcceptorGroup  nodeconsensus.AcceptorGroup
 	APIServer            server.PathAdder
 	ShutdownF            func()
 }
@@ -121,11 +129,11 @@ type indexer struct {
 	txIndices map[ids.ID]*index
 
 	// Notifies of newly accepted blocks
-	blockAcceptorGroup consensus.AcceptorGroup
+	blockAcceptorGroup nodeconsensus.AcceptorGroup
 	// Notifies of newly accepted transactions
-	txAcceptorGroup consensus.AcceptorGroup
+	txAcceptorGroup nodeconsensus.AcceptorGroup
 	// Notifies of newly accepted vertices
-	vertexAcceptorGroup consensus.AcceptorGroup
+	vertexAcceptorGroup nodeconsensus.AcceptorGroup
 }
 
 // RegisterChain registers a chain for indexing
@@ -246,7 +254,7 @@ func (i *indexer) RegisterChain(chainName string, ctx context.Context, vm interf
 		return
 	}
 
-	index, err := i.registerChainHelper(chainID, blockPrefix, chainName, "block", i.blockAcceptorGroup)
+	index, err := i.registerChainHelper(chainID, blockPrefix, blockQueuePrefix, chainName, "block", i.blockAcceptorGroup)
 	if err != nil {
 		i.log.Error("failed to create index",
 			zap.String("chainName", chainName),
@@ -269,7 +277,7 @@ func (i *indexer) RegisterChain(chainName string, ctx context.Context, vm interf
 	)
 	switch vm.(type) {
 	case vertex.LinearizableVMWithEngine:
-		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, chainName, "vtx", i.vertexAcceptorGroup)
+		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, vtxQueuePrefix, chainName, "vtx", i.vertexAcceptorGroup)
 		if err != nil {
 			i.log.Error("couldn't create index",
 				zap.String("chainName", chainName),
@@ -285,7 +293,7 @@ func (i *indexer) RegisterChain(chainName string, ctx context.Context, vm interf
 		}
 		i.vtxIndices[chainID] = vtxIndex
 
-		txIndex, err := i.registerChainHelper(chainID, txPrefix, chainName, "tx", i.txAcceptorGroup)
+		txIndex, err := i.registerChainHelper(chainID, txPrefix, txQueuePrefix, chainName, "tx", i.txAcceptorGroup)
 		if err != nil {
 			i.log.Error("couldn't create index",
 				zap.String("chainName", chainName),
@@ -318,13 +326,11 @@ func (i *indexer) RegisterChain(chainName string, ctx context.Context, vm interf
 func (i *indexer) registerChainHelper(
 	chainID ids.ID,
 	prefixEnd byte,
+	queuePrefixEnd byte,
 	name, endpoint string,
-	acceptorGroup consensus.AcceptorGroup,
+	acceptorGroup nodeconsensus.AcceptorGroup,
 ) (*index, error) {
-	prefix := make([]byte, ids.IDLen+wrappers.ByteLen)
-	copy(prefix, chainID[:])
-	prefix[ids.IDLen] = prefixEnd
-	indexDB := prefixdb.New(prefix, i.db)
+	indexDB := prefixdb.New(chainPrefix(chainID, prefixEnd), i.db)
 	index, err := newIndex(indexDB, i.log, i.clock)
 	if err != nil {
 		// Don't close indexDB as it would close the underlying database
@@ -332,28 +338,46 @@ func (i *indexer) registerChainHelper(
 		return nil, err
 	}
 
-	// Register index to learn about new accepted vertices
-	if err := acceptorGroup.RegisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID), index, true); err != nil {
+	// Register index to learn about new accepted vertices. Containers are
+	// indexed from a durable queue, so that a slow index doesn't delay
+	// acceptance. Indexing a container twice is a no-op, so the at-least-once
+	// delivery of the queue is sufficient.
+	acceptorName := fmt.Sprintf("%s%s", indexNamePrefix, chainID)
+	err = acceptorGroup.RegisterAsyncAcceptor(chainID, acceptorName, index, nodeconsensus.AsyncAcceptorConfig{
+		DB:         prefixdb.New(chainPrefix(chainID, queuePrefixEnd), i.db),
+		MaxPending: maxPendingAccepted,
+	})
+	if err != nil {
 		_ = index.Close()
 		return nil, err
 	}
 
 	// Create an API endpoint for this index
-	apiServer := rpc.NewServer()
+	apiServer := json.NewServer()
 	codec := json.NewCodec()
 	apiServer.RegisterCodec(codec, "application/json")
 	apiServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	if err := apiServer.RegisterService(&service{index: index}, "index"); err != nil {
+		_ = acceptorGroup.DeregisterAcceptor(chainID, acceptorName)
 		_ = index.Close()
 		return nil, err
 	}
 	if err := i.pathAdder.AddRoute(apiServer, "index/"+name, "/"+endpoint); err != nil {
+		_ = acceptorGroup.DeregisterAcceptor(chainID, acceptorName)
 		_ = index.Close()
 		return nil, err
 	}
 	return index, nil
 }
 
+// chainPrefix returns the prefix of the [prefixEnd] database of [chainID].
+func chainPrefix(chainID ids.ID, prefixEnd byte) []byte {
+	prefix := make([]byte, ids.IDLen+wrappers.ByteLen)
+	copy(prefix, chainID[:])
+	prefix[ids.IDLen] = prefixEnd
+	return prefix
+}
+
 // Close this indexer. Stops indexing all chains.
 // Closes [i.db]. Assumes Close is only called after
 // the node is done making decisions.
@@ -371,23 +395,25 @@ func (i *indexer) close() error {
 	}
 	i.closed = true
 
+	// Deregistering an acceptor waits for an in-progress delivery, so the
+	// indices are only closed once they are no longer being written to.
 	errs := &wrappers.Errs{}
 	for chainID, txIndex := range i.txIndices {
 		errs.Add(
-			txIndex.Close(),
 			i.txAcceptorGroup.DeregisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID)),
+			txIndex.Close(),
 		)
 	}
 	for chainID, vtxIndex := range i.vtxIndices {
 		errs.Add(
-			vtxIndex.Close(),
 			i.vertexAcceptorGroup.DeregisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID)),
+			vtxIndex.Close(),
 		)
 	}
 	for chainID, blockIndex := range i.blockIndices {
 		errs.Add(
-			blockIndex.Close(),
 			i.blockAcceptorGroup.DeregisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID)),
+			blockIndex.Close(),
 		)
 	}
 	errs.Add(i.db.Close())
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 	"github.com/luxfi/mock/gomock"
 
@@ -49,6 +50,12 @@ func (*apiServerMock) AddAliases(string, ...string) error {
 	return errUnimplemented
 }
 
+func newTestAcceptorGroup(t *testing.T) consensus.AcceptorGroup {
+	acceptorGroup, err := consensus.NewAcceptorGroup(log.NoLog{}, prometheus.NewRegistry())
+	require.NoError(t, err)
+	return acceptorGroup
+}
+
 // Test that newIndexer sets fields correctly
 func TestNewIndexer(t *testing.T) {
 	require := require.New(t)
@@ -57,9 +64,9 @@ func TestNewIndexer(t *testing.T) {
 		AllowIncompleteIndex: true,
 		Log:                  log.NoLog{},
 		DB:                   memdb.New(),
-		BlockAcceptorGroup:   consensus.NewAcceptorGroup(log.NoLog{}),
-		TxAcceptorGroup:      consensus.NewAcceptorGroup(log.NoLog{}),
-		VertexAcceptorGroup:  consensus.NewAcceptorGroup(log.NoLog{}),
+		BlockAcceptorGroup:   newTestAcceptorGroup(t),
+		TxAcceptorGroup:      newTestAcceptorGroup(t),
+		VertexAcceptorGroup:  newTestAcceptorGroup(t),
 		APIServer:            &apiServerMock{},
 		ShutdownF:            func() {},
 	}
@@ -98,9 +105,9 @@ func TestMarkHasRunAndShutdown(t *testing.T) {
 		IndexingEnabled:     true,
 		Log:                 log.NoLog{},
 		DB:                  db,
-		BlockAcceptorGroup:  consensus.NewAcceptorGroup(log.NoLog{}),
-		TxAcceptorGroup:     consensus.NewAcceptorGroup(log.NoLog{}),
-		VertexAcceptorGroup: consensus.NewAcceptorGroup(log.NoLog{}),
+		BlockAcceptorGroup:  newTestAcceptorGroup(t),
+		TxAcceptorGroup:     newTestAcceptorGroup(t),
+		VertexAcceptorGroup: newTestAcceptorGroup(t),
 		APIServer:           &apiServerMock{},
 		ShutdownF:           shutdown.Done,
 	}
@@ -137,9 +144,9 @@ func TestIndexer(t *testing.T) {
 		AllowIncompleteIndex: false,
 		Log:                  log.NoLog{},
 		DB:                   db,
-		BlockAcceptorGroup:   consensus.NewAcceptorGroup(log.NoLog{}),
-		TxAcceptorGroup:      consensus.NewAcceptorGroup(log.NoLog{}),
-		VertexAcceptorGroup:  consensus.NewAcceptorGroup(log.NoLog{}),
+		BlockAcceptorGroup:   newTestAcceptorGroup(t),
+		TxAcceptorGroup:      newTestAcceptorGroup(t),
+		VertexAcceptorGroup:  newTestAcceptorGroup(t),
 		APIServer:            server,
 		ShutdownF:            func() {},
 	}
@@ -193,9 +200,12 @@ func TestIndexer(t *testing.T) {
 	blkIdx := idxr.blockIndices[testChainID]
 	require.NotNil(blkIdx)
 	
-	// Accept the container
-	err = blkIdx.Accept(context.Background(), blkID, blkBytes)
-	require.NoError(err)
+	// Accept the container. The index is notified asynchronously.
+	require.NoError(config.BlockAcceptorGroup.Accept(context.Background(), testChainID, blkID, blkBytes))
+	require.Eventually(func() bool {
+		_, err := blkIdx.GetIndex(blkID)
+		return err == nil
+	}, time.Second, time.Millisecond)
 
 	// Verify GetLastAccepted is right
 	gotLastAccepted, err := blkIdx.GetLastAccepted()
@@ -235,9 +245,9 @@ func TestIndexer(t *testing.T) {
 	// Create a new indexer using the same baseDB to simulate restart
 	config.DB = versiondb.New(baseDB)
 	// Create new AcceptorGroups since the old ones still have the chain registered
-	config.BlockAcceptorGroup = consensus.NewAcceptorGroup(log.NoLog{})
-	config.TxAcceptorGroup = consensus.NewAcceptorGroup(log.NoLog{})
-	config.VertexAcceptorGroup = consensus.NewAcceptorGroup(log.NoLog{})
+	config.BlockAcceptorGroup = newTestAcceptorGroup(t)
+	config.TxAcceptorGroup = newTestAcceptorGroup(t)
+	config.VertexAcceptorGroup = newTestAcceptorGroup(t)
 	idxrIntf, err = NewIndexer(config)
 	require.NoError(err)
 	require.IsType(&indexer{}, idxrIntf)
@@ -358,9 +368,9 @@ func TestIndexer(t *testing.T) {
 	// Re-open one more time and re-register chains
 	config.DB = versiondb.New(baseDB)
 	// Create new AcceptorGroups since the old ones were closed
-	config.BlockAcceptorGroup = consensus.NewAcceptorGroup(log.NoLog{})
-	config.TxAcceptorGroup = consensus.NewAcceptorGroup(log.NoLog{})
-	config.VertexAcceptorGroup = consensus.NewAcceptorGroup(log.NoLog{})
+	config.BlockAcceptorGroup = newTestAcceptorGroup(t)
+	config.TxAcceptorGroup = newTestAcceptorGroup(t)
+	config.VertexAcceptorGroup = newTestAcceptorGroup(t)
 	idxrIntf, err = NewIndexer(config)
 	require.NoError(err)
 	require.IsType(&indexer{}, idxrIntf)
@@ -395,9 +405,9 @@ func TestIncompleteIndex(t *testing.T) {
 		AllowIncompleteIndex: false,
 		Log:                  log.NoLog{},
 		DB:                   versiondb.New(baseDB),
-		BlockAcceptorGroup:   consensus.NewAcceptorGroup(log.NoLog{}),
-		TxAcceptorGroup:      consensus.NewAcceptorGroup(log.NoLog{}),
-		VertexAcceptorGroup:  consensus.NewAcceptorGroup(log.NoLog{}),
+		BlockAcceptorGroup:   newTestAcceptorGroup(t),
+		TxAcceptorGroup:      newTestAcceptorGroup(t),
+		VertexAcceptorGroup:  newTestAcceptorGroup(t),
 		APIServer:            &apiServerMock{},
 		ShutdownF:            func() {},
 	}
@@ -479,9 +489,9 @@ func TestIgnoreNonDefaultChains(t *testing.T) {
 		AllowIncompleteIndex: false,
 		Log:                  log.NoLog{},
 		DB:                   db,
-		BlockAcceptorGroup:   consensus.NewAcceptorGroup(log.NoLog{}),
-		TxAcceptorGroup:      consensus.NewAcceptorGroup(log.NoLog{}),
-		VertexAcceptorGroup:  consensus.NewAcceptorGroup(log.NoLog{}),
+		BlockAcceptorGroup:   newTestAcceptorGroup(t),
+		TxAcceptorGroup:      newTestAcceptorGroup(t),
+		VertexAcceptorGroup:  newTestAcceptorGroup(t),
 		APIServer:            &apiServerMock{},
 		ShutdownF:            func() {},
 	}
@@ -37,6 +37,7 @@ type OutboundMsgBuilder interface {
 		objectedLPs []uint32,
 		knownPeersFilter []byte,
 		knownPeersSalt []byte,
+		upgradeScheduleHash []byte,
 	) (OutboundMessage, error)
 
 	GetPeerList(
@@ -248,6 +249,7 @@ func (b *outMsgBuilder) Handshake(
 	objectedLPs []uint32,
 	knownPeersFilter []byte,
 	knownPeersSalt []byte,
+	upgradeScheduleHash []byte,
 ) (OutboundMessage, error) {
 	subnetIDBytes := make([][]byte, len(trackedSubnets))
 	encodeIDs(trackedSubnets, subnetIDBytes)
@@ -275,7 +277,8 @@ func (b *outMsgBuilder) Handshake(
 						Filter: knownPeersFilter,
 						Salt:   knownPeersSalt,
 					},
-					IpBlsSig: ipBLSSig,
+					IpBlsSig:            ipBLSSig,
+					UpgradeScheduleHash: upgradeScheduleHash,
 				},
 			},
 		},
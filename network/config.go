@@ -13,6 +13,7 @@ import (
 	"github.com/luxfi/consensus/uptime"
 	"github.com/luxfi/consensus/validators"
 	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/database"
 	"github.com/luxfi/ids"
 	"github.com/luxfi/node/network/dialer"
 	"github.com/luxfi/node/network/throttling"
@@ -121,6 +122,11 @@ type Config struct {
 	SupportedLPs set.Set[uint32] `json:"supportedLPs"`
 	ObjectedLPs  set.Set[uint32] `json:"objectedLPs"`
 
+	// UpgradeScheduleHash is the hash of the network upgrade schedule of this
+	// node. Connected peers that report a different hash are listed by the
+	// health check.
+	UpgradeScheduleHash ids.ID `json:"upgradeScheduleHash"`
+
 	// The compression type to use when compressing outbound messages.
 	// Assumes all peers support this compression type.
 	CompressionType compression.Type `json:"compressionType"`
@@ -139,6 +145,10 @@ type Config struct {
 
 	UptimeCalculator uptime.Calculator `json:"-"`
 
+	// ConnectionHistoryDB persists the recent connection intervals of
+	// validators across restarts. If nil, they are only kept in memory.
+	ConnectionHistoryDB database.Database `json:"-"`
+
 	// UptimeMetricFreq marks how frequently this node will recalculate the
 	// observed average uptime metric.
 	UptimeMetricFreq time.Duration `json:"uptimeMetricFreq"`
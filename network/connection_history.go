@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+const (
+	// maxConnectionIntervals is the number of most recent connection
+	// intervals that are remembered per node.
+	maxConnectionIntervals = 128
+
+	// connectionIntervalLen is the number of bytes a connection interval is
+	// persisted with.
+	connectionIntervalLen = 2 * wrappers.LongLen
+)
+
+var errInvalidConnectionIntervals = errors.New("invalid connection intervals")
+
+// ConnectionInterval is a period of time during which this node was connected
+// to a peer.
+type ConnectionInterval struct {
+	Connected time.Time
+	// Disconnected is the zero time if the peer is still connected.
+	Disconnected time.Time
+}
+
+// connectionHistory tracks the recent connection intervals of peers and
+// persists them in [db], so that they survive restarts.
+type connectionHistory struct {
+	lock      sync.Mutex
+	db        database.Database
+	intervals map[ids.NodeID][]ConnectionInterval
+}
+
+// newConnectionHistory loads the connection intervals persisted in [db].
+//
+// An interval that is still open when it is loaded was interrupted by an
+// unclean shutdown. Its end is unknown, so it is dropped.
+func newConnectionHistory(db database.Database) (*connectionHistory, error) {
+	c := &connectionHistory{
+		db:        db,
+		intervals: make(map[ids.NodeID][]ConnectionInterval),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		intervals, err := parseConnectionIntervals(it.Value())
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse connection intervals of %s: %w", nodeID, err)
+		}
+		if len(intervals) != 0 && intervals[len(intervals)-1].Disconnected.IsZero() {
+			intervals = intervals[:len(intervals)-1]
+		}
+		if len(intervals) != 0 {
+			c.intervals[nodeID] = intervals
+		}
+	}
+	return c, it.Error()
+}
+
+// connected opens a new connection interval for [nodeID] at [now].
+func (c *connectionHistory) connected(nodeID ids.NodeID, now time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	intervals := c.intervals[nodeID]
+	if len(intervals) >= maxConnectionIntervals {
+		intervals = intervals[len(intervals)-maxConnectionIntervals+1:]
+	}
+	intervals = append(intervals, ConnectionInterval{
+		Connected: now,
+	})
+	c.intervals[nodeID] = intervals
+	return c.db.Put(nodeID.Bytes(), connectionIntervalsBytes(intervals))
+}
+
+// disconnected closes the open connection interval of [nodeID], if any, at
+// [now].
+func (c *connectionHistory) disconnected(nodeID ids.NodeID, now time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	intervals := c.intervals[nodeID]
+	if len(intervals) == 0 {
+		return nil
+	}
+	last := &intervals[len(intervals)-1]
+	if !last.Disconnected.IsZero() {
+		return nil
+	}
+	last.Disconnected = now
+	return c.db.Put(nodeID.Bytes(), connectionIntervalsBytes(intervals))
+}
+
+// remove forgets the connection intervals of [nodeID].
+func (c *connectionHistory) remove(nodeID ids.NodeID) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.intervals, nodeID)
+	return c.db.Delete(nodeID.Bytes())
+}
+
+// get returns the remembered connection intervals of [nodeID], oldest first.
+func (c *connectionHistory) get(nodeID ids.NodeID) []ConnectionInterval {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return slices.Clone(c.intervals[nodeID])
+}
+
+// connectionIntervalsBytes serializes [intervals] as the unix nanoseconds of
+// their bounds. An open interval is persisted with a disconnection time of 0.
+func connectionIntervalsBytes(intervals []ConnectionInterval) []byte {
+	p := wrappers.Packer{
+		Bytes: make([]byte, len(intervals)*connectionIntervalLen),
+	}
+	for _, interval := range intervals {
+		p.PackLong(uint64(interval.Connected.UnixNano()))
+		if interval.Disconnected.IsZero() {
+			p.PackLong(0)
+		} else {
+			p.PackLong(uint64(interval.Disconnected.UnixNano()))
+		}
+	}
+	return p.Bytes
+}
+
+func parseConnectionIntervals(b []byte) ([]ConnectionInterval, error) {
+	if len(b)%connectionIntervalLen != 0 {
+		return nil, fmt.Errorf("%w: unexpected length %d", errInvalidConnectionIntervals, len(b))
+	}
+
+	p := wrappers.Packer{Bytes: b}
+	intervals := make([]ConnectionInterval, len(b)/connectionIntervalLen)
+	for i := range intervals {
+		intervals[i].Connected = time.Unix(0, int64(p.UnpackLong()))
+		if disconnected := p.UnpackLong(); disconnected != 0 {
+			intervals[i].Disconnected = time.Unix(0, int64(disconnected))
+		}
+	}
+	return intervals, p.Err
+}
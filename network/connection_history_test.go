@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database/memdb"
+	"github.com/luxfi/ids"
+)
+
+func TestConnectionHistory(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db     = memdb.New()
+		nodeID = ids.GenerateTestNodeID()
+		start  = time.Unix(1_000, 0)
+	)
+
+	history, err := newConnectionHistory(db)
+	require.NoError(err)
+	require.Empty(history.get(nodeID))
+
+	// Disconnecting without being connected is a noop
+	require.NoError(history.disconnected(nodeID, start))
+	require.Empty(history.get(nodeID))
+
+	require.NoError(history.connected(nodeID, start))
+	require.Equal(
+		[]ConnectionInterval{
+			{Connected: start},
+		},
+		history.get(nodeID),
+	)
+
+	require.NoError(history.disconnected(nodeID, start.Add(time.Minute)))
+	require.NoError(history.connected(nodeID, start.Add(2*time.Minute)))
+	require.Equal(
+		[]ConnectionInterval{
+			{Connected: start, Disconnected: start.Add(time.Minute)},
+			{Connected: start.Add(2 * time.Minute)},
+		},
+		history.get(nodeID),
+	)
+
+	require.NoError(history.remove(nodeID))
+	require.Empty(history.get(nodeID))
+}
+
+func TestConnectionHistoryBounded(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db     = memdb.New()
+		nodeID = ids.GenerateTestNodeID()
+		start  = time.Unix(1_000, 0)
+	)
+
+	history, err := newConnectionHistory(db)
+	require.NoError(err)
+	for i := 0; i < 2*maxConnectionIntervals; i++ {
+		require.NoError(history.connected(nodeID, start.Add(time.Duration(2*i)*time.Second)))
+		require.NoError(history.disconnected(nodeID, start.Add(time.Duration(2*i+1)*time.Second)))
+	}
+
+	intervals := history.get(nodeID)
+	require.Len(intervals, maxConnectionIntervals)
+
+	// Only the most recent intervals are retained
+	require.Equal(
+		start.Add(time.Duration(2*maxConnectionIntervals)*time.Second),
+		intervals[0].Connected,
+	)
+	require.Equal(
+		start.Add(time.Duration(4*maxConnectionIntervals-1)*time.Second),
+		intervals[len(intervals)-1].Disconnected,
+	)
+}
+
+func TestConnectionHistoryPersisted(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db       = memdb.New()
+		nodeID0  = ids.GenerateTestNodeID()
+		nodeID1  = ids.GenerateTestNodeID()
+		nodeID2  = ids.GenerateTestNodeID()
+		start    = time.Unix(1_000, 0)
+		interval = ConnectionInterval{
+			Connected:    start,
+			Disconnected: start.Add(time.Minute),
+		}
+	)
+
+	history, err := newConnectionHistory(db)
+	require.NoError(err)
+	for _, nodeID := range []ids.NodeID{nodeID0, nodeID1, nodeID2} {
+		require.NoError(history.connected(nodeID, interval.Connected))
+		require.NoError(history.disconnected(nodeID, interval.Disconnected))
+	}
+	// nodeID1 is still connected when the node shuts down uncleanly.
+	require.NoError(history.connected(nodeID1, start.Add(2*time.Minute)))
+	require.NoError(history.remove(nodeID2))
+
+	history, err = newConnectionHistory(db)
+	require.NoError(err)
+	require.Equal([]ConnectionInterval{interval}, history.get(nodeID0))
+	// The interval that was never closed is dropped.
+	require.Equal([]ConnectionInterval{interval}, history.get(nodeID1))
+	require.Empty(history.get(nodeID2))
+}
+
+func TestConnectionHistoryInvalid(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(db.Put(nodeID.Bytes(), []byte{0}))
+
+	_, err := newConnectionHistory(db)
+	require.ErrorIs(err, errInvalidConnectionIntervals)
+}
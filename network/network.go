@@ -22,6 +22,7 @@ import (
 	"github.com/luxfi/consensus/core"
 	"github.com/luxfi/consensus/networking/router"
 	"github.com/luxfi/consensus/networking/sender"
+	"github.com/luxfi/database/memdb"
 	"github.com/luxfi/ids"
 	"github.com/luxfi/log"
 	"github.com/luxfi/node/api/health"
@@ -46,6 +47,7 @@ const (
 	TimeSinceLastMsgReceivedKey = "timeSinceLastMsgReceived"
 	TimeSinceLastMsgSentKey     = "timeSinceLastMsgSent"
 	SendFailRateKey             = "sendFailRate"
+	MismatchedUpgradePeersKey   = "mismatchedUpgradePeers"
 )
 
 var (
@@ -88,6 +90,10 @@ type Network interface {
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
+
+	// UptimeReport returns this node's view of the uptime of the validator
+	// [nodeID] of [subnetID].
+	UptimeReport(nodeID ids.NodeID, subnetID ids.ID) (UptimeReport, error)
 }
 
 type UptimeResult struct {
@@ -106,6 +112,33 @@ type UptimeResult struct {
 	WeightedAveragePercentage float64
 }
 
+type UptimeReport struct {
+	// UptimePercentage is the fraction of the validator's staking period
+	// that this node observed it to be online.
+	UptimePercentage float64
+
+	// UpDuration is the amount of time that this node observed the validator
+	// to be online, as of LastUpdated.
+	UpDuration  time.Duration
+	LastUpdated time.Time
+
+	// Connected is true if this node is currently connected to the
+	// validator.
+	Connected bool
+
+	// ConnectionIntervals are the most recent periods during which this node
+	// was connected to the validator, oldest first. Intervals are persisted
+	// across restarts.
+	ConnectionIntervals []ConnectionInterval
+
+	// UptimeRequirement is the fraction of time a validator must be online to
+	// be rewarded.
+	UptimeRequirement float64
+
+	// RewardEligible is true if UptimePercentage meets UptimeRequirement.
+	RewardEligible bool
+}
+
 // To avoid potential deadlocks, we maintain that locks must be grabbed in the
 // following order:
 //
@@ -153,6 +186,9 @@ type network struct {
 	connectedPeers  peer.Set
 	closing         bool
 
+	// Tracks the recent connection intervals of validators
+	connectionHistory *connectionHistory
+
 	// router is notified about all peer [Connected] and [Disconnected] events
 	// as well as all non-handshake peer messages.
 	//
@@ -240,6 +276,15 @@ func NewNetwork(
 	}
 	config.Validators.RegisterSetCallbackListener(constants.PrimaryNetworkID, ipTracker)
 
+	connectionHistoryDB := config.ConnectionHistoryDB
+	if connectionHistoryDB == nil {
+		connectionHistoryDB = memdb.New()
+	}
+	connectionHistory, err := newConnectionHistory(connectionHistoryDB)
+	if err != nil {
+		return nil, fmt.Errorf("initializing connection history failed with: %w", err)
+	}
+
 	// Track all default bootstrappers to ensure their current IPs are gossiped
 	// like validator IPs.
 	for _, bootstrapper := range genesis.GetBootstrappers(config.NetworkID) {
@@ -271,6 +316,7 @@ func NewNetwork(
 		MaxClockDifference:   config.MaxClockDifference,
 		SupportedLPs:         config.SupportedLPs.List(),
 		ObjectedLPs:          config.ObjectedLPs.List(),
+		UpgradeScheduleHash:  config.UpgradeScheduleHash,
 		ResourceTracker:      config.ResourceTracker,
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
@@ -298,11 +344,12 @@ func NewNetwork(
 			time.Now(),
 		)),
 
-		trackedIPs:      make(map[ids.NodeID]*trackedIP),
-		ipTracker:       ipTracker,
-		connectingPeers: peer.NewSet(),
-		connectedPeers:  peer.NewSet(),
-		router:          router,
+		trackedIPs:        make(map[ids.NodeID]*trackedIP),
+		ipTracker:         ipTracker,
+		connectionHistory: connectionHistory,
+		connectingPeers:   peer.NewSet(),
+		connectedPeers:    peer.NewSet(),
+		router:            router,
 	}
 	n.peerConfig.Network = n
 	return n, nil
@@ -364,6 +411,7 @@ func (n *network) Send(
 func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	n.peersLock.RLock()
 	connectedTo := n.connectedPeers.Len()
+	mismatchedUpgradePeers := n.mismatchedUpgradePeers()
 	n.peersLock.RUnlock()
 
 	sendFailRate := n.sendFailRateCalculator.Read()
@@ -407,6 +455,13 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	details[SendFailRateKey] = sendFailRate
 	n.metrics.sendFailRate.Set(sendFailRate)
 
+	// Report connected peers that scheduled a different network upgrade. Peers
+	// are expected to disagree while an upgrade is being rolled out, so this
+	// doesn't affect the health of the node.
+	if len(mismatchedUpgradePeers) > 0 {
+		details[MismatchedUpgradePeersKey] = mismatchedUpgradePeers
+	}
+
 	// emit metrics about the lifetime of peer connections
 	n.metrics.updatePeerConnectionLifetimeMetrics()
 
@@ -468,6 +523,12 @@ func (n *network) Connected(nodeID ids.NodeID) {
 	n.ipTracker.Connected(newIP)
 
 	n.metrics.markConnected(peer)
+	if err := n.connectionHistory.connected(nodeID, n.peerConfig.Clock.Time()); err != nil {
+		n.peerConfig.Log.Error("failed to persist connection history",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
 
 	peerVersion := peer.Version()
 	n.router.Connected(nodeID, peerVersion, constants.PrimaryNetworkID)
@@ -817,6 +878,21 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	}
 
 	n.metrics.markDisconnected(peer)
+
+	// Only the connection history of validators is retained to bound memory
+	// and disk usage.
+	var err error
+	if n.config.Validators.GetWeight(constants.PrimaryNetworkID, nodeID) == 0 {
+		err = n.connectionHistory.remove(nodeID)
+	} else {
+		err = n.connectionHistory.disconnected(nodeID, n.peerConfig.Clock.Time())
+	}
+	if err != nil {
+		n.peerConfig.Log.Error("failed to persist connection history",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
 }
 
 // dial will spin up a new goroutine and attempt to establish a connection with
@@ -1164,6 +1240,39 @@ func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
 	}, nil
 }
 
+func (n *network) UptimeReport(nodeID ids.NodeID, subnetID ids.ID) (UptimeReport, error) {
+	if subnetID != constants.PrimaryNetworkID && !n.config.TrackedSubnets.Contains(subnetID) {
+		return UptimeReport{}, errNotTracked
+	}
+
+	if n.config.Validators.GetWeight(subnetID, nodeID) == 0 {
+		return UptimeReport{}, errNotValidator
+	}
+
+	uptimePercentage, err := n.config.UptimeCalculator.CalculateUptimePercent(nodeID, subnetID)
+	if err != nil {
+		return UptimeReport{}, fmt.Errorf("couldn't calculate uptime percentage of %s: %w", nodeID, err)
+	}
+	upDuration, lastUpdated, err := n.config.UptimeCalculator.CalculateUptime(nodeID, subnetID)
+	if err != nil {
+		return UptimeReport{}, fmt.Errorf("couldn't calculate uptime of %s: %w", nodeID, err)
+	}
+
+	n.peersLock.RLock()
+	_, connected := n.connectedPeers.GetByID(nodeID)
+	n.peersLock.RUnlock()
+
+	return UptimeReport{
+		UptimePercentage:    uptimePercentage,
+		UpDuration:          upDuration,
+		LastUpdated:         lastUpdated,
+		Connected:           connected,
+		ConnectionIntervals: n.connectionHistory.get(nodeID),
+		UptimeRequirement:   n.config.UptimeRequirement,
+		RewardEligible:      uptimePercentage >= n.config.UptimeRequirement,
+	}, nil
+}
+
 func (n *network) runTimers() {
 	pullGossipPeerlists := time.NewTicker(n.config.PeerListPullGossipFreq)
 	resetPeerListBloom := time.NewTicker(n.config.PeerListBloomResetFreq)
@@ -1243,3 +1352,23 @@ func (n *network) getLastSent() (time.Time, bool) {
 	}
 	return time.Unix(lastSent, 0), true
 }
+
+// mismatchedUpgradePeers returns the connected peers that reported a network
+// upgrade schedule different from ours, mapped to the hash of the schedule
+// they reported. Peers that didn't report a schedule are ignored.
+//
+// Assumes [n.peersLock] is held.
+func (n *network) mismatchedUpgradePeers() map[string]string {
+	if n.config.UpgradeScheduleHash == ids.Empty {
+		return nil
+	}
+
+	mismatched := make(map[string]string)
+	for _, info := range n.connectedPeers.AllInfo() {
+		if info.UpgradeScheduleHash == ids.Empty || info.UpgradeScheduleHash == n.config.UpgradeScheduleHash {
+			continue
+		}
+		mismatched[info.ID.String()] = info.UpgradeScheduleHash.String()
+	}
+	return mismatched
+}
@@ -6,6 +6,7 @@ package network
 import (
 	"context"
 	"crypto"
+	"errors"
 	"net/netip"
 	"sync"
 	"testing"
@@ -21,6 +22,7 @@ import (
 	"github.com/luxfi/consensus/utils/timer/mockable"
 	"github.com/luxfi/consensus/validators"
 	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/database/memdb"
 	"github.com/luxfi/ids"
 	"github.com/luxfi/log"
 	"github.com/luxfi/node/message"
@@ -790,3 +792,159 @@ func TestAllowConnectionAsAValidator(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+type testWeights struct {
+	validators.Manager
+	weights map[ids.ID]map[ids.NodeID]uint64
+}
+
+func (w *testWeights) GetWeight(subnetID ids.ID, nodeID ids.NodeID) uint64 {
+	return w.weights[subnetID][nodeID]
+}
+
+type testUptimeCalculator struct {
+	uptime.Calculator
+	percentage  float64
+	upDuration  time.Duration
+	lastUpdated time.Time
+	err         error
+}
+
+func (c *testUptimeCalculator) CalculateUptimePercent(ids.NodeID, ids.ID) (float64, error) {
+	return c.percentage, c.err
+}
+
+func (c *testUptimeCalculator) CalculateUptime(ids.NodeID, ids.ID) (time.Duration, time.Time, error) {
+	return c.upDuration, c.lastUpdated, c.err
+}
+
+type testPeer struct {
+	peer.Peer
+	id ids.NodeID
+}
+
+func (p *testPeer) ID() ids.NodeID {
+	return p.id
+}
+
+func TestUptimeReport(t *testing.T) {
+	var (
+		nodeID      = ids.GenerateTestNodeID()
+		subnetID    = ids.GenerateTestID()
+		start       = time.Unix(1_000, 0)
+		lastUpdated = start.Add(time.Hour)
+		intervals   = []ConnectionInterval{
+			{Connected: start, Disconnected: start.Add(time.Minute)},
+			{Connected: start.Add(2 * time.Minute)},
+		}
+		errCalculator = errors.New("calculator failed")
+	)
+
+	tests := []struct {
+		name           string
+		subnetID       ids.ID
+		weight         uint64
+		connected      bool
+		calculator     *testUptimeCalculator
+		expectedReport UptimeReport
+		expectedErr    error
+	}{
+		{
+			name:        "untracked subnet",
+			subnetID:    ids.GenerateTestID(),
+			weight:      1,
+			calculator:  &testUptimeCalculator{},
+			expectedErr: errNotTracked,
+		},
+		{
+			name:        "not a validator",
+			subnetID:    constants.PrimaryNetworkID,
+			calculator:  &testUptimeCalculator{},
+			expectedErr: errNotValidator,
+		},
+		{
+			name:     "calculator error",
+			subnetID: constants.PrimaryNetworkID,
+			weight:   1,
+			calculator: &testUptimeCalculator{
+				err: errCalculator,
+			},
+			expectedErr: errCalculator,
+		},
+		{
+			name:      "eligible validator",
+			subnetID:  constants.PrimaryNetworkID,
+			weight:    1,
+			connected: true,
+			calculator: &testUptimeCalculator{
+				percentage:  .9,
+				upDuration:  time.Hour,
+				lastUpdated: lastUpdated,
+			},
+			expectedReport: UptimeReport{
+				UptimePercentage:    .9,
+				UpDuration:          time.Hour,
+				LastUpdated:         lastUpdated,
+				Connected:           true,
+				ConnectionIntervals: intervals,
+				UptimeRequirement:   .8,
+				RewardEligible:      true,
+			},
+		},
+		{
+			name:     "ineligible subnet validator",
+			subnetID: subnetID,
+			weight:   1,
+			calculator: &testUptimeCalculator{
+				percentage:  .5,
+				upDuration:  time.Minute,
+				lastUpdated: lastUpdated,
+			},
+			expectedReport: UptimeReport{
+				UptimePercentage:    .5,
+				UpDuration:          time.Minute,
+				LastUpdated:         lastUpdated,
+				ConnectionIntervals: intervals,
+				UptimeRequirement:   .8,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			history, err := newConnectionHistory(memdb.New())
+			require.NoError(err)
+			for _, interval := range intervals {
+				require.NoError(history.connected(nodeID, interval.Connected))
+				if !interval.Disconnected.IsZero() {
+					require.NoError(history.disconnected(nodeID, interval.Disconnected))
+				}
+			}
+
+			connectedPeers := peer.NewSet()
+			if test.connected {
+				connectedPeers.Add(&testPeer{id: nodeID})
+			}
+
+			n := &network{
+				config: &Config{
+					TrackedSubnets: set.Of(subnetID),
+					Validators: &testWeights{
+						weights: map[ids.ID]map[ids.NodeID]uint64{
+							test.subnetID: {nodeID: test.weight},
+						},
+					},
+					UptimeCalculator:  test.calculator,
+					UptimeRequirement: .8,
+				},
+				connectedPeers:    connectedPeers,
+				connectionHistory: history,
+			}
+
+			report, err := n.UptimeReport(nodeID, test.subnetID)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedReport, report)
+		})
+	}
+}
@@ -45,6 +45,11 @@ type Config struct {
 	SupportedLPs []uint32
 	ObjectedLPs  []uint32
 
+	// UpgradeScheduleHash is the hash of the network upgrade schedule of this
+	// node. It is advertised in the Handshake message so that peers with a
+	// different upgrade schedule can be detected.
+	UpgradeScheduleHash ids.ID
+
 	// Unix time of the last message sent and received respectively
 	// Must only be accessed atomically
 	LastSent, LastReceived int64
@@ -24,4 +24,5 @@ type Info struct {
 	TrackedSubnets        set.Set[ids.ID]        `json:"trackedSubnets"`
 	SupportedLPs          set.Set[uint32]        `json:"supportedLPs"`
 	ObjectedLPs           set.Set[uint32]        `json:"objectedLPs"`
+	UpgradeScheduleHash   ids.ID                 `json:"upgradeScheduleHash"`
 }
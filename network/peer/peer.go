@@ -149,6 +149,9 @@ type peer struct {
 	// options of LPs provided in the Handshake message.
 	supportedLPs set.Set[uint32]
 	objectedLPs  set.Set[uint32]
+	// upgradeScheduleHash is the hash of the network upgrade schedule of the
+	// peer, or ids.Empty if the peer didn't report one.
+	upgradeScheduleHash ids.ID
 
 	// txIDOfVerifiedBLSKey is the txID that added the BLS key that was most
 	// recently verified to have signed the IP.
@@ -297,6 +300,7 @@ func (p *peer) Info() Info {
 		TrackedSubnets:        p.trackedSubnets,
 		SupportedLPs:          p.supportedLPs,
 		ObjectedLPs:           p.objectedLPs,
+		UpgradeScheduleHash:   p.upgradeScheduleHash,
 	}
 }
 
@@ -550,6 +554,7 @@ func (p *peer) writeMessages() {
 		p.ObjectedLPs,
 		knownPeersFilter,
 		knownPeersSalt,
+		upgradeScheduleHashBytes(p.UpgradeScheduleHash),
 	)
 	if err != nil {
 		p.Log.Error(failedToCreateMessageLog,
@@ -1027,6 +1032,34 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 		return
 	}
 
+	// A peer running an older client doesn't report its upgrade schedule, so
+	// an empty hash is treated as unknown rather than as a mismatch.
+	if len(msg.UpgradeScheduleHash) != 0 {
+		upgradeScheduleHash, err := ids.ToID(msg.UpgradeScheduleHash)
+		if err != nil {
+			p.Log.Debug(malformedMessageLog,
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", message.HandshakeOp),
+				zap.String("field", "upgradeScheduleHash"),
+				zap.Error(err),
+			)
+			p.StartClose()
+			return
+		}
+		p.upgradeScheduleHash = upgradeScheduleHash
+		if p.UpgradeScheduleHash != ids.Empty && p.upgradeScheduleHash != p.UpgradeScheduleHash {
+			log := p.Log.Debug
+			if _, ok := p.Beacons.GetValidator(constants.PrimaryNetworkID, p.id); ok {
+				log = p.Log.Warn
+			}
+			log("peer has a different network upgrade schedule",
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("peerUpgradeScheduleHash", p.upgradeScheduleHash),
+				zap.Stringer("localUpgradeScheduleHash", p.UpgradeScheduleHash),
+			)
+		}
+	}
+
 	var (
 		knownPeers = bloom.EmptyFilter
 		salt       []byte
@@ -1307,3 +1340,13 @@ func (p *peer) storeLastReceived(time time.Time) {
 	atomic.StoreInt64(&p.Config.LastReceived, unixTime)
 	atomic.StoreInt64(&p.lastReceived, unixTime)
 }
+
+// upgradeScheduleHashBytes returns the bytes advertised in the Handshake
+// message for [upgradeScheduleHash]. ids.Empty is advertised as no bytes so
+// that peers treat it as unknown.
+func upgradeScheduleHashBytes(upgradeScheduleHash ids.ID) []byte {
+	if upgradeScheduleHash == ids.Empty {
+		return nil
+	}
+	return upgradeScheduleHash[:]
+}
@@ -413,6 +413,64 @@ func TestTrackedSubnets(t *testing.T) {
 	}
 }
 
+func TestUpgradeScheduleHash(t *testing.T) {
+	var (
+		upgradeScheduleHash      = ids.GenerateTestID()
+		otherUpgradeScheduleHash = ids.GenerateTestID()
+	)
+
+	tests := []struct {
+		name                        string
+		upgradeScheduleHash0        ids.ID
+		upgradeScheduleHash1        ids.ID
+		expectedUpgradeScheduleHash ids.ID
+	}{
+		{
+			name:                        "matching upgrade schedules",
+			upgradeScheduleHash0:        upgradeScheduleHash,
+			upgradeScheduleHash1:        upgradeScheduleHash,
+			expectedUpgradeScheduleHash: upgradeScheduleHash,
+		},
+		{
+			name:                        "mismatched upgrade schedules",
+			upgradeScheduleHash0:        otherUpgradeScheduleHash,
+			upgradeScheduleHash1:        upgradeScheduleHash,
+			expectedUpgradeScheduleHash: otherUpgradeScheduleHash,
+		},
+		{
+			name:                        "unknown upgrade schedule",
+			upgradeScheduleHash0:        ids.Empty,
+			upgradeScheduleHash1:        upgradeScheduleHash,
+			expectedUpgradeScheduleHash: ids.Empty,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			sharedConfig := newConfig(t)
+			rawPeer0 := newRawTestPeer(t, sharedConfig)
+			rawPeer1 := newRawTestPeer(t, sharedConfig)
+			rawPeer0.config.UpgradeScheduleHash = test.upgradeScheduleHash0
+			rawPeer1.config.UpgradeScheduleHash = test.upgradeScheduleHash1
+
+			peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+			defer func() {
+				peer1.StartClose()
+				peer0.StartClose()
+				require.NoError(peer0.AwaitClosed(context.Background()))
+				require.NoError(peer1.AwaitClosed(context.Background()))
+			}()
+
+			// A mismatched upgrade schedule is reported, not disconnected.
+			awaitReady(t, peer0, peer1)
+
+			require.Equal(test.expectedUpgradeScheduleHash, peer1.Info().UpgradeScheduleHash)
+		})
+	}
+}
+
 // Test that a peer using the wrong BLS key is disconnected from.
 func TestInvalidBLSKeyDisconnects(t *testing.T) {
 	require := require.New(t)
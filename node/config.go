@@ -6,6 +6,7 @@ package node
 import (
 	"crypto/tls"
 	"net/netip"
+	"os"
 	"time"
 
 	"github.com/luxfi/consensus/networking/benchlist"
@@ -19,6 +20,7 @@ import (
 	"github.com/luxfi/node/genesis"
 	"github.com/luxfi/node/network"
 	"github.com/luxfi/node/subnets"
+	"github.com/luxfi/node/upgrade"
 	"github.com/luxfi/node/utils/profiler"
 	"github.com/luxfi/math/set"
 	"github.com/luxfi/node/utils/timer"
@@ -41,6 +43,11 @@ type HTTPConfig struct {
 	HTTPSKey     []byte `json:"-"`
 	HTTPSCert    []byte `json:"-"`
 
+	// HTTPUnixSocketPath is the path of a Unix domain socket the API is also
+	// served on. If empty, the API is only served on HTTPHost:HTTPPort.
+	HTTPUnixSocketPath        string      `json:"httpUnixSocketPath"`
+	HTTPUnixSocketPermissions os.FileMode `json:"httpUnixSocketPermissions"`
+
 	HTTPAllowedOrigins []string `json:"httpAllowedOrigins"`
 	HTTPAllowedHosts   []string `json:"httpAllowedHosts"`
 
@@ -226,6 +233,9 @@ type Config struct {
 	// staking address).
 	ProcessContextFilePath string `json:"processContextFilePath"`
 
+	// UpgradeConfig is the schedule of network upgrades
+	UpgradeConfig upgrade.Config `json:"upgradeConfig"`
+
 	// POA Mode Configuration
 	POAModeEnabled     bool          `json:"poaModeEnabled"`
 	POASingleNodeMode  bool          `json:"poaSingleNodeMode"`
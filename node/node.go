@@ -25,7 +25,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 
-	"github.com/luxfi/consensus"
 	"github.com/luxfi/consensus/networking/benchlist"
 	"github.com/luxfi/consensus/networking/router"
 	"github.com/luxfi/consensus/networking/timeout"
@@ -75,6 +74,7 @@ import (
 	"github.com/luxfi/trace"
 
 	// "github.com/luxfi/node/vms/cchainvm" // Temporarily disabled
+	nodeconsensus "github.com/luxfi/node/consensus"
 	platformconfig "github.com/luxfi/node/vms/platformvm/config"
 	xvmconfig "github.com/luxfi/node/vms/xvm/config"
 )
@@ -87,6 +87,7 @@ const (
 
 	apiNamespace             = constants.PlatformName + "_" + "api"
 	benchlistNamespace       = constants.PlatformName + "_" + "benchlist"
+	blockAcceptorsNamespace  = constants.PlatformName + "_" + "block_acceptors"
 	dbNamespace              = constants.PlatformName + "_" + "db"
 	healthNamespace          = constants.PlatformName + "_" + "health"
 	meterDBNamespace         = constants.PlatformName + "_" + "meterdb"
@@ -97,14 +98,17 @@ const (
 	responsesNamespace       = constants.PlatformName + "_" + "responses"
 	rpcchainvmNamespace      = constants.PlatformName + "_" + "rpcchainvm"
 	systemResourcesNamespace = constants.PlatformName + "_" + "system_resources"
+	txAcceptorsNamespace     = constants.PlatformName + "_" + "tx_acceptors"
+	vertexAcceptorsNamespace = constants.PlatformName + "_" + "vertex_acceptors"
 )
 
 var (
 	genesisHashKey     = []byte("genesisID")
 	ungracefulShutdown = []byte("ungracefulShutdown")
 
-	indexerDBPrefix  = []byte{0x00}
-	keystoreDBPrefix = []byte("keystore")
+	indexerDBPrefix           = []byte{0x00}
+	keystoreDBPrefix          = []byte("keystore")
+	connectionHistoryDBPrefix = []byte("connection history")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -231,7 +235,9 @@ func New(
 		return nil, fmt.Errorf("problem initializing networking: %w", err)
 	}
 
-	n.initEventDispatchers()
+	if err := n.initEventDispatchers(); err != nil {
+		return nil, fmt.Errorf("couldn't initialize event dispatchers: %w", err)
+	}
 
 	// Start the Health API
 	// Has to be initialized before chain manager
@@ -326,9 +332,9 @@ type Node struct {
 	uptimeCalculator uptime.LockedCalculator
 
 	// dispatcher for events as they happen in consensus
-	BlockAcceptorGroup  consensus.AcceptorGroup
-	TxAcceptorGroup     consensus.AcceptorGroup
-	VertexAcceptorGroup consensus.AcceptorGroup
+	BlockAcceptorGroup  nodeconsensus.AcceptorGroup
+	TxAcceptorGroup     nodeconsensus.AcceptorGroup
+	VertexAcceptorGroup nodeconsensus.AcceptorGroup
 
 	// Net runs the networking stack
 	Net network.Network
@@ -625,6 +631,7 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 	n.Config.NetworkConfig.BLSKey = n.Config.StakingSigningKey
 	n.Config.NetworkConfig.TrackedSubnets = n.Config.TrackedSubnets
 	n.Config.NetworkConfig.UptimeCalculator = n.uptimeCalculator
+	n.Config.NetworkConfig.ConnectionHistoryDB = prefixdb.New(connectionHistoryDBPrefix, n.DB)
 	n.Config.NetworkConfig.UptimeRequirement = n.Config.UptimeRequirement
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
@@ -880,10 +887,31 @@ func (n *Node) initBootstrappers() error {
 
 // Create the EventDispatcher used for hooking events
 // into the general process flow.
-func (n *Node) initEventDispatchers() {
-	n.BlockAcceptorGroup = consensus.NewAcceptorGroup(n.Log)
-	n.TxAcceptorGroup = consensus.NewAcceptorGroup(n.Log)
-	n.VertexAcceptorGroup = consensus.NewAcceptorGroup(n.Log)
+func (n *Node) initEventDispatchers() error {
+	var err error
+	n.BlockAcceptorGroup, err = n.newAcceptorGroup(blockAcceptorsNamespace)
+	if err != nil {
+		return err
+	}
+	n.TxAcceptorGroup, err = n.newAcceptorGroup(txAcceptorsNamespace)
+	if err != nil {
+		return err
+	}
+	n.VertexAcceptorGroup, err = n.newAcceptorGroup(vertexAcceptorsNamespace)
+	return err
+}
+
+// newAcceptorGroup returns an acceptor group that reports its metrics under
+// [namespace].
+func (n *Node) newAcceptorGroup(namespace string) (nodeconsensus.AcceptorGroup, error) {
+	reg, err := metric.MakeAndRegister(
+		n.MetricsGatherer,
+		namespace,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return nodeconsensus.NewAcceptorGroup(n.Log, reg)
 }
 
 // Initialize [n.indexer].
@@ -1018,9 +1046,9 @@ func (n *Node) initAPIServer() error {
 		if err != nil {
 			return err
 		}
-		config := &tls.Config{
-			MinVersion:   tls.VersionTLS12,
-			Certificates: []tls.Certificate{cert},
+		config, err := server.NewTLSConfig(cert, n.Config.HTTPConfig.HTTPConfig.ClientAuth)
+		if err != nil {
+			return err
 		}
 		listener = tls.NewListener(listener, config)
 
@@ -1028,6 +1056,20 @@ func (n *Node) initAPIServer() error {
 	}
 	n.apiURI = fmt.Sprintf("%s://%s", protocol, listener.Addr())
 
+	listeners := []net.Listener{listener}
+	if n.Config.HTTPUnixSocketPath != "" {
+		unixListener, err := server.NewUnixListener(n.Config.HTTPUnixSocketPath, n.Config.HTTPUnixSocketPermissions)
+		if err != nil {
+			_ = listener.Close()
+			return err
+		}
+		listeners = append(listeners, unixListener)
+
+		n.Log.Info("API server listening on Unix domain socket",
+			zap.String("path", n.Config.HTTPUnixSocketPath),
+		)
+	}
+
 	apiRegisterer, err := metric.MakeAndRegister(
 		n.MetricsGatherer,
 		apiNamespace,
@@ -1039,7 +1081,7 @@ func (n *Node) initAPIServer() error {
 	n.APIServer, err = server.New(
 		n.Log,
 		n.LogFactory,
-		listener,
+		listeners,
 		n.Config.HTTPAllowedOrigins,
 		n.Config.ShutdownTimeout,
 		n.ID,
@@ -1048,6 +1090,7 @@ func (n *Node) initAPIServer() error {
 		apiRegisterer,
 		n.Config.HTTPConfig.HTTPConfig,
 		n.Config.HTTPAllowedHosts,
+		nil, // The node's APIs don't require auth tokens
 	)
 	return err
 }
@@ -1185,8 +1228,9 @@ func (n *Node) initChainManager(luxAssetID ids.ID) error {
 			BootstrapMaxTimeGetAncestors:            n.Config.BootstrapMaxTimeGetAncestors,
 			BootstrapAncestorsMaxContainersSent:     n.Config.BootstrapAncestorsMaxContainersSent,
 			BootstrapAncestorsMaxContainersReceived: n.Config.BootstrapAncestorsMaxContainersReceived,
-			ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
+			ApricotPhase4Time:                       n.Config.UpgradeConfig.ApricotPhase4Time,
 			ApricotPhase4MinPChainHeight:            version.ApricotPhase4MinPChainHeight[n.Config.NetworkID],
+			DurangoTime:                             n.Config.UpgradeConfig.DurangoTime,
 			ResourceTracker:                         n.resourceTracker,
 			StateSyncBeacons:                        n.Config.StateSyncIDs,
 			TracingEnabled:                          n.Config.TraceConfig.ExporterConfig.Type != trace.Disabled,
@@ -1220,7 +1264,7 @@ func (n *Node) initVMs() error {
 	}
 
 	// Register the VMs that Lux supports
-	etnaTime := version.GetEtnaTime(n.Config.NetworkID)
+	upgradeConfig := n.Config.UpgradeConfig
 	err := errors.Join(
 		n.VMManager.RegisterFactory(context.TODO(), constants.PlatformVMID, &platformvm.Factory{
 			Config: platformconfig.Config{
@@ -1231,6 +1275,7 @@ func (n *Node) initVMs() error {
 				PartialSyncPrimaryNetwork: n.Config.PartialSyncPrimaryNetwork,
 				TrackedSubnets:            n.Config.TrackedSubnets,
 				StaticFeeConfig:           n.Config.StaticConfig,
+				DynamicFeeConfig:          genesis.GetDynamicFeeConfig(n.Config.NetworkID),
 				UptimePercentage:          n.Config.UptimeRequirement,
 				MinValidatorStake:         n.Config.MinValidatorStake,
 				MaxValidatorStake:         n.Config.MaxValidatorStake,
@@ -1240,12 +1285,13 @@ func (n *Node) initVMs() error {
 				MaxStakeDuration:          n.Config.MaxStakeDuration,
 				RewardConfig:              n.Config.RewardConfig,
 				UpgradeConfig: upgrade.Config{
-					ApricotPhase3Time: version.GetApricotPhase3Time(n.Config.NetworkID),
-					ApricotPhase5Time: version.GetApricotPhase5Time(n.Config.NetworkID),
-					BanffTime:         version.GetBanffTime(n.Config.NetworkID),
-					CortinaTime:       version.GetCortinaTime(n.Config.NetworkID),
-					DurangoTime:       version.GetDurangoTime(n.Config.NetworkID),
-					EtnaTime:          etnaTime,
+					ApricotPhase3Time: upgradeConfig.ApricotPhase3Time,
+					ApricotPhase5Time: upgradeConfig.ApricotPhase5Time,
+					BanffTime:         upgradeConfig.BanffTime,
+					CortinaTime:       upgradeConfig.CortinaTime,
+					DurangoTime:       upgradeConfig.DurangoTime,
+					EtnaTime:          upgradeConfig.EtnaTime,
+					FortunaTime:       upgradeConfig.FortunaTime,
 				},
 				UseCurrentHeight: n.Config.UseCurrentHeight,
 			},
@@ -1254,7 +1300,10 @@ func (n *Node) initVMs() error {
 			Config: xvmconfig.Config{
 				TxFee:            n.Config.TxFee,
 				CreateAssetTxFee: n.Config.CreateAssetTxFee,
-				EtnaTime:         etnaTime,
+				DynamicFeeConfig: genesis.GetXChainDynamicFeeConfig(n.Config.NetworkID),
+				CortinaTime:      upgradeConfig.CortinaTime,
+				EtnaTime:         upgradeConfig.EtnaTime,
+				FortunaTime:      upgradeConfig.FortunaTime,
 			},
 		}),
 		// n.VMManager.RegisterFactory(context.TODO(), constants.EVMID, &cchainvm.Factory{}), // Temporarily disabled
@@ -0,0 +1,195 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build test
+
+package faultinjection
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/tests"
+	"github.com/luxfi/node/tests/fixture/e2e"
+	"github.com/luxfi/node/tests/fixture/faultinjection"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/vms/secp256k1fx"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+)
+
+const (
+	faultNodeCount = 5
+
+	// recoveryTimeout bounds how long the network may take to make progress
+	// once a fault is removed.
+	recoveryTimeout = 5 * time.Minute
+
+	// clockSkew is within the tolerance of the chains for block timestamps
+	// ahead of or behind local time.
+	clockSkew = 5 * time.Second
+)
+
+var _ = ginkgo.Describe("[Network Faults]", ginkgo.Ordered, func() {
+	require := require.New(ginkgo.GinkgoT())
+
+	var (
+		network  *tmpnet.Network
+		injector *faultinjection.Injector
+	)
+
+	ginkgo.BeforeAll(func() {
+		ginkgo.By("creating a private network whose nodes are proxied")
+		network = &tmpnet.Network{
+			Owner: "node-e2e-network-faults",
+			Nodes: tmpnet.NewNodesOrPanic(faultNodeCount),
+		}
+		injector = faultinjection.NewInjector(network)
+		require.NoError(injector.ProxyNodes(network.Nodes...))
+		// Registered before the network is started so that the proxies are
+		// only closed after the network is stopped.
+		ginkgo.DeferCleanup(func() {
+			require.NoError(injector.Close())
+		})
+		e2e.Env.StartPrivateNetwork(network)
+	})
+
+	ginkgo.AfterEach(func() {
+		injector.ClearFaults()
+
+		ginkgo.By("checking that every node recovers")
+		checkLiveness(network, network.Nodes[0], network.Nodes)
+
+		ginkgo.By("checking that no conflicting blocks were accepted")
+		require.NoError(faultinjection.CheckConsistency(e2e.DefaultContext(), network.Nodes))
+	})
+
+	ginkgo.It("should tolerate a paused node", func() {
+		paused := network.Nodes[faultNodeCount-1]
+
+		ginkgo.By("pausing a node")
+		require.NoError(injector.Pause(paused))
+
+		ginkgo.By("checking that the remaining nodes make progress")
+		checkLiveness(network, network.Nodes[0], network.Nodes[:faultNodeCount-1])
+
+		ginkgo.By("resuming the node")
+		require.NoError(injector.Resume(paused))
+	})
+
+	ginkgo.It("should tolerate a crashed node", func() {
+		crashed := network.Nodes[faultNodeCount-1]
+
+		ginkgo.By("killing a node")
+		require.NoError(injector.Kill(e2e.DefaultContext(), crashed))
+
+		ginkgo.By("checking that the remaining nodes make progress")
+		checkLiveness(network, network.Nodes[0], network.Nodes[:faultNodeCount-1])
+
+		ginkgo.By("restarting the node")
+		require.NoError(injector.Start(e2e.DefaultContext(), ginkgo.GinkgoWriter, crashed))
+	})
+
+	ginkgo.It("should tolerate a partitioned node", func() {
+		isolated := network.Nodes[faultNodeCount-1]
+
+		ginkgo.By("blackholing the links of a node")
+		require.NoError(injector.Isolate(faultinjection.Fault{Blackhole: true}, isolated))
+
+		ginkgo.By("checking that the remaining nodes make progress")
+		checkLiveness(network, network.Nodes[0], network.Nodes[:faultNodeCount-1])
+	})
+
+	ginkgo.It("should tolerate slow links", func() {
+		ginkgo.By("throttling the links of two nodes")
+		require.NoError(injector.Isolate(
+			faultinjection.Fault{
+				Latency:        200 * time.Millisecond,
+				BytesPerSecond: 64 * 1024,
+			},
+			network.Nodes[faultNodeCount-2:]...,
+		))
+
+		ginkgo.By("checking that every node makes progress")
+		checkLiveness(network, network.Nodes[0], network.Nodes)
+	})
+
+	// Clocks are only skewed if the node binary was built with the
+	// faultinjection tag.
+	ginkgo.It("should tolerate skewed clocks", func() {
+		ahead := network.Nodes[faultNodeCount-1]
+		behind := network.Nodes[faultNodeCount-2]
+
+		ginkgo.By("skewing the clocks of two nodes")
+		require.NoError(injector.SkewClock(e2e.DefaultContext(), ginkgo.GinkgoWriter, ahead, clockSkew))
+		require.NoError(injector.SkewClock(e2e.DefaultContext(), ginkgo.GinkgoWriter, behind, -clockSkew))
+
+		ginkgo.By("checking that every node makes progress")
+		checkLiveness(network, ahead, network.Nodes)
+		checkLiveness(network, behind, network.Nodes)
+
+		ginkgo.By("restoring the clocks")
+		require.NoError(injector.SkewClock(e2e.DefaultContext(), ginkgo.GinkgoWriter, ahead, 0))
+		require.NoError(injector.SkewClock(e2e.DefaultContext(), ginkgo.GinkgoWriter, behind, 0))
+	})
+
+	ginkgo.It("should tolerate a corrupted database", func() {
+		corrupted := network.Nodes[faultNodeCount-1]
+		seed := time.Now().UnixNano()
+		tests.Outf("{{blue}}corrupting with seed %d{{/}}\n", seed)
+
+		ginkgo.By("corrupting the database of a stopped node")
+		require.NoError(corrupted.Stop(e2e.DefaultContext()))
+		require.NoError(injector.CorruptDatabase(
+			corrupted,
+			rand.New(rand.NewSource(seed)), //#nosec G404
+			64,
+		))
+
+		ginkgo.By("starting the node with its corrupted database")
+		// The node may fail to start or to become healthy, but it must not
+		// accept blocks that conflict with the rest of the network.
+		if err := injector.Start(e2e.DefaultContext(), ginkgo.GinkgoWriter, corrupted); err == nil {
+			require.NoError(faultinjection.CheckConsistency(e2e.DefaultContext(), network.Nodes))
+		}
+		require.NoError(corrupted.Stop(e2e.DefaultContext()))
+
+		ginkgo.By("checking that the remaining nodes make progress")
+		checkLiveness(network, network.Nodes[0], network.Nodes[:faultNodeCount-1])
+
+		ginkgo.By("restarting the node with an empty database")
+		require.NoError(injector.WipeDatabase(corrupted))
+		require.NoError(injector.Start(e2e.ContextWithTimeout(recoveryTimeout), ginkgo.GinkgoWriter, corrupted))
+	})
+})
+
+// checkLiveness issues a P-Chain transaction through [issuer] and waits for
+// every one of [nodes] to accept it.
+func checkLiveness(network *tmpnet.Network, issuer *tmpnet.Node, nodes []*tmpnet.Node) {
+	require := require.New(ginkgo.GinkgoT())
+
+	keychain := secp256k1fx.NewKeychain(network.PreFundedKeys[0])
+	pWallet := e2e.NewWallet(keychain, tmpnet.NodeURI{
+		NodeID: issuer.NodeID,
+		URI:    issuer.URI,
+	}).P()
+
+	ctx := e2e.ContextWithTimeout(recoveryTimeout)
+	_, err := pWallet.IssueCreateSubnetTx(
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs: []ids.ShortID{
+				keychain.Keys[0].Address(),
+			},
+		},
+		e2e.WithDefaultContext(),
+	)
+	require.NoError(err)
+
+	heights, err := faultinjection.GetHeights(ctx, []*tmpnet.Node{issuer})
+	require.NoError(err)
+	require.NoError(faultinjection.WaitForHeight(ctx, nodes, heights[0]))
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faultinjection
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/vms/platformvm"
+)
+
+const checkInterval = time.Second
+
+var errConflictingBlocks = errors.New("conflicting blocks accepted")
+
+// GetHeights returns the height of the last accepted P-Chain block of each of
+// [nodes].
+func GetHeights(ctx context.Context, nodes []*tmpnet.Node) ([]uint64, error) {
+	heights := make([]uint64, len(nodes))
+	for i, node := range nodes {
+		height, err := platformvm.NewClient(node.URI).GetHeight(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get height of node %s: %w", node.NodeID, err)
+		}
+		heights[i] = height
+	}
+	return heights, nil
+}
+
+// WaitForHeight waits until every one of [nodes] has accepted the P-Chain block
+// at [height]. Errors from nodes that aren't reachable yet are retried until
+// [ctx] is done.
+func WaitForHeight(ctx context.Context, nodes []*tmpnet.Node, height uint64) error {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		heights, err := GetHeights(ctx, nodes)
+		if err == nil && minHeight(heights) >= height {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to see height %d accepted by all nodes (heights: %v, err: %w): %w", height, heights, err, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckConsistency verifies that [nodes] accepted the same P-Chain blocks at
+// every height accepted by all of them. Any difference means that conflicting
+// blocks were accepted.
+func CheckConsistency(ctx context.Context, nodes []*tmpnet.Node) error {
+	heights, err := GetHeights(ctx, nodes)
+	if err != nil {
+		return err
+	}
+
+	clients := make([]platformvm.Client, len(nodes))
+	for i, node := range nodes {
+		clients[i] = platformvm.NewClient(node.URI)
+	}
+	for height := uint64(0); height <= minHeight(heights); height++ {
+		var expected []byte
+		for i, client := range clients {
+			block, err := client.GetBlockByHeight(ctx, height)
+			if err != nil {
+				return fmt.Errorf("failed to get block %d of node %s: %w", height, nodes[i].NodeID, err)
+			}
+			if i == 0 {
+				expected = block
+				continue
+			}
+			if !bytes.Equal(block, expected) {
+				return fmt.Errorf("%w at height %d: nodes %s and %s differ",
+					errConflictingBlocks,
+					height,
+					nodes[0].NodeID,
+					nodes[i].NodeID,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func minHeight(heights []uint64) uint64 {
+	if len(heights) == 0 {
+		return 0
+	}
+	lowest := uint64(math.MaxUint64)
+	for _, height := range heights {
+		lowest = min(lowest, height)
+	}
+	return lowest
+}
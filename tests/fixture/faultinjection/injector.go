@@ -0,0 +1,308 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faultinjection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"math/rand"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cast"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/config"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/utils/timer/mockable"
+)
+
+// ProxyHost is the loopback address proxies accept connections on. The nodes
+// themselves listen on 127.0.0.1, so a proxy can use the same port as the node
+// it is in front of. Linux routes all of 127.0.0.0/8 to the loopback
+// interface, other platforms may require the address to be added as an alias.
+const ProxyHost = "127.0.0.2"
+
+// nodeHost is the loopback address proxied nodes listen on.
+var nodeHost = netip.MustParseAddr("127.0.0.1")
+
+var (
+	errNotProxied       = errors.New("node is not proxied")
+	errAlreadyProxied   = errors.New("node is already proxied")
+	errNodeRunning      = errors.New("node must be stopped")
+	errNothingToCorrupt = errors.New("no database files to corrupt")
+)
+
+// Injector injects faults into a tmpnet network whose nodes run as local
+// processes.
+type Injector struct {
+	network *tmpnet.Network
+
+	lock    sync.Mutex
+	proxies map[ids.NodeID]*Proxy
+	// addrs maps the staking address of each proxied node to its ID.
+	addrs map[netip.AddrPort]ids.NodeID
+}
+
+// NewInjector returns an injector for the nodes of [network].
+func NewInjector(network *tmpnet.Network) *Injector {
+	return &Injector{
+		network: network,
+		proxies: make(map[ids.NodeID]*Proxy),
+		addrs:   make(map[netip.AddrPort]ids.NodeID),
+	}
+}
+
+// ProxyNodes routes the staking connections made to each of [nodes] through a
+// proxy so that the links between them can be disrupted. Must be called before
+// the nodes are started. Every connection between two nodes is opened by one of
+// them and passes through the proxy of the other, so link faults only apply
+// reliably between nodes that are both proxied.
+func (i *Injector) ProxyNodes(nodes ...*tmpnet.Node) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	for _, node := range nodes {
+		if err := node.EnsureKeys(); err != nil {
+			return err
+		}
+		if _, ok := i.proxies[node.NodeID]; ok {
+			return fmt.Errorf("%w: %s", errAlreadyProxied, node.NodeID)
+		}
+
+		// The proxy claims its port first. The node listens on the same
+		// port of a different address, which is very likely to be free.
+		listener, err := net.Listen("tcp", net.JoinHostPort(ProxyHost, "0"))
+		if err != nil {
+			return fmt.Errorf("failed to start proxy for node %s: %w", node.NodeID, err)
+		}
+		port := uint16(listener.Addr().(*net.TCPAddr).Port)
+		nodeAddr := netip.AddrPortFrom(nodeHost, port)
+
+		// Peers learn the address to dial from the node's public IP.
+		node.Flags[config.PublicIPKey] = ProxyHost
+		node.Flags[config.StakingHostKey] = nodeHost.String()
+		node.Flags[config.StakingPortKey] = int(port)
+		i.proxies[node.NodeID] = NewProxy(listener, nodeAddr.String(), NewProcessResolver(i.nodeAddrs))
+		i.addrs[nodeAddr] = node.NodeID
+	}
+	return nil
+}
+
+// nodeAddrs returns the addresses the proxied nodes listen on.
+func (i *Injector) nodeAddrs() map[netip.AddrPort]ids.NodeID {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return maps.Clone(i.addrs)
+}
+
+// SetLinkFault applies [fault] to the traffic between [a] and [b] in both
+// directions, including on connections that are already open.
+func (i *Injector) SetLinkFault(a *tmpnet.Node, b *tmpnet.Node, fault Fault) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	proxyA, ok := i.proxies[a.NodeID]
+	if !ok {
+		return fmt.Errorf("%w: %s", errNotProxied, a.NodeID)
+	}
+	proxyB, ok := i.proxies[b.NodeID]
+	if !ok {
+		return fmt.Errorf("%w: %s", errNotProxied, b.NodeID)
+	}
+	proxyA.SetFault(b.NodeID, fault)
+	proxyB.SetFault(a.NodeID, fault)
+	return nil
+}
+
+// Isolate applies [fault] to the links between each of [nodes] and every other
+// proxied node.
+func (i *Injector) Isolate(fault Fault, nodes ...*tmpnet.Node) error {
+	isolated := make(map[ids.NodeID]bool, len(nodes))
+	for _, node := range nodes {
+		isolated[node.NodeID] = true
+	}
+	for _, node := range nodes {
+		for _, peer := range i.network.Nodes {
+			if isolated[peer.NodeID] || !i.isProxied(peer) {
+				continue
+			}
+			if err := i.SetLinkFault(node, peer, fault); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (i *Injector) isProxied(node *tmpnet.Node) bool {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	_, ok := i.proxies[node.NodeID]
+	return ok
+}
+
+// ClearFaults removes every link fault.
+func (i *Injector) ClearFaults() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	for _, proxy := range i.proxies {
+		proxy.ClearFaults()
+	}
+}
+
+// Pause suspends [node] without notifying it, as if it had hung.
+func (*Injector) Pause(node *tmpnet.Node) error {
+	return node.Signal(syscall.SIGSTOP)
+}
+
+// Resume continues a node suspended by Pause.
+func (*Injector) Resume(node *tmpnet.Node) error {
+	return node.Signal(syscall.SIGCONT)
+}
+
+// Kill stops [node] without allowing it to shut down gracefully, as if it had
+// crashed.
+func (*Injector) Kill(ctx context.Context, node *tmpnet.Node) error {
+	if err := node.Signal(syscall.SIGKILL); err != nil {
+		return err
+	}
+	return node.WaitForStopped(ctx)
+}
+
+// Start starts a stopped node and waits for it to report healthy.
+func (i *Injector) Start(ctx context.Context, w io.Writer, node *tmpnet.Node) error {
+	if err := i.network.StartNode(ctx, w, node); err != nil {
+		return err
+	}
+	return tmpnet.WaitForHealthy(ctx, node)
+}
+
+// SkewClock restarts [node] with its clock offset from the system clock by
+// [offset]. An offset of zero restores the node's clock. The offset is only
+// applied by node binaries built with the faultinjection tag.
+func (i *Injector) SkewClock(ctx context.Context, w io.Writer, node *tmpnet.Node, offset time.Duration) error {
+	if offset == 0 {
+		delete(node.Env, mockable.ClockOffsetEnvName)
+	} else {
+		if node.Env == nil {
+			node.Env = map[string]string{}
+		}
+		node.Env[mockable.ClockOffsetEnvName] = offset.String()
+	}
+	return i.network.RestartNode(ctx, w, node)
+}
+
+// CorruptDatabase overwrites [numBytes] randomly chosen bytes of the database
+// files of a stopped node. The bytes are chosen by [rng] so that a corruption
+// can be reproduced.
+func (*Injector) CorruptDatabase(node *tmpnet.Node, rng *rand.Rand, numBytes int) error {
+	pid, err := node.GetPID()
+	if err != nil {
+		return err
+	}
+	if pid != 0 {
+		return fmt.Errorf("%w: %s", errNodeRunning, node.NodeID)
+	}
+
+	var paths []string
+	err = filepath.WalkDir(getDBDir(node), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() > 0 {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find database files of node %s: %w", node.NodeID, err)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("%w: %s", errNothingToCorrupt, node.NodeID)
+	}
+
+	for n := 0; n < numBytes; n++ {
+		if err := corruptByte(paths[rng.Intn(len(paths))], rng); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func corruptByte(path string, rng *rand.Rand) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	offset := rng.Int63n(info.Size())
+	b := make([]byte, 1)
+	if _, err := file.ReadAt(b, offset); err != nil {
+		return err
+	}
+	// Flipping every bit guarantees the byte changes.
+	b[0] ^= 0xff
+	_, err = file.WriteAt(b, offset)
+	return err
+}
+
+// WipeDatabase removes the database of a stopped node so that it has to
+// bootstrap from its peers when it is started again.
+func (*Injector) WipeDatabase(node *tmpnet.Node) error {
+	pid, err := node.GetPID()
+	if err != nil {
+		return err
+	}
+	if pid != 0 {
+		return fmt.Errorf("%w: %s", errNodeRunning, node.NodeID)
+	}
+	return os.RemoveAll(getDBDir(node))
+}
+
+func getDBDir(node *tmpnet.Node) string {
+	if dbDir := cast.ToString(node.Flags[config.DBPathKey]); len(dbDir) > 0 {
+		return dbDir
+	}
+	return filepath.Join(node.GetDataDir(), "db")
+}
+
+// Close stops the proxies. Nodes that were proxied can no longer be reached by
+// their peers afterwards.
+func (i *Injector) Close() error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	var errs []error
+	for _, proxy := range i.proxies {
+		errs = append(errs, proxy.Close())
+	}
+	clear(i.proxies)
+	clear(i.addrs)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faultinjection
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/luxfi/ids"
+)
+
+// bufferSize is the most traffic that is read from a connection before faults
+// are applied to it.
+const bufferSize = 32 * 1024
+
+const (
+	// minAcceptBackoff and maxAcceptBackoff bound how long a proxy waits before
+	// accepting again after a failed accept, e.g. when out of file descriptors.
+	minAcceptBackoff = 5 * time.Millisecond
+	maxAcceptBackoff = time.Second
+)
+
+// Fault describes how traffic between two nodes is disrupted. The zero value
+// leaves traffic untouched.
+type Fault struct {
+	// Blackhole discards all traffic. Connections stay open so that peers have
+	// to detect the failure themselves.
+	Blackhole bool
+	// Latency delays every chunk of traffic.
+	Latency time.Duration
+	// BytesPerSecond limits the throughput in each direction. Zero is
+	// unlimited.
+	BytesPerSecond int
+}
+
+// SourceResolver identifies the node that opened a connection to a proxy.
+type SourceResolver interface {
+	// Resolve returns the ID of the node that opened [conn]. Returns false if
+	// the source can't be identified, in which case no faults are applied to
+	// the connection.
+	Resolve(conn net.Conn) (ids.NodeID, bool)
+}
+
+// SourceResolverFunc is a function that satisfies SourceResolver.
+type SourceResolverFunc func(net.Conn) (ids.NodeID, bool)
+
+func (f SourceResolverFunc) Resolve(conn net.Conn) (ids.NodeID, bool) {
+	return f(conn)
+}
+
+// Proxy forwards the connections made to a node, applying the faults configured
+// for the node that opened each connection. Faults are evaluated for every
+// chunk of traffic, so changes apply to open connections immediately.
+type Proxy struct {
+	listener net.Listener
+	target   string
+	resolver SourceResolver
+
+	lock   sync.RWMutex
+	faults map[ids.NodeID]Fault
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewProxy forwards the connections accepted by [listener] to [target].
+func NewProxy(listener net.Listener, target string, resolver SourceResolver) *Proxy {
+	p := &Proxy{
+		listener: listener,
+		target:   target,
+		resolver: resolver,
+		faults:   make(map[ids.NodeID]Fault),
+		conns:    make(map[net.Conn]struct{}),
+	}
+	p.wg.Add(1)
+	go p.serve()
+	return p
+}
+
+// Addr returns the address the proxy accepts connections on.
+func (p *Proxy) Addr() net.Addr {
+	return p.listener.Addr()
+}
+
+// SetFault applies [fault] to the traffic of connections opened by [source].
+func (p *Proxy) SetFault(source ids.NodeID, fault Fault) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.faults[source] = fault
+}
+
+// ClearFaults removes every fault from the proxy.
+func (p *Proxy) ClearFaults() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	clear(p.faults)
+}
+
+// Close stops accepting connections and closes the open ones.
+func (p *Proxy) Close() error {
+	p.lock.Lock()
+	p.closed = true
+	for conn := range p.conns {
+		_ = conn.Close()
+	}
+	p.lock.Unlock()
+
+	err := p.listener.Close()
+	p.wg.Wait()
+	return err
+}
+
+func (p *Proxy) fault(source ids.NodeID, known bool) Fault {
+	if !known {
+		return Fault{}
+	}
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.faults[source]
+}
+
+// track records [conns] so that they are closed with the proxy. Returns false
+// if the proxy is already closed.
+func (p *Proxy) track(conns ...net.Conn) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		return false
+	}
+	for _, conn := range conns {
+		p.conns[conn] = struct{}{}
+	}
+	return true
+}
+
+func (p *Proxy) untrack(conns ...net.Conn) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, conn := range conns {
+		delete(p.conns, conn)
+	}
+}
+
+func (p *Proxy) serve() {
+	defer p.wg.Done()
+
+	var backoff time.Duration
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			backoff = min(max(2*backoff, minAcceptBackoff), maxAcceptBackoff)
+			time.Sleep(backoff)
+			continue
+		}
+		backoff = 0
+
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.forward(conn)
+		}()
+	}
+}
+
+func (p *Proxy) forward(conn net.Conn) {
+	// The source is resolved before dialing the target, while the connection
+	// is known to be open.
+	source, known := p.resolver.Resolve(conn)
+
+	target, err := net.Dial("tcp", p.target)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	if !p.track(conn, target) {
+		_ = conn.Close()
+		_ = target.Close()
+		return
+	}
+	defer p.untrack(conn, target)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		p.copy(target, conn, source, known)
+	}()
+	go func() {
+		defer wg.Done()
+		p.copy(conn, target, source, known)
+	}()
+	wg.Wait()
+}
+
+// copy forwards traffic from [src] to [dst] until either is closed, and then
+// closes both so that the other direction stops as well.
+func (p *Proxy) copy(dst io.WriteCloser, src io.ReadCloser, source ids.NodeID, known bool) {
+	defer func() {
+		_ = dst.Close()
+		_ = src.Close()
+	}()
+
+	buf := make([]byte, bufferSize)
+	for {
+		readSize := len(buf)
+		if fault := p.fault(source, known); fault.BytesPerSecond > 0 {
+			// Throttled traffic is read in smaller chunks so that it
+			// trickles out rather than arriving in bursts.
+			readSize = min(readSize, max(fault.BytesPerSecond/10, 1))
+		}
+
+		n, err := src.Read(buf[:readSize])
+		if n > 0 {
+			fault := p.fault(source, known)
+			if !fault.Blackhole {
+				if fault.Latency > 0 {
+					time.Sleep(fault.Latency)
+				}
+				if fault.BytesPerSecond > 0 {
+					time.Sleep(time.Duration(n) * time.Second / time.Duration(fault.BytesPerSecond))
+				}
+				if _, err := dst.Write(buf[:n]); err != nil {
+					return
+				}
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
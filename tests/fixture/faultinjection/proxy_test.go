@@ -0,0 +1,235 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faultinjection
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+)
+
+var testNodeID = ids.BuildTestNodeID([]byte{1})
+
+// newEchoServer returns the address of a server that echoes back everything it
+// reads.
+func newEchoServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func newTestProxy(t *testing.T, resolver SourceResolver) *Proxy {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	proxy := NewProxy(listener, newEchoServer(t), resolver)
+	t.Cleanup(func() {
+		require.NoError(t, proxy.Close())
+	})
+	return proxy
+}
+
+func staticResolver(nodeID ids.NodeID) SourceResolver {
+	return SourceResolverFunc(func(net.Conn) (ids.NodeID, bool) {
+		return nodeID, true
+	})
+}
+
+// roundTrip sends [msg] through [conn] and returns how long it took to be
+// echoed back.
+func roundTrip(t *testing.T, conn net.Conn, msg []byte, timeout time.Duration) (time.Duration, error) {
+	start := time.Now()
+	require.NoError(t, conn.SetDeadline(start.Add(timeout)))
+
+	_, err := conn.Write(msg)
+	require.NoError(t, err)
+
+	reply := make([]byte, len(msg))
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return 0, err
+	}
+	require.Equal(t, msg, reply)
+	return time.Since(start), nil
+}
+
+func TestProxyForwards(t *testing.T) {
+	require := require.New(t)
+
+	proxy := newTestProxy(t, staticResolver(testNodeID))
+	conn, err := net.Dial("tcp", proxy.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+
+	_, err = roundTrip(t, conn, []byte("hello"), 5*time.Second)
+	require.NoError(err)
+}
+
+func TestProxyBlackhole(t *testing.T) {
+	require := require.New(t)
+
+	proxy := newTestProxy(t, staticResolver(testNodeID))
+	conn, err := net.Dial("tcp", proxy.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+
+	// Faults apply to connections that are already open.
+	proxy.SetFault(testNodeID, Fault{Blackhole: true})
+	_, err = roundTrip(t, conn, []byte("lost"), 200*time.Millisecond)
+	require.ErrorIs(err, os.ErrDeadlineExceeded)
+
+	// Traffic discarded by a blackhole isn't delivered once it is removed.
+	proxy.ClearFaults()
+	_, err = roundTrip(t, conn, []byte("found"), 5*time.Second)
+	require.NoError(err)
+}
+
+func TestProxyFaultsAreScopedToSource(t *testing.T) {
+	require := require.New(t)
+
+	proxy := newTestProxy(t, staticResolver(testNodeID))
+	proxy.SetFault(ids.BuildTestNodeID([]byte{2}), Fault{Blackhole: true})
+
+	conn, err := net.Dial("tcp", proxy.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+
+	_, err = roundTrip(t, conn, []byte("hello"), 5*time.Second)
+	require.NoError(err)
+}
+
+func TestProxyUnresolvedSource(t *testing.T) {
+	require := require.New(t)
+
+	proxy := newTestProxy(t, SourceResolverFunc(func(net.Conn) (ids.NodeID, bool) {
+		return ids.EmptyNodeID, false
+	}))
+	proxy.SetFault(ids.EmptyNodeID, Fault{Blackhole: true})
+
+	conn, err := net.Dial("tcp", proxy.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+
+	_, err = roundTrip(t, conn, []byte("hello"), 5*time.Second)
+	require.NoError(err)
+}
+
+func TestProxyLatency(t *testing.T) {
+	require := require.New(t)
+
+	const latency = 100 * time.Millisecond
+	proxy := newTestProxy(t, staticResolver(testNodeID))
+	proxy.SetFault(testNodeID, Fault{Latency: latency})
+
+	conn, err := net.Dial("tcp", proxy.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+
+	// The latency applies in both directions.
+	duration, err := roundTrip(t, conn, []byte("hello"), 5*time.Second)
+	require.NoError(err)
+	require.GreaterOrEqual(duration, 2*latency)
+}
+
+func TestProxyThrottle(t *testing.T) {
+	require := require.New(t)
+
+	const bytesPerSecond = 10_000
+	proxy := newTestProxy(t, staticResolver(testNodeID))
+	proxy.SetFault(testNodeID, Fault{BytesPerSecond: bytesPerSecond})
+
+	conn, err := net.Dial("tcp", proxy.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+
+	// A second of traffic in each direction. The directions overlap because
+	// the reply is echoed as it arrives.
+	duration, err := roundTrip(t, conn, make([]byte, bytesPerSecond), 10*time.Second)
+	require.NoError(err)
+	require.GreaterOrEqual(duration, time.Second)
+}
+
+func TestProxyClose(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	proxy := NewProxy(listener, newEchoServer(t), staticResolver(testNodeID))
+
+	conn, err := net.Dial("tcp", proxy.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+	_, err = roundTrip(t, conn, []byte("hello"), 5*time.Second)
+	require.NoError(err)
+
+	// Open connections are closed with the proxy.
+	require.NoError(proxy.Close())
+	require.NoError(conn.SetDeadline(time.Now().Add(5 * time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	require.ErrorIs(err, io.EOF)
+
+	_, err = net.Dial("tcp", proxy.Addr().String())
+	var opErr *net.OpError
+	require.ErrorAs(err, &opErr)
+}
+
+// failingListener fails every accept until it is closed.
+type failingListener struct {
+	net.Listener
+
+	accepts atomic.Int64
+	closed  atomic.Bool
+}
+
+func (l *failingListener) Accept() (net.Conn, error) {
+	l.accepts.Add(1)
+	if l.closed.Load() {
+		return nil, net.ErrClosed
+	}
+	return nil, errors.New("too many open files")
+}
+
+func (l *failingListener) Close() error {
+	l.closed.Store(true)
+	return l.Listener.Close()
+}
+
+func TestProxyBacksOffFailedAccepts(t *testing.T) {
+	require := require.New(t)
+
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	listener := &failingListener{Listener: inner}
+	proxy := NewProxy(listener, newEchoServer(t), staticResolver(testNodeID))
+
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(proxy.Close())
+
+	// Backing off from 5ms allows at most 6 accepts in 100ms, compared to
+	// millions without a backoff.
+	require.LessOrEqual(listener.accepts.Load(), int64(10))
+}
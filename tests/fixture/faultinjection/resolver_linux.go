@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build linux
+
+package faultinjection
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/luxfi/ids"
+)
+
+// tcpListen is the state of a listening socket in the kernel's socket tables.
+const tcpListen = "0A"
+
+var socketTables = []string{"/proc/net/tcp", "/proc/net/tcp6"}
+
+type socket struct {
+	local  netip.AddrPort
+	remote netip.AddrPort
+	state  string
+	inode  string
+}
+
+// NewProcessResolver returns a resolver that identifies the source of a
+// connection as the node whose process owns the other end of it. A node is
+// recognized by the address its process listens on for staking connections,
+// which [nodes] maps to the node's ID. Only connections between processes on
+// the same host can be resolved.
+func NewProcessResolver(nodes func() map[netip.AddrPort]ids.NodeID) SourceResolver {
+	return SourceResolverFunc(func(conn net.Conn) (ids.NodeID, bool) {
+		// The socket of the source is the one whose local address is the
+		// remote address of the accepted connection.
+		local, ok := toAddrPort(conn.RemoteAddr())
+		if !ok {
+			return ids.EmptyNodeID, false
+		}
+		remote, ok := toAddrPort(conn.LocalAddr())
+		if !ok {
+			return ids.EmptyNodeID, false
+		}
+
+		var (
+			listeners   = nodes()
+			sourceInode string
+			nodeInodes  = make(map[string]ids.NodeID, len(listeners))
+		)
+		for _, s := range readSockets() {
+			switch {
+			case s.local == local && s.remote == remote:
+				sourceInode = s.inode
+			case s.state == tcpListen:
+				if nodeID, ok := listeners[s.local]; ok {
+					nodeInodes[s.inode] = nodeID
+				}
+			}
+		}
+		if len(sourceInode) == 0 {
+			return ids.EmptyNodeID, false
+		}
+		return findOwner(sourceInode, nodeInodes)
+	})
+}
+
+func toAddrPort(addr net.Addr) (netip.AddrPort, bool) {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return netip.AddrPort{}, false
+	}
+	addrPort := tcpAddr.AddrPort()
+	return netip.AddrPortFrom(addrPort.Addr().Unmap(), addrPort.Port()), true
+}
+
+// readSockets returns the TCP sockets of the host.
+func readSockets() []socket {
+	var sockets []socket
+	for _, table := range socketTables {
+		file, err := os.Open(table)
+		if err != nil {
+			continue
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Scan() // Skip the header
+		for scanner.Scan() {
+			// The fields are:
+			// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode ...
+			fields := strings.Fields(scanner.Text())
+			if len(fields) < 10 {
+				continue
+			}
+			local, ok := parseSocketAddress(fields[1])
+			if !ok {
+				continue
+			}
+			remote, ok := parseSocketAddress(fields[2])
+			if !ok {
+				continue
+			}
+			sockets = append(sockets, socket{
+				local:  local,
+				remote: remote,
+				state:  fields[3],
+				inode:  fields[9],
+			})
+		}
+		_ = file.Close()
+	}
+	return sockets
+}
+
+// parseSocketAddress parses an address of the form IP:PORT as formatted by the
+// kernel, where the IP is written as native endian 32-bit words in hex and the
+// port is in hex.
+func parseSocketAddress(s string) (netip.AddrPort, bool) {
+	ipHex, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return netip.AddrPort{}, false
+	}
+	ipBytes, err := hex.DecodeString(ipHex)
+	if err != nil || (len(ipBytes) != net.IPv4len && len(ipBytes) != net.IPv6len) {
+		return netip.AddrPort{}, false
+	}
+	for i := 0; i < len(ipBytes); i += 4 {
+		word := binary.LittleEndian.Uint32(ipBytes[i:])
+		binary.BigEndian.PutUint32(ipBytes[i:], word)
+	}
+	ip, ok := netip.AddrFromSlice(ipBytes)
+	if !ok {
+		return netip.AddrPort{}, false
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return netip.AddrPort{}, false
+	}
+	return netip.AddrPortFrom(ip.Unmap(), uint16(port)), true
+}
+
+// findOwner finds the process that owns the socket [sourceInode] and returns
+// the node whose listening socket the same process owns.
+func findOwner(sourceInode string, nodeInodes map[string]ids.NodeID) (ids.NodeID, bool) {
+	processes, err := os.ReadDir("/proc")
+	if err != nil {
+		return ids.EmptyNodeID, false
+	}
+
+	sourceLink := "socket:[" + sourceInode + "]"
+	for _, process := range processes {
+		if _, err := strconv.Atoi(process.Name()); err != nil {
+			continue
+		}
+
+		// Processes of other users can't be inspected, which is fine since
+		// the nodes are run by the same user as the proxy.
+		fdDir := filepath.Join("/proc", process.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+
+		var (
+			ownsSource bool
+			nodeID     ids.NodeID
+			isNode     bool
+		)
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			if link == sourceLink {
+				ownsSource = true
+			}
+			if id, ok := nodeInodes[strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")]; ok {
+				nodeID = id
+				isNode = true
+			}
+		}
+		if ownsSource {
+			return nodeID, isNode
+		}
+	}
+	return ids.EmptyNodeID, false
+}
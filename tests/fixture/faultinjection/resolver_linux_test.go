@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build linux
+
+package faultinjection
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+)
+
+func TestParseSocketAddress(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected netip.AddrPort
+	}{
+		{
+			input:    "0100007F:1F90",
+			expected: netip.MustParseAddrPort("127.0.0.1:8080"),
+		},
+		{
+			input:    "0200007F:2616",
+			expected: netip.MustParseAddrPort("127.0.0.2:9750"),
+		},
+		{
+			input:    "00000000000000000000000001000000:0050",
+			expected: netip.MustParseAddrPort("[::1]:80"),
+		},
+		{
+			// IPv4 mapped addresses are unmapped.
+			input:    "0000000000000000FFFF00000100007F:0050",
+			expected: netip.MustParseAddrPort("127.0.0.1:80"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			addrPort, ok := parseSocketAddress(test.input)
+			require.True(t, ok)
+			require.Equal(t, test.expected, addrPort)
+		})
+	}
+}
+
+func TestProcessResolver(t *testing.T) {
+	require := require.New(t)
+
+	// This process stands in for a node by listening on a staking address.
+	stakingListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer stakingListener.Close()
+	stakingAddr, ok := toAddrPort(stakingListener.Addr())
+	require.True(ok)
+
+	nodeID := ids.BuildTestNodeID([]byte{1})
+	resolver := NewProcessResolver(func() map[netip.AddrPort]ids.NodeID {
+		return map[netip.AddrPort]ids.NodeID{
+			stakingAddr: nodeID,
+		}
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	source, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(err)
+	defer source.Close()
+	conn, err := listener.Accept()
+	require.NoError(err)
+	defer conn.Close()
+
+	resolvedID, ok := resolver.Resolve(conn)
+	require.True(ok)
+	require.Equal(nodeID, resolvedID)
+
+	// Processes that don't listen on a known staking address aren't nodes.
+	_, ok = NewProcessResolver(func() map[netip.AddrPort]ids.NodeID {
+		return nil
+	}).Resolve(conn)
+	require.False(ok)
+}
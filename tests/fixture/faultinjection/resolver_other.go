@@ -0,0 +1,23 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !linux
+
+package faultinjection
+
+import (
+	"net"
+	"net/netip"
+
+	"github.com/luxfi/ids"
+)
+
+// NewProcessResolver returns a resolver that identifies the source of a
+// connection by finding the process that owns the other end of it. Resolution
+// is only supported on linux, so elsewhere no connection can be resolved and
+// link faults have no effect.
+func NewProcessResolver(func() map[netip.AddrPort]ids.NodeID) SourceResolver {
+	return SourceResolverFunc(func(net.Conn) (ids.NodeID, bool) {
+		return ids.EmptyNodeID, false
+	})
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/message"
+	"github.com/luxfi/node/utils"
+	"github.com/luxfi/node/utils/set"
+)
+
+var errDuplicateNode = errors.New("duplicate node")
+
+// Handler handles the messages delivered to a simulated node.
+type Handler interface {
+	HandleInbound(context.Context, message.InboundMessage)
+}
+
+// LinkConfig describes how messages sent from one node to another are
+// delivered.
+type LinkConfig struct {
+	// Latency of each message is chosen uniformly from
+	// [MinLatency, MaxLatency].
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// DropRate is the probability that a message is lost.
+	DropRate float64
+	// ReorderRate is the probability that a message is delayed by up to an
+	// additional ReorderDelay, so that messages sent after it can overtake it.
+	ReorderRate  float64
+	ReorderDelay time.Duration
+}
+
+// Stats counts the messages sent over a network.
+type Stats struct {
+	Sent      int
+	Delivered int
+	Dropped   int
+}
+
+type link struct {
+	from ids.NodeID
+	to   ids.NodeID
+}
+
+// Network is a simulated transport between the handlers of simulated nodes.
+// Messages are delivered by the simulator after a latency, and may be
+// dropped, reordered, or blocked by a partition, all decided by the
+// simulator's random source.
+//
+// Network only models message delivery. It doesn't implement network.Network,
+// so it can't be used in place of the peer-to-peer network of a full node.
+type Network struct {
+	sim    *Simulator
+	parser message.InboundMsgBuilder
+
+	lock        sync.Mutex
+	defaultLink LinkConfig
+	links       map[link]LinkConfig
+	handlers    map[ids.NodeID]Handler
+	// groups maps each node to its side of the current partition. Nodes on
+	// different sides can't reach each other.
+	groups map[ids.NodeID]int
+	stats  Stats
+}
+
+// NewNetwork returns a network whose links are configured by [defaultLink].
+// Sent messages are parsed by [parser] before they are delivered.
+func NewNetwork(sim *Simulator, parser message.InboundMsgBuilder, defaultLink LinkConfig) *Network {
+	return &Network{
+		sim:         sim,
+		parser:      parser,
+		defaultLink: defaultLink,
+		links:       make(map[link]LinkConfig),
+		handlers:    make(map[ids.NodeID]Handler),
+		groups:      make(map[ids.NodeID]int),
+	}
+}
+
+// AddNode connects [nodeID] to the network. Messages sent to it are delivered
+// to [handler].
+func (n *Network) AddNode(nodeID ids.NodeID, handler Handler) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.handlers[nodeID]; ok {
+		return fmt.Errorf("%w: %s", errDuplicateNode, nodeID)
+	}
+	n.handlers[nodeID] = handler
+	return nil
+}
+
+// NodeIDs returns the nodes connected to the network in sorted order.
+func (n *Network) NodeIDs() []ids.NodeID {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	nodeIDs := make([]ids.NodeID, 0, len(n.handlers))
+	for nodeID := range n.handlers {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	utils.Sort(nodeIDs)
+	return nodeIDs
+}
+
+// SetLink overrides the config of the link from [from] to [to].
+func (n *Network) SetLink(from, to ids.NodeID, config LinkConfig) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.links[link{from: from, to: to}] = config
+}
+
+// Partition splits the network so that nodes can only reach nodes in the same
+// group. Nodes that aren't in any of [groups] form a group of their own.
+// Messages in flight across the partition are lost.
+func (n *Network) Partition(groups ...[]ids.NodeID) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	clear(n.groups)
+	for i, group := range groups {
+		for _, nodeID := range group {
+			n.groups[nodeID] = i + 1
+		}
+	}
+}
+
+// Heal removes the current partition.
+func (n *Network) Heal() {
+	n.Partition()
+}
+
+// Connected returns true if messages can be sent from [from] to [to].
+func (n *Network) Connected(from, to ids.NodeID) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.connected(from, to)
+}
+
+func (n *Network) connected(from, to ids.NodeID) bool {
+	_, ok := n.handlers[to]
+	return ok && n.groups[from] == n.groups[to]
+}
+
+// Sample returns up to [n] nodes, chosen uniformly at random, that [from] can
+// send messages to.
+func (n *Network) Sample(from ids.NodeID, size int) set.Set[ids.NodeID] {
+	nodeIDs := n.NodeIDs()
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	peers := make([]ids.NodeID, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if nodeID != from && n.connected(from, nodeID) {
+			peers = append(peers, nodeID)
+		}
+	}
+	n.sim.Rand.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+	return set.Of(peers[:min(size, len(peers))]...)
+}
+
+// Stats returns the number of messages sent, delivered and dropped so far.
+func (n *Network) Stats() Stats {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.stats
+}
+
+// Send sends [msg] from [from] to each of [nodeIDs] that it is connected to,
+// and returns the nodes it was sent to. As with a real network, a message
+// being sent doesn't mean it will be delivered.
+func (n *Network) Send(from ids.NodeID, nodeIDs set.Set[ids.NodeID], msg message.OutboundMessage) set.Set[ids.NodeID] {
+	// Sets are iterated in a random order, so they are sorted to keep the
+	// simulation deterministic.
+	sorted := nodeIDs.List()
+	utils.Sort(sorted)
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	sentTo := set.NewSet[ids.NodeID](len(sorted))
+	for _, to := range sorted {
+		if to == from || !n.connected(from, to) {
+			continue
+		}
+		sentTo.Add(to)
+		n.stats.Sent++
+
+		config, ok := n.links[link{from: from, to: to}]
+		if !ok {
+			config = n.defaultLink
+		}
+		if n.sim.Rand.Float64() < config.DropRate {
+			n.stats.Dropped++
+			continue
+		}
+
+		latency := config.MinLatency
+		if spread := config.MaxLatency - config.MinLatency; spread > 0 {
+			latency += time.Duration(n.sim.Rand.Int63n(int64(spread) + 1))
+		}
+		if config.ReorderDelay > 0 && n.sim.Rand.Float64() < config.ReorderRate {
+			latency += time.Duration(n.sim.Rand.Int63n(int64(config.ReorderDelay) + 1))
+		}
+
+		to := to
+		n.sim.Schedule(latency, func() {
+			n.deliver(from, to, msg)
+		})
+	}
+	return sentTo
+}
+
+func (n *Network) deliver(from, to ids.NodeID, msg message.OutboundMessage) {
+	n.lock.Lock()
+	handler := n.handlers[to]
+	if !n.connected(from, to) {
+		n.stats.Dropped++
+		n.lock.Unlock()
+		return
+	}
+	n.lock.Unlock()
+
+	inbound, err := n.parser.Parse(msg.Bytes(), from, nil)
+
+	n.lock.Lock()
+	if err != nil {
+		n.stats.Dropped++
+	} else {
+		n.stats.Delivered++
+	}
+	n.lock.Unlock()
+
+	if err == nil {
+		handler.HandleInbound(context.Background(), inbound)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+	luxmetric "github.com/luxfi/metric"
+	"github.com/luxfi/node/message"
+	"github.com/luxfi/node/proto/pb/p2p"
+	"github.com/luxfi/node/utils/constants"
+	"github.com/luxfi/node/utils/set"
+)
+
+var testLink = LinkConfig{
+	MinLatency: 10 * time.Millisecond,
+	MaxLatency: 100 * time.Millisecond,
+}
+
+func newMessageCreator(t *testing.T) message.Creator {
+	t.Helper()
+
+	mc, err := message.NewCreator(
+		log.NoLog{},
+		luxmetric.NewNoOpMetrics("test"),
+		constants.DefaultNetworkCompressionType,
+		10*time.Second,
+	)
+	require.NoError(t, err)
+	return mc
+}
+
+type delivery struct {
+	time  time.Time
+	from  ids.NodeID
+	to    ids.NodeID
+	value string
+}
+
+// gossipNode floods every value it learns to a sample of its peers, and
+// periodically re-gossips the values it knows.
+type gossipNode struct {
+	t       *testing.T
+	sim     *Simulator
+	network *Network
+	creator message.Creator
+	nodeID  ids.NodeID
+
+	values []string
+	known  set.Set[string]
+	trace  *[]delivery
+}
+
+func (n *gossipNode) HandleInbound(_ context.Context, msg message.InboundMessage) {
+	gossip, ok := msg.Message().(*p2p.AppGossip)
+	require.True(n.t, ok)
+
+	value := string(gossip.AppBytes)
+	*n.trace = append(*n.trace, delivery{
+		time:  n.sim.Now(),
+		from:  msg.NodeID(),
+		to:    n.nodeID,
+		value: value,
+	})
+	n.learn(value)
+}
+
+func (n *gossipNode) learn(value string) {
+	if n.known.Contains(value) {
+		return
+	}
+	n.known.Add(value)
+	n.values = append(n.values, value)
+	n.gossip(value)
+}
+
+func (n *gossipNode) gossip(value string) {
+	msg, err := n.creator.AppGossip(ids.Empty, []byte(value))
+	require.NoError(n.t, err)
+	n.network.Send(n.nodeID, n.network.Sample(n.nodeID, 2), msg)
+}
+
+type gossipNetwork struct {
+	sim     *Simulator
+	network *Network
+	nodes   []*gossipNode
+	trace   []delivery
+}
+
+func newGossipNetwork(t *testing.T, seed int64, numNodes int, link LinkConfig) *gossipNetwork {
+	creator := newMessageCreator(t)
+	sim := NewSimulator(seed, DefaultStartTime)
+	g := &gossipNetwork{
+		sim:     sim,
+		network: NewNetwork(sim, creator, link),
+	}
+	for i := 0; i < numNodes; i++ {
+		node := &gossipNode{
+			t:       t,
+			sim:     sim,
+			network: g.network,
+			creator: creator,
+			nodeID:  ids.BuildTestNodeID([]byte{byte(i + 1)}),
+			trace:   &g.trace,
+		}
+		require.NoError(t, g.network.AddNode(node.nodeID, node))
+		g.nodes = append(g.nodes, node)
+
+		sim.Every(time.Second, func() bool {
+			for _, value := range node.values {
+				node.gossip(value)
+			}
+			return true
+		})
+	}
+	return g
+}
+
+func (g *gossipNetwork) nodeIDs(nodes ...*gossipNode) []ids.NodeID {
+	nodeIDs := make([]ids.NodeID, len(nodes))
+	for i, node := range nodes {
+		nodeIDs[i] = node.nodeID
+	}
+	return nodeIDs
+}
+
+func (g *gossipNetwork) allKnow(values ...string) bool {
+	for _, node := range g.nodes {
+		for _, value := range values {
+			if !node.known.Contains(value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestSimulatorOrdering(t *testing.T) {
+	require := require.New(t)
+
+	sim := NewSimulator(0, DefaultStartTime)
+
+	var order []string
+	sim.Schedule(2*time.Second, func() { order = append(order, "c") })
+	sim.Schedule(time.Second, func() { order = append(order, "a") })
+	sim.Schedule(time.Second, func() { order = append(order, "b") })
+	sim.Schedule(time.Minute, func() { order = append(order, "d") })
+
+	sim.RunFor(30 * time.Second)
+	require.Equal([]string{"a", "b", "c"}, order)
+	require.Equal(DefaultStartTime.Add(30*time.Second), sim.Now())
+	require.Equal(DefaultStartTime.Add(30*time.Second), sim.Clock().Time())
+
+	require.True(sim.Step())
+	require.Equal([]string{"a", "b", "c", "d"}, order)
+	require.Equal(DefaultStartTime.Add(time.Minute), sim.Now())
+	require.False(sim.Step())
+}
+
+func TestPartitionAndHeal(t *testing.T) {
+	require := require.New(t)
+
+	g := newGossipNetwork(t, 0, 5, testLink)
+	minority := g.nodes[:2]
+	majority := g.nodes[2:]
+
+	// Partition 2 of the 5 nodes for 30s.
+	g.network.Partition(g.nodeIDs(minority...), g.nodeIDs(majority...))
+	minority[0].learn("minority")
+	majority[0].learn("majority")
+	g.sim.RunFor(30 * time.Second)
+
+	for _, node := range minority {
+		require.True(node.known.Contains("minority"))
+		require.False(node.known.Contains("majority"))
+	}
+	for _, node := range majority {
+		require.True(node.known.Contains("majority"))
+		require.False(node.known.Contains("minority"))
+	}
+
+	g.network.Heal()
+	require.True(g.sim.RunUntil(
+		func() bool {
+			return g.allKnow("minority", "majority")
+		},
+		10*time.Second,
+	))
+}
+
+func TestDeterminism(t *testing.T) {
+	run := func(seed int64) []delivery {
+		g := newGossipNetwork(t, seed, 5, LinkConfig{
+			MinLatency:   10 * time.Millisecond,
+			MaxLatency:   100 * time.Millisecond,
+			DropRate:     0.1,
+			ReorderRate:  0.1,
+			ReorderDelay: time.Second,
+		})
+		for i, node := range g.nodes {
+			node.learn(fmt.Sprintf("value %d", i))
+		}
+		g.sim.RunFor(10 * time.Second)
+		return g.trace
+	}
+
+	require := require.New(t)
+	require.Equal(run(1), run(1))
+	require.NotEqual(run(1), run(2))
+}
+
+func TestDrops(t *testing.T) {
+	require := require.New(t)
+
+	g := newGossipNetwork(t, 0, 2, testLink)
+	g.network.SetLink(g.nodes[0].nodeID, g.nodes[1].nodeID, LinkConfig{
+		DropRate: 1,
+	})
+
+	g.nodes[0].learn("dropped")
+	g.nodes[1].learn("delivered")
+	g.sim.RunFor(10 * time.Second)
+
+	require.False(g.nodes[1].known.Contains("dropped"))
+	require.True(g.nodes[0].known.Contains("delivered"))
+
+	stats := g.network.Stats()
+	// Messages still in flight are neither delivered nor dropped yet.
+	require.LessOrEqual(stats.Delivered+stats.Dropped, stats.Sent)
+	require.Positive(stats.Dropped)
+}
+
+func TestReordering(t *testing.T) {
+	require := require.New(t)
+
+	creator := newMessageCreator(t)
+	sim := NewSimulator(0, DefaultStartTime)
+	network := NewNetwork(sim, creator, LinkConfig{
+		MinLatency:   10 * time.Millisecond,
+		MaxLatency:   10 * time.Millisecond,
+		ReorderRate:  0.5,
+		ReorderDelay: time.Second,
+	})
+
+	var (
+		senderID   = ids.BuildTestNodeID([]byte{1})
+		receiverID = ids.BuildTestNodeID([]byte{2})
+		receiver   = &recorder{}
+	)
+	require.NoError(network.AddNode(senderID, &recorder{}))
+	require.NoError(network.AddNode(receiverID, receiver))
+
+	const numMessages = 100
+	sent := make([]string, numMessages)
+	for i := range sent {
+		sent[i] = fmt.Sprintf("%03d", i)
+		msg, err := creator.AppGossip(ids.Empty, []byte(sent[i]))
+		require.NoError(err)
+		require.Equal(set.Of(receiverID), network.Send(senderID, set.Of(receiverID), msg))
+	}
+	sim.RunFor(time.Minute)
+
+	require.ElementsMatch(sent, receiver.values)
+	require.NotEqual(sent, receiver.values)
+}
+
+type recorder struct {
+	values []string
+}
+
+func (r *recorder) HandleInbound(_ context.Context, msg message.InboundMessage) {
+	r.values = append(r.values, string(msg.Message().(*p2p.AppGossip).AppBytes))
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/luxfi/node/utils/heap"
+	"github.com/luxfi/node/utils/timer/mockable"
+)
+
+// DefaultStartTime is the virtual time a simulation starts at if no other time
+// is provided.
+var DefaultStartTime = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type event struct {
+	time time.Time
+	// seq breaks ties between events scheduled for the same time in the order
+	// they were scheduled.
+	seq uint64
+	f   func()
+}
+
+func lessEvent(a, b *event) bool {
+	if !a.time.Equal(b.time) {
+		return a.time.Before(b.time)
+	}
+	return a.seq < b.seq
+}
+
+// Simulator is a discrete event simulator driven by a virtual clock. Events are
+// executed one at a time in order of their virtual time, with ties broken by
+// the order they were scheduled. Time only advances when an event is executed,
+// so a simulation is fully determined by its seed as long as every event is
+// scheduled from the simulation itself rather than from other goroutines.
+type Simulator struct {
+	// Random source of the simulation. It must only be used from events.
+	Rand *rand.Rand
+
+	lock   sync.Mutex
+	clock  mockable.Clock
+	seq    uint64
+	events heap.Queue[*event]
+}
+
+// NewSimulator returns a simulator starting at [start] whose randomness is
+// derived from [seed].
+func NewSimulator(seed int64, start time.Time) *Simulator {
+	s := &Simulator{
+		Rand:   rand.New(rand.NewSource(seed)), //#nosec G404
+		events: heap.NewQueue(lessEvent),
+	}
+	s.clock.Set(start)
+	return s
+}
+
+// Clock returns the virtual clock of the simulation. It should be provided to
+// every component of a simulated node that reads the time.
+func (s *Simulator) Clock() *mockable.Clock {
+	return &s.clock
+}
+
+// Now returns the current virtual time.
+func (s *Simulator) Now() time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.clock.Time()
+}
+
+// Schedule executes [f] after [delay] of virtual time.
+func (s *Simulator) Schedule(delay time.Duration, f func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.seq++
+	s.events.Push(&event{
+		time: s.clock.Time().Add(max(delay, 0)),
+		seq:  s.seq,
+		f:    f,
+	})
+}
+
+// Every executes [f] every [interval] of virtual time until it returns false.
+func (s *Simulator) Every(interval time.Duration, f func() bool) {
+	s.Schedule(interval, func() {
+		if f() {
+			s.Every(interval, f)
+		}
+	})
+}
+
+// Step executes the next event, advancing the clock to its time. Returns false
+// if there are no events left.
+func (s *Simulator) Step() bool {
+	s.lock.Lock()
+	next, ok := s.events.Pop()
+	if ok {
+		s.clock.Set(next.time)
+	}
+	s.lock.Unlock()
+
+	if !ok {
+		return false
+	}
+	next.f()
+	return true
+}
+
+// RunFor executes every event scheduled within [duration] of virtual time and
+// then advances the clock to the end of [duration].
+func (s *Simulator) RunFor(duration time.Duration) {
+	end := s.Now().Add(duration)
+	s.runUntil(end, func() bool { return false })
+
+	s.lock.Lock()
+	s.clock.Set(end)
+	s.lock.Unlock()
+}
+
+// RunUntil executes events until [done] returns true, giving up once [timeout]
+// of virtual time has passed. Returns whether [done] returned true.
+func (s *Simulator) RunUntil(done func() bool, timeout time.Duration) bool {
+	return s.runUntil(s.Now().Add(timeout), done)
+}
+
+func (s *Simulator) runUntil(end time.Time, done func() bool) bool {
+	for !done() {
+		s.lock.Lock()
+		next, ok := s.events.Peek()
+		s.lock.Unlock()
+
+		if !ok || next.time.After(end) {
+			return false
+		}
+		s.Step()
+	}
+	return true
+}
@@ -15,10 +15,16 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/tests/fixture/tmpnet/scenario"
 	"github.com/luxfi/node/version"
 )
 
-const cliVersion = "0.0.1"
+const (
+	cliVersion = "0.0.1"
+
+	// Applying a scenario may involve restarting every node more than once
+	defaultApplyTimeout = 10 * time.Minute
+)
 
 var (
 	errLuxNodeRequired    = fmt.Errorf("--node-path or %s are required", tmpnet.LuxNodePathEnvName)
@@ -144,6 +150,60 @@ func main() {
 	}
 	rootCmd.AddCommand(restartNetworkCmd)
 
+	var applyTimeout time.Duration
+	applyCmd := &cobra.Command{
+		Use:   "apply <scenario file>",
+		Short: "Converge a temporary network to a YAML or JSON scenario",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(networkDir) == 0 {
+				return errNetworkDirRequired
+			}
+			s, err := scenario.Read(args[0])
+			if err != nil {
+				return err
+			}
+			network, err := tmpnet.ReadNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), applyTimeout)
+			defer cancel()
+			if err := scenario.Apply(ctx, os.Stdout, network, s); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Applied %s to network configured at: %s\n", args[0], networkDir)
+			return nil
+		},
+	}
+	applyCmd.PersistentFlags().DurationVar(&applyTimeout, "timeout", defaultApplyTimeout, "The maximum duration of applying the scenario")
+	rootCmd.AddCommand(applyCmd)
+
+	exportCmd := &cobra.Command{
+		Use:   "export <scenario file>",
+		Short: "Write a temporary network out as a YAML or JSON scenario",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(networkDir) == 0 {
+				return errNetworkDirRequired
+			}
+			network, err := tmpnet.ReadNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			s, err := scenario.Export(network)
+			if err != nil {
+				return err
+			}
+			if err := s.Write(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Exported network configured at %s to: %s\n", networkDir, args[0])
+			return nil
+		},
+	}
+	rootCmd.AddCommand(exportCmd)
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "tmpnetctl failed: %v\n", err)
 		os.Exit(1)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/luxfi/node/config"
+	"github.com/luxfi/node/utils/units"
 	"github.com/luxfi/node/vms/platformvm/txs/executor"
 )
 
@@ -22,6 +23,9 @@ const (
 
 	DefaultNetworkTimeout = 2 * time.Minute
 
+	// Weight of subnet validators whose weight isn't configured
+	DefaultValidatorWeight = units.Schmeckle
+
 	// These constants are defined in config.go to avoid circular dependencies
 	// DefaultNodeCount = 2
 	// DefaultMinStakeDuration = time.Second
@@ -566,11 +566,7 @@ func (n *Network) EnsureNodeConfig(node *Node) error {
 	node.NetworkOwner = n.Owner
 
 	// Set the network name if available
-	networkID := n.NetworkID
-	if networkID == 0 && n.Genesis != nil && n.Genesis.NetworkID > 0 {
-		networkID = n.Genesis.NetworkID
-	}
-	if networkID > 0 {
+	if networkID := n.GetNetworkID(); networkID > 0 {
 		// Convert the network id to a string to ensure consistency in JSON round-tripping.
 		flags[config.NetworkNameKey] = strconv.FormatUint(uint64(networkID), 10)
 	}
@@ -620,6 +616,15 @@ func (n *Network) EnsureNodeConfig(node *Node) error {
 	return nil
 }
 
+// GetNetworkID returns the ID of the network, which is taken from the genesis
+// if not set explicitly.
+func (n *Network) GetNetworkID() uint32 {
+	if n.NetworkID == 0 && n.Genesis != nil {
+		return n.Genesis.NetworkID
+	}
+	return n.NetworkID
+}
+
 // TrackedSubnetsForNode returns the subnet IDs for the given node
 func (n *Network) TrackedSubnetsForNode(nodeID ids.NodeID) string {
 	subnetIDs := make([]string, 0, len(n.Subnets))
@@ -842,7 +847,11 @@ func (n *Network) getBootstrapIPsAndIDs(skippedNode *Node) ([]string, []string,
 			continue
 		}
 
-		bootstrapIPs = append(bootstrapIPs, node.StakingAddress)
+		bootstrapIP, err := node.getBootstrapIP()
+		if err != nil {
+			return nil, nil, err
+		}
+		bootstrapIPs = append(bootstrapIPs, bootstrapIP)
 		bootstrapIDs = append(bootstrapIDs, node.NodeID.String())
 	}
 
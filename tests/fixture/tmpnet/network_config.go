@@ -230,6 +230,11 @@ func (n *Network) getSubnetDir() string {
 	return filepath.Join(n.Dir, defaultSubnetDirName)
 }
 
+// WriteSubnet writes the configuration of the subnet to the network dir.
+func (n *Network) WriteSubnet(subnet *Subnet) error {
+	return subnet.Write(n.getSubnetDir(), n.getChainConfigDir())
+}
+
 func (n *Network) readSubnets() error {
 	subnets, err := readSubnets(n.getSubnetDir())
 	if err != nil {
@@ -11,8 +11,10 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/netip"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -85,6 +87,11 @@ type Node struct {
 	// The configuration used to initialize the node runtime.
 	RuntimeConfig *NodeRuntimeConfig
 
+	// Environment variables that will be supplied to the node at startup in
+	// addition to those of the current process. Only applied by the process
+	// runtime.
+	Env map[string]string
+
 	// Runtime state, intended to be set by NodeRuntime
 	URI            string
 	StakingAddress string
@@ -194,6 +201,27 @@ func (n *Node) WaitForStopped(ctx context.Context) error {
 	return n.getRuntime().WaitForStopped(ctx)
 }
 
+// Sends the provided signal to the node, e.g. SIGSTOP to pause the node or
+// SIGKILL to stop it without a graceful shutdown. Only supported for nodes
+// running as local processes.
+func (n *Node) Signal(sig os.Signal) error {
+	process, ok := n.getRuntime().(*NodeProcess)
+	if !ok {
+		return errProcessRuntimeRequired
+	}
+	return process.Signal(sig)
+}
+
+// Retrieves the PID of the node, or 0 if it is not running. Only
+// supported for nodes running as local processes.
+func (n *Node) GetPID() (int, error) {
+	process, ok := n.getRuntime().(*NodeProcess)
+	if !ok {
+		return 0, errProcessRuntimeRequired
+	}
+	return process.GetPID()
+}
+
 func (n *Node) readState() error {
 	return n.getRuntime().readState()
 }
@@ -233,6 +261,21 @@ func (n *Node) Stop(ctx context.Context) error {
 	return n.WaitForStopped(ctx)
 }
 
+// Retrieves the address other nodes should use to connect to the node. This
+// is the staking address unless the node was configured with a public IP,
+// e.g. because its inbound connections are proxied.
+func (n *Node) getBootstrapIP() (string, error) {
+	publicIP := cast.ToString(n.Flags[config.PublicIPKey])
+	if len(publicIP) == 0 {
+		return n.StakingAddress, nil
+	}
+	stakingAddress, err := netip.ParseAddrPort(n.StakingAddress)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse staking address of node %s: %w", n.NodeID, err)
+	}
+	return net.JoinHostPort(publicIP, strconv.Itoa(int(stakingAddress.Port()))), nil
+}
+
 // Sets networking configuration for the node.
 // Convenience method for setting networking flags.
 func (n *Node) SetNetworkingConfig(bootstrapIDs []string, bootstrapIPs []string) {
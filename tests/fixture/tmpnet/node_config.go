@@ -65,6 +65,7 @@ type serializedNodeConfig struct {
 	NetworkOwner  string
 	IsEphemeral   bool
 	RuntimeConfig *NodeRuntimeConfig
+	Env           map[string]string `json:",omitempty"`
 }
 
 func (n *Node) writeConfig() error {
@@ -73,6 +74,7 @@ func (n *Node) writeConfig() error {
 		NetworkOwner:  n.NetworkOwner,
 		IsEphemeral:   n.IsEphemeral,
 		RuntimeConfig: n.RuntimeConfig,
+		Env:           n.Env,
 	}
 	bytes, err := DefaultJSONMarshal(config)
 	if err != nil {
@@ -31,7 +31,10 @@ const (
 	defaultNodeInitTimeout = 10 * time.Second
 )
 
-var errNodeAlreadyRunning = errors.New("failed to start node: node is already running")
+var (
+	errNodeAlreadyRunning     = errors.New("failed to start node: node is already running")
+	errProcessRuntimeRequired = errors.New("only supported for nodes running as local processes")
+)
 
 func checkNodeHealth(ctx context.Context, uri string) (bool, error) {
 	// Check that the node is reporting healthy
@@ -114,6 +117,12 @@ func (p *NodeProcess) Start(w io.Writer) error {
 
 	// All arguments are provided in the flags file
 	cmd := exec.Command(p.node.RuntimeConfig.LuxNodePath, "--config-file", p.node.getFlagsPath()) // #nosec G204
+	if len(p.node.Env) > 0 {
+		cmd.Env = os.Environ()
+		for name, value := range p.node.Env {
+			cmd.Env = append(cmd.Env, name+"="+value)
+		}
+	}
 	// Ensure process is detached from the parent process so that an error in the parent will not affect the child
 	configureDetachedProcess(cmd)
 
@@ -185,6 +194,33 @@ func (p *NodeProcess) WaitForStopped(ctx context.Context) error {
 	}
 }
 
+// Sends the provided signal to the node process.
+func (p *NodeProcess) Signal(sig os.Signal) error {
+	proc, err := p.getProcess()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve process to signal: %w", err)
+	}
+	if proc == nil {
+		return ErrNotRunning
+	}
+	if err := proc.Signal(sig); err != nil {
+		return fmt.Errorf("failed to send %s to pid %d: %w", sig, p.pid, err)
+	}
+	return nil
+}
+
+// Retrieves the PID of the node process, or 0 if it is not running.
+func (p *NodeProcess) GetPID() (int, error) {
+	proc, err := p.getProcess()
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve process: %w", err)
+	}
+	if proc == nil {
+		return 0, nil
+	}
+	return p.pid, nil
+}
+
 func (p *NodeProcess) IsHealthy(ctx context.Context) (bool, error) {
 	// Check that the node process is running as a precondition for
 	// checking health. getProcess will also ensure that the node's
@@ -0,0 +1,424 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/math/set"
+	"github.com/luxfi/node/config"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/wallet/subnet/primary/common"
+)
+
+var (
+	errNodeRemoval       = errors.New("removing nodes from a network is not supported")
+	errChainsChanged     = errors.New("the chains of an existing subnet can't be changed")
+	errL1Reverted        = errors.New("an L1 can't be converted back to a subnet")
+	errL1Changed         = errors.New("the validator manager of an L1 can't be changed")
+	errL1ValidatorsAdded = errors.New("adding or removing L1 validators is not supported")
+)
+
+// Apply converges the running [network] to [scenario]:
+//
+//   - Nodes whose flags or binary differ from the scenario are reconfigured and
+//     restarted, and nodes missing from the network are started.
+//   - Subnets missing from the network are created along with their chains.
+//   - The validators of permissioned subnets are added, removed or reweighted.
+//   - Subnets are converted to L1s, and the weights of L1 validators are
+//     updated through their validator manager.
+//
+// Changes that can't be made to a running network, like removing nodes or
+// changing the chains of a subnet, result in an error.
+func Apply(ctx context.Context, w io.Writer, network *tmpnet.Network, scenario *Scenario) error {
+	if err := scenario.Validate(); err != nil {
+		return err
+	}
+
+	nodes, err := applyNodes(ctx, w, network, scenario.Nodes)
+	if err != nil {
+		return err
+	}
+
+	// Create the missing subnets together to restart their validators once
+	var (
+		existingSubnets = make(map[string]bool, len(network.Subnets))
+		created         bool
+	)
+	for _, subnet := range network.Subnets {
+		existingSubnets[subnet.Name] = true
+	}
+	for _, subnet := range scenario.Subnets {
+		if existingSubnets[subnet.Name] {
+			continue
+		}
+		validatorIDs, weights := getValidators(subnet, nodes)
+		tmpnetSubnet := &tmpnet.Subnet{
+			Name:             subnet.Name,
+			Config:           subnet.Config,
+			ValidatorIDs:     validatorIDs,
+			ValidatorWeights: weights,
+		}
+		for _, chain := range subnet.Chains {
+			tmpnetSubnet.Chains = append(tmpnetSubnet.Chains, &tmpnet.Chain{
+				VMID:    chain.VMID,
+				Genesis: chain.Genesis,
+				Config:  chain.Config,
+			})
+		}
+		network.Subnets = append(network.Subnets, tmpnetSubnet)
+		created = true
+	}
+	if created {
+		if err := network.CreateSubnets(ctx, w, network.Nodes[0].URI, true /* restartRequired */); err != nil {
+			return err
+		}
+	}
+
+	for _, subnet := range scenario.Subnets {
+		tmpnetSubnet := network.GetSubnet(subnet.Name)
+		if existingSubnets[subnet.Name] {
+			if err := applySubnet(ctx, w, network, tmpnetSubnet, subnet, nodes); err != nil {
+				return err
+			}
+		}
+		if err := applyL1(ctx, w, network, tmpnetSubnet, subnet, nodes); err != nil {
+			return err
+		}
+		if err := network.WriteSubnet(tmpnetSubnet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyNodes reconfigures and starts the nodes of [network] to match [nodes].
+// The nodes of the network are returned by scenario name.
+func applyNodes(ctx context.Context, w io.Writer, network *tmpnet.Network, nodes []*Node) (map[string]*tmpnet.Node, error) {
+	var (
+		matched       = make(map[string]*tmpnet.Node, len(nodes))
+		matchedIDs    = set.NewSet[ids.NodeID](len(nodes))
+		withoutKeys   []*Node
+		newNodes      []*Node
+		unmatchedKeys []*tmpnet.Node
+	)
+	for _, node := range nodes {
+		if !hasIdentity(node) {
+			withoutKeys = append(withoutKeys, node)
+			continue
+		}
+		nodeID, err := getNodeID(node)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive node ID of node %s: %w", node.Name, err)
+		}
+		existing := getNode(network, nodeID)
+		if existing == nil {
+			newNodes = append(newNodes, node)
+			continue
+		}
+		matched[node.Name] = existing
+		matchedIDs.Add(nodeID)
+	}
+
+	// Nodes without keys take the place of the remaining nodes in order
+	for _, node := range network.Nodes {
+		if node.IsEphemeral || matchedIDs.Contains(node.NodeID) {
+			continue
+		}
+		unmatchedKeys = append(unmatchedKeys, node)
+	}
+	if len(unmatchedKeys) > len(withoutKeys) {
+		return nil, fmt.Errorf("%w: %d node(s) of the network are missing from the scenario", errNodeRemoval, len(unmatchedKeys)-len(withoutKeys))
+	}
+	for i, node := range withoutKeys {
+		if i < len(unmatchedKeys) {
+			matched[node.Name] = unmatchedKeys[i]
+		} else {
+			newNodes = append(newNodes, node)
+		}
+	}
+
+	for _, node := range nodes {
+		existing, ok := matched[node.Name]
+		if !ok || isConfigured(network, existing, node) {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "Reconfiguring node %s as %q\n", existing.NodeID, node.Name); err != nil {
+			return nil, err
+		}
+		configureNode(network, existing, node)
+		if err := network.RestartNode(ctx, w, existing); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, node := range newNodes {
+		if _, err := fmt.Fprintf(w, "Starting node %q\n", node.Name); err != nil {
+			return nil, err
+		}
+		newNode := tmpnet.NewNode("")
+		configureNode(network, newNode, node)
+		if err := network.StartNode(ctx, w, newNode); err != nil {
+			return nil, err
+		}
+		network.Nodes = append(network.Nodes, newNode)
+		if _, err := fmt.Fprintf(w, " waiting for node %s to report healthy\n", newNode.NodeID); err != nil {
+			return nil, err
+		}
+		if err := tmpnet.WaitForHealthy(ctx, newNode); err != nil {
+			return nil, err
+		}
+		matched[node.Name] = newNode
+	}
+	return matched, nil
+}
+
+// applySubnet converges the configuration and validators of an existing
+// subnet to [subnet].
+func applySubnet(ctx context.Context, w io.Writer, network *tmpnet.Network, tmpnetSubnet *tmpnet.Subnet, subnet *Subnet, nodes map[string]*tmpnet.Node) error {
+	if len(tmpnetSubnet.Chains) != len(subnet.Chains) {
+		return fmt.Errorf("%w: %s", errChainsChanged, subnet.Name)
+	}
+	for i, chain := range subnet.Chains {
+		if chain.VMID != tmpnetSubnet.Chains[i].VMID {
+			return fmt.Errorf("%w: %s", errChainsChanged, subnet.Name)
+		}
+	}
+
+	var (
+		uri       = network.Nodes[0].URI
+		toAdd     []*tmpnet.Node
+		toRestart []*tmpnet.Node
+	)
+	// The validators of an L1 are updated by applyL1
+	if tmpnetSubnet.L1 == nil {
+		validatorIDs, weights := getValidators(subnet, nodes)
+		current := set.Of(tmpnetSubnet.ValidatorIDs...)
+		wallet, err := tmpnetSubnet.GetWallet(ctx, uri)
+		if err != nil {
+			return err
+		}
+		for _, nodeID := range tmpnetSubnet.ValidatorIDs {
+			weight, ok := weights[nodeID]
+			if ok && weight == tmpnetSubnet.GetValidatorWeight(nodeID) {
+				continue
+			}
+			// The weight of a permissioned validator can only be changed by
+			// removing and adding it again.
+			if _, err := wallet.P().IssueRemoveSubnetValidatorTx(nodeID, tmpnetSubnet.SubnetID, common.WithContext(ctx)); err != nil {
+				return fmt.Errorf("failed to remove validator %s of subnet %s: %w", nodeID, subnet.Name, err)
+			}
+			if _, err := fmt.Fprintf(w, " removed %s as validator for subnet `%s`\n", nodeID, subnet.Name); err != nil {
+				return err
+			}
+			if ok {
+				toAdd = append(toAdd, getNode(network, nodeID))
+			}
+		}
+		for _, nodeID := range validatorIDs {
+			if !current.Contains(nodeID) {
+				toAdd = append(toAdd, getNode(network, nodeID))
+			}
+		}
+		tmpnetSubnet.ValidatorIDs = validatorIDs
+		tmpnetSubnet.ValidatorWeights = weights
+	}
+
+	if !flagsEqual(tmpnetSubnet.Config, subnet.Config) {
+		tmpnetSubnet.Config = subnet.Config
+		for _, nodeID := range tmpnetSubnet.ValidatorIDs {
+			toRestart = append(toRestart, getNode(network, nodeID))
+		}
+	}
+	if err := network.WriteSubnet(tmpnetSubnet); err != nil {
+		return err
+	}
+
+	// Validators need to track the subnet and read its configuration
+	for _, node := range network.Nodes {
+		existingTrackedSubnets, err := node.Flags.GetStringVal(config.TrackSubnetsKey)
+		if err != nil {
+			return err
+		}
+		trackedSubnets := network.TrackedSubnetsForNode(node.NodeID)
+		if existingTrackedSubnets == trackedSubnets {
+			continue
+		}
+		node.Flags[config.TrackSubnetsKey] = trackedSubnets
+		toRestart = append(toRestart, node)
+	}
+	restarted := set.NewSet[ids.NodeID](len(toRestart))
+	for _, node := range toRestart {
+		if restarted.Contains(node.NodeID) {
+			continue
+		}
+		restarted.Add(node.NodeID)
+		if err := network.RestartNode(ctx, w, node); err != nil {
+			return err
+		}
+	}
+
+	if len(toAdd) == 0 {
+		return nil
+	}
+	return tmpnetSubnet.AddValidators(ctx, w, uri, toAdd...)
+}
+
+// applyL1 converts [tmpnetSubnet] to an L1 if required by [subnet] and updates
+// the weights of its validators.
+func applyL1(ctx context.Context, w io.Writer, network *tmpnet.Network, tmpnetSubnet *tmpnet.Subnet, subnet *Subnet, nodes map[string]*tmpnet.Node) error {
+	switch {
+	case subnet.L1 == nil && tmpnetSubnet.L1 == nil:
+		return nil
+	case subnet.L1 == nil:
+		return fmt.Errorf("%w: %s", errL1Reverted, subnet.Name)
+	case tmpnetSubnet.L1 == nil:
+		validators := make([]*tmpnet.Node, len(subnet.Validators))
+		for i, validator := range subnet.Validators {
+			validators[i] = nodes[validator.Node]
+		}
+		return convertToL1(ctx, w, network, tmpnetSubnet, subnet.L1, validators)
+	}
+
+	address, err := subnet.L1.managerAddress()
+	if err != nil {
+		return err
+	}
+	if tmpnetSubnet.Chains[subnet.L1.ManagerChain].ChainID != tmpnetSubnet.L1.ManagerChainID ||
+		string(address) != string(tmpnetSubnet.L1.ManagerAddress) {
+		return fmt.Errorf("%w: %s", errL1Changed, subnet.Name)
+	}
+
+	_, weights := getValidators(subnet, nodes)
+	if len(weights) != len(tmpnetSubnet.L1.Validators) {
+		return fmt.Errorf("%w: %s", errL1ValidatorsAdded, subnet.Name)
+	}
+	for _, validator := range tmpnetSubnet.L1.Validators {
+		weight, ok := weights[validator.NodeID]
+		if !ok {
+			return fmt.Errorf("%w: %s", errL1ValidatorsAdded, subnet.Name)
+		}
+		if weight == validator.Weight {
+			continue
+		}
+		if err := setL1ValidatorWeight(ctx, w, network, tmpnetSubnet, validator, weight); err != nil {
+			return err
+		}
+		// Persist the nonce as soon as it is used
+		if err := network.WriteSubnet(tmpnetSubnet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getValidators returns the IDs and weights of the validators of [subnet].
+func getValidators(subnet *Subnet, nodes map[string]*tmpnet.Node) ([]ids.NodeID, map[ids.NodeID]uint64) {
+	var (
+		validatorIDs = make([]ids.NodeID, len(subnet.Validators))
+		weights      = make(map[ids.NodeID]uint64, len(subnet.Validators))
+	)
+	for i, validator := range subnet.Validators {
+		nodeID := nodes[validator.Node].NodeID
+		validatorIDs[i] = nodeID
+		weights[nodeID] = validator.Weight
+		if validator.Weight == 0 {
+			weights[nodeID] = tmpnet.DefaultValidatorWeight
+		}
+	}
+	return validatorIDs, weights
+}
+
+// isConfigured indicates whether [tmpnetNode] already runs with the
+// configuration of [node].
+func isConfigured(network *tmpnet.Network, tmpnetNode *tmpnet.Node, node *Node) bool {
+	luxNodePath := node.LuxNodePath
+	if len(luxNodePath) == 0 {
+		luxNodePath = network.DefaultRuntimeConfig.LuxNodePath
+	}
+	if tmpnetNode.RuntimeConfig != nil && tmpnetNode.RuntimeConfig.LuxNodePath != luxNodePath {
+		return false
+	}
+
+	current := userFlags(network, tmpnetNode)
+	desired := getFlags(network, node)
+	if !hasIdentity(node) {
+		// The node keeps its keys
+		for _, key := range identityFlags {
+			delete(current, key)
+		}
+	}
+	return flagsEqual(current, desired)
+}
+
+// configureNode replaces the flags and binary of [tmpnetNode] with those of
+// [node]. Flags managed by tmpnet are retained, as are the keys of the node if
+// [node] doesn't provide them.
+func configureNode(network *tmpnet.Network, tmpnetNode *tmpnet.Node, node *Node) {
+	flags := getFlags(network, node)
+	for key, value := range tmpnetNode.Flags {
+		if isManagedFlag(key, value) || (isIdentityFlag(key) && !hasIdentity(node)) {
+			flags[key] = value
+		}
+	}
+	tmpnetNode.Flags = flags
+
+	if tmpnetNode.RuntimeConfig == nil {
+		tmpnetNode.RuntimeConfig = &tmpnet.NodeRuntimeConfig{}
+	}
+	tmpnetNode.RuntimeConfig.LuxNodePath = node.LuxNodePath
+	if len(node.LuxNodePath) == 0 {
+		tmpnetNode.RuntimeConfig.LuxNodePath = network.DefaultRuntimeConfig.LuxNodePath
+	}
+}
+
+// getFlags returns the flags configured for [node], omitting those equal to
+// the defaults of [network].
+func getFlags(network *tmpnet.Network, node *Node) tmpnet.FlagsMap {
+	configured := maps.Clone(node.Flags)
+	if len(node.PluginDir) > 0 {
+		if configured == nil {
+			configured = tmpnet.FlagsMap{}
+		}
+		configured[config.PluginDirKey] = node.PluginDir
+	}
+
+	flags := tmpnet.FlagsMap{}
+	for key, value := range configured {
+		if defaultValue, ok := network.DefaultFlags[key]; ok && flagValuesEqual(value, defaultValue) {
+			continue
+		}
+		flags[key] = value
+	}
+	return flags
+}
+
+func hasIdentity(node *Node) bool {
+	_, ok := node.Flags[config.StakingCertContentKey]
+	return ok
+}
+
+func getNodeID(node *Node) (ids.NodeID, error) {
+	tmpnetNode := &tmpnet.Node{
+		Flags: maps.Clone(node.Flags),
+	}
+	if err := tmpnetNode.EnsureNodeID(); err != nil {
+		return ids.EmptyNodeID, err
+	}
+	return tmpnetNode.NodeID, nil
+}
+
+func getNode(network *tmpnet.Network, nodeID ids.NodeID) *tmpnet.Node {
+	for _, node := range network.Nodes {
+		if node.NodeID == nodeID {
+			return node
+		}
+	}
+	return nil
+}
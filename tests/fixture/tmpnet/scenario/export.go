@@ -0,0 +1,178 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scenario
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/config"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+)
+
+// Flags that tmpnet derives from the network and the runtime state of a node.
+// They are never part of a scenario.
+var managedFlags = []string{
+	config.DataDirKey,
+	config.NetworkNameKey,
+	config.ChainConfigDirKey,
+	config.GenesisFileKey,
+	config.SubnetConfigDirKey,
+	config.TrackSubnetsKey,
+	config.BootstrapIDsKey,
+	config.BootstrapIPsKey,
+	config.HTTPPortKey,
+}
+
+// Flags that determine the identity of a node
+var identityFlags = []string{
+	config.StakingTLSKeyContentKey,
+	config.StakingCertContentKey,
+	config.StakingSignerKeyContentKey,
+}
+
+// Export describes the current state of [network] as a scenario. The
+// scenario includes the keys of the nodes so that applying it to the same
+// network is a no-op and applying it to a new network reproduces the same
+// topology.
+func Export(network *tmpnet.Network) (*Scenario, error) {
+	scenario := &Scenario{}
+
+	nodeNames := make(map[ids.NodeID]string, len(network.Nodes))
+	for i, node := range network.Nodes {
+		if node.IsEphemeral {
+			continue
+		}
+		name := fmt.Sprintf("node-%d", i+1)
+		nodeNames[node.NodeID] = name
+		scenario.Nodes = append(scenario.Nodes, exportNode(network, node, name))
+	}
+
+	for _, subnet := range network.Subnets {
+		exported, err := exportSubnet(subnet, nodeNames)
+		if err != nil {
+			return nil, err
+		}
+		scenario.Subnets = append(scenario.Subnets, exported)
+	}
+
+	return scenario, scenario.Validate()
+}
+
+func exportNode(network *tmpnet.Network, node *tmpnet.Node, name string) *Node {
+	exported := &Node{
+		Name:  name,
+		Flags: userFlags(network, node),
+	}
+	if pluginDir, ok := exported.Flags[config.PluginDirKey]; ok {
+		exported.PluginDir = fmt.Sprint(pluginDir)
+		delete(exported.Flags, config.PluginDirKey)
+	}
+	if node.RuntimeConfig != nil && node.RuntimeConfig.LuxNodePath != network.DefaultRuntimeConfig.LuxNodePath {
+		exported.LuxNodePath = node.RuntimeConfig.LuxNodePath
+	}
+	return exported
+}
+
+func exportSubnet(subnet *tmpnet.Subnet, nodeNames map[ids.NodeID]string) (*Subnet, error) {
+	exported := &Subnet{
+		Name:   subnet.Name,
+		Config: subnet.Config,
+	}
+	for _, chain := range subnet.Chains {
+		exported.Chains = append(exported.Chains, &Chain{
+			VMID:    chain.VMID,
+			Genesis: chain.Genesis,
+			Config:  chain.Config,
+		})
+	}
+
+	for _, nodeID := range subnet.ValidatorIDs {
+		name, ok := nodeNames[nodeID]
+		if !ok {
+			return nil, fmt.Errorf("%w %s validating subnet %s", errUnknownNode, nodeID, subnet.Name)
+		}
+		weight := subnet.GetValidatorWeight(nodeID)
+		if subnet.L1 != nil {
+			// Only the L1 state reflects weight changes since conversion
+			if validator := subnet.L1.GetValidator(nodeID); validator != nil {
+				weight = validator.Weight
+			}
+		}
+		exported.Validators = append(exported.Validators, &Validator{
+			Node:   name,
+			Weight: weight,
+		})
+	}
+
+	if subnet.L1 == nil {
+		return exported, nil
+	}
+	managerChain := -1
+	for i, chain := range subnet.Chains {
+		if chain.ChainID == subnet.L1.ManagerChainID {
+			managerChain = i
+		}
+	}
+	exported.L1 = &L1{
+		ManagerChain:   managerChain,
+		ManagerAddress: hex.EncodeToString(subnet.L1.ManagerAddress),
+		Balance:        subnet.L1.Balance,
+	}
+	return exported, nil
+}
+
+// userFlags returns the flags of [node] that were configured for it rather
+// than derived by tmpnet or inherited from the defaults of [network].
+func userFlags(network *tmpnet.Network, node *tmpnet.Node) tmpnet.FlagsMap {
+	flags := tmpnet.FlagsMap{}
+	for key, value := range node.Flags {
+		if isManagedFlag(key, value) {
+			continue
+		}
+		if defaultValue, ok := network.DefaultFlags[key]; ok && flagValuesEqual(value, defaultValue) {
+			continue
+		}
+		flags[key] = value
+	}
+	return flags
+}
+
+func isManagedFlag(key string, value interface{}) bool {
+	for _, managedKey := range managedFlags {
+		if key == managedKey {
+			return true
+		}
+	}
+	// A staking port of zero is the default for dynamic allocation
+	return key == config.StakingPortKey && flagValuesEqual(value, 0)
+}
+
+func isIdentityFlag(key string) bool {
+	for _, identityKey := range identityFlags {
+		if key == identityKey {
+			return true
+		}
+	}
+	return false
+}
+
+// flagValuesEqual compares flag values by their string representation since
+// the types of values depend on whether they were read from disk.
+func flagValuesEqual(a, b interface{}) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func flagsEqual(a, b tmpnet.FlagsMap) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if otherValue, ok := b[key]; !ok || !flagValuesEqual(value, otherValue) {
+			return false
+		}
+	}
+	return true
+}
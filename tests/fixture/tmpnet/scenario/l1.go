@@ -0,0 +1,316 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/luxfi/consensus/networking/router"
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+	"github.com/luxfi/math/set"
+	"github.com/luxfi/node/network/p2p/lp118"
+	"github.com/luxfi/node/network/peer"
+	"github.com/luxfi/node/proto/pb/sdk"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/utils/constants"
+	"github.com/luxfi/node/utils/units"
+	"github.com/luxfi/node/vms/platformvm/txs"
+	"github.com/luxfi/node/vms/platformvm/warp"
+	"github.com/luxfi/node/vms/platformvm/warp/payload"
+	"github.com/luxfi/node/wallet/subnet/primary/common"
+
+	metrics "github.com/luxfi/metric"
+	p2pmessage "github.com/luxfi/node/message"
+	p2psdk "github.com/luxfi/node/network/p2p"
+	p2ppb "github.com/luxfi/node/proto/pb/p2p"
+	warpmessage "github.com/luxfi/node/vms/platformvm/warp/message"
+)
+
+const (
+	// Balance of L1 validators whose balance isn't configured
+	DefaultL1Balance = units.Lux
+
+	// Signature requests are answered immediately by healthy nodes
+	signatureRequestTimeout = 10 * time.Second
+)
+
+var errSignatureRequestFailed = errors.New("signature request failed")
+
+// convertToL1 converts [subnet] to an L1 validated by [validators] with the
+// weights configured for the subnet.
+func convertToL1(ctx context.Context, w io.Writer, network *tmpnet.Network, subnet *tmpnet.Subnet, l1 *L1, validators []*tmpnet.Node) error {
+	address, err := l1.managerAddress()
+	if err != nil {
+		return err
+	}
+	balance := l1.Balance
+	if balance == 0 {
+		balance = DefaultL1Balance
+	}
+	state := &tmpnet.L1{
+		ManagerChainID: subnet.Chains[l1.ManagerChain].ChainID,
+		ManagerAddress: address,
+		Balance:        balance,
+	}
+
+	// The validators of the conversion must be sorted by node ID, and the
+	// validation ID of each validator is derived from its index.
+	validators = slices.Clone(validators)
+	slices.SortFunc(validators, func(a, b *tmpnet.Node) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+	conversionValidators := make([]*txs.ConvertSubnetToL1Validator, len(validators))
+	for i, node := range validators {
+		pop, err := node.GetProofOfPossession()
+		if err != nil {
+			return fmt.Errorf("failed to get proof of possession of node %s: %w", node.NodeID, err)
+		}
+		weight := subnet.GetValidatorWeight(node.NodeID)
+		conversionValidators[i] = &txs.ConvertSubnetToL1Validator{
+			NodeID:  node.NodeID.Bytes(),
+			Weight:  weight,
+			Balance: balance,
+			Signer:  *pop,
+		}
+		state.Validators = append(state.Validators, &tmpnet.L1Validator{
+			NodeID:       node.NodeID,
+			ValidationID: subnet.SubnetID.Append(uint32(i)),
+			Weight:       weight,
+		})
+	}
+
+	wallet, err := subnet.GetWallet(ctx, network.Nodes[0].URI)
+	if err != nil {
+		return err
+	}
+	_, err = wallet.P().IssueConvertSubnetToL1Tx(
+		subnet.SubnetID,
+		state.ManagerChainID,
+		state.ManagerAddress,
+		conversionValidators,
+		common.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to convert subnet %s to an L1: %w", subnet.Name, err)
+	}
+	subnet.L1 = state
+
+	_, err = fmt.Fprintf(w, " converted subnet %q to an L1\n", subnet.Name)
+	return err
+}
+
+// setL1ValidatorWeight sets the weight of [validator] with a warp message of
+// the validator manager of [subnet]. The message is signed by the current
+// validators of the L1.
+func setL1ValidatorWeight(ctx context.Context, w io.Writer, network *tmpnet.Network, subnet *tmpnet.Subnet, validator *tmpnet.L1Validator, weight uint64) error {
+	weightMessage, err := warpmessage.NewL1ValidatorWeight(validator.ValidationID, validator.Nonce, weight)
+	if err != nil {
+		return err
+	}
+	addressedCall, err := payload.NewAddressedCall(subnet.L1.ManagerAddress, weightMessage.Bytes())
+	if err != nil {
+		return err
+	}
+	unsignedMessage, err := warp.NewUnsignedMessage(
+		network.GetNetworkID(),
+		subnet.L1.ManagerChainID,
+		addressedCall.Bytes(),
+	)
+	if err != nil {
+		return err
+	}
+	message, err := signWarpMessage(ctx, network, subnet.L1, unsignedMessage)
+	if err != nil {
+		return err
+	}
+
+	wallet, err := subnet.GetWallet(ctx, network.Nodes[0].URI)
+	if err != nil {
+		return err
+	}
+	if _, err := wallet.P().IssueSetL1ValidatorWeightTx(message.Bytes(), common.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to set weight of %s on L1 %s: %w", validator.NodeID, subnet.Name, err)
+	}
+	validator.Weight = weight
+	validator.Nonce++
+
+	_, err = fmt.Fprintf(w, " set weight of %s on L1 %q to %d\n", validator.NodeID, subnet.Name, weight)
+	return err
+}
+
+// signWarpMessage requests a signature of [unsignedMessage] from every
+// validator of [l1] and aggregates them.
+func signWarpMessage(ctx context.Context, network *tmpnet.Network, l1 *tmpnet.L1, unsignedMessage *warp.UnsignedMessage) (*warp.Message, error) {
+	type signer struct {
+		validator *warp.Validator
+		node      *tmpnet.Node
+	}
+	signers := make([]*signer, 0, len(l1.Validators))
+	for _, l1Validator := range l1.Validators {
+		if l1Validator.Weight == 0 {
+			continue
+		}
+		node := getNode(network, l1Validator.NodeID)
+		if node == nil {
+			return nil, fmt.Errorf("%w %s validating the L1", errUnknownNode, l1Validator.NodeID)
+		}
+		pop, err := node.GetProofOfPossession()
+		if err != nil {
+			return nil, err
+		}
+		publicKey, err := bls.PublicKeyFromCompressedBytes(pop.PublicKey[:])
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, &signer{
+			validator: &warp.Validator{
+				PublicKey:      publicKey,
+				PublicKeyBytes: bls.PublicKeyToUncompressedBytes(publicKey),
+				Weight:         l1Validator.Weight,
+				NodeIDs:        []ids.NodeID{l1Validator.NodeID},
+			},
+			node: node,
+		})
+	}
+
+	// The signers of a warp message are identified by their index in the
+	// canonical ordering of the validator set.
+	slices.SortFunc(signers, func(a, b *signer) int {
+		return a.validator.Compare(b.validator)
+	})
+
+	signerIndices := set.NewBits()
+	signatures := make([]*bls.Signature, 0, len(signers))
+	for i, signer := range signers {
+		signature, err := requestSignature(ctx, network.GetNetworkID(), signer.node, unsignedMessage)
+		if err != nil {
+			return nil, err
+		}
+		if !bls.Verify(signer.validator.PublicKey, signature, unsignedMessage.Bytes()) {
+			return nil, fmt.Errorf("%w: invalid signature from %s", errSignatureRequestFailed, signer.node.NodeID)
+		}
+		signerIndices.Add(i)
+		signatures = append(signatures, signature)
+	}
+
+	aggregateSignature, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+	return warp.NewMessage(
+		unsignedMessage,
+		&warp.BitSetSignature{
+			Signers:   signerIndices.Bytes(),
+			Signature: ([bls.SignatureLen]byte)(bls.SignatureToBytes(aggregateSignature)),
+		},
+	)
+}
+
+// requestSignature connects to [node] as a peer and requests its signature of
+// [unsignedMessage] with an LP-118 signature request.
+func requestSignature(ctx context.Context, networkID uint32, node *tmpnet.Node, unsignedMessage *warp.UnsignedMessage) (*bls.Signature, error) {
+	ctx, cancel := context.WithTimeout(ctx, signatureRequestTimeout)
+	defer cancel()
+
+	stakingAddress, err := netip.ParseAddrPort(node.StakingAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse staking address of node %s: %w", node.NodeID, err)
+	}
+
+	responses := make(chan p2pmessage.InboundMessage, 1)
+	testPeer, err := peer.StartTestPeer(
+		ctx,
+		stakingAddress,
+		networkID,
+		router.InboundHandlerFunc(func(_ context.Context, msgIntf interface{}) {
+			msg, ok := msgIntf.(p2pmessage.InboundMessage)
+			if !ok {
+				return
+			}
+			switch msg.Message().(type) {
+			case *p2ppb.AppResponse, *p2ppb.AppError:
+				select {
+				case responses <- msg:
+				default:
+				}
+			}
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to node %s: %w", node.NodeID, err)
+	}
+	defer func() {
+		testPeer.StartClose()
+		_ = testPeer.AwaitClosed(ctx)
+	}()
+
+	request, err := newSignatureRequest(unsignedMessage)
+	if err != nil {
+		return nil, err
+	}
+	if !testPeer.Send(ctx, request) {
+		return nil, fmt.Errorf("%w: failed to send request to %s", errSignatureRequestFailed, node.NodeID)
+	}
+
+	select {
+	case msg := <-responses:
+		return parseSignatureResponse(msg)
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: no response from %s: %w", errSignatureRequestFailed, node.NodeID, ctx.Err())
+	}
+}
+
+func newSignatureRequest(unsignedMessage *warp.UnsignedMessage) (p2pmessage.OutboundMessage, error) {
+	messageCreator, err := p2pmessage.NewCreator(
+		log.NewNoOpLogger(),
+		metrics.NewNoOp(),
+		constants.DefaultNetworkCompressionType,
+		signatureRequestTimeout,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	requestBytes, err := proto.Marshal(&sdk.SignatureRequest{
+		Message: unsignedMessage.Bytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messageCreator.AppRequest(
+		unsignedMessage.SourceChainID,
+		0,
+		signatureRequestTimeout,
+		p2psdk.PrefixMessage(
+			p2psdk.ProtocolPrefix(lp118.HandlerID),
+			requestBytes,
+		),
+	)
+}
+
+func parseSignatureResponse(msg p2pmessage.InboundMessage) (*bls.Signature, error) {
+	var appResponse *p2ppb.AppResponse
+	switch msg := msg.Message().(type) {
+	case *p2ppb.AppResponse:
+		appResponse = msg
+	case *p2ppb.AppError:
+		return nil, fmt.Errorf("%w: %s", errSignatureRequestFailed, msg.ErrorMessage)
+	}
+
+	var response sdk.SignatureResponse
+	if err := proto.Unmarshal(appResponse.AppBytes, &response); err != nil {
+		return nil, err
+	}
+	return bls.SignatureFromBytes(response.Signature)
+}
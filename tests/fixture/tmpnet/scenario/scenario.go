@@ -0,0 +1,258 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scenario
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/math/set"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/utils/perms"
+)
+
+var (
+	errUnsupportedFormat   = errors.New("unsupported scenario format")
+	errMissingNodeName     = errors.New("node name is required")
+	errDuplicateNodeName   = errors.New("duplicate node name")
+	errMissingSubnetName   = errors.New("subnet name is required")
+	errDuplicateSubnetName = errors.New("duplicate subnet name")
+	errMissingValidators   = errors.New("subnet needs at least one validator")
+	errUnknownNode         = errors.New("unknown node")
+	errDuplicateValidator  = errors.New("duplicate validator")
+	errInvalidManagerChain = errors.New("invalid L1 manager chain")
+	errInvalidAddress      = errors.New("invalid L1 manager address")
+)
+
+// Scenario declares the desired state of a temporary network: the nodes that
+// constitute it and the subnets and chains they validate.
+type Scenario struct {
+	Nodes   []*Node   `json:"nodes"`
+	Subnets []*Subnet `json:"subnets,omitempty"`
+}
+
+// Node declares a node of the network. A node whose flags include a staking
+// keypair is identified by the resulting node ID. Other nodes are matched to
+// the nodes of the network in order.
+type Node struct {
+	// Refers to the node from the subnets of the scenario
+	Name string `json:"name"`
+
+	// The node binary to run. Defaults to the binary of the network.
+	LuxNodePath string `json:"luxNodePath,omitempty"`
+
+	// The directory containing the VM plugins of the node. Defaults to the
+	// plugin dir of the network.
+	PluginDir string `json:"pluginDir,omitempty"`
+
+	Flags tmpnet.FlagsMap `json:"flags,omitempty"`
+}
+
+type Subnet struct {
+	// Uniquely identifies the subnet across the scenario and the network
+	Name string `json:"name"`
+
+	Config tmpnet.FlagsMap `json:"config,omitempty"`
+
+	Chains []*Chain `json:"chains,omitempty"`
+
+	Validators []*Validator `json:"validators"`
+
+	// If set, the subnet is converted to an L1 validated by [Validators]
+	L1 *L1 `json:"l1,omitempty"`
+}
+
+type Chain struct {
+	VMID ids.ID `json:"vmID"`
+
+	// Encoded as base64
+	Genesis []byte `json:"genesis,omitempty"`
+
+	Config string `json:"config,omitempty"`
+}
+
+type Validator struct {
+	// Name of the validating node
+	Node string `json:"node"`
+
+	// Defaults to tmpnet.DefaultValidatorWeight
+	Weight uint64 `json:"weight,omitempty"`
+}
+
+type L1 struct {
+	// Index of the chain of the subnet that hosts the validator manager
+	ManagerChain int `json:"managerChain"`
+
+	// Address of the validator manager, encoded as hex
+	ManagerAddress string `json:"managerAddress,omitempty"`
+
+	// Balance given to each validator to pay for its continuous fee. Defaults
+	// to DefaultL1Balance.
+	Balance uint64 `json:"balance,omitempty"`
+}
+
+// Read reads a scenario from a YAML or JSON file. The format is determined by
+// the extension of the file.
+func Read(path string) (*Scenario, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+	if isYAML(path) {
+		bytes, err = yamlToJSON(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse scenario %s: %w", path, err)
+		}
+	} else if filepath.Ext(path) != ".json" {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedFormat, path)
+	}
+
+	scenario, err := Parse(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse scenario %s: %w", path, err)
+	}
+	return scenario, nil
+}
+
+// Parse parses a JSON encoded scenario and validates it. Unknown fields are
+// rejected to catch misspelled keys.
+func Parse(b []byte) (*Scenario, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+
+	scenario := &Scenario{}
+	if err := decoder.Decode(scenario); err != nil {
+		return nil, err
+	}
+	return scenario, scenario.Validate()
+}
+
+// Write writes the scenario to a YAML or JSON file. The format is determined
+// by the extension of the file.
+func (s *Scenario) Write(path string) error {
+	bytes, err := tmpnet.DefaultJSONMarshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scenario: %w", err)
+	}
+	if isYAML(path) {
+		bytes, err = jsonToYAML(bytes)
+		if err != nil {
+			return fmt.Errorf("failed to marshal scenario: %w", err)
+		}
+	} else if filepath.Ext(path) != ".json" {
+		return fmt.Errorf("%w: %s", errUnsupportedFormat, path)
+	}
+	if err := os.WriteFile(path, bytes, perms.ReadWrite); err != nil {
+		return fmt.Errorf("failed to write scenario: %w", err)
+	}
+	return nil
+}
+
+// Validate checks that the scenario is internally consistent. It doesn't check
+// whether the scenario can be applied to a given network.
+func (s *Scenario) Validate() error {
+	nodeNames := set.NewSet[string](len(s.Nodes))
+	for _, node := range s.Nodes {
+		if len(node.Name) == 0 {
+			return errMissingNodeName
+		}
+		if nodeNames.Contains(node.Name) {
+			return fmt.Errorf("%w: %s", errDuplicateNodeName, node.Name)
+		}
+		nodeNames.Add(node.Name)
+	}
+
+	subnetNames := set.NewSet[string](len(s.Subnets))
+	for _, subnet := range s.Subnets {
+		if len(subnet.Name) == 0 {
+			return errMissingSubnetName
+		}
+		if subnetNames.Contains(subnet.Name) {
+			return fmt.Errorf("%w: %s", errDuplicateSubnetName, subnet.Name)
+		}
+		subnetNames.Add(subnet.Name)
+
+		if len(subnet.Validators) == 0 {
+			return fmt.Errorf("%w: %s", errMissingValidators, subnet.Name)
+		}
+		validatorNames := set.NewSet[string](len(subnet.Validators))
+		for _, validator := range subnet.Validators {
+			if !nodeNames.Contains(validator.Node) {
+				return fmt.Errorf("%w %q validating subnet %s", errUnknownNode, validator.Node, subnet.Name)
+			}
+			if validatorNames.Contains(validator.Node) {
+				return fmt.Errorf("%w %q of subnet %s", errDuplicateValidator, validator.Node, subnet.Name)
+			}
+			validatorNames.Add(validator.Node)
+		}
+
+		if subnet.L1 == nil {
+			continue
+		}
+		if subnet.L1.ManagerChain < 0 || subnet.L1.ManagerChain >= len(subnet.Chains) {
+			return fmt.Errorf("%w %d of subnet %s", errInvalidManagerChain, subnet.L1.ManagerChain, subnet.Name)
+		}
+		if _, err := subnet.L1.managerAddress(); err != nil {
+			return fmt.Errorf("%w of subnet %s: %w", errInvalidAddress, subnet.Name, err)
+		}
+	}
+	return nil
+}
+
+func (l *L1) managerAddress() ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(l.ManagerAddress, "0x"))
+}
+
+func isYAML(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// yamlToJSON converts YAML to JSON so that the JSON encoding of the scenario
+// types is the only one that needs to be maintained.
+func yamlToJSON(b []byte) ([]byte, error) {
+	var value interface{}
+	if err := yaml.Unmarshal(b, &value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// jsonToYAML converts JSON to YAML while retaining the order of fields.
+func jsonToYAML(b []byte) ([]byte, error) {
+	// JSON is valid YAML, so decoding it yields a document that only needs
+	// its flow style removed to be written as idiomatic YAML.
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	clearStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
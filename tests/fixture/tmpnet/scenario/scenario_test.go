@@ -0,0 +1,335 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scenario
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/config"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+)
+
+const testScenarioYAML = `
+nodes:
+  - name: node-1
+  - name: node-2
+    luxNodePath: /opt/luxd/v1.2.3/luxd
+    pluginDir: /opt/luxd/plugins
+    flags:
+      log-level: info
+      http-allowed-hosts: "*"
+subnets:
+  - name: xsvm
+    config:
+      proposerMinBlockDelay: 0
+    chains:
+      - vmID: v3m4wPxaHpvGr8qfMeyK6PRW3idZrPHmYcMTt7oXdK47yurVH
+        genesis: AAAA
+        config: '{"foo": "bar"}'
+    validators:
+      - node: node-1
+      - node: node-2
+        weight: 200
+    l1:
+      managerChain: 0
+      managerAddress: "0x0102"
+`
+
+func TestRead(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scenario.yaml")
+	require.NoError(os.WriteFile(path, []byte(testScenarioYAML), 0o600))
+
+	scenario, err := Read(path)
+	require.NoError(err)
+
+	require.Len(scenario.Nodes, 2)
+	node := scenario.Nodes[1]
+	require.Equal("node-2", node.Name)
+	require.Equal("/opt/luxd/v1.2.3/luxd", node.LuxNodePath)
+	require.Equal("/opt/luxd/plugins", node.PluginDir)
+	require.Equal("*", node.Flags["http-allowed-hosts"])
+
+	require.Len(scenario.Subnets, 1)
+	subnet := scenario.Subnets[0]
+	require.Equal([]byte{0, 0, 0}, subnet.Chains[0].Genesis)
+	require.Equal(`{"foo": "bar"}`, subnet.Chains[0].Config)
+	require.Equal(uint64(200), subnet.Validators[1].Weight)
+
+	address, err := subnet.L1.managerAddress()
+	require.NoError(err)
+	require.Equal([]byte{1, 2}, address)
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	original, err := Parse([]byte(`{
+		"nodes": [{"name": "node-1", "flags": {"log-level": "info", "index-enabled": true}}],
+		"subnets": [{
+			"name": "subnet",
+			"chains": [{"vmID": "v3m4wPxaHpvGr8qfMeyK6PRW3idZrPHmYcMTt7oXdK47yurVH", "genesis": "AQID"}],
+			"validators": [{"node": "node-1", "weight": 100}]
+		}]
+	}`))
+	require.NoError(t, err)
+
+	for _, name := range []string{"scenario.json", "scenario.yaml", "scenario.yml"} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			path := filepath.Join(dir, name)
+			require.NoError(original.Write(path))
+
+			scenario, err := Read(path)
+			require.NoError(err)
+			require.Equal(original, scenario)
+		})
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "scenario.toml")
+	require.ErrorIs((&Scenario{}).Write(path), errUnsupportedFormat)
+
+	require.NoError(os.WriteFile(path, nil, 0o600))
+	_, err := Read(path)
+	require.ErrorIs(err, errUnsupportedFormat)
+}
+
+func TestParseRejectsUnknownFields(t *testing.T) {
+	_, err := Parse([]byte(`{"nodes": [{"name": "node-1", "flag": {}}]}`))
+	require.ErrorContains(t, err, "unknown field")
+}
+
+func TestValidate(t *testing.T) {
+	validator := []*Validator{{Node: "node-1"}}
+	chains := []*Chain{{VMID: ids.GenerateTestID()}}
+	tests := []struct {
+		name        string
+		scenario    *Scenario
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			scenario: &Scenario{
+				Nodes: []*Node{{Name: "node-1"}},
+				Subnets: []*Subnet{{
+					Name:       "subnet",
+					Chains:     chains,
+					Validators: validator,
+					L1:         &L1{ManagerAddress: "0xabcd"},
+				}},
+			},
+		},
+		{
+			name: "missing node name",
+			scenario: &Scenario{
+				Nodes: []*Node{{}},
+			},
+			expectedErr: errMissingNodeName,
+		},
+		{
+			name: "duplicate node name",
+			scenario: &Scenario{
+				Nodes: []*Node{{Name: "node-1"}, {Name: "node-1"}},
+			},
+			expectedErr: errDuplicateNodeName,
+		},
+		{
+			name: "missing subnet name",
+			scenario: &Scenario{
+				Nodes:   []*Node{{Name: "node-1"}},
+				Subnets: []*Subnet{{Validators: validator}},
+			},
+			expectedErr: errMissingSubnetName,
+		},
+		{
+			name: "duplicate subnet name",
+			scenario: &Scenario{
+				Nodes: []*Node{{Name: "node-1"}},
+				Subnets: []*Subnet{
+					{Name: "subnet", Validators: validator},
+					{Name: "subnet", Validators: validator},
+				},
+			},
+			expectedErr: errDuplicateSubnetName,
+		},
+		{
+			name: "missing validators",
+			scenario: &Scenario{
+				Nodes:   []*Node{{Name: "node-1"}},
+				Subnets: []*Subnet{{Name: "subnet"}},
+			},
+			expectedErr: errMissingValidators,
+		},
+		{
+			name: "unknown validator",
+			scenario: &Scenario{
+				Nodes: []*Node{{Name: "node-1"}},
+				Subnets: []*Subnet{{
+					Name:       "subnet",
+					Validators: []*Validator{{Node: "node-2"}},
+				}},
+			},
+			expectedErr: errUnknownNode,
+		},
+		{
+			name: "duplicate validator",
+			scenario: &Scenario{
+				Nodes: []*Node{{Name: "node-1"}},
+				Subnets: []*Subnet{{
+					Name:       "subnet",
+					Validators: []*Validator{{Node: "node-1"}, {Node: "node-1"}},
+				}},
+			},
+			expectedErr: errDuplicateValidator,
+		},
+		{
+			name: "invalid manager chain",
+			scenario: &Scenario{
+				Nodes: []*Node{{Name: "node-1"}},
+				Subnets: []*Subnet{{
+					Name:       "subnet",
+					Chains:     chains,
+					Validators: validator,
+					L1:         &L1{ManagerChain: 1},
+				}},
+			},
+			expectedErr: errInvalidManagerChain,
+		},
+		{
+			name: "invalid manager address",
+			scenario: &Scenario{
+				Nodes: []*Node{{Name: "node-1"}},
+				Subnets: []*Subnet{{
+					Name:       "subnet",
+					Chains:     chains,
+					Validators: validator,
+					L1:         &L1{ManagerAddress: "0xz"},
+				}},
+			},
+			expectedErr: errInvalidAddress,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.scenario.Validate(), test.expectedErr)
+		})
+	}
+}
+
+// Applying the export of a network to the same network must not reconfigure
+// any of its nodes.
+func TestExportIsConfigured(t *testing.T) {
+	require := require.New(t)
+
+	network := &tmpnet.Network{
+		DefaultFlags: tmpnet.FlagsMap{
+			config.LogLevelKey:  "debug",
+			config.PluginDirKey: "/plugins",
+		},
+		DefaultRuntimeConfig: tmpnet.NodeRuntimeConfig{
+			LuxNodePath: "/luxd",
+		},
+		Nodes: tmpnet.NewNodesOrPanic(3),
+	}
+	for _, node := range network.Nodes {
+		require.NoError(network.EnsureNodeConfig(node))
+		node.Flags[config.BootstrapIPsKey] = "127.0.0.1:9651"
+		node.Flags[config.HTTPPortKey] = "9650"
+	}
+	network.Nodes[1].Flags[config.LogLevelKey] = "info"
+	network.Nodes[1].Flags[config.PluginDirKey] = "/other-plugins"
+	network.Nodes[2].RuntimeConfig.LuxNodePath = "/old/luxd"
+
+	managerChainID := ids.GenerateTestID()
+	network.Subnets = []*tmpnet.Subnet{{
+		Name:     "l1",
+		SubnetID: ids.GenerateTestID(),
+		Chains: []*tmpnet.Chain{
+			{VMID: ids.GenerateTestID(), ChainID: ids.GenerateTestID()},
+			{VMID: ids.GenerateTestID(), ChainID: managerChainID},
+		},
+		ValidatorIDs: []ids.NodeID{network.Nodes[0].NodeID, network.Nodes[1].NodeID},
+		ValidatorWeights: map[ids.NodeID]uint64{
+			network.Nodes[1].NodeID: 50,
+		},
+		L1: &tmpnet.L1{
+			ManagerChainID: managerChainID,
+			ManagerAddress: []byte{1, 2, 3},
+			Balance:        10,
+			Validators: []*tmpnet.L1Validator{
+				{NodeID: network.Nodes[1].NodeID, Weight: 75},
+			},
+		},
+	}}
+
+	scenario, err := Export(network)
+	require.NoError(err)
+
+	require.Len(scenario.Nodes, 3)
+	require.NotContains(scenario.Nodes[0].Flags, config.DataDirKey)
+	require.NotContains(scenario.Nodes[0].Flags, config.LogLevelKey)
+	require.Contains(scenario.Nodes[0].Flags, config.StakingCertContentKey)
+	require.Equal("info", scenario.Nodes[1].Flags[config.LogLevelKey])
+	require.Equal("/other-plugins", scenario.Nodes[1].PluginDir)
+	require.Empty(scenario.Nodes[1].LuxNodePath)
+	require.Equal("/old/luxd", scenario.Nodes[2].LuxNodePath)
+
+	require.Equal(
+		[]*Validator{
+			{Node: "node-1", Weight: tmpnet.DefaultValidatorWeight},
+			{Node: "node-2", Weight: 75},
+		},
+		scenario.Subnets[0].Validators,
+	)
+	require.Equal(
+		&L1{
+			ManagerChain:   1,
+			ManagerAddress: "010203",
+			Balance:        10,
+		},
+		scenario.Subnets[0].L1,
+	)
+
+	// The scenario must survive being written out
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	require.NoError(scenario.Write(path))
+	scenario, err = Read(path)
+	require.NoError(err)
+
+	for i, node := range scenario.Nodes {
+		require.True(isConfigured(network, network.Nodes[i], node), node.Name)
+
+		nodeID, err := getNodeID(node)
+		require.NoError(err)
+		require.Equal(network.Nodes[i].NodeID, nodeID)
+	}
+
+	// A node without keys keeps the keys of the node it is matched to
+	node := scenario.Nodes[1]
+	for _, key := range identityFlags {
+		delete(node.Flags, key)
+	}
+	require.True(isConfigured(network, network.Nodes[1], node))
+	node.Flags[config.LogLevelKey] = "warn"
+	require.False(isConfigured(network, network.Nodes[1], node))
+
+	nodeID := network.Nodes[1].NodeID
+	configureNode(network, network.Nodes[1], node)
+	require.Equal("warn", network.Nodes[1].Flags[config.LogLevelKey])
+	require.Equal("9650", network.Nodes[1].Flags[config.HTTPPortKey])
+	require.NoError(network.Nodes[1].EnsureNodeID())
+	require.Equal(nodeID, network.Nodes[1].NodeID)
+	require.True(isConfigured(network, network.Nodes[1], node))
+}
@@ -17,7 +17,6 @@ import (
 	"github.com/luxfi/node/utils/constants"
 	"github.com/luxfi/node/utils/perms"
 	"github.com/luxfi/math/set"
-	"github.com/luxfi/node/utils/units"
 	"github.com/luxfi/node/vms/platformvm"
 	"github.com/luxfi/node/vms/platformvm/txs"
 	"github.com/luxfi/node/vms/secp256k1fx"
@@ -75,7 +74,48 @@ type Subnet struct {
 	// IDs of the nodes responsible for validating the subnet
 	ValidatorIDs []ids.NodeID
 
+	// Weights of the subnet's validators. Validators without an entry are
+	// added with DefaultValidatorWeight.
+	ValidatorWeights map[ids.NodeID]uint64 `json:",omitempty"`
+
 	Chains []*Chain
+
+	// Set once the subnet has been converted to an L1
+	L1 *L1 `json:",omitempty"`
+}
+
+// L1 records the state of a subnet that has been converted to an L1. The
+// validators of an L1 are managed by a contract on one of its chains, so the
+// state needed to construct the warp messages of the manager is retained.
+type L1 struct {
+	// The chain and address of the validator manager
+	ManagerChainID ids.ID
+	ManagerAddress []byte
+
+	// The balance each validator was given to pay for its continuous fee
+	Balance uint64
+
+	Validators []*L1Validator
+}
+
+type L1Validator struct {
+	NodeID       ids.NodeID
+	ValidationID ids.ID
+	Weight       uint64
+
+	// The nonce to use in the next weight update of the validator
+	Nonce uint64
+}
+
+// GetValidator returns the L1 validator with the given node ID, or nil if the
+// node doesn't validate the L1.
+func (l *L1) GetValidator(nodeID ids.NodeID) *L1Validator {
+	for _, validator := range l.Validators {
+		if validator.NodeID == nodeID {
+			return validator
+		}
+	}
+	return nil
 }
 
 // Retrieves a wallet configured for use with the subnet
@@ -157,6 +197,15 @@ func (s *Subnet) CreateChains(ctx context.Context, w io.Writer, uri string) erro
 	return nil
 }
 
+// GetValidatorWeight returns the weight with which the given node validates
+// the subnet.
+func (s *Subnet) GetValidatorWeight(nodeID ids.NodeID) uint64 {
+	if weight, ok := s.ValidatorWeights[nodeID]; ok {
+		return weight
+	}
+	return DefaultValidatorWeight
+}
+
 // Add validators to the subnet
 func (s *Subnet) AddValidators(ctx context.Context, w io.Writer, apiURI string, nodes ...*Node) error {
 	wallet, err := s.GetWallet(ctx, apiURI)
@@ -189,7 +238,7 @@ func (s *Subnet) AddValidators(ctx context.Context, w io.Writer, apiURI string,
 					NodeID: node.NodeID,
 					Start:  uint64(startTime.Unix()),
 					End:    endTime,
-					Wght:   units.Schmeckle,
+					Wght:   s.GetValidatorWeight(node.NodeID),
 				},
 				Subnet: s.SubnetID,
 			},
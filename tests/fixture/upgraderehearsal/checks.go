@@ -0,0 +1,218 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package upgraderehearsal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/luxfi/crypto/secp256k1"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/api/health"
+	"github.com/luxfi/node/network"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/utils/units"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/platformvm"
+	"github.com/luxfi/node/vms/secp256k1fx"
+	"github.com/luxfi/node/vms/xvm"
+	"github.com/luxfi/node/wallet/keychain"
+	"github.com/luxfi/node/wallet/subnet/primary"
+	"github.com/luxfi/node/wallet/subnet/primary/common"
+)
+
+const (
+	checkInterval = time.Second
+
+	// networkHealthCheck is the name the network layer registers its health
+	// check under.
+	networkHealthCheck = "network"
+
+	transferAmount = units.MilliLux
+)
+
+// chain identifies a chain whose finalization is checked.
+type chain struct {
+	name      string
+	getHeight func(ctx context.Context, uri string) (uint64, error)
+}
+
+var chains = []chain{
+	{
+		name: "P",
+		getHeight: func(ctx context.Context, uri string) (uint64, error) {
+			return platformvm.NewClient(uri).GetHeight(ctx)
+		},
+	},
+	{
+		name: "X",
+		getHeight: func(ctx context.Context, uri string) (uint64, error) {
+			return xvm.NewClient(uri, "X").GetHeight(ctx)
+		},
+	},
+}
+
+// CheckFinalization issues a transfer on the P-Chain and the X-Chain through
+// [issuer] and waits until every one of [nodes] has accepted the blocks that
+// [issuer] accepted up to and including the transfer.
+func CheckFinalization(
+	ctx context.Context,
+	key *secp256k1.PrivateKey,
+	issuer *tmpnet.Node,
+	nodes []*tmpnet.Node,
+) error {
+	kc := keychain.NewWalletKeychain(secp256k1fx.NewKeychain(key))
+	wallet, err := primary.MakeWallet(ctx, &primary.WalletConfig{
+		URI:         issuer.URI,
+		LUXKeychain: kc,
+		EthKeychain: kc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize wallet for node %s: %w", issuer.NodeID, err)
+	}
+
+	owner := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{key.Address()},
+	}
+
+	pAssetID := wallet.P().Builder().Context().LUXAssetID
+	if _, err := wallet.P().IssueBaseTx(
+		[]*lux.TransferableOutput{newTransferableOutput(pAssetID, owner)},
+		common.WithContext(ctx),
+	); err != nil {
+		return fmt.Errorf("failed to issue P-Chain transfer: %w", err)
+	}
+
+	xAssetID := wallet.X().Builder().Context().LUXAssetID
+	if _, err := wallet.X().IssueBaseTx(
+		[]*lux.TransferableOutput{newTransferableOutput(xAssetID, owner)},
+		common.WithContext(ctx),
+	); err != nil {
+		return fmt.Errorf("failed to issue X-Chain transfer: %w", err)
+	}
+
+	for _, chain := range chains {
+		height, err := chain.getHeight(ctx, issuer.URI)
+		if err != nil {
+			return fmt.Errorf("failed to get %s-Chain height of node %s: %w", chain.name, issuer.NodeID, err)
+		}
+		if err := waitForHeight(ctx, chain, nodes, height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForHeight waits until every one of [nodes] has accepted the block of
+// [chain] at [height]. Errors from nodes that aren't reachable yet are retried
+// until [ctx] is done.
+func waitForHeight(ctx context.Context, chain chain, nodes []*tmpnet.Node, height uint64) error {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		var (
+			lagging *tmpnet.Node
+			err     error
+		)
+		for _, node := range nodes {
+			var nodeHeight uint64
+			nodeHeight, err = chain.getHeight(ctx, node.URI)
+			if err != nil || nodeHeight < height {
+				lagging = node
+				break
+			}
+		}
+		if lagging == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to see %s-Chain height %d accepted by node %s (err: %w): %w", chain.name, height, lagging.NodeID, err, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetMismatchedUpgradePeers returns the peers that [node] reports via its
+// health API as having a different network upgrade schedule, mapped to the
+// hash of the schedule each of them advertised.
+func GetMismatchedUpgradePeers(ctx context.Context, node *tmpnet.Node) (map[ids.NodeID]ids.ID, error) {
+	reply, err := health.NewClient(node.URI).Health(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query health of node %s: %w", node.NodeID, err)
+	}
+	result, ok := reply.Checks[networkHealthCheck]
+	if !ok {
+		return nil, fmt.Errorf("node %s didn't report the %s health check", node.NodeID, networkHealthCheck)
+	}
+
+	// The details were decoded without type information, so they are
+	// re-encoded to be decoded into the expected types.
+	detailsJSON, err := json.Marshal(result.Details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal health details of node %s: %w", node.NodeID, err)
+	}
+	var details map[string]json.RawMessage
+	if err := json.Unmarshal(detailsJSON, &details); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal health details of node %s: %w", node.NodeID, err)
+	}
+
+	mismatched := make(map[ids.NodeID]ids.ID)
+	mismatchedJSON, ok := details[network.MismatchedUpgradePeersKey]
+	if !ok {
+		return mismatched, nil
+	}
+	var peers map[string]ids.ID
+	if err := json.Unmarshal(mismatchedJSON, &peers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mismatched upgrade peers of node %s: %w", node.NodeID, err)
+	}
+	for nodeIDStr, upgradeScheduleHash := range peers {
+		nodeID, err := ids.NodeIDFromString(nodeIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("node %s reported an invalid node ID: %w", node.NodeID, err)
+		}
+		mismatched[nodeID] = upgradeScheduleHash
+	}
+	return mismatched, nil
+}
+
+// WaitForMismatchReported waits until [reporter] reports via its health API
+// whether [nodeID] has a different network upgrade schedule, as expected by
+// [reported].
+func WaitForMismatchReported(ctx context.Context, reporter *tmpnet.Node, nodeID ids.NodeID, reported bool) error {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		mismatched, err := GetMismatchedUpgradePeers(ctx, reporter)
+		if err == nil {
+			if _, ok := mismatched[nodeID]; ok == reported {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("node %s didn't report node %s as mismatched=%t (err: %w): %w", reporter.NodeID, nodeID, reported, err, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func newTransferableOutput(assetID ids.ID, owner secp256k1fx.OutputOwners) *lux.TransferableOutput {
+	return &lux.TransferableOutput{
+		Asset: lux.Asset{
+			ID: assetID,
+		},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          transferAmount,
+			OutputOwners: owner,
+		},
+	}
+}
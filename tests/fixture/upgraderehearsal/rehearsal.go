@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package upgraderehearsal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/upgrade"
+	"github.com/luxfi/node/upgrade/upgradetest"
+)
+
+// mismatchOffset is how far the schedule of a mismatched node is moved from
+// the schedule being rehearsed.
+const mismatchOffset = time.Hour
+
+var (
+	errNoNewNodePath     = errors.New("no binary to upgrade to")
+	errNoWaves           = errors.New("no waves to roll")
+	errInvalidWaveOrder  = errors.New("waves rolled before activation must precede waves rolled after activation")
+	errEmptyWave         = errors.New("wave has no nodes")
+	errActivationElapsed = errors.New("activation time elapsed before the waves preceding it were rolled")
+	errNoPreFundedKeys   = errors.New("network has no pre-funded keys to issue transactions with")
+)
+
+// Wave is a set of nodes that are restarted onto the new binary together.
+type Wave struct {
+	Nodes []*tmpnet.Node
+	// AfterActivation defers restarting the nodes until the upgrade has
+	// activated.
+	AfterActivation bool
+}
+
+// Config describes the rehearsal of a scheduled network upgrade.
+type Config struct {
+	// NewNodePath is the binary the nodes are upgraded to. The network is
+	// expected to have been started on the old binary with [Schedule].
+	NewNodePath string
+	// Fork is the upgrade being rehearsed.
+	Fork upgradetest.Fork
+	// ActivationTime is the time [Fork] activates.
+	ActivationTime time.Time
+	// Waves are rolled in order. Every wave that is rolled before activation
+	// must precede the waves that are rolled after activation.
+	Waves []Wave
+	// CheckMismatchDetection starts a node whose upgrade schedule differs from
+	// the one being rehearsed once the first wave is rolled, and checks that
+	// the first node of that wave reports it via its health API. Nodes running
+	// a binary that doesn't advertise its schedule can't detect mismatches.
+	CheckMismatchDetection bool
+}
+
+// Schedule returns the upgrade schedule being rehearsed.
+func (c *Config) Schedule() upgrade.Config {
+	return NewSchedule(c.Fork, c.ActivationTime)
+}
+
+func (c *Config) verify() error {
+	if len(c.NewNodePath) == 0 {
+		return errNoNewNodePath
+	}
+	if len(c.Waves) == 0 {
+		return errNoWaves
+	}
+	afterActivation := false
+	for i, wave := range c.Waves {
+		if len(wave.Nodes) == 0 {
+			return fmt.Errorf("%w: wave %d", errEmptyWave, i)
+		}
+		if afterActivation && !wave.AfterActivation {
+			return fmt.Errorf("%w: wave %d", errInvalidWaveOrder, i)
+		}
+		afterActivation = wave.AfterActivation
+	}
+	return nil
+}
+
+// Rehearsal upgrades the nodes of a running tmpnet network onto a new binary
+// around the activation of a scheduled network upgrade.
+type Rehearsal struct {
+	network *tmpnet.Network
+	config  Config
+}
+
+// NewRehearsal returns a rehearsal of [config] against [network].
+func NewRehearsal(network *tmpnet.Network, config Config) (*Rehearsal, error) {
+	if err := config.verify(); err != nil {
+		return nil, err
+	}
+	return &Rehearsal{
+		network: network,
+		config:  config,
+	}, nil
+}
+
+// Run rolls every wave onto the new binary, waiting for the upgrade to
+// activate before the first wave that is rolled after activation. Every chain
+// is checked to keep finalizing on every node after each wave and once the
+// upgrade has activated.
+func (r *Rehearsal) Run(ctx context.Context, w io.Writer) error {
+	if len(r.network.PreFundedKeys) == 0 {
+		return errNoPreFundedKeys
+	}
+
+	if err := r.checkFinalization(ctx, w, "before rolling any wave"); err != nil {
+		return err
+	}
+
+	activated := false
+	for i, wave := range r.config.Waves {
+		if wave.AfterActivation && !activated {
+			if err := r.awaitActivation(ctx, w); err != nil {
+				return err
+			}
+			activated = true
+		}
+		if !wave.AfterActivation && !time.Now().Before(r.config.ActivationTime) {
+			return fmt.Errorf("%w: wave %d", errActivationElapsed, i)
+		}
+
+		if err := r.rollWave(ctx, w, i, wave); err != nil {
+			return err
+		}
+		if err := r.checkFinalization(ctx, w, fmt.Sprintf("after rolling wave %d", i)); err != nil {
+			return err
+		}
+		if i == 0 && r.config.CheckMismatchDetection {
+			if err := r.checkMismatchDetection(ctx, w, wave.Nodes[0]); err != nil {
+				return err
+			}
+		}
+	}
+	if !activated {
+		if err := r.awaitActivation(ctx, w); err != nil {
+			return err
+		}
+	}
+
+	// Nodes added to the network from now on run the new binary.
+	r.network.DefaultRuntimeConfig.LuxNodePath = r.config.NewNodePath
+	return nil
+}
+
+// rollWave restarts the nodes of [wave] onto the new binary one at a time,
+// waiting for each to report healthy before restarting the next.
+func (r *Rehearsal) rollWave(ctx context.Context, w io.Writer, index int, wave Wave) error {
+	if _, err := fmt.Fprintf(w, " rolling wave %d (%d nodes) onto %s\n", index, len(wave.Nodes), r.config.NewNodePath); err != nil {
+		return err
+	}
+	for _, node := range wave.Nodes {
+		if node.RuntimeConfig == nil {
+			node.RuntimeConfig = &tmpnet.NodeRuntimeConfig{}
+		}
+		node.RuntimeConfig.LuxNodePath = r.config.NewNodePath
+		if err := r.network.RestartNode(ctx, w, node); err != nil {
+			return fmt.Errorf("failed to upgrade node %s in wave %d: %w", node.NodeID, index, err)
+		}
+	}
+	return nil
+}
+
+// awaitActivation waits until the upgrade has activated and checks that every
+// chain finalizes past activation.
+func (r *Rehearsal) awaitActivation(ctx context.Context, w io.Writer) error {
+	if _, err := fmt.Fprintf(w, " waiting for %s to activate at %s\n", r.config.Fork, r.config.ActivationTime.Format(time.RFC3339)); err != nil {
+		return err
+	}
+	timer := time.NewTimer(time.Until(r.config.ActivationTime))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for %s to activate: %w", r.config.Fork, ctx.Err())
+	case <-timer.C:
+	}
+	return r.checkFinalization(ctx, w, fmt.Sprintf("after %s activated", r.config.Fork))
+}
+
+// checkFinalization checks that every chain keeps finalizing on every node of
+// the network.
+func (r *Rehearsal) checkFinalization(ctx context.Context, w io.Writer, when string) error {
+	if _, err := fmt.Fprintf(w, " checking that every chain finalizes %s\n", when); err != nil {
+		return err
+	}
+	err := CheckFinalization(ctx, r.network.PreFundedKeys[0], r.network.Nodes[0], r.network.Nodes)
+	if err != nil {
+		return fmt.Errorf("chains stopped finalizing %s: %w", when, err)
+	}
+	return nil
+}
+
+// checkMismatchDetection starts a node whose schedule activates the upgrade
+// later than the rest of the network, checks that [reporter] reports it and
+// stops it again. Only the handshake with [reporter] is required, so the node
+// isn't expected to become healthy.
+func (r *Rehearsal) checkMismatchDetection(ctx context.Context, w io.Writer, reporter *tmpnet.Node) error {
+	schedule := NewSchedule(r.config.Fork, r.config.ActivationTime.Add(mismatchOffset))
+	flags := tmpnet.FlagsMap{}
+	if err := SetSchedule(flags, schedule); err != nil {
+		return err
+	}
+	node := tmpnet.NewEphemeralNode(flags)
+	node.RuntimeConfig = &tmpnet.NodeRuntimeConfig{
+		LuxNodePath: r.config.NewNodePath,
+	}
+	if err := node.EnsureKeys(); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, " starting node %s with a mismatched upgrade schedule\n", node.NodeID); err != nil {
+		return err
+	}
+	if err := r.network.StartNode(ctx, w, node); err != nil {
+		return fmt.Errorf("failed to start mismatched node: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, " waiting for node %s to report node %s as mismatched\n", reporter.NodeID, node.NodeID); err != nil {
+		return errors.Join(err, node.Stop(ctx))
+	}
+	if err := WaitForMismatchReported(ctx, reporter, node.NodeID, true); err != nil {
+		return errors.Join(err, node.Stop(ctx))
+	}
+	if err := node.Stop(ctx); err != nil {
+		return fmt.Errorf("failed to stop mismatched node %s: %w", node.NodeID, err)
+	}
+
+	// Once disconnected, the node must no longer be reported.
+	return WaitForMismatchReported(ctx, reporter, node.NodeID, false)
+}
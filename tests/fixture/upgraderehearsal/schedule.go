@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package upgraderehearsal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/luxfi/node/config"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/upgrade"
+	"github.com/luxfi/node/upgrade/upgradetest"
+)
+
+var errUnknownFork = errors.New("unknown fork")
+
+// ParseFork returns the fork named [name], e.g. "Etna".
+func ParseFork(name string) (upgradetest.Fork, error) {
+	for fork := upgradetest.NoUpgrades; fork <= upgradetest.Latest; fork++ {
+		if fork.String() == name {
+			return fork, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", errUnknownFork, name)
+}
+
+// NewSchedule returns an upgrade config with the forks prior to [fork]
+// initially active, [fork] activating at [activationTime] and all later forks
+// unscheduled.
+func NewSchedule(fork upgradetest.Fork, activationTime time.Time) upgrade.Config {
+	schedule := upgradetest.GetConfigWithUpgradeTime(fork, activationTime)
+	if fork > upgradetest.NoUpgrades {
+		upgradetest.SetTimesTo(&schedule, fork-1, upgrade.InitiallyActiveTime)
+	}
+	return schedule
+}
+
+// SetSchedule configures [flags] to start nodes with [schedule]. Applied to
+// the default flags of a network, it configures every node of the network.
+func SetSchedule(flags tmpnet.FlagsMap, schedule upgrade.Config) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	scheduleJSON, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal upgrade schedule: %w", err)
+	}
+	flags[config.UpgradeFileContentKey] = base64.StdEncoding.EncodeToString(scheduleJSON)
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package upgraderehearsal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/config"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/upgrade"
+	"github.com/luxfi/node/upgrade/upgradetest"
+)
+
+func TestParseFork(t *testing.T) {
+	require := require.New(t)
+
+	for fork := upgradetest.NoUpgrades; fork <= upgradetest.Latest; fork++ {
+		parsed, err := ParseFork(fork.String())
+		require.NoError(err)
+		require.Equal(fork, parsed)
+	}
+
+	_, err := ParseFork("Unknown")
+	require.ErrorIs(err, errUnknownFork)
+}
+
+func TestNewSchedule(t *testing.T) {
+	require := require.New(t)
+
+	activationTime := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	schedule := NewSchedule(upgradetest.Etna, activationTime)
+
+	require.Equal(upgrade.InitiallyActiveTime, schedule.ApricotPhase1Time)
+	require.Equal(upgrade.InitiallyActiveTime, schedule.DurangoTime)
+	require.Equal(activationTime, schedule.EtnaTime)
+	require.Equal(upgrade.UnscheduledActivationTime, schedule.FortunaTime)
+	require.Equal(upgrade.UnscheduledActivationTime, schedule.GraniteTime)
+	require.NoError(schedule.Validate())
+}
+
+func TestSetSchedule(t *testing.T) {
+	require := require.New(t)
+
+	schedule := NewSchedule(upgradetest.Fortuna, time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC))
+	flags := tmpnet.FlagsMap{}
+	require.NoError(SetSchedule(flags, schedule))
+
+	content, err := flags.GetStringVal(config.UpgradeFileContentKey)
+	require.NoError(err)
+	scheduleJSON, err := base64.StdEncoding.DecodeString(content)
+	require.NoError(err)
+
+	var decoded upgrade.Config
+	require.NoError(json.Unmarshal(scheduleJSON, &decoded))
+	require.Equal(schedule.FortunaTime, decoded.FortunaTime)
+	require.True(schedule.GraniteTime.Equal(decoded.GraniteTime))
+}
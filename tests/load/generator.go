@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/luxfi/crypto/secp256k1"
 	"github.com/luxfi/geth/ethclient"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
@@ -17,8 +18,9 @@ import (
 	"github.com/luxfi/node/utils/logging"
 )
 
-type Test interface {
-	Run(tc tests.TestContext, wallet *Wallet)
+// Test issues transactions through a wallet of type W.
+type Test[W any] interface {
+	Run(tc tests.TestContext, wallet W)
 }
 
 type Worker struct {
@@ -27,9 +29,16 @@ type Worker struct {
 	Client  *ethclient.Client
 }
 
-type LoadGenerator struct {
-	wallets []*Wallet
-	test    Test
+// PrimaryWorker is an account used to issue P-Chain and X-Chain transactions
+// through the node at URI.
+type PrimaryWorker struct {
+	Key *secp256k1.PrivateKey
+	URI string
+}
+
+type LoadGenerator[W any] struct {
+	wallets []W
+	test    Test[W]
 }
 
 func NewLoadGenerator(
@@ -37,11 +46,11 @@ func NewLoadGenerator(
 	chainID *big.Int,
 	metricsNamespace string,
 	registry *prometheus.Registry,
-	test Test,
-) (LoadGenerator, error) {
+	test Test[*Wallet],
+) (LoadGenerator[*Wallet], error) {
 	metrics, err := newMetrics(metricsNamespace, registry)
 	if err != nil {
-		return LoadGenerator{}, err
+		return LoadGenerator[*Wallet]{}, err
 	}
 
 	wallets := make([]*Wallet, len(workers))
@@ -55,13 +64,47 @@ func NewLoadGenerator(
 		)
 	}
 
-	return LoadGenerator{
+	return LoadGenerator[*Wallet]{
+		wallets: wallets,
+		test:    test,
+	}, nil
+}
+
+// NewPrimaryLoadGenerator creates a load generator that issues P-Chain and
+// X-Chain transactions with a wallet per worker. The wallets are synced with
+// the network before returning.
+func NewPrimaryLoadGenerator(
+	ctx context.Context,
+	workers []PrimaryWorker,
+	metricsNamespace string,
+	registry *prometheus.Registry,
+	test Test[*PrimaryWallet],
+) (LoadGenerator[*PrimaryWallet], error) {
+	metrics, err := newWorkloadMetrics(metricsNamespace, registry)
+	if err != nil {
+		return LoadGenerator[*PrimaryWallet]{}, err
+	}
+
+	wallets := make([]*PrimaryWallet, len(workers))
+	for i := range wallets {
+		wallets[i], err = newPrimaryWallet(
+			ctx,
+			workers[i].Key,
+			workers[i].URI,
+			metrics,
+		)
+		if err != nil {
+			return LoadGenerator[*PrimaryWallet]{}, err
+		}
+	}
+
+	return LoadGenerator[*PrimaryWallet]{
 		wallets: wallets,
 		test:    test,
 	}, nil
 }
 
-func (l LoadGenerator) Run(
+func (l LoadGenerator[W]) Run(
 	ctx context.Context,
 	log logging.Logger,
 	loadTimeout time.Duration,
@@ -94,7 +137,7 @@ func (l LoadGenerator) Run(
 
 // execTestWithRecovery ensures assertion-related panics encountered during test execution are recovered
 // and that deferred cleanups are always executed before returning.
-func execTestWithRecovery(ctx context.Context, log logging.Logger, test Test, wallet *Wallet, testTimeout time.Duration) {
+func execTestWithRecovery[W any](ctx context.Context, log logging.Logger, test Test[W], wallet W, testTimeout time.Duration) {
 	tc := tests.NewTestContext(log)
 	defer tc.Recover()
 	contextWithTimeout, cancel := context.WithTimeout(ctx, testTimeout)
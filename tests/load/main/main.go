@@ -4,11 +4,14 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"math/rand"
 	"os"
 	"time"
 
+	"github.com/luxfi/crypto/secp256k1"
 	"github.com/luxfi/geth/ethclient"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
@@ -21,6 +24,7 @@ import (
 
 const (
 	blockchainID     = "C"
+	primaryChains    = "PX"
 	metricsNamespace = "load"
 	pollFrequency    = time.Millisecond
 	testTimeout      = time.Minute
@@ -31,6 +35,7 @@ var (
 	flagVars *e2e.FlagVars
 
 	loadTimeout time.Duration
+	chains      string
 )
 
 func init() {
@@ -43,6 +48,13 @@ func init() {
 		"the duration that the load test should run for",
 	)
 
+	flag.StringVar(
+		&chains,
+		"chains",
+		blockchainID,
+		fmt.Sprintf("the chains to generate load against: %q for the C-Chain or %q for the P-Chain and X-Chain", blockchainID, primaryChains),
+	)
+
 	flag.Parse()
 }
 
@@ -52,6 +64,7 @@ func main() {
 	defer tc.RecoverAndExit()
 
 	require := require.New(tc)
+	require.Contains([]string{blockchainID, primaryChains}, chains, "unsupported chains")
 
 	numNodes := flagVars.NodeCount()
 
@@ -67,9 +80,6 @@ func main() {
 	e2e.NewTestEnvironment(tc, flagVars, network)
 
 	ctx := tests.DefaultNotifyContext(0, tc.DeferCleanup)
-	wsURIs, err := tmpnet.GetNodeWebsocketURIs(network.Nodes, blockchainID)
-	require.NoError(err)
-
 	registry := prometheus.NewRegistry()
 	metricsServer, err := tests.NewPrometheusServer(registry)
 	require.NoError(err)
@@ -90,6 +100,25 @@ func main() {
 		)
 	})
 
+	if chains == primaryChains {
+		runPrimary(ctx, tc, network, keys, registry)
+		return
+	}
+	runCChain(ctx, tc, network, keys, registry)
+}
+
+func runCChain(
+	ctx context.Context,
+	tc tests.TestContext,
+	network *tmpnet.Network,
+	keys []*secp256k1.PrivateKey,
+	registry *prometheus.Registry,
+) {
+	require := require.New(tc)
+
+	wsURIs, err := tmpnet.GetNodeWebsocketURIs(network.Nodes, blockchainID)
+	require.NoError(err)
+
 	workers := make([]load.Worker, len(keys))
 	for i := range len(keys) {
 		wsURI := wsURIs[i%len(wsURIs)]
@@ -122,5 +151,38 @@ func main() {
 	)
 	require.NoError(err)
 
-	generator.Run(ctx, log, loadTimeout, testTimeout)
+	generator.Run(ctx, tc.Log(), loadTimeout, testTimeout)
+}
+
+func runPrimary(
+	ctx context.Context,
+	tc tests.TestContext,
+	network *tmpnet.Network,
+	keys []*secp256k1.PrivateKey,
+	registry *prometheus.Registry,
+) {
+	require := require.New(tc)
+
+	nodeURIs := tmpnet.GetNodeURIs(network.Nodes)
+	workers := make([]load.PrimaryWorker, len(keys))
+	for i := range len(keys) {
+		workers[i] = load.PrimaryWorker{
+			Key: keys[i],
+			URI: nodeURIs[i%len(nodeURIs)].URI,
+		}
+	}
+
+	randomTest, err := load.NewRandomPrimaryTest(rand.NewSource(time.Now().UnixMilli()))
+	require.NoError(err)
+
+	generator, err := load.NewPrimaryLoadGenerator(
+		ctx,
+		workers,
+		metricsNamespace,
+		registry,
+		randomTest,
+	)
+	require.NoError(err)
+
+	generator.Run(ctx, tc.Log(), loadTimeout, testTimeout)
 }
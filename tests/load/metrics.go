@@ -62,3 +62,70 @@ func (m metrics) accept(confirmationDuration time.Duration, totalDuration time.D
 	m.txConfirmationLatency.Observe(float64(confirmationDuration.Milliseconds()))
 	m.txTotalLatency.Observe(float64(totalDuration.Milliseconds()))
 }
+
+// workloadMetrics track transactions by the chain they were issued to and the
+// workload that issued them.
+type workloadMetrics struct {
+	txsIssuedCounter      *prometheus.CounterVec
+	txsFailedCounter      *prometheus.CounterVec
+	txIssuanceLatency     *prometheus.HistogramVec
+	txConfirmationLatency *prometheus.HistogramVec
+	txTotalLatency        *prometheus.HistogramVec
+}
+
+func newWorkloadMetrics(namespace string, registry *prometheus.Registry) (workloadMetrics, error) {
+	labels := []string{"chain", "workload"}
+	m := workloadMetrics{
+		txsIssuedCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "workload_txs_issued",
+			Help:      "Number of transactions issued per workload",
+		}, labels),
+		txsFailedCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "workload_txs_failed",
+			Help:      "Number of transactions that failed to be issued or accepted per workload",
+		}, labels),
+		txIssuanceLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "workload_tx_issuance_latency",
+			Help:      "Issuance latency of transactions per workload",
+		}, labels),
+		txConfirmationLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "workload_tx_confirmation_latency",
+			Help:      "Confirmation latency of transactions per workload",
+		}, labels),
+		txTotalLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "workload_tx_total_latency",
+			Help:      "Total latency of transactions per workload",
+		}, labels),
+	}
+
+	if err := errors.Join(
+		registry.Register(m.txsIssuedCounter),
+		registry.Register(m.txsFailedCounter),
+		registry.Register(m.txIssuanceLatency),
+		registry.Register(m.txConfirmationLatency),
+		registry.Register(m.txTotalLatency),
+	); err != nil {
+		return workloadMetrics{}, err
+	}
+
+	return m, nil
+}
+
+func (m workloadMetrics) issue(chain string, workload string, d time.Duration) {
+	m.txsIssuedCounter.WithLabelValues(chain, workload).Inc()
+	m.txIssuanceLatency.WithLabelValues(chain, workload).Observe(float64(d.Milliseconds()))
+}
+
+func (m workloadMetrics) accept(chain string, workload string, confirmationDuration time.Duration, totalDuration time.Duration) {
+	m.txConfirmationLatency.WithLabelValues(chain, workload).Observe(float64(confirmationDuration.Milliseconds()))
+	m.txTotalLatency.WithLabelValues(chain, workload).Observe(float64(totalDuration.Milliseconds()))
+}
+
+func (m workloadMetrics) fail(chain string, workload string) {
+	m.txsFailedCounter.WithLabelValues(chain, workload).Inc()
+}
@@ -0,0 +1,368 @@
+// Copyright (C) 2019-2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"crypto/rand"
+	"math"
+	mathrand "math/rand"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/tests"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/utils/constants"
+	"github.com/luxfi/node/utils/units"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/components/verify"
+	"github.com/luxfi/node/vms/nftfx"
+	"github.com/luxfi/node/vms/platformvm"
+	"github.com/luxfi/node/vms/platformvm/signer"
+	"github.com/luxfi/node/vms/secp256k1fx"
+	"github.com/luxfi/node/wallet/subnet/primary/common"
+
+	xsgenesis "github.com/luxfi/node/vms/example/xsvm/genesis"
+	ptxs "github.com/luxfi/node/vms/platformvm/txs"
+	warpmessage "github.com/luxfi/node/vms/platformvm/warp/message"
+	xtxs "github.com/luxfi/node/vms/xvm/txs"
+	xbuilder "github.com/luxfi/node/wallet/chain/x/builder"
+)
+
+// Supply of the asset transferred by XAssetTransferTest. Since transfers are
+// sent back to the wallet, the supply only needs to cover a single transfer.
+const xAssetSupply = units.MegaLux
+
+// NewRandomPrimaryTest creates a RandomWeightedTest containing a collection of
+// P-Chain and X-Chain load testing scenarios.
+//
+// Each test is assigned an equal weight, making them equally likely to be
+// selected during random test execution.
+func NewRandomPrimaryTest(source mathrand.Source) (*RandomWeightedTest[*PrimaryWallet], error) {
+	var (
+		// weight determines the relative probability of each test being selected
+		// during random test execution.
+		weight = uint64(100)
+		// amount specifies the amount to send in a transfer test
+		amount = units.Schmeckle
+		// balance specifies the initial balance of L1 validators
+		balance = units.Lux
+	)
+
+	weightedTests := []WeightedTest[*PrimaryWallet]{
+		{
+			Test:   XBaseTxTest{Amount: amount},
+			Weight: weight,
+		},
+		{
+			Test:   XCreateAssetTest{Supply: xAssetSupply},
+			Weight: weight,
+		},
+		{
+			Test:   XAssetTransferTest{Amount: amount},
+			Weight: weight,
+		},
+		{
+			Test:   XNFTTest{Payload: []byte("load")},
+			Weight: weight,
+		},
+		{
+			Test:   PBaseTxTest{Amount: amount},
+			Weight: weight,
+		},
+		{
+			Test:   PCreateSubnetTest{},
+			Weight: weight,
+		},
+		{
+			Test:   PSubnetValidatorTest{},
+			Weight: weight,
+		},
+		{
+			Test:   L1Test{Balance: balance},
+			Weight: weight,
+		},
+	}
+
+	return NewRandomWeightedTest(weightedTests, source)
+}
+
+// XBaseTxTest transfers LUX to the wallet on the X-Chain.
+type XBaseTxTest struct {
+	Amount uint64
+}
+
+func (t XBaseTxTest) Run(tc tests.TestContext, wallet *PrimaryWallet) {
+	require := require.New(tc)
+
+	luxAssetID := wallet.X().Builder().Context().LUXAssetID
+	_, err := wallet.IssueXTx(tc.GetDefaultContextParent(), "base", func(options ...common.Option) (*xtxs.Tx, error) {
+		return wallet.X().IssueBaseTx(
+			[]*lux.TransferableOutput{newTransferableOutput(luxAssetID, t.Amount, wallet.owner)},
+			options...,
+		)
+	})
+	require.NoError(err)
+}
+
+// XCreateAssetTest creates a new fungible asset on the X-Chain.
+type XCreateAssetTest struct {
+	Supply uint64
+}
+
+func (t XCreateAssetTest) Run(tc tests.TestContext, wallet *PrimaryWallet) {
+	_, err := createXAsset(tc, wallet, t.Supply)
+	require.NoError(tc, err)
+}
+
+// XAssetTransferTest transfers a fungible asset other than LUX to the wallet
+// on the X-Chain. The asset is created by the first run of the test.
+type XAssetTransferTest struct {
+	Amount uint64
+}
+
+func (t XAssetTransferTest) Run(tc tests.TestContext, wallet *PrimaryWallet) {
+	require := require.New(tc)
+
+	if wallet.assetID == ids.Empty {
+		tx, err := createXAsset(tc, wallet, xAssetSupply)
+		require.NoError(err)
+		wallet.assetID = tx.ID()
+	}
+
+	_, err := wallet.IssueXTx(tc.GetDefaultContextParent(), "asset_transfer", func(options ...common.Option) (*xtxs.Tx, error) {
+		return wallet.X().IssueBaseTx(
+			[]*lux.TransferableOutput{newTransferableOutput(wallet.assetID, t.Amount, wallet.owner)},
+			options...,
+		)
+	})
+	require.NoError(err)
+}
+
+// XNFTTest creates a new NFT asset on the X-Chain and mints an NFT of it.
+type XNFTTest struct {
+	Payload []byte
+}
+
+func (t XNFTTest) Run(tc tests.TestContext, wallet *PrimaryWallet) {
+	require := require.New(tc)
+
+	ctx := tc.GetDefaultContextParent()
+	createAssetTx, err := wallet.IssueXTx(ctx, "create_nft_asset", func(options ...common.Option) (*xtxs.Tx, error) {
+		return wallet.X().IssueCreateAssetTx(
+			"LOAD",
+			"LOAD",
+			0,
+			map[uint32][]verify.State{
+				xbuilder.NFTFxIndex: {
+					&nftfx.MintOutput{
+						OutputOwners: *wallet.owner,
+					},
+				},
+			},
+			options...,
+		)
+	})
+	require.NoError(err)
+
+	_, err = wallet.IssueXTx(ctx, "mint_nft", func(options ...common.Option) (*xtxs.Tx, error) {
+		return wallet.X().IssueOperationTxMintNFT(
+			createAssetTx.ID(),
+			t.Payload,
+			[]*secp256k1fx.OutputOwners{wallet.owner},
+			options...,
+		)
+	})
+	require.NoError(err)
+}
+
+// PBaseTxTest transfers LUX to the wallet on the P-Chain.
+type PBaseTxTest struct {
+	Amount uint64
+}
+
+func (t PBaseTxTest) Run(tc tests.TestContext, wallet *PrimaryWallet) {
+	require := require.New(tc)
+
+	luxAssetID := wallet.P().Builder().Context().LUXAssetID
+	_, err := wallet.IssuePTx(tc.GetDefaultContextParent(), "base", func(options ...common.Option) (*ptxs.Tx, error) {
+		return wallet.P().IssueBaseTx(
+			[]*lux.TransferableOutput{newTransferableOutput(luxAssetID, t.Amount, wallet.owner)},
+			options...,
+		)
+	})
+	require.NoError(err)
+}
+
+// PCreateSubnetTest creates a new subnet owned by the wallet.
+type PCreateSubnetTest struct{}
+
+func (PCreateSubnetTest) Run(tc tests.TestContext, wallet *PrimaryWallet) {
+	_, err := createSubnet(tc, wallet)
+	require.NoError(tc, err)
+}
+
+// PSubnetValidatorTest adds a validator of the primary network to a subnet
+// of the wallet and removes it again. The subnet is created by the first run
+// of the test.
+type PSubnetValidatorTest struct{}
+
+func (PSubnetValidatorTest) Run(tc tests.TestContext, wallet *PrimaryWallet) {
+	require := require.New(tc)
+
+	if wallet.subnetID == ids.Empty {
+		tx, err := createSubnet(tc, wallet)
+		require.NoError(err)
+		wallet.subnetID = tx.ID()
+	}
+
+	ctx := tc.GetDefaultContextParent()
+	validators, err := platformvm.NewClient(wallet.uri).GetCurrentValidators(ctx, constants.PrimaryNetworkID, nil)
+	require.NoError(err)
+	require.NotEmpty(validators)
+	validator := validators[0]
+
+	_, err = wallet.IssuePTx(ctx, "add_subnet_validator", func(options ...common.Option) (*ptxs.Tx, error) {
+		return wallet.P().IssueAddSubnetValidatorTx(
+			&ptxs.SubnetValidator{
+				Validator: ptxs.Validator{
+					NodeID: validator.NodeID,
+					Start:  uint64(time.Now().Add(tmpnet.DefaultValidatorStartTimeDiff).Unix()),
+					End:    validator.EndTime,
+					Wght:   tmpnet.DefaultValidatorWeight,
+				},
+				Subnet: wallet.subnetID,
+			},
+			options...,
+		)
+	})
+	require.NoError(err)
+
+	_, err = wallet.IssuePTx(ctx, "remove_subnet_validator", func(options ...common.Option) (*ptxs.Tx, error) {
+		return wallet.P().IssueRemoveSubnetValidatorTx(validator.NodeID, wallet.subnetID, options...)
+	})
+	require.NoError(err)
+}
+
+// L1Test runs through the lifecycle of an L1: a subnet and chain are created,
+// the subnet is converted to an L1 with a single validator, and the validator
+// is topped up and then disabled. The validator isn't backed by a node, so
+// the L1 never becomes active.
+type L1Test struct {
+	Balance uint64
+}
+
+func (t L1Test) Run(tc tests.TestContext, wallet *PrimaryWallet) {
+	require := require.New(tc)
+
+	subnetTx, err := createSubnet(tc, wallet)
+	require.NoError(err)
+	subnetID := subnetTx.ID()
+
+	genesisBytes, err := xsgenesis.Codec.Marshal(xsgenesis.CodecVersion, &xsgenesis.Genesis{
+		Timestamp: time.Now().Unix(),
+		Allocations: []xsgenesis.Allocation{
+			{
+				Address: wallet.owner.Addrs[0],
+				Balance: math.MaxUint64,
+			},
+		},
+	})
+	require.NoError(err)
+
+	ctx := tc.GetDefaultContextParent()
+	chainTx, err := wallet.IssuePTx(ctx, "create_chain", func(options ...common.Option) (*ptxs.Tx, error) {
+		return wallet.P().IssueCreateChainTx(
+			subnetID,
+			genesisBytes,
+			constants.XSVMID,
+			nil,
+			"load",
+			options...,
+		)
+	})
+	require.NoError(err)
+
+	var nodeID ids.NodeID
+	_, err = rand.Read(nodeID[:])
+	require.NoError(err)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	owner := warpmessage.PChainOwner{
+		Threshold: wallet.owner.Threshold,
+		Addresses: wallet.owner.Addrs,
+	}
+
+	_, err = wallet.IssuePTx(ctx, "convert_subnet_to_l1", func(options ...common.Option) (*ptxs.Tx, error) {
+		return wallet.P().IssueConvertSubnetToL1Tx(
+			subnetID,
+			chainTx.ID(),
+			nil,
+			[]*ptxs.ConvertSubnetToL1Validator{
+				{
+					NodeID:                nodeID.Bytes(),
+					Weight:                tmpnet.DefaultValidatorWeight,
+					Balance:               t.Balance,
+					Signer:                *signer.NewProofOfPossession(sk),
+					RemainingBalanceOwner: owner,
+					DeactivationOwner:     owner,
+				},
+			},
+			options...,
+		)
+	})
+	require.NoError(err)
+
+	// The validation ID of a conversion validator is derived from its index
+	validationID := subnetID.Append(0)
+
+	_, err = wallet.IssuePTx(ctx, "increase_l1_validator_balance", func(options ...common.Option) (*ptxs.Tx, error) {
+		return wallet.P().IssueIncreaseL1ValidatorBalanceTx(validationID, t.Balance, options...)
+	})
+	require.NoError(err)
+
+	_, err = wallet.IssuePTx(ctx, "disable_l1_validator", func(options ...common.Option) (*ptxs.Tx, error) {
+		return wallet.P().IssueDisableL1ValidatorTx(validationID, options...)
+	})
+	require.NoError(err)
+}
+
+func createXAsset(tc tests.TestContext, wallet *PrimaryWallet, supply uint64) (*xtxs.Tx, error) {
+	return wallet.IssueXTx(tc.GetDefaultContextParent(), "create_asset", func(options ...common.Option) (*xtxs.Tx, error) {
+		return wallet.X().IssueCreateAssetTx(
+			"LOAD",
+			"LOAD",
+			9,
+			map[uint32][]verify.State{
+				xbuilder.SECP256K1FxIndex: {
+					&secp256k1fx.TransferOutput{
+						Amt:          supply,
+						OutputOwners: *wallet.owner,
+					},
+				},
+			},
+			options...,
+		)
+	})
+}
+
+func createSubnet(tc tests.TestContext, wallet *PrimaryWallet) (*ptxs.Tx, error) {
+	return wallet.IssuePTx(tc.GetDefaultContextParent(), "create_subnet", func(options ...common.Option) (*ptxs.Tx, error) {
+		return wallet.P().IssueCreateSubnetTx(wallet.owner, options...)
+	})
+}
+
+func newTransferableOutput(assetID ids.ID, amount uint64, owner *secp256k1fx.OutputOwners) *lux.TransferableOutput {
+	return &lux.TransferableOutput{
+		Asset: lux.Asset{
+			ID: assetID,
+		},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          amount,
+			OutputOwners: *owner,
+		},
+	}
+}
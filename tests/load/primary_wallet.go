@@ -0,0 +1,138 @@
+// Copyright (C) 2019-2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package load
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/luxfi/crypto/secp256k1"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/vms/secp256k1fx"
+	"github.com/luxfi/node/wallet/chain/p"
+	"github.com/luxfi/node/wallet/chain/x"
+	"github.com/luxfi/node/wallet/keychain"
+	"github.com/luxfi/node/wallet/subnet/primary"
+	"github.com/luxfi/node/wallet/subnet/primary/common"
+
+	ptxs "github.com/luxfi/node/vms/platformvm/txs"
+	xtxs "github.com/luxfi/node/vms/xvm/txs"
+)
+
+const (
+	pChainLabel = "P"
+	xChainLabel = "X"
+)
+
+// PrimaryWallet issues P-Chain and X-Chain transactions for a single account.
+// Every transaction is awaited until it is accepted, and its latency is
+// recorded against the workload that issued it.
+type PrimaryWallet struct {
+	owner   *secp256k1fx.OutputOwners
+	uri     string
+	wallet  primary.Wallet
+	metrics workloadMetrics
+
+	// Created on first use by the tests that depend on them
+	assetID  ids.ID
+	subnetID ids.ID
+}
+
+func newPrimaryWallet(
+	ctx context.Context,
+	key *secp256k1.PrivateKey,
+	uri string,
+	metrics workloadMetrics,
+) (*PrimaryWallet, error) {
+	kc := keychain.NewWalletKeychain(secp256k1fx.NewKeychain(key))
+	wallet, err := primary.MakeWallet(ctx, &primary.WalletConfig{
+		URI:         uri,
+		LUXKeychain: kc,
+		EthKeychain: kc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize wallet for %s: %w", key.Address(), err)
+	}
+
+	return &PrimaryWallet{
+		owner: &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{key.Address()},
+		},
+		uri:     uri,
+		wallet:  wallet,
+		metrics: metrics,
+	}, nil
+}
+
+// P returns the P-Chain wallet of the account.
+func (w *PrimaryWallet) P() p.Wallet {
+	return w.wallet.P()
+}
+
+// X returns the X-Chain wallet of the account.
+func (w *PrimaryWallet) X() x.Wallet {
+	return w.wallet.X()
+}
+
+// Owner returns an output owner consisting of the address of the account.
+func (w *PrimaryWallet) Owner() *secp256k1fx.OutputOwners {
+	return w.owner
+}
+
+// IssuePTx issues the P-Chain transaction created by issueFunc and waits for
+// it to be accepted. The options passed to issueFunc must be forwarded to the
+// wallet to measure the latency of the transaction.
+func (w *PrimaryWallet) IssuePTx(
+	ctx context.Context,
+	workload string,
+	issueFunc func(options ...common.Option) (*ptxs.Tx, error),
+) (*ptxs.Tx, error) {
+	return issueTx(ctx, w.metrics, pChainLabel, workload, issueFunc)
+}
+
+// IssueXTx issues the X-Chain transaction created by issueFunc and waits for
+// it to be accepted. The options passed to issueFunc must be forwarded to the
+// wallet to measure the latency of the transaction.
+func (w *PrimaryWallet) IssueXTx(
+	ctx context.Context,
+	workload string,
+	issueFunc func(options ...common.Option) (*xtxs.Tx, error),
+) (*xtxs.Tx, error) {
+	return issueTx(ctx, w.metrics, xChainLabel, workload, issueFunc)
+}
+
+// issueTx measures issuance latency up to the point the node has accepted the
+// transaction into its mempool and confirmation latency from then until the
+// wallet has observed the transaction being accepted.
+func issueTx[T any](
+	ctx context.Context,
+	metrics workloadMetrics,
+	chain string,
+	workload string,
+	issueFunc func(options ...common.Option) (T, error),
+) (T, error) {
+	var (
+		startTime        = time.Now()
+		issuanceDuration time.Duration
+	)
+	tx, err := issueFunc(
+		common.WithContext(ctx),
+		common.WithPostIssuanceFunc(func(ids.ID) {
+			issuanceDuration = time.Since(startTime)
+			metrics.issue(chain, workload, issuanceDuration)
+		}),
+	)
+	if err != nil {
+		metrics.fail(chain, workload)
+		return tx, fmt.Errorf("failed to issue %s %s-Chain tx: %w", workload, chain, err)
+	}
+
+	totalDuration := time.Since(startTime)
+	confirmationDuration := totalDuration - issuanceDuration
+	metrics.accept(chain, workload, confirmationDuration, totalDuration)
+
+	return tx, nil
+}
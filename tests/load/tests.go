@@ -36,7 +36,7 @@ func NewRandomTest(
 	chainID *big.Int,
 	worker *Worker,
 	source rand.Source,
-) (*RandomWeightedTest, error) {
+) (*RandomWeightedTest[*Wallet], error) {
 	txOpts, err := bind.NewKeyedTransactorWithChainID(worker.PrivKey, chainID)
 	if err != nil {
 		return nil, err
@@ -79,7 +79,7 @@ func NewRandomTest(
 		value = big.NewInt(1)
 	)
 
-	weightedTests := []WeightedTest{
+	weightedTests := []WeightedTest[*Wallet]{
 		{
 			Test:   TransferTest{Value: value},
 			Weight: weight,
@@ -160,8 +160,8 @@ func NewRandomTest(
 	return NewRandomWeightedTest(weightedTests, source)
 }
 
-type RandomWeightedTest struct {
-	tests       []Test
+type RandomWeightedTest[W any] struct {
+	tests       []Test[W]
 	weighted    sampler.Weighted
 	totalWeight int64
 
@@ -169,14 +169,14 @@ type RandomWeightedTest struct {
 	rand *rand.Rand
 }
 
-func NewRandomWeightedTest(
-	weightedTests []WeightedTest,
+func NewRandomWeightedTest[W any](
+	weightedTests []WeightedTest[W],
 	source rand.Source,
-) (*RandomWeightedTest, error) {
+) (*RandomWeightedTest[W], error) {
 	weighted := sampler.NewWeighted()
 
 	// Initialize weighted set
-	tests := make([]Test, len(weightedTests))
+	tests := make([]Test[W], len(weightedTests))
 	weights := make([]uint64, len(weightedTests))
 	totalWeight := uint64(0)
 	for i, w := range weightedTests {
@@ -198,7 +198,7 @@ func NewRandomWeightedTest(
 
 	rand := rand.New(source) //#nosec G404
 
-	return &RandomWeightedTest{
+	return &RandomWeightedTest[W]{
 		tests:       tests,
 		weighted:    weighted,
 		totalWeight: int64(totalWeight),
@@ -206,7 +206,7 @@ func NewRandomWeightedTest(
 	}, nil
 }
 
-func (r *RandomWeightedTest) Run(tc tests.TestContext, wallet *Wallet) {
+func (r *RandomWeightedTest[W]) Run(tc tests.TestContext, wallet W) {
 	require := require.New(tc)
 
 	r.mu.Lock()
@@ -219,8 +219,8 @@ func (r *RandomWeightedTest) Run(tc tests.TestContext, wallet *Wallet) {
 	r.tests[index].Run(tc, wallet)
 }
 
-type WeightedTest struct {
-	Test   Test
+type WeightedTest[W any] struct {
+	Test   Test[W]
 	Weight uint64
 }
 
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build test
+
+package upgrade
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/tests/fixture/e2e"
+	"github.com/luxfi/node/tests/fixture/tmpnet"
+	"github.com/luxfi/node/tests/fixture/upgraderehearsal"
+	"github.com/luxfi/node/upgrade/upgradetest"
+)
+
+var (
+	rehearsalFork            string
+	rehearsalActivationDelay time.Duration
+	rehearsalWaveSize        int
+	rehearsalWavesBefore     int
+)
+
+func init() {
+	flag.StringVar(
+		&rehearsalFork,
+		"rehearsal-fork",
+		upgradetest.Latest.String(),
+		"network upgrade to rehearse",
+	)
+	flag.DurationVar(
+		&rehearsalActivationDelay,
+		"rehearsal-activation-delay",
+		5*time.Minute,
+		"delay between starting the network and activating the rehearsed upgrade",
+	)
+	flag.IntVar(
+		&rehearsalWaveSize,
+		"rehearsal-wave-size",
+		2,
+		"number of nodes upgraded together",
+	)
+	flag.IntVar(
+		&rehearsalWavesBefore,
+		"rehearsal-waves-before-activation",
+		1,
+		"number of waves upgraded before the rehearsed upgrade activates",
+	)
+}
+
+var _ = ginkgo.Describe("[Upgrade Rehearsal]", func() {
+	require := require.New(ginkgo.GinkgoT())
+
+	ginkgo.It("can roll nodes onto a new binary around a scheduled upgrade", func() {
+		fork, err := upgraderehearsal.ParseFork(rehearsalFork)
+		require.NoError(err)
+
+		network := tmpnet.NewDefaultNetwork("node-upgrade-rehearsal")
+		network.DefaultFlags = tmpnet.FlagsMap{}
+
+		// The schedule is only honored by binaries supporting the upgrade file
+		// content flag. It is ignored by older binaries.
+		config := upgraderehearsal.Config{
+			NewNodePath:            luxNodeExecPathToUpgradeTo,
+			Fork:                   fork,
+			ActivationTime:         time.Now().Add(rehearsalActivationDelay).Truncate(time.Second),
+			Waves:                  newWaves(network.Nodes, rehearsalWaveSize, rehearsalWavesBefore),
+			CheckMismatchDetection: true,
+		}
+		require.NoError(upgraderehearsal.SetSchedule(network.DefaultFlags, config.Schedule()))
+
+		rehearsal, err := upgraderehearsal.NewRehearsal(network, config)
+		require.NoError(err)
+
+		ginkgo.By(fmt.Sprintf("starting the network with %s scheduled at %s", fork, config.ActivationTime.Format(time.RFC3339)))
+		e2e.StartNetwork(network, luxNodeExecPath, "" /* pluginDir */, 0 /* shutdownDelay */, false /* reuseNetwork */)
+
+		ginkgo.By(fmt.Sprintf("rolling nodes onto %q", luxNodeExecPathToUpgradeTo))
+		ctx := e2e.ContextWithTimeout(rehearsalActivationDelay + 2*e2e.DefaultTimeout*time.Duration(len(network.Nodes)))
+		require.NoError(rehearsal.Run(ctx, ginkgo.GinkgoWriter))
+
+		e2e.CheckBootstrapIsPossible(network)
+	})
+})
+
+// newWaves splits [nodes] into waves of [waveSize] nodes, the first
+// [wavesBefore] of which are upgraded before activation.
+func newWaves(nodes []*tmpnet.Node, waveSize int, wavesBefore int) []upgraderehearsal.Wave {
+	var waves []upgraderehearsal.Wave
+	for start := 0; start < len(nodes); start += waveSize {
+		end := min(start+waveSize, len(nodes))
+		waves = append(waves, upgraderehearsal.Wave{
+			Nodes:           nodes[start:end],
+			AfterActivation: len(waves) >= wavesBefore,
+		})
+	}
+	return waves
+}
@@ -4,7 +4,10 @@
 package upgrade
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -12,8 +15,14 @@ var (
 	// InitiallyActiveTime is the time that upgrades are initially active
 	InitiallyActiveTime = time.Unix(0, 0)
 
-	// UnscheduledActivationTime is the time that upgrades are scheduled to be inactive
-	UnscheduledActivationTime = time.Unix(1<<63-1, 0)
+	// UnscheduledActivationTime is the time that upgrades are scheduled to be
+	// inactive.
+	//
+	// time.Unix(1<<63-1, 0) overflows the internal representation of
+	// time.Time, which makes it compare as before InitiallyActiveTime and fail
+	// to encode as JSON. A time in 9999, the last year encodable as JSON, is used
+	// instead.
+	UnscheduledActivationTime = time.Date(9999, time.December, 1, 0, 0, 0, 0, time.UTC)
 
 	// ErrInvalidUpgradeTimes is returned when upgrade times are invalid
 	ErrInvalidUpgradeTimes = errors.New("invalid upgrade configuration")
@@ -22,23 +31,71 @@ var (
 // Config contains the upgrade configuration
 type Config struct {
 	// Time when the upgrade should take effect
-	ActivationTime time.Time
+	ActivationTime time.Time `json:"activationTime"`
 
 	// Individual upgrade times
-	ApricotPhase1Time     time.Time
-	ApricotPhase2Time     time.Time
-	ApricotPhase3Time     time.Time
-	ApricotPhase4Time     time.Time
-	ApricotPhase5Time     time.Time
-	ApricotPhasePre6Time  time.Time
-	ApricotPhase6Time     time.Time
-	ApricotPhasePost6Time time.Time
-	BanffTime             time.Time
-	CortinaTime           time.Time
-	DurangoTime           time.Time
-	EtnaTime              time.Time
-	FortunaTime           time.Time
-	GraniteTime           time.Time
+	ApricotPhase1Time     time.Time `json:"apricotPhase1Time"`
+	ApricotPhase2Time     time.Time `json:"apricotPhase2Time"`
+	ApricotPhase3Time     time.Time `json:"apricotPhase3Time"`
+	ApricotPhase4Time     time.Time `json:"apricotPhase4Time"`
+	ApricotPhase5Time     time.Time `json:"apricotPhase5Time"`
+	ApricotPhasePre6Time  time.Time `json:"apricotPhasePre6Time"`
+	ApricotPhase6Time     time.Time `json:"apricotPhase6Time"`
+	ApricotPhasePost6Time time.Time `json:"apricotPhasePost6Time"`
+	BanffTime             time.Time `json:"banffTime"`
+	CortinaTime           time.Time `json:"cortinaTime"`
+	DurangoTime           time.Time `json:"durangoTime"`
+	EtnaTime              time.Time `json:"etnaTime"`
+	FortunaTime           time.Time `json:"fortunaTime"`
+	GraniteTime           time.Time `json:"graniteTime"`
+}
+
+// Validate returns an error if the upgrades are not scheduled in order.
+func (c *Config) Validate() error {
+	upgrades := c.forkOrder()
+	for i := 0; i < len(upgrades)-1; i++ {
+		if upgrades[i].After(upgrades[i+1]) {
+			return fmt.Errorf("%w: upgrade %d (%s) is after upgrade %d (%s)",
+				ErrInvalidUpgradeTimes,
+				i,
+				upgrades[i],
+				i+1,
+				upgrades[i+1],
+			)
+		}
+	}
+	return nil
+}
+
+// Hash returns a digest of the full upgrade schedule. Schedules that activate
+// every upgrade at the same second have the same hash.
+func (c *Config) Hash() [sha256.Size]byte {
+	upgrades := c.forkOrder()
+	bytes := make([]byte, 0, len(upgrades)*8)
+	for _, upgradeTime := range upgrades {
+		bytes = binary.BigEndian.AppendUint64(bytes, uint64(upgradeTime.Unix()))
+	}
+	return sha256.Sum256(bytes)
+}
+
+// forkOrder returns the upgrade times in the order the upgrades must activate.
+func (c *Config) forkOrder() []time.Time {
+	return []time.Time{
+		c.ApricotPhase1Time,
+		c.ApricotPhase2Time,
+		c.ApricotPhase3Time,
+		c.ApricotPhase4Time,
+		c.ApricotPhase5Time,
+		c.ApricotPhasePre6Time,
+		c.ApricotPhase6Time,
+		c.ApricotPhasePost6Time,
+		c.BanffTime,
+		c.CortinaTime,
+		c.DurangoTime,
+		c.EtnaTime,
+		c.FortunaTime,
+		c.GraniteTime,
+	}
 }
 
 // Default upgrade configuration with all upgrades initially active
@@ -4,6 +4,7 @@
 package upgrade
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -46,3 +47,72 @@ func TestInvalidUpgrade(t *testing.T) {
 	err := upgrade.Validate()
 	require.ErrorIs(err, ErrInvalidUpgradeTimes)
 }
+
+func TestUnscheduledActivationTime(t *testing.T) {
+	require := require.New(t)
+
+	// The maximum Unix time overflows time.Time and can't be used.
+	require.False(time.Unix(1<<63-1, 0).After(InitiallyActiveTime))
+
+	require.True(UnscheduledActivationTime.After(InitiallyActiveTime))
+	require.True(UnscheduledActivationTime.After(time.Now().AddDate(1_000, 0, 0)))
+
+	timeJSON, err := json.Marshal(UnscheduledActivationTime)
+	require.NoError(err)
+	var decoded time.Time
+	require.NoError(json.Unmarshal(timeJSON, &decoded))
+	require.True(UnscheduledActivationTime.Equal(decoded))
+}
+
+func TestHash(t *testing.T) {
+	upgradeTime := time.Unix(1_700_000_000, 0)
+	schedule := Config{
+		ApricotPhase1Time: InitiallyActiveTime,
+		DurangoTime:       upgradeTime,
+		EtnaTime:          UnscheduledActivationTime,
+	}
+
+	tests := []struct {
+		name          string
+		upgrade       Config
+		expectedEqual bool
+	}{
+		{
+			name:          "same schedule",
+			upgrade:       schedule,
+			expectedEqual: true,
+		},
+		{
+			name: "sub-second difference",
+			upgrade: Config{
+				ApricotPhase1Time: InitiallyActiveTime,
+				DurangoTime:       upgradeTime.Add(time.Millisecond),
+				EtnaTime:          UnscheduledActivationTime,
+			},
+			expectedEqual: true,
+		},
+		{
+			name: "different earlier upgrade",
+			upgrade: Config{
+				ApricotPhase1Time: upgradeTime,
+				DurangoTime:       upgradeTime,
+				EtnaTime:          UnscheduledActivationTime,
+			},
+			expectedEqual: false,
+		},
+		{
+			name: "different unscheduled upgrade",
+			upgrade: Config{
+				ApricotPhase1Time: InitiallyActiveTime,
+				DurangoTime:       upgradeTime,
+				EtnaTime:          upgradeTime.Add(time.Hour),
+			},
+			expectedEqual: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedEqual, schedule.Hash() == test.upgrade.Hash())
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gorilla/rpc/v2"
+)
+
+var (
+	typeOfError   = reflect.TypeOf((*error)(nil)).Elem()
+	typeOfRequest = reflect.TypeOf((*http.Request)(nil)).Elem()
+)
+
+// Method describes a JSON-RPC method registered with a [Server].
+type Method struct {
+	// Name is the name the method is called with, e.g. "info.getNodeID".
+	Name string
+	// Args and Reply are the types of the method's arguments and reply.
+	Args  reflect.Type
+	Reply reflect.Type
+}
+
+// Server is an [rpc.Server] that records the methods of the services
+// registered with it, so that they can be described to clients.
+type Server struct {
+	*rpc.Server
+
+	lock    sync.RWMutex
+	methods []Method
+}
+
+// NewServer returns a new [Server].
+func NewServer() *Server {
+	return &Server{
+		Server: rpc.NewServer(),
+	}
+}
+
+// RegisterService registers [receiver] as a service named [name]. Only the
+// methods of [receiver] that satisfy the requirements of [rpc.Server] are
+// recorded.
+func (s *Server) RegisterService(receiver interface{}, name string) error {
+	if err := s.Server.RegisterService(receiver, name); err != nil {
+		return err
+	}
+
+	methods := serviceMethods(reflect.TypeOf(receiver), name)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.methods = append(s.methods, methods...)
+	sort.Slice(s.methods, func(i, j int) bool {
+		return s.methods[i].Name < s.methods[j].Name
+	})
+	return nil
+}
+
+// Methods returns the registered methods, sorted by name.
+func (s *Server) Methods() []Method {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return append([]Method(nil), s.methods...)
+}
+
+// serviceMethods returns the methods of [receiverType] that [rpc.Server]
+// exposes. That is, exported methods of the form:
+//
+//	func (*Service) Method(*http.Request, *Args, *Reply) error
+//
+// The first character of the method is lowercased to match the name clients
+// use with the codec returned by [NewCodec].
+func serviceMethods(receiverType reflect.Type, service string) []Method {
+	var methods []Method
+	for i := 0; i < receiverType.NumMethod(); i++ {
+		method := receiverType.Method(i)
+		methodType := method.Type
+		if method.PkgPath != "" ||
+			methodType.NumIn() != 4 ||
+			methodType.NumOut() != 1 ||
+			methodType.Out(0) != typeOfError {
+			continue
+		}
+
+		reqType := methodType.In(1)
+		argsType := methodType.In(2)
+		replyType := methodType.In(3)
+		if reqType.Kind() != reflect.Ptr || reqType.Elem() != typeOfRequest ||
+			argsType.Kind() != reflect.Ptr || !isExportedOrBuiltin(argsType) ||
+			replyType.Kind() != reflect.Ptr || !isExportedOrBuiltin(replyType) {
+			continue
+		}
+
+		methods = append(methods, Method{
+			Name:  service + "." + lowercaseFirst(method.Name),
+			Args:  argsType.Elem(),
+			Reply: replyType.Elem(),
+		})
+	}
+	return methods
+}
+
+func isExportedOrBuiltin(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" {
+		return true
+	}
+	firstRune, _ := utf8.DecodeRuneInString(t.Name())
+	return unicode.IsUpper(firstRune)
+}
+
+func lowercaseFirst(s string) string {
+	firstRune, runeLen := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(firstRune)) + s[runeLen:]
+}
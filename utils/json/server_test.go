@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type TestArgs struct {
+	Value string `json:"value"`
+}
+
+type TestReply struct {
+	Value string `json:"value"`
+}
+
+type testService struct{}
+
+func (*testService) Echo(_ *http.Request, args *TestArgs, reply *TestReply) error {
+	reply.Value = args.Value
+	return nil
+}
+
+func (*testService) GetNodeID(*http.Request, *struct{}, *TestReply) error {
+	return nil
+}
+
+// Not exposed because it has the wrong signature.
+func (*testService) Helper(string) error {
+	return nil
+}
+
+// Not exposed because it doesn't return an error.
+func (*testService) NoError(*http.Request, *TestArgs, *TestReply) {}
+
+func TestServerMethods(t *testing.T) {
+	require := require.New(t)
+
+	server := NewServer()
+	require.NoError(server.RegisterService(&testService{}, "test"))
+
+	require.Equal(
+		[]Method{
+			{
+				Name:  "test.echo",
+				Args:  reflect.TypeOf(TestArgs{}),
+				Reply: reflect.TypeOf(TestReply{}),
+			},
+			{
+				Name:  "test.getNodeID",
+				Args:  reflect.TypeOf(struct{}{}),
+				Reply: reflect.TypeOf(TestReply{}),
+			},
+		},
+		server.Methods(),
+	)
+
+	// Services that can't be registered aren't recorded.
+	require.Error(server.RegisterService(&testService{}, "test"))
+	require.Len(server.Methods(), 2)
+}
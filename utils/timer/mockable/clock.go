@@ -5,6 +5,12 @@ package mockable
 
 import "time"
 
+// ClockOffsetEnvName is the environment variable that skews every clock that
+// isn't set from global time. It is only read by binaries built with the
+// faultinjection tag, to test the tolerance of the network to nodes with
+// skewed clocks.
+const ClockOffsetEnvName = "LUXD_CLOCK_OFFSET"
+
 // MaxTime was taken from https://stackoverflow.com/questions/25065055/what-is-the-maximum-time-time-in-go/32620397#32620397
 var MaxTime = time.Unix(1<<63-62135596801, 0) // 0 is used because we drop the nano-seconds
 
@@ -25,7 +31,7 @@ func (c *Clock) Time() time.Time {
 	if c.faked {
 		return c.time
 	}
-	return time.Now()
+	return now()
 }
 
 // Time returns the unix time on this clock
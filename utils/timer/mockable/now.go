@@ -0,0 +1,12 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !faultinjection
+
+package mockable
+
+import "time"
+
+func now() time.Time {
+	return time.Now()
+}
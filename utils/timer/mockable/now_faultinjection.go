@@ -0,0 +1,29 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build faultinjection
+
+package mockable
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+var offset = mustParseOffset(os.Getenv(ClockOffsetEnvName))
+
+func mustParseOffset(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s %q: %v", ClockOffsetEnvName, value, err))
+	}
+	return d
+}
+
+func now() time.Time {
+	return time.Now().Add(offset)
+}
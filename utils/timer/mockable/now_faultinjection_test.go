@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build faultinjection
+
+package mockable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMustParseOffset(t *testing.T) {
+	require := require.New(t)
+
+	require.Zero(mustParseOffset(""))
+	require.Equal(-30*time.Second, mustParseOffset("-30s"))
+	require.Panics(func() { mustParseOffset("soon") })
+}
@@ -10,6 +10,7 @@ import (
 	_ "embed"
 
 	"github.com/luxfi/ids"
+	"github.com/luxfi/node/upgrade"
 	"github.com/luxfi/node/utils/constants"
 )
 
@@ -145,6 +146,11 @@ var (
 		constants.MainnetID: time.Date(2024, time.December, 16, 17, 0, 0, 0, time.UTC),
 		constants.TestnetID: time.Date(2024, time.November, 25, 16, 0, 0, 0, time.UTC),
 	}
+
+	FortunaTime = map[uint32]time.Time{
+		constants.MainnetID: upgrade.UnscheduledActivationTime,
+		constants.TestnetID: upgrade.UnscheduledActivationTime,
+	}
 )
 
 func init() {
@@ -252,6 +258,37 @@ func GetEtnaTime(networkID uint32) time.Time {
 	return DefaultUpgradeTime
 }
 
+// GetFortunaTime returns the time of the Fortuna upgrade on [networkID].
+//
+// Fortuna isn't scheduled on networks without an entry in [FortunaTime]. It
+// can be activated on local and custom networks through the upgrade config.
+func GetFortunaTime(networkID uint32) time.Time {
+	if upgradeTime, exists := FortunaTime[networkID]; exists {
+		return upgradeTime
+	}
+	return upgrade.UnscheduledActivationTime
+}
+
+// GetUpgradeConfig returns the network upgrade schedule of [networkID].
+func GetUpgradeConfig(networkID uint32) upgrade.Config {
+	return upgrade.Config{
+		ApricotPhase1Time:     GetApricotPhase1Time(networkID),
+		ApricotPhase2Time:     GetApricotPhase2Time(networkID),
+		ApricotPhase3Time:     GetApricotPhase3Time(networkID),
+		ApricotPhase4Time:     GetApricotPhase4Time(networkID),
+		ApricotPhase5Time:     GetApricotPhase5Time(networkID),
+		ApricotPhasePre6Time:  GetApricotPhasePre6Time(networkID),
+		ApricotPhase6Time:     GetApricotPhase6Time(networkID),
+		ApricotPhasePost6Time: GetApricotPhasePost6Time(networkID),
+		BanffTime:             GetBanffTime(networkID),
+		CortinaTime:           GetCortinaTime(networkID),
+		DurangoTime:           GetDurangoTime(networkID),
+		EtnaTime:              GetEtnaTime(networkID),
+		FortunaTime:           GetFortunaTime(networkID),
+		GraniteTime:           upgrade.UnscheduledActivationTime,
+	}
+}
+
 func GetCompatibility(networkID uint32) Compatibility {
 	return NewCompatibility(
 		CurrentApp,
@@ -7,9 +7,27 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/upgrade"
+	"github.com/luxfi/node/utils/constants"
 )
 
 func TestCurrentRPCChainVMCompatible(t *testing.T) {
 	compatibleVersions := RPCChainVMProtocolCompatibility[RPCChainVMProtocol]
 	require.Contains(t, compatibleVersions, Current)
 }
+
+func TestGetUpgradeConfigFortunaUnscheduled(t *testing.T) {
+	for _, networkID := range []uint32{
+		constants.MainnetID,
+		constants.TestnetID,
+		constants.LocalID,
+		constants.UnitTestID,
+	} {
+		require := require.New(t)
+
+		config := GetUpgradeConfig(networkID)
+		require.Equal(upgrade.UnscheduledActivationTime, config.FortunaTime)
+		require.NoError(config.Validate())
+	}
+}
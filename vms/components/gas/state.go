@@ -38,6 +38,22 @@ func (s State) AdvanceTime(
 	}
 }
 
+// MaxExcess returns the largest excess that can be reached after the provided
+// duration.
+//
+// The largest excess is reached if all of the capacity is consumed
+// immediately and maxPerSecond is consumed every following second.
+func (s State) MaxExcess(
+	maxPerSecond Gas,
+	targetPerSecond Gas,
+	duration uint64,
+) Gas {
+	return s.Excess.
+		AddPerSecond(s.Capacity, 1).
+		AddPerSecond(maxPerSecond, duration).
+		SubPerSecond(targetPerSecond, duration)
+}
+
 // ConsumeGas removes gas from capacity and adds gas to excess.
 //
 // If the capacity is insufficient, an error is returned.
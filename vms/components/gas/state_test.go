@@ -89,6 +89,68 @@ func Test_State_AdvanceTime(t *testing.T) {
 	}
 }
 
+func Test_State_MaxExcess(t *testing.T) {
+	tests := []struct {
+		name            string
+		initial         State
+		maxPerSecond    Gas
+		targetPerSecond Gas
+		duration        uint64
+		expected        Gas
+	}{
+		{
+			name: "consume capacity",
+			initial: State{
+				Capacity: 10,
+				Excess:   5,
+			},
+			maxPerSecond:    10,
+			targetPerSecond: 5,
+			duration:        0,
+			expected:        15,
+		},
+		{
+			name: "consume max per second",
+			initial: State{
+				Capacity: 10,
+				Excess:   5,
+			},
+			maxPerSecond:    10,
+			targetPerSecond: 5,
+			duration:        2,
+			expected:        25,
+		},
+		{
+			name: "avoid excess underflow",
+			initial: State{
+				Capacity: 0,
+				Excess:   5,
+			},
+			maxPerSecond:    0,
+			targetPerSecond: 5,
+			duration:        2,
+			expected:        0,
+		},
+		{
+			name: "avoid excess overflow",
+			initial: State{
+				Capacity: 10,
+				Excess:   math.MaxUint64 - 5,
+			},
+			maxPerSecond:    10,
+			targetPerSecond: 5,
+			duration:        2,
+			expected:        math.MaxUint64 - 10,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.initial.MaxExcess(test.maxPerSecond, test.targetPerSecond, test.duration)
+			require.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func Test_State_ConsumeGas(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,40 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package lux
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*Asset)(nil)
+	_ codec.Unmarshaler = (*Asset)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*Asset) CodecType() any {
+	return (*Asset)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Asset) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += len(v.ID)
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Asset) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackFixedBytes(v.ID[:])
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Asset) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	copy(v.ID[:], p.UnpackFixedBytes(len(v.ID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	errNilAssetID   = errors.New("nil asset ID is not valid")
 	errEmptyAssetID = errors.New("empty asset ID is not valid")
@@ -0,0 +1,88 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package lux
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*BaseTx)(nil)
+	_ codec.Unmarshaler = (*BaseTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*BaseTx) CodecType() any {
+	return (*BaseTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *BaseTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.IntLen
+	size += len(v.BlockchainID)
+	fieldSize, err := c.Size(&v.Outs)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Ins)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += wrappers.IntLen + len(v.Memo)
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *BaseTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackInt(v.NetworkID)
+	p.PackFixedBytes(v.BlockchainID[:])
+	if err := c.MarshalInto(&v.Outs, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Ins, p); err != nil {
+		return err
+	}
+	if len(v.Memo) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Memo), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Memo)))
+	p.PackFixedBytes(v.Memo)
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *BaseTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.NetworkID = p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	copy(v.BlockchainID[:], p.UnpackFixedBytes(len(v.BlockchainID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.Outs); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Ins); err != nil {
+		return err
+	}
+	numMemo := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numMemo > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numMemo, math.MaxInt32)
+	}
+	v.Memo = p.UnpackFixedBytes(int(numMemo))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/luxfi/node/vms/types"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 // MaxMemoSize is the maximum number of bytes in the memo field
 const MaxMemoSize = 256
 
@@ -0,0 +1,112 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package lux
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*TransferableOutput)(nil)
+	_ codec.Unmarshaler = (*TransferableOutput)(nil)
+	_ codec.Marshaler   = (*TransferableInput)(nil)
+	_ codec.Unmarshaler = (*TransferableInput)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*TransferableOutput) CodecType() any {
+	return (*TransferableOutput)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *TransferableOutput) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.Asset)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Out)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *TransferableOutput) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.Asset, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Out, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *TransferableOutput) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.Asset); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Out); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CodecType implements [codec.Marshaler].
+func (*TransferableInput) CodecType() any {
+	return (*TransferableInput)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *TransferableInput) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.UTXOID)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Asset)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.In)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *TransferableInput) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.UTXOID, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Asset, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.In, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *TransferableInput) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.UTXOID); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Asset); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.In); err != nil {
+		return err
+	}
+	return nil
+}
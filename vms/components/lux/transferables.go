@@ -17,6 +17,8 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	ErrNilTransferableOutput   = errors.New("nil transferable output is not valid")
 	ErrNilTransferableFxOutput = errors.New("nil transferable feature extension output is not valid")
@@ -9,6 +9,8 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	errNilUTXO   = errors.New("nil utxo is not valid")
 	errEmptyUTXO = errors.New("empty utxo is not valid")
@@ -0,0 +1,46 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package lux
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*UTXOID)(nil)
+	_ codec.Unmarshaler = (*UTXOID)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*UTXOID) CodecType() any {
+	return (*UTXOID)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *UTXOID) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += len(v.TxID)
+	size += wrappers.IntLen
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *UTXOID) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackFixedBytes(v.TxID[:])
+	p.PackInt(v.OutputIndex)
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *UTXOID) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	copy(v.TxID[:], p.UnpackFixedBytes(len(v.TxID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	v.OutputIndex = p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
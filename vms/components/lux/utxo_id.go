@@ -16,6 +16,8 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	errNilUTXOID                 = errors.New("nil utxo ID is not valid")
 	errMalformedUTXOIDString     = errors.New("unexpected number of tokens in string")
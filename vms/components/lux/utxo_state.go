@@ -107,23 +107,30 @@ func NewMeteredUTXOState(
 	db database.Database,
 	codec codec.Manager,
 	metrics prometheus.Registerer,
+	cachePolicy cache.Policy,
 	trackChecksum bool,
 ) (UTXOState, error) {
-	utxoCache, err := metercacher.New[ids.ID, *UTXO](
+	utxoCacher, err := cache.NewCacher[ids.ID, *UTXO](cachePolicy, utxoCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	utxoCache, err := metercacher.New(
 		"utxo_cache",
 		metrics,
-		&cache.LRU[ids.ID, *UTXO]{Size: utxoCacheSize},
+		utxoCacher,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	indexCache, err := metercacher.New[string, linkeddb.LinkedDB](
+	indexCacher, err := cache.NewCacher[string, linkeddb.LinkedDB](cachePolicy, indexCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	indexCache, err := metercacher.New(
 		"index_cache",
 		metrics,
-		&cache.LRU[string, linkeddb.LinkedDB]{
-			Size: indexCacheSize,
-		},
+		indexCacher,
 	)
 	if err != nil {
 		return nil, err
@@ -292,3 +299,10 @@ func (s *utxoState) updateChecksum(modifiedID ids.ID) {
 
 	s.checksum = s.checksum.XOR(modifiedID)
 }
+
+// NewUTXOIterator returns an iterator over the UTXOs that a UTXOState created
+// with [db] has stored, in increasing order of UTXO ID, starting at [start].
+// Keys are UTXO IDs and values are serialized UTXOs.
+func NewUTXOIterator(db database.Database, start []byte) database.Iterator {
+	return prefixdb.New(utxoPrefix, db).NewIteratorWithStart(start)
+}
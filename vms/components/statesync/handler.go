@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/luxfi/consensus/core"
+	"github.com/luxfi/database"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+	"github.com/luxfi/node/network/p2p"
+)
+
+// HandlerID is the p2p protocol ID used to serve state sync chunks.
+const HandlerID = 1
+
+var (
+	_ p2p.Handler = (*Handler)(nil)
+
+	// ErrFailedToParseRequest is returned if the request couldn't be parsed.
+	ErrFailedToParseRequest = &core.AppError{
+		Code:    1,
+		Message: "failed to parse request",
+	}
+	// ErrUnknownChunk is returned if the requested chunk isn't available,
+	// for example because the summary was pruned.
+	ErrUnknownChunk = &core.AppError{
+		Code:    2,
+		Message: "unknown chunk",
+	}
+)
+
+// ChunkGetter returns the serialized chunks of the locally stored summaries.
+type ChunkGetter interface {
+	GetSyncChunk(height uint64, index uint32) ([]byte, error)
+}
+
+// Handler serves the chunks of the summaries produced by this node.
+type Handler struct {
+	p2p.NoOpHandler
+
+	log    log.Logger
+	lock   sync.Locker
+	chunks ChunkGetter
+}
+
+func NewHandler(log log.Logger, lock sync.Locker, chunks ChunkGetter) *Handler {
+	return &Handler{
+		log:    log,
+		lock:   lock,
+		chunks: chunks,
+	}
+}
+
+func (h *Handler) AppRequest(
+	_ context.Context,
+	nodeID ids.NodeID,
+	_ time.Time,
+	requestBytes []byte,
+) ([]byte, *core.AppError) {
+	request := &Request{}
+	if _, err := Codec.Unmarshal(requestBytes, request); err != nil {
+		h.log.Debug("failed to parse state sync request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return nil, ErrFailedToParseRequest
+	}
+
+	h.lock.Lock()
+	chunk, err := h.chunks.GetSyncChunk(request.Height, request.Index)
+	h.lock.Unlock()
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, ErrUnknownChunk
+	}
+	if err != nil {
+		h.log.Error("failed to get state sync chunk",
+			zap.Uint64("height", request.Height),
+			zap.Uint32("index", request.Index),
+			zap.Error(err),
+		)
+		return nil, p2p.ErrUnexpected
+	}
+	return chunk, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/consensus/core"
+	"github.com/luxfi/database"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+	"github.com/luxfi/node/network/p2p"
+)
+
+var errTest = errors.New("non-nil error")
+
+type chunkKey struct {
+	height uint64
+	index  uint32
+}
+
+type testChunkGetter struct {
+	chunks map[chunkKey][]byte
+	err    error
+}
+
+func (g *testChunkGetter) GetSyncChunk(height uint64, index uint32) ([]byte, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	chunk, ok := g.chunks[chunkKey{height: height, index: index}]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return chunk, nil
+}
+
+func TestHandlerAppRequest(t *testing.T) {
+	chunk := []byte("chunk")
+	getter := &testChunkGetter{
+		chunks: map[chunkKey][]byte{
+			{height: 10, index: 1}: chunk,
+		},
+	}
+
+	tests := []struct {
+		name             string
+		getter           ChunkGetter
+		request          *Request
+		expectedResponse []byte
+		expectedErr      *core.AppError
+	}{
+		{
+			name:   "known chunk",
+			getter: getter,
+			request: &Request{
+				Height: 10,
+				Index:  1,
+			},
+			expectedResponse: chunk,
+		},
+		{
+			name:   "unknown index",
+			getter: getter,
+			request: &Request{
+				Height: 10,
+				Index:  2,
+			},
+			expectedErr: ErrUnknownChunk,
+		},
+		{
+			name:   "unknown height",
+			getter: getter,
+			request: &Request{
+				Height: 11,
+				Index:  1,
+			},
+			expectedErr: ErrUnknownChunk,
+		},
+		{
+			name: "database error",
+			getter: &testChunkGetter{
+				err: errTest,
+			},
+			request: &Request{
+				Height: 10,
+				Index:  1,
+			},
+			expectedErr: p2p.ErrUnexpected,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			requestBytes, err := Codec.Marshal(CodecVersion, test.request)
+			require.NoError(err)
+
+			handler := NewHandler(log.NoLog{}, &sync.Mutex{}, test.getter)
+			response, appErr := handler.AppRequest(
+				context.Background(),
+				ids.GenerateTestNodeID(),
+				time.Time{},
+				requestBytes,
+			)
+			require.Equal(test.expectedErr, appErr)
+			require.Equal(test.expectedResponse, response)
+		})
+	}
+}
+
+func TestHandlerAppRequestInvalid(t *testing.T) {
+	handler := NewHandler(log.NoLog{}, &sync.Mutex{}, &testChunkGetter{})
+	_, appErr := handler.AppRequest(
+		context.Background(),
+		ids.GenerateTestNodeID(),
+		time.Time{},
+		[]byte{0, 1, 2, 3},
+	)
+	require.Equal(t, ErrFailedToParseRequest, appErr)
+}
@@ -0,0 +1,11 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+// Request is sent to a peer to fetch chunk [Index] of the summary at
+// [Height].
+type Request struct {
+	Height uint64 `serialize:"true"`
+	Index  uint32 `serialize:"true"`
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"fmt"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/hashing"
+)
+
+// Summary commits to the state of a chain as of the accepted block
+// [BlockBytes] at [Height].
+//
+// The state is transferred in chunks, whose format is defined by each VM, and
+// [Chunks] contains the hash of each serialized chunk. Because the ID of the
+// summary is the hash of its bytes, agreeing on a summary ID is sufficient to
+// verify every chunk.
+type Summary struct {
+	Height     uint64   `serialize:"true"`
+	BlockBytes []byte   `serialize:"true"`
+	Chunks     []ids.ID `serialize:"true"`
+
+	id    ids.ID
+	bytes []byte
+}
+
+func (s *Summary) ID() ids.ID {
+	return s.id
+}
+
+func (s *Summary) Bytes() []byte {
+	return s.bytes
+}
+
+func Build(
+	height uint64,
+	blockBytes []byte,
+	chunks []ids.ID,
+) (*Summary, error) {
+	summary := &Summary{
+		Height:     height,
+		BlockBytes: blockBytes,
+		Chunks:     chunks,
+	}
+
+	bytes, err := Codec.Marshal(CodecVersion, summary)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal summary due to: %w", err)
+	}
+
+	summary.id = hashing.ComputeHash256Array(bytes)
+	summary.bytes = bytes
+	return summary, nil
+}
+
+func Parse(bytes []byte) (*Summary, error) {
+	summary := &Summary{
+		id:    hashing.ComputeHash256Array(bytes),
+		bytes: bytes,
+	}
+	version, err := Codec.Unmarshal(bytes, summary)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal summary due to: %w", err)
+	}
+	if version != CodecVersion {
+		return nil, errWrongCodecVersion
+	}
+	return summary, nil
+}
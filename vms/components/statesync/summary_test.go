@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/codec"
+)
+
+func TestBuildParse(t *testing.T) {
+	require := require.New(t)
+
+	height := uint64(2022)
+	blockBytes := []byte("blockBytes")
+	chunks := []ids.ID{
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+	}
+	builtSummary, err := Build(height, blockBytes, chunks)
+	require.NoError(err)
+
+	require.Equal(height, builtSummary.Height)
+	require.Equal(blockBytes, builtSummary.BlockBytes)
+	require.Equal(chunks, builtSummary.Chunks)
+
+	parsedSummary, err := Parse(builtSummary.Bytes())
+	require.NoError(err)
+	require.Equal(builtSummary, parsedSummary)
+
+	// Changing any chunk hash must change the summary ID.
+	otherSummary, err := Build(height, blockBytes, chunks[:1])
+	require.NoError(err)
+	require.NotEqual(builtSummary.ID(), otherSummary.ID())
+}
+
+func TestParseGibberish(t *testing.T) {
+	_, err := Parse([]byte{0, 1, 2, 3, 4, 5})
+	require.ErrorIs(t, err, codec.ErrUnknownVersion)
+}
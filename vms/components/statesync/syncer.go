@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+	"github.com/luxfi/node/network/p2p"
+	"github.com/luxfi/node/utils/hashing"
+)
+
+// ChunkWriter persists the chunks fetched by Sync.
+type ChunkWriter interface {
+	WriteSyncChunk(chunk []byte) error
+}
+
+type SyncerConfig struct {
+	Log    log.Logger
+	Client *p2p.Client
+	// Lock is held while a chunk is written.
+	Lock   sync.Locker
+	Writer ChunkWriter
+	// SimultaneousRequests is the maximum number of chunks that are requested
+	// at the same time.
+	SimultaneousRequests int
+	// RetryFrequency is how long to wait before re-requesting a chunk after a
+	// failed request.
+	RetryFrequency time.Duration
+}
+
+// Sync fetches every chunk of [summary] and writes it with [config.Writer].
+//
+// Chunks may be served by any peer, as each chunk is verified against the
+// hash committed to by [summary] before being written. Failed requests are
+// retried until [ctx] is cancelled.
+func Sync(ctx context.Context, config SyncerConfig, summary *Summary) error {
+	indices := make(chan uint32, len(summary.Chunks))
+	for index := range summary.Chunks {
+		indices <- uint32(index)
+	}
+	close(indices)
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for i := 0; i < max(config.SimultaneousRequests, 1); i++ {
+		eg.Go(func() error {
+			for index := range indices {
+				chunk, err := fetchChunk(ctx, config, summary, index)
+				if err != nil {
+					return err
+				}
+
+				config.Lock.Lock()
+				err = config.Writer.WriteSyncChunk(chunk)
+				config.Lock.Unlock()
+				if err != nil {
+					return fmt.Errorf("failed to write chunk %d: %w", index, err)
+				}
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// fetchChunk requests chunk [index] of [summary] until a valid chunk is
+// received.
+func fetchChunk(ctx context.Context, config SyncerConfig, summary *Summary, index uint32) ([]byte, error) {
+	requestBytes, err := Codec.Marshal(CodecVersion, &Request{
+		Height: summary.Height,
+		Index:  index,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	expectedID := summary.Chunks[index]
+	for {
+		nodeID, chunk, err := request(ctx, config.Client, requestBytes)
+		switch {
+		case err != nil:
+			config.Log.Debug("failed to fetch state sync chunk",
+				zap.Uint32("index", index),
+				zap.Stringer("nodeID", nodeID),
+				zap.Error(err),
+			)
+		case hashing.ComputeHash256Array(chunk) != expectedID:
+			config.Log.Debug("dropping invalid state sync chunk",
+				zap.Uint32("index", index),
+				zap.Stringer("nodeID", nodeID),
+			)
+		default:
+			return chunk, nil
+		}
+
+		select {
+		case <-time.After(config.RetryFrequency):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// request sends [requestBytes] to an arbitrary peer and waits for the
+// response.
+func request(ctx context.Context, client *p2p.Client, requestBytes []byte) (ids.NodeID, []byte, error) {
+	type response struct {
+		nodeID ids.NodeID
+		bytes  []byte
+		err    error
+	}
+	responses := make(chan response, 1)
+	err := client.AppRequestAny(
+		ctx,
+		requestBytes,
+		func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+			responses <- response{
+				nodeID: nodeID,
+				bytes:  responseBytes,
+				err:    err,
+			}
+		},
+	)
+	if err != nil {
+		return ids.EmptyNodeID, nil, err
+	}
+
+	select {
+	case response := <-responses:
+		return response.nodeID, response.bytes, response.err
+	case <-ctx.Done():
+		return ids.EmptyNodeID, nil, ctx.Err()
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+	"github.com/luxfi/node/network/p2p"
+	"github.com/luxfi/node/network/p2p/p2ptest"
+	"github.com/luxfi/node/utils/hashing"
+)
+
+type testChunkWriter struct {
+	chunks [][]byte
+}
+
+func (w *testChunkWriter) WriteSyncChunk(chunk []byte) error {
+	w.chunks = append(w.chunks, chunk)
+	return nil
+}
+
+// corruptingChunkGetter serves a corrupted chunk the first time each chunk is
+// requested.
+type corruptingChunkGetter struct {
+	ChunkGetter
+
+	lock      sync.Mutex
+	requested map[uint32]bool
+}
+
+func (g *corruptingChunkGetter) GetSyncChunk(height uint64, index uint32) ([]byte, error) {
+	chunk, err := g.ChunkGetter.GetSyncChunk(height, index)
+	if err != nil {
+		return nil, err
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.requested[index] {
+		return chunk, nil
+	}
+	g.requested[index] = true
+	return append([]byte("corrupted"), chunk...), nil
+}
+
+func TestSync(t *testing.T) {
+	const height = 10
+	chunks := [][]byte{
+		[]byte("chunk 0"),
+		[]byte("chunk 1"),
+		[]byte("chunk 2"),
+	}
+	getter := &testChunkGetter{
+		chunks: make(map[chunkKey][]byte),
+	}
+	chunkIDs := make([]ids.ID, len(chunks))
+	for i, chunk := range chunks {
+		getter.chunks[chunkKey{height: height, index: uint32(i)}] = chunk
+		chunkIDs[i] = hashing.ComputeHash256Array(chunk)
+	}
+	summary, err := Build(height, []byte("block"), chunkIDs)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		getter ChunkGetter
+	}{
+		{
+			name:   "honest peer",
+			getter: getter,
+		},
+		{
+			name: "corrupted chunks are re-requested",
+			getter: &corruptingChunkGetter{
+				ChunkGetter: getter,
+				requested:   make(map[uint32]bool),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := context.Background()
+			client := p2ptest.NewClient(
+				t,
+				ctx,
+				ids.GenerateTestNodeID(),
+				p2p.NoOpHandler{},
+				ids.GenerateTestNodeID(),
+				NewHandler(log.NoLog{}, &sync.Mutex{}, test.getter),
+			)
+
+			writer := &testChunkWriter{}
+			require.NoError(Sync(ctx, SyncerConfig{
+				Log:                  log.NoLog{},
+				Client:               client,
+				Lock:                 &sync.Mutex{},
+				Writer:               writer,
+				SimultaneousRequests: 1,
+				RetryFrequency:       time.Millisecond,
+			}, summary))
+			require.Equal(chunks, writer.chunks)
+		})
+	}
+}
+
+func TestSyncCancelled(t *testing.T) {
+	require := require.New(t)
+
+	summary, err := Build(10, []byte("block"), []ids.ID{ids.GenerateTestID()})
+	require.NoError(err)
+
+	// The peer doesn't have the chunk, so the request is retried until the
+	// context is cancelled.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	client := p2ptest.NewClient(
+		t,
+		ctx,
+		ids.GenerateTestNodeID(),
+		p2p.NoOpHandler{},
+		ids.GenerateTestNodeID(),
+		NewHandler(log.NoLog{}, &sync.Mutex{}, &testChunkGetter{}),
+	)
+
+	err = Sync(ctx, SyncerConfig{
+		Log:                  log.NoLog{},
+		Client:               client,
+		Lock:                 &sync.Mutex{},
+		Writer:               &testChunkWriter{},
+		SimultaneousRequests: 2,
+		RetryFrequency:       time.Millisecond,
+	}, summary)
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,85 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package block
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*BanffAbortBlock)(nil)
+	_ codec.Unmarshaler = (*BanffAbortBlock)(nil)
+	_ codec.Marshaler   = (*ApricotAbortBlock)(nil)
+	_ codec.Unmarshaler = (*ApricotAbortBlock)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*BanffAbortBlock) CodecType() any {
+	return (*BanffAbortBlock)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *BanffAbortBlock) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.LongLen
+	fieldSize, err := c.Size(&v.ApricotAbortBlock)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *BanffAbortBlock) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackLong(v.Time)
+	if err := c.MarshalInto(&v.ApricotAbortBlock, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *BanffAbortBlock) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.Time = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.ApricotAbortBlock); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CodecType implements [codec.Marshaler].
+func (*ApricotAbortBlock) CodecType() any {
+	return (*ApricotAbortBlock)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *ApricotAbortBlock) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.CommonBlock)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *ApricotAbortBlock) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.CommonBlock, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *ApricotAbortBlock) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.CommonBlock); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package block
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*ApricotAtomicBlock)(nil)
+	_ codec.Unmarshaler = (*ApricotAtomicBlock)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*ApricotAtomicBlock) CodecType() any {
+	return (*ApricotAtomicBlock)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *ApricotAtomicBlock) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.CommonBlock)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Tx)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *ApricotAtomicBlock) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.CommonBlock, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Tx, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *ApricotAtomicBlock) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.CommonBlock); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Tx); err != nil {
+		return err
+	}
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/luxfi/node/vms/platformvm/txs"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var _ Block = (*ApricotAtomicBlock)(nil)
 
 // ApricotAtomicBlock being accepted results in the atomic transaction contained
@@ -18,6 +18,7 @@ import (
 	"github.com/luxfi/math/set"
 	"github.com/luxfi/node/utils/timer/mockable"
 	"github.com/luxfi/node/utils/units"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/platformvm/block"
 	"github.com/luxfi/node/vms/platformvm/state"
 	"github.com/luxfi/node/vms/platformvm/status"
@@ -345,7 +346,6 @@ func packBlockTxs(
 		if txSize > remainingSize {
 			break
 		}
-		mempool.Remove(tx)
 
 		// Invariant: [tx] has already been syntactically verified.
 
@@ -361,6 +361,15 @@ func packBlockTxs(
 		}
 
 		err = tx.Unsigned.Visit(executor)
+		if err == nil {
+			// If the remaining gas capacity is exhausted, [tx] is left in the
+			// mempool to be included in a later block.
+			err = txexecutor.ConsumeGas(backend, txDiff, tx.Unsigned)
+			if errors.Is(err, gas.ErrInsufficientCapacity) {
+				break
+			}
+		}
+		mempool.Remove(tx)
 		if err != nil {
 			txID := tx.ID()
 			mempool.MarkDropped(txID, err)
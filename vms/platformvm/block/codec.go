@@ -30,14 +30,10 @@ func init() {
 	gc := linearcodec.NewDefault()
 
 	errs := wrappers.Errs{}
-	for _, c := range []linearcodec.Codec{c, gc} {
-		errs.Add(
-			RegisterApricotBlockTypes(c),
-			txs.RegisterUnsignedTxsTypes(c),
-			RegisterBanffBlockTypes(c),
-			txs.RegisterDUnsignedTxsTypes(c),
-		)
-	}
+	errs.Add(
+		registerTypes(c),
+		registerTypes(gc),
+	)
 
 	Codec = codec.NewDefaultManager()
 	GenesisCodec = codec.NewManager(math.MaxInt32)
@@ -50,6 +46,16 @@ func init() {
 	}
 }
 
+// registerTypes registers the block and tx types into [c].
+func registerTypes(c linearcodec.Codec) error {
+	return errors.Join(
+		RegisterApricotBlockTypes(c),
+		txs.RegisterUnsignedTxsTypes(c),
+		RegisterBanffBlockTypes(c),
+		txs.RegisterDUnsignedTxsTypes(c),
+	)
+}
+
 // RegisterApricotBlockTypes allows registering relevant type of blocks package
 // in the right sequence. Following repackaging of platformvm package, a few
 // subpackage-level codecs were introduced, each handling serialization of
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"testing"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/codec/codectest"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/components/verify"
+	"github.com/luxfi/node/vms/platformvm/txs"
+	"github.com/luxfi/node/vms/secp256k1fx"
+)
+
+func FuzzCodecGenerated(f *testing.F) {
+	tx := &txs.Tx{
+		Unsigned: &txs.BaseTx{
+			BaseTx: lux.BaseTx{
+				NetworkID:    1,
+				BlockchainID: ids.GenerateTestID(),
+				Ins: []*lux.TransferableInput{{
+					UTXOID: lux.UTXOID{
+						TxID:        ids.GenerateTestID(),
+						OutputIndex: 1,
+					},
+					Asset: lux.Asset{ID: ids.GenerateTestID()},
+					In: &secp256k1fx.TransferInput{
+						Amt: 2,
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0},
+						},
+					},
+				}},
+			},
+		},
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{},
+		},
+	}
+	commonBlock := CommonBlock{
+		PrntID: ids.GenerateTestID(),
+		Hght:   1,
+	}
+	codectest.FuzzGenerated(
+		f,
+		registerTypes,
+		&BanffStandardBlock{
+			Time: 1,
+			ApricotStandardBlock: ApricotStandardBlock{
+				CommonBlock:  commonBlock,
+				Transactions: []*txs.Tx{tx},
+			},
+		},
+		&BanffProposalBlock{
+			Time:         1,
+			Transactions: []*txs.Tx{},
+			ApricotProposalBlock: ApricotProposalBlock{
+				CommonBlock: commonBlock,
+				Tx:          tx,
+			},
+		},
+		&BanffCommitBlock{
+			Time: 1,
+			ApricotCommitBlock: ApricotCommitBlock{
+				CommonBlock: commonBlock,
+			},
+		},
+	)
+}
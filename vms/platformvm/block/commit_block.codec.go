@@ -0,0 +1,85 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package block
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*BanffCommitBlock)(nil)
+	_ codec.Unmarshaler = (*BanffCommitBlock)(nil)
+	_ codec.Marshaler   = (*ApricotCommitBlock)(nil)
+	_ codec.Unmarshaler = (*ApricotCommitBlock)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*BanffCommitBlock) CodecType() any {
+	return (*BanffCommitBlock)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *BanffCommitBlock) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.LongLen
+	fieldSize, err := c.Size(&v.ApricotCommitBlock)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *BanffCommitBlock) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackLong(v.Time)
+	if err := c.MarshalInto(&v.ApricotCommitBlock, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *BanffCommitBlock) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.Time = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.ApricotCommitBlock); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CodecType implements [codec.Marshaler].
+func (*ApricotCommitBlock) CodecType() any {
+	return (*ApricotCommitBlock)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *ApricotCommitBlock) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.CommonBlock)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *ApricotCommitBlock) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.CommonBlock, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *ApricotCommitBlock) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.CommonBlock); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package block
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*CommonBlock)(nil)
+	_ codec.Unmarshaler = (*CommonBlock)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*CommonBlock) CodecType() any {
+	return (*CommonBlock)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *CommonBlock) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += len(v.PrntID)
+	size += wrappers.LongLen
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *CommonBlock) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackFixedBytes(v.PrntID[:])
+	p.PackLong(v.Hght)
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *CommonBlock) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	copy(v.PrntID[:], p.UnpackFixedBytes(len(v.PrntID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Hght = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/luxfi/node/utils/hashing"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 // CommonBlock contains fields and methods common to all blocks in this VM.
 type CommonBlock struct {
 	// parent's ID
@@ -18,6 +18,7 @@ import (
 	block "github.com/luxfi/node/vms/platformvm/block"
 	state "github.com/luxfi/node/vms/platformvm/state"
 	txs "github.com/luxfi/node/vms/platformvm/txs"
+	executor "github.com/luxfi/node/vms/platformvm/txs/executor"
 	gomock "github.com/luxfi/mock/gomock"
 )
 
@@ -132,6 +133,18 @@ func (mr *ManagerMockRecorder) Preferred() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preferred", reflect.TypeOf((*Manager)(nil).Preferred))
 }
 
+// ResetLastAccepted mocks base method.
+func (m *Manager) ResetLastAccepted() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetLastAccepted")
+}
+
+// ResetLastAccepted indicates an expected call of ResetLastAccepted.
+func (mr *ManagerMockRecorder) ResetLastAccepted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLastAccepted", reflect.TypeOf((*Manager)(nil).ResetLastAccepted))
+}
+
 // SetPreference mocks base method.
 func (m *Manager) SetPreference(blkID ids.ID) {
 	m.ctrl.T.Helper()
@@ -144,6 +157,21 @@ func (mr *ManagerMockRecorder) SetPreference(blkID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*Manager)(nil).SetPreference), blkID)
 }
 
+// SimulateTx mocks base method.
+func (m *Manager) SimulateTx(tx *txs.Tx) (*executor.Simulation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateTx", tx)
+	ret0, _ := ret[0].(*executor.Simulation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateTx indicates an expected call of SimulateTx.
+func (mr *ManagerMockRecorder) SimulateTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateTx", reflect.TypeOf((*Manager)(nil).SimulateTx), tx)
+}
+
 // VerifyTx mocks base method.
 func (m *Manager) VerifyTx(tx *txs.Tx) error {
 	m.ctrl.T.Helper()
@@ -34,6 +34,11 @@ type Manager interface {
 	SetPreference(blkID ids.ID) (updated bool)
 	Preferred() ids.ID
 
+	// ResetLastAccepted drops all processing blocks and prefers the last
+	// accepted block of the state. It must be called after the state was
+	// replaced by state sync.
+	ResetLastAccepted()
+
 	GetBlock(blkID ids.ID) (chain.Block, error)
 	GetStatelessBlock(blkID ids.ID) (block.Block, error)
 	NewBlock(block.Block) chain.Block
@@ -42,6 +47,11 @@ type Manager interface {
 	// preferred state. This should *not* be used to verify transactions in a block.
 	VerifyTx(tx *txs.Tx) error
 
+	// SimulateTx executes the transaction on top of the currently preferred
+	// state, without modifying it, and reports the changes that the
+	// transaction would make.
+	SimulateTx(tx *txs.Tx) (*executor.Simulation, error)
+
 	// VerifyUniqueInputs verifies that the inputs are not duplicated in the
 	// provided blk or any of its ancestors pinned in memory.
 	VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error
@@ -126,31 +136,59 @@ func (m *manager) Preferred() ids.ID {
 	return m.preferred
 }
 
+func (m *manager) ResetLastAccepted() {
+	m.lastAccepted = m.state.GetLastAccepted()
+	m.blkIDToState = map[ids.ID]*blockState{}
+	m.preferred = m.lastAccepted
+}
+
 func (m *manager) VerifyTx(tx *txs.Tx) error {
+	stateDiff, err := m.nextBlockDiff()
+	if err != nil {
+		return err
+	}
+
+	if err := executor.VerifyMaxGas(m.txExecutorBackend, stateDiff.GetTimestamp(), tx.Unsigned); err != nil {
+		return err
+	}
+	return tx.Unsigned.Visit(&executor.StandardTxExecutor{
+		Backend: m.txExecutorBackend,
+		State:   stateDiff,
+		Tx:      tx,
+	})
+}
+
+func (m *manager) SimulateTx(tx *txs.Tx) (*executor.Simulation, error) {
+	stateDiff, err := m.nextBlockDiff()
+	if err != nil {
+		return nil, err
+	}
+
+	return executor.Simulate(m.txExecutorBackend, stateDiff, tx)
+}
+
+// nextBlockDiff returns a diff on top of the currently preferred state with
+// the chain time advanced to the time of the next block.
+func (m *manager) nextBlockDiff() (state.Diff, error) {
 	if !m.txExecutorBackend.Bootstrapped.Get() {
-		return ErrChainNotSynced
+		return nil, ErrChainNotSynced
 	}
 
 	stateDiff, err := state.NewDiff(m.preferred, m)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	nextBlkTime, _, err := state.NextBlockTime(stateDiff, m.txExecutorBackend.Clk)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	_, err = executor.AdvanceTimeTo(m.txExecutorBackend, stateDiff, nextBlkTime)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	return tx.Unsigned.Visit(&executor.StandardTxExecutor{
-		Backend: m.txExecutorBackend,
-		State:   stateDiff,
-		Tx:      tx,
-	})
+	return stateDiff, nil
 }
 
 func (m *manager) VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error {
@@ -11,8 +11,11 @@ import (
 
 	"github.com/luxfi/database"
 	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils"
 	"github.com/luxfi/node/vms/platformvm/block"
 	"github.com/luxfi/node/vms/platformvm/state"
+	"github.com/luxfi/node/vms/platformvm/txs"
+	"github.com/luxfi/node/vms/platformvm/txs/executor"
 )
 
 func TestGetBlock(t *testing.T) {
@@ -86,3 +89,42 @@ func TestManagerSetPreference(t *testing.T) {
 	require.False(manager.SetPreference(newPreference))
 	require.True(manager.SetPreference(initialPreference))
 }
+
+func TestManagerResetLastAccepted(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	processingID := ids.GenerateTestID()
+	syncedID := ids.GenerateTestID()
+	state := state.NewMockState(ctrl)
+	manager := &manager{
+		backend: &backend{
+			state:        state,
+			lastAccepted: ids.GenerateTestID(),
+			blkIDToState: map[ids.ID]*blockState{
+				processingID: {},
+			},
+		},
+		preferred: processingID,
+	}
+
+	state.EXPECT().GetLastAccepted().Return(syncedID)
+	manager.ResetLastAccepted()
+	require.Equal(syncedID, manager.LastAccepted())
+	require.Equal(syncedID, manager.Preferred())
+	require.Empty(manager.blkIDToState)
+}
+
+func TestManagerSimulateTxNotSynced(t *testing.T) {
+	require := require.New(t)
+
+	var bootstrapped utils.Atomic[bool]
+	manager := &manager{
+		txExecutorBackend: &executor.Backend{
+			Bootstrapped: &bootstrapped,
+		},
+	}
+
+	_, err := manager.SimulateTx(&txs.Tx{})
+	require.ErrorIs(err, ErrChainNotSynced)
+}
@@ -18,6 +18,7 @@ import (
 	block "github.com/luxfi/node/vms/platformvm/block"
 	state "github.com/luxfi/node/vms/platformvm/state"
 	txs "github.com/luxfi/node/vms/platformvm/txs"
+	executor "github.com/luxfi/node/vms/platformvm/txs/executor"
 	gomock "github.com/luxfi/mock/gomock"
 )
 
@@ -131,6 +132,18 @@ func (mr *MockManagerMockRecorder) Preferred() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preferred", reflect.TypeOf((*MockManager)(nil).Preferred))
 }
 
+// ResetLastAccepted mocks base method.
+func (m *MockManager) ResetLastAccepted() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetLastAccepted")
+}
+
+// ResetLastAccepted indicates an expected call of ResetLastAccepted.
+func (mr *MockManagerMockRecorder) ResetLastAccepted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLastAccepted", reflect.TypeOf((*MockManager)(nil).ResetLastAccepted))
+}
+
 // SetPreference mocks base method.
 func (m *MockManager) SetPreference(blkID ids.ID) bool {
 	m.ctrl.T.Helper()
@@ -145,6 +158,21 @@ func (mr *MockManagerMockRecorder) SetPreference(blkID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*MockManager)(nil).SetPreference), blkID)
 }
 
+// SimulateTx mocks base method.
+func (m *MockManager) SimulateTx(tx *txs.Tx) (*executor.Simulation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateTx", tx)
+	ret0, _ := ret[0].(*executor.Simulation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateTx indicates an expected call of SimulateTx.
+func (mr *MockManagerMockRecorder) SimulateTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateTx", reflect.TypeOf((*MockManager)(nil).SimulateTx), tx)
+}
+
 // VerifyTx mocks base method.
 func (m *MockManager) VerifyTx(tx *txs.Tx) error {
 	m.ctrl.T.Helper()
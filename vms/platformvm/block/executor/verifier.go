@@ -449,6 +449,9 @@ func (v *verifier) processStandardTxs(txs []*txs.Tx, state state.Diff, parentID
 			v.MarkDropped(txID, err) // cache tx as dropped
 			return nil, nil, nil, err
 		}
+		if err := executor.ConsumeGas(v.txExecutorBackend, state, tx.Unsigned); err != nil {
+			return nil, nil, nil, err
+		}
 		// ensure it doesn't overlap with current input batch
 		if inputs.Overlaps(txExecutor.Inputs) {
 			return nil, nil, nil, ErrConflictingBlockTxs
@@ -0,0 +1,107 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package block
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*BanffProposalBlock)(nil)
+	_ codec.Unmarshaler = (*BanffProposalBlock)(nil)
+	_ codec.Marshaler   = (*ApricotProposalBlock)(nil)
+	_ codec.Unmarshaler = (*ApricotProposalBlock)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*BanffProposalBlock) CodecType() any {
+	return (*BanffProposalBlock)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *BanffProposalBlock) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.LongLen
+	fieldSize, err := c.Size(&v.Transactions)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.ApricotProposalBlock)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *BanffProposalBlock) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackLong(v.Time)
+	if err := c.MarshalInto(&v.Transactions, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.ApricotProposalBlock, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *BanffProposalBlock) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.Time = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.Transactions); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.ApricotProposalBlock); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CodecType implements [codec.Marshaler].
+func (*ApricotProposalBlock) CodecType() any {
+	return (*ApricotProposalBlock)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *ApricotProposalBlock) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.CommonBlock)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Tx)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *ApricotProposalBlock) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.CommonBlock, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Tx, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *ApricotProposalBlock) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.CommonBlock); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Tx); err != nil {
+		return err
+	}
+	return nil
+}
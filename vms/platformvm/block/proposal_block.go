@@ -12,6 +12,8 @@ import (
 	"github.com/luxfi/node/vms/platformvm/txs"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	_ BanffBlock = (*BanffProposalBlock)(nil)
 	_ Block      = (*ApricotProposalBlock)(nil)
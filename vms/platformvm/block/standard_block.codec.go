@@ -0,0 +1,96 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package block
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*BanffStandardBlock)(nil)
+	_ codec.Unmarshaler = (*BanffStandardBlock)(nil)
+	_ codec.Marshaler   = (*ApricotStandardBlock)(nil)
+	_ codec.Unmarshaler = (*ApricotStandardBlock)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*BanffStandardBlock) CodecType() any {
+	return (*BanffStandardBlock)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *BanffStandardBlock) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.LongLen
+	fieldSize, err := c.Size(&v.ApricotStandardBlock)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *BanffStandardBlock) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackLong(v.Time)
+	if err := c.MarshalInto(&v.ApricotStandardBlock, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *BanffStandardBlock) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.Time = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.ApricotStandardBlock); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CodecType implements [codec.Marshaler].
+func (*ApricotStandardBlock) CodecType() any {
+	return (*ApricotStandardBlock)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *ApricotStandardBlock) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.CommonBlock)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Transactions)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *ApricotStandardBlock) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.CommonBlock, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Transactions, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *ApricotStandardBlock) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.CommonBlock); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Transactions); err != nil {
+		return err
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/luxfi/node/utils/formatting/address"
 	"github.com/luxfi/node/utils/json"
 	"github.com/luxfi/node/utils/rpc"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/platformvm/status"
 )
 
@@ -84,6 +85,9 @@ type Client interface {
 	GetBlockchains(ctx context.Context, options ...rpc.Option) ([]APIBlockchain, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	// SimulateTx executes the transaction against the currently preferred
+	// state without issuing it and returns the effects it would have
+	SimulateTx(ctx context.Context, tx []byte, options ...rpc.Option) (*SimulateTxReply, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
@@ -107,8 +111,25 @@ type Client interface {
 	//
 	// Deprecated: GetRewardUTXOs should be fetched from a dedicated indexer.
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
+	// GetPotentialRewards returns the potential and projected rewards of the
+	// current validator [nodeID] of [subnetID] and of its delegators
+	GetPotentialRewards(ctx context.Context, subnetID ids.ID, nodeID ids.NodeID, options ...rpc.Option) (*GetPotentialRewardsReply, error)
+	// GetStakerPotentialRewards returns the potential and projected rewards
+	// of the current staker added by [txID]
+	GetStakerPotentialRewards(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetPotentialRewardsReply, error)
+	// GetRewardHistory returns the rewards issued to [addr] by the stakers
+	// that were rewarded in blocks [startHeight] to [endHeight], inclusive
+	GetRewardHistory(ctx context.Context, addr ids.ShortID, startHeight, endHeight uint64, options ...rpc.Option) (*GetRewardHistoryReply, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
+	// GetFeeConfig returns the config used to calculate the dynamic fee state
+	GetFeeConfig(ctx context.Context, options ...rpc.Option) (*gas.Config, error)
+	// GetFeeState returns the dynamic fee state, the current gas price, and
+	// the timestamp of the last accepted block
+	GetFeeState(ctx context.Context, options ...rpc.Option) (gas.State, gas.Price, time.Time, error)
+	// EstimateFee returns the current fee of the unsigned transaction [utx]
+	// and the highest fee that it could require within [duration]
+	EstimateFee(ctx context.Context, utx []byte, duration time.Duration, options ...rpc.Option) (*EstimateFeeReply, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided
 	// subnet at the specified height.
 	GetValidatorsAt(
@@ -373,6 +394,20 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) SimulateTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SimulateTxReply{}
+	err = c.requester.SendRequest(ctx, "platform.simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "platform.getTx", &api.GetTxArgs{
@@ -472,12 +507,66 @@ func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, option
 	return utxos, err
 }
 
+func (c *client) GetPotentialRewards(ctx context.Context, subnetID ids.ID, nodeID ids.NodeID, options ...rpc.Option) (*GetPotentialRewardsReply, error) {
+	res := &GetPotentialRewardsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getPotentialRewards", &GetPotentialRewardsArgs{
+		SubnetID: subnetID,
+		NodeID:   nodeID,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetStakerPotentialRewards(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetPotentialRewardsReply, error) {
+	res := &GetPotentialRewardsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getPotentialRewards", &GetPotentialRewardsArgs{
+		TxID: txID,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetRewardHistory(ctx context.Context, addr ids.ShortID, startHeight, endHeight uint64, options ...rpc.Option) (*GetRewardHistoryReply, error) {
+	res := &GetRewardHistoryReply{}
+	err := c.requester.SendRequest(ctx, "platform.getRewardHistory", &GetRewardHistoryArgs{
+		Address:     addr.String(),
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error) {
 	res := &GetTimestampReply{}
 	err := c.requester.SendRequest(ctx, "platform.getTimestamp", struct{}{}, res, options...)
 	return res.Timestamp, err
 }
 
+func (c *client) GetFeeConfig(ctx context.Context, options ...rpc.Option) (*gas.Config, error) {
+	res := &gas.Config{}
+	err := c.requester.SendRequest(ctx, "platform.getFeeConfig", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) GetFeeState(ctx context.Context, options ...rpc.Option) (gas.State, gas.Price, time.Time, error) {
+	res := &GetFeeStateReply{}
+	err := c.requester.SendRequest(ctx, "platform.getFeeState", struct{}{}, res, options...)
+	return res.State, res.Price, res.Time, err
+}
+
+func (c *client) EstimateFee(ctx context.Context, utxBytes []byte, duration time.Duration, options ...rpc.Option) (*EstimateFeeReply, error) {
+	utxStr, err := formatting.Encode(formatting.Hex, utxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &EstimateFeeReply{}
+	err = c.requester.SendRequest(ctx, "platform.estimateFee", &EstimateFeeArgs{
+		Tx:       utxStr,
+		Encoding: formatting.Hex,
+		Duration: json.Uint64(duration / time.Second),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetValidatorsAt(
 	ctx context.Context,
 	subnetID ids.ID,
@@ -12,6 +12,7 @@ import (
 	"github.com/luxfi/node/chains"
 	"github.com/luxfi/node/utils/constants"
 	"github.com/luxfi/math/set"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/platformvm/reward"
 	"github.com/luxfi/node/vms/platformvm/txs"
 	"github.com/luxfi/node/vms/platformvm/txs/fee"
@@ -34,6 +35,9 @@ type Config struct {
 	// All static fees config active before E-upgrade
 	StaticFeeConfig fee.StaticConfig
 
+	// Dynamic fee state parameters active after Etna
+	DynamicFeeConfig gas.Config
+
 	// Provides access to the uptime manager as a thread safe data structure
 	UptimeLockedCalculator uptime.LockedCalculator
 
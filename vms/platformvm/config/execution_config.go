@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/luxfi/node/cache"
 	"github.com/luxfi/node/utils/units"
 )
 
@@ -20,8 +21,11 @@ var DefaultExecutionConfig = ExecutionConfig{
 	ChainDBCacheSize:             2048,
 	BlockIDCacheSize:             8192,
 	FxOwnerCacheSize:             4 * units.MiB,
+	CachePolicy:                  cache.LRUPolicy,
 	ChecksumsEnabled:             false,
 	MempoolPruneFrequency:        30 * time.Minute,
+	StateSyncEnabled:             false,
+	StateSyncSummaryFrequency:    16_384,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
@@ -35,8 +39,16 @@ type ExecutionConfig struct {
 	ChainDBCacheSize             int           `json:"chain-db-cache-size"`
 	BlockIDCacheSize             int           `json:"block-id-cache-size"`
 	FxOwnerCacheSize             int           `json:"fx-owner-cache-size"`
+	CachePolicy                  cache.Policy  `json:"cache-policy"`
 	ChecksumsEnabled             bool          `json:"checksums-enabled"`
 	MempoolPruneFrequency        time.Duration `json:"mempool-prune-frequency"`
+	// StateSyncEnabled allows a node without any accepted blocks after
+	// genesis to sync to a recent state summary rather than executing every
+	// block.
+	StateSyncEnabled bool `json:"state-sync-enabled"`
+	// StateSyncSummaryFrequency is the number of blocks between the state
+	// summaries produced by this node. If 0, no summaries are produced.
+	StateSyncSummaryFrequency uint64 `json:"state-sync-summary-frequency"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
@@ -50,5 +62,8 @@ func GetExecutionConfig(b []byte) (*ExecutionConfig, error) {
 		return &ec, nil
 	}
 
-	return &ec, json.Unmarshal(b, &ec)
+	if err := json.Unmarshal(b, &ec); err != nil {
+		return nil, err
+	}
+	return &ec, ec.CachePolicy.Verify()
 }
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/cache"
 )
 
 // Requires all values in a struct to be initialized
@@ -73,8 +75,11 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			ChainDBCacheSize:             7,
 			BlockIDCacheSize:             8,
 			FxOwnerCacheSize:             9,
+			CachePolicy:                  cache.TinyLFUPolicy,
 			ChecksumsEnabled:             true,
 			MempoolPruneFrequency:        time.Minute,
+			StateSyncEnabled:             true,
+			StateSyncSummaryFrequency:    10,
 		}
 		verifyInitializedStruct(t, *expected)
 		verifyInitializedStruct(t, expected.Network)
@@ -86,4 +91,10 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 		require.NoError(err)
 		require.Equal(expected, actual)
 	})
+
+	t.Run("unknown cache policy", func(t *testing.T) {
+		b := []byte(`{"cache-policy":"mru"}`)
+		_, err := GetExecutionConfig(b)
+		require.ErrorIs(t, err, cache.ErrUnknownPolicy)
+	})
 }
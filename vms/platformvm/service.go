@@ -11,6 +11,7 @@ import (
 	"maps"
 	"math"
 	"net/http"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
@@ -28,7 +29,9 @@ import (
 	"github.com/luxfi/math/set"
 
 	// "github.com/luxfi/node/vms/components/keystore" // Removed - keystore functionality deprecated
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/platformvm/block"
 	"github.com/luxfi/node/vms/platformvm/fx"
 	"github.com/luxfi/node/vms/platformvm/reward"
 	"github.com/luxfi/node/vms/platformvm/signer"
@@ -36,11 +39,13 @@ import (
 	"github.com/luxfi/node/vms/platformvm/state"
 	"github.com/luxfi/node/vms/platformvm/status"
 	"github.com/luxfi/node/vms/platformvm/txs"
+	"github.com/luxfi/node/vms/platformvm/txs/fee"
 	"github.com/luxfi/node/vms/secp256k1fx"
 
 	avajson "github.com/luxfi/node/utils/json"
 	safemath "github.com/luxfi/math/math"
 	platformapi "github.com/luxfi/node/vms/platformvm/api"
+	txexecutor "github.com/luxfi/node/vms/platformvm/txs/executor"
 )
 
 const (
@@ -56,6 +61,9 @@ const (
 	// Note: Staker attributes cache should be large enough so that no evictions
 	// happen when the API loops through all stakers.
 	stakerAttributesCacheSize = 100_000
+
+	// Max number of blocks that can be searched by GetRewardHistory
+	maxRewardHistoryBlocks = 256
 )
 
 var (
@@ -63,6 +71,11 @@ var (
 	errPrimaryNetworkIsNotASubnet = errors.New("the primary network isn't a subnet")
 	errNoAddresses                = errors.New("no addresses provided")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
+	errNodeIDOrTxID               = errors.New("exactly one of 'nodeID' and 'txID' must be given")
+	errNotCurrentStaker           = errors.New("not a current staker")
+	errStakerNotRewarded          = errors.New("staker isn't rewarded")
+	errInvalidHeightRange         = errors.New("start height is greater than end height")
+	errHeightRangeTooLarge        = errors.New("height range is too large")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -1274,6 +1287,150 @@ func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response *api.
 	return nil
 }
 
+// TxComplexity is the gas complexity of a transaction in each dimension.
+type TxComplexity struct {
+	Bandwidth avajson.Uint64 `json:"bandwidth"`
+	DBRead    avajson.Uint64 `json:"dbRead"`
+	DBWrite   avajson.Uint64 `json:"dbWrite"`
+	Compute   avajson.Uint64 `json:"compute"`
+}
+
+// SimulateTxStakerChange is a staker that a transaction would add or remove.
+type SimulateTxStakerChange struct {
+	TxID            ids.ID         `json:"txID"`
+	NodeID          ids.NodeID     `json:"nodeID"`
+	SubnetID        ids.ID         `json:"subnetID"`
+	Weight          avajson.Uint64 `json:"weight"`
+	StartTime       avajson.Uint64 `json:"startTime"`
+	EndTime         avajson.Uint64 `json:"endTime"`
+	PotentialReward avajson.Uint64 `json:"potentialReward"`
+	IsValidator     bool           `json:"isValidator"`
+	IsPending       bool           `json:"isPending"`
+	Removed         bool           `json:"removed"`
+}
+
+// SimulateTxL1ValidatorChange is an L1 validator that a transaction would
+// write.
+type SimulateTxL1ValidatorChange struct {
+	ValidationID ids.ID         `json:"validationID"`
+	SubnetID     ids.ID         `json:"subnetID"`
+	NodeID       ids.NodeID     `json:"nodeID"`
+	Weight       avajson.Uint64 `json:"weight"`
+	MinNonce     avajson.Uint64 `json:"minNonce"`
+}
+
+// SimulateTxReply is the response from SimulateTx
+type SimulateTxReply struct {
+	// Fee, in nLUX, that the transaction is required to burn
+	Fee        avajson.Uint64 `json:"fee"`
+	Complexity TxComplexity   `json:"complexity"`
+	// UTXOs of this chain that the transaction would consume
+	ConsumedUTXOs []string `json:"consumedUTXOs"`
+	// UTXOs that the transaction would produce
+	ProducedUTXOs      []string                      `json:"producedUTXOs"`
+	StakerChanges      []SimulateTxStakerChange      `json:"stakerChanges"`
+	L1ValidatorChanges []SimulateTxL1ValidatorChange `json:"l1ValidatorChanges"`
+	Encoding           formatting.Encoding           `json:"encoding"`
+	// Error is the reason the transaction would fail verification. It is
+	// empty if the transaction is valid.
+	Error string `json:"error,omitempty"`
+}
+
+// SimulateTx executes a transaction against the currently preferred state
+// without issuing it and reports the effects it would have.
+func (s *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, reply *SimulateTxReply) error {
+	s.vm.log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "simulateTx"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+
+	s.vm.lock.Lock()
+	defer s.vm.lock.Unlock()
+
+	sim, err := s.vm.manager.SimulateTx(tx)
+	if err != nil {
+		return fmt.Errorf("couldn't simulate tx: %w", err)
+	}
+
+	reply.Fee = avajson.Uint64(sim.Fee)
+	reply.Complexity = newTxComplexity(sim.Complexity)
+	reply.ConsumedUTXOs, err = encodeUTXOs(sim.Consumed, args.Encoding)
+	if err != nil {
+		return err
+	}
+	reply.ProducedUTXOs, err = encodeUTXOs(sim.Produced, args.Encoding)
+	if err != nil {
+		return err
+	}
+
+	reply.StakerChanges = make([]SimulateTxStakerChange, len(sim.StakerChanges))
+	for i, change := range sim.StakerChanges {
+		staker := change.Staker
+		reply.StakerChanges[i] = SimulateTxStakerChange{
+			TxID:            staker.TxID,
+			NodeID:          staker.NodeID,
+			SubnetID:        staker.SubnetID,
+			Weight:          avajson.Uint64(staker.Weight),
+			StartTime:       avajson.Uint64(staker.StartTime.Unix()),
+			EndTime:         avajson.Uint64(staker.EndTime.Unix()),
+			PotentialReward: avajson.Uint64(staker.PotentialReward),
+			IsValidator:     staker.Priority.IsValidator(),
+			IsPending:       staker.Priority.IsPending(),
+			Removed:         change.Removed,
+		}
+	}
+
+	reply.L1ValidatorChanges = make([]SimulateTxL1ValidatorChange, len(sim.L1ValidatorChanges))
+	for i, l1Validator := range sim.L1ValidatorChanges {
+		reply.L1ValidatorChanges[i] = SimulateTxL1ValidatorChange{
+			ValidationID: l1Validator.ValidationID,
+			SubnetID:     l1Validator.SubnetID,
+			NodeID:       l1Validator.NodeID,
+			Weight:       avajson.Uint64(l1Validator.Weight),
+			MinNonce:     avajson.Uint64(l1Validator.MinNonce),
+		}
+	}
+
+	reply.Encoding = args.Encoding
+	if sim.Err != nil {
+		reply.Error = sim.Err.Error()
+	}
+	return nil
+}
+
+func newTxComplexity(complexity gas.Dimensions) TxComplexity {
+	return TxComplexity{
+		Bandwidth: avajson.Uint64(complexity[gas.Bandwidth]),
+		DBRead:    avajson.Uint64(complexity[gas.DBRead]),
+		DBWrite:   avajson.Uint64(complexity[gas.DBWrite]),
+		Compute:   avajson.Uint64(complexity[gas.Compute]),
+	}
+}
+
+func encodeUTXOs(utxos []*lux.UTXO, encoding formatting.Encoding) ([]string, error) {
+	encoded := make([]string, len(utxos))
+	for i, utxo := range utxos {
+		bytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't serialize UTXO %q: %w", utxo.InputID(), err)
+		}
+		encoded[i], err = formatting.Encode(encoding, bytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't encode UTXO %s as %s: %w", utxo.InputID(), encoding, err)
+		}
+	}
+	return encoded, nil
+}
+
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	s.vm.log.Debug("API called",
 		zap.String("service", "platform"),
@@ -1606,6 +1763,388 @@ func (s *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *Ge
 	return nil
 }
 
+// GetPotentialRewardsArgs are the arguments for calling GetPotentialRewards.
+// Exactly one of [NodeID] and [TxID] must be provided.
+type GetPotentialRewardsArgs struct {
+	// Subnet the validator identified by [NodeID] is validating. If omitted,
+	// defaults to the primary network. Ignored if [TxID] is provided.
+	SubnetID ids.ID `json:"subnetID"`
+	// NodeID of a current validator. The validator and all of its current
+	// delegators are returned.
+	NodeID ids.NodeID `json:"nodeID"`
+	// TxID of a current validator or delegator. Only that staker is returned.
+	TxID ids.ID `json:"txID"`
+}
+
+// PotentialReward is the projected reward of a current staker.
+type PotentialReward struct {
+	TxID      ids.ID         `json:"txID"`
+	NodeID    ids.NodeID     `json:"nodeID"`
+	SubnetID  ids.ID         `json:"subnetID"`
+	Role      string         `json:"role"`
+	StartTime avajson.Uint64 `json:"startTime"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+	Weight    avajson.Uint64 `json:"weight"`
+	// PotentialReward is the reward that was locked in when the staker was
+	// added. This is the reward that will be issued if the staker is rewarded.
+	PotentialReward avajson.Uint64 `json:"potentialReward"`
+	// ProjectedReward is the reward the staker would be locked into if it
+	// were added now, with the current supply.
+	ProjectedReward avajson.Uint64 `json:"projectedReward"`
+
+	// Only populated for validators
+	DelegationFee *avajson.Float32 `json:"delegationFee,omitempty"`
+	// AccruedDelegateeReward is the portion of the rewards of delegators that
+	// have already been rewarded that will be issued to the validator.
+	AccruedDelegateeReward *avajson.Uint64 `json:"accruedDelegateeReward,omitempty"`
+	// PotentialDelegateeReward is the portion of the potential rewards of the
+	// current delegators that will be issued to the validator.
+	PotentialDelegateeReward *avajson.Uint64 `json:"potentialDelegateeReward,omitempty"`
+
+	// Only populated for delegators
+	// DelegateeReward is the portion of [PotentialReward] that will be issued
+	// to the validator as its delegation fee.
+	DelegateeReward *avajson.Uint64 `json:"delegateeReward,omitempty"`
+	// DelegatorReward is the portion of [PotentialReward] that will be issued
+	// to the delegator.
+	DelegatorReward *avajson.Uint64 `json:"delegatorReward,omitempty"`
+}
+
+// GetPotentialRewardsReply are the results from calling GetPotentialRewards
+type GetPotentialRewardsReply struct {
+	// CurrentSupply of the staking asset used to project the rewards
+	CurrentSupply avajson.Uint64    `json:"currentSupply"`
+	Stakers       []PotentialReward `json:"stakers"`
+}
+
+// GetPotentialRewards returns the potential and projected rewards of a current
+// validator and its delegators, or of a single current staker.
+func (s *Service) GetPotentialRewards(_ *http.Request, args *GetPotentialRewardsArgs, reply *GetPotentialRewardsReply) error {
+	s.vm.log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getPotentialRewards"),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Stringer("txID", args.TxID),
+	)
+
+	if (args.NodeID == ids.EmptyNodeID) == (args.TxID == ids.Empty) {
+		return errNodeIDOrTxID
+	}
+
+	s.vm.lock.Lock()
+	defer s.vm.lock.Unlock()
+
+	subnetID := args.SubnetID
+	nodeID := args.NodeID
+	if args.TxID != ids.Empty {
+		tx, _, err := s.vm.state.GetTx(args.TxID)
+		if err != nil {
+			return fmt.Errorf("couldn't get tx %s: %w", args.TxID, err)
+		}
+		stakerTx, ok := tx.Unsigned.(txs.Staker)
+		if !ok {
+			return fmt.Errorf("%w: %s", errNotCurrentStaker, args.TxID)
+		}
+		subnetID = stakerTx.SubnetID()
+		nodeID = stakerTx.NodeID()
+	}
+
+	validator, err := s.vm.state.GetCurrentValidator(subnetID, nodeID)
+	if errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("%w: %s", errNotCurrentStaker, nodeID)
+	}
+	if err != nil {
+		return err
+	}
+	if validator.Priority.IsPermissionedValidator() {
+		return fmt.Errorf("%w: %s", errStakerNotRewarded, nodeID)
+	}
+
+	currentSupply, err := s.vm.state.GetCurrentSupply(subnetID)
+	if err != nil {
+		return fmt.Errorf("fetching current supply failed: %w", err)
+	}
+	rewards, err := txexecutor.GetRewardsCalculator(
+		&txexecutor.Backend{
+			Config:  &s.vm.Config,
+			Rewards: reward.NewCalculator(s.vm.RewardConfig),
+		},
+		s.vm.state,
+		subnetID,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't get rewards calculator: %w", err)
+	}
+
+	attr, err := s.loadStakerTxAttributes(validator.TxID)
+	if err != nil {
+		return err
+	}
+	accruedDelegateeReward, err := s.vm.state.GetDelegateeReward(subnetID, nodeID)
+	if err != nil {
+		return err
+	}
+
+	reply.CurrentSupply = avajson.Uint64(currentSupply)
+	reply.Stakers = []PotentialReward{}
+
+	var potentialDelegateeReward uint64
+	delegatorIt, err := s.vm.state.GetCurrentDelegatorIterator(subnetID, nodeID)
+	if err != nil {
+		return err
+	}
+	defer delegatorIt.Release()
+	for delegatorIt.Next() {
+		delegator := delegatorIt.Value()
+		delegateeReward, delegatorReward := reward.Split(delegator.PotentialReward, attr.shares)
+		potentialDelegateeReward, err = safemath.Add64(potentialDelegateeReward, delegateeReward)
+		if err != nil {
+			return err
+		}
+
+		if args.TxID != ids.Empty && args.TxID != delegator.TxID {
+			continue
+		}
+
+		jsonDelegateeReward := avajson.Uint64(delegateeReward)
+		jsonDelegatorReward := avajson.Uint64(delegatorReward)
+		potentialReward := newPotentialReward(rewards, delegator, currentSupply)
+		potentialReward.Role = "delegator"
+		potentialReward.DelegateeReward = &jsonDelegateeReward
+		potentialReward.DelegatorReward = &jsonDelegatorReward
+		reply.Stakers = append(reply.Stakers, potentialReward)
+	}
+
+	if args.TxID == ids.Empty || args.TxID == validator.TxID {
+		delegationFee := avajson.Float32(100 * float32(attr.shares) / float32(reward.PercentDenominator))
+		jsonAccruedDelegateeReward := avajson.Uint64(accruedDelegateeReward)
+		jsonPotentialDelegateeReward := avajson.Uint64(potentialDelegateeReward)
+		potentialReward := newPotentialReward(rewards, validator, currentSupply)
+		potentialReward.Role = "validator"
+		potentialReward.DelegationFee = &delegationFee
+		potentialReward.AccruedDelegateeReward = &jsonAccruedDelegateeReward
+		potentialReward.PotentialDelegateeReward = &jsonPotentialDelegateeReward
+		// The validator is reported before its delegators.
+		reply.Stakers = append([]PotentialReward{potentialReward}, reply.Stakers...)
+	}
+
+	if len(reply.Stakers) == 0 {
+		return fmt.Errorf("%w: %s", errNotCurrentStaker, args.TxID)
+	}
+	return nil
+}
+
+func newPotentialReward(rewards reward.Calculator, staker *state.Staker, currentSupply uint64) PotentialReward {
+	return PotentialReward{
+		TxID:            staker.TxID,
+		NodeID:          staker.NodeID,
+		SubnetID:        staker.SubnetID,
+		StartTime:       avajson.Uint64(staker.StartTime.Unix()),
+		EndTime:         avajson.Uint64(staker.EndTime.Unix()),
+		Weight:          avajson.Uint64(staker.Weight),
+		PotentialReward: avajson.Uint64(staker.PotentialReward),
+		ProjectedReward: avajson.Uint64(rewards.Calculate(
+			staker.EndTime.Sub(staker.StartTime),
+			staker.Weight,
+			currentSupply,
+		)),
+	}
+}
+
+// GetRewardHistoryArgs are the arguments for calling GetRewardHistory
+type GetRewardHistoryArgs struct {
+	// Address the reward UTXOs are issued to
+	Address string `json:"address"`
+	// Inclusive range of block heights to search for rewarded stakers
+	StartHeight avajson.Uint64 `json:"startHeight"`
+	EndHeight   avajson.Uint64 `json:"endHeight"`
+}
+
+// RewardHistoryEntry is the reward issued to an address for a single staker.
+type RewardHistoryEntry struct {
+	// TxID of the rewarded validator or delegator
+	TxID     ids.ID     `json:"txID"`
+	NodeID   ids.NodeID `json:"nodeID"`
+	SubnetID ids.ID     `json:"subnetID"`
+	Role     string     `json:"role"`
+	// Height of the commit or abort block that issued the reward
+	Height  avajson.Uint64 `json:"height"`
+	AssetID ids.ID         `json:"assetID"`
+	// Amount issued to the address in reward UTXOs
+	Amount avajson.Uint64 `json:"amount"`
+}
+
+// GetRewardHistoryReply are the results from calling GetRewardHistory
+type GetRewardHistoryReply struct {
+	Rewards []RewardHistoryEntry `json:"rewards"`
+	// Totals maps each asset ID to the total amount of that asset issued to
+	// the address
+	Totals map[ids.ID]avajson.Uint64 `json:"totals"`
+}
+
+// GetRewardHistory returns the rewards issued to an address by the stakers
+// that were rewarded in the provided range of blocks.
+func (s *Service) GetRewardHistory(_ *http.Request, args *GetRewardHistoryArgs, reply *GetRewardHistoryReply) error {
+	s.vm.log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getRewardHistory"),
+		zap.String("address", args.Address),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+	)
+
+	switch {
+	case args.StartHeight > args.EndHeight:
+		return errInvalidHeightRange
+	case uint64(args.EndHeight-args.StartHeight) >= maxRewardHistoryBlocks:
+		return fmt.Errorf("%w: at most %d blocks can be searched", errHeightRangeTooLarge, maxRewardHistoryBlocks)
+	}
+
+	addr, err := lux.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+	}
+
+	totals := map[ids.ID]uint64{}
+	reply.Rewards = []RewardHistoryEntry{}
+	for height := uint64(args.StartHeight); height <= uint64(args.EndHeight); height++ {
+		// The VM lock is only held while a single block is searched so that
+		// block processing isn't stalled for the whole range.
+		entries, err := s.getRewardHistoryAtHeight(height, addr)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			reply.Rewards = append(reply.Rewards, entry)
+
+			total, err := safemath.Add64(totals[entry.AssetID], uint64(entry.Amount))
+			if err != nil {
+				total = math.MaxUint64
+			}
+			totals[entry.AssetID] = total
+		}
+	}
+
+	reply.Totals = newJSONBalanceMap(totals)
+	return nil
+}
+
+// getRewardHistoryAtHeight returns the rewards issued to [addr] by the block at
+// [height]. Reward UTXOs are issued by the commit or abort block that decides
+// a proposal block, so only option blocks issue rewards.
+func (s *Service) getRewardHistoryAtHeight(height uint64, addr ids.ShortID) ([]RewardHistoryEntry, error) {
+	s.vm.lock.Lock()
+	defer s.vm.lock.Unlock()
+
+	blk, err := s.getStatelessBlockAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	switch blk.(type) {
+	case *block.BanffCommitBlock, *block.ApricotCommitBlock,
+		*block.BanffAbortBlock, *block.ApricotAbortBlock:
+	default:
+		return nil, nil
+	}
+
+	proposalBlk, err := s.vm.manager.GetStatelessBlock(blk.Parent())
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block with id %s: %w", blk.Parent(), err)
+	}
+
+	var entries []RewardHistoryEntry
+	for _, tx := range proposalBlk.Txs() {
+		rewardTx, ok := tx.Unsigned.(*txs.RewardValidatorTx)
+		if !ok {
+			continue
+		}
+
+		entry, err := s.getRewardHistoryEntry(rewardTx.TxID, addr)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil {
+			continue
+		}
+
+		entry.Height = avajson.Uint64(height)
+		entries = append(entries, *entry)
+	}
+	return entries, nil
+}
+
+func (s *Service) getStatelessBlockAtHeight(height uint64) (block.Block, error) {
+	blkID, err := s.vm.state.GetBlockIDAtHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block at height %d: %w", height, err)
+	}
+	blk, err := s.vm.manager.GetStatelessBlock(blkID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block with id %s: %w", blkID, err)
+	}
+	return blk, nil
+}
+
+// getRewardHistoryEntry returns the rewards issued to [addr] when the staker
+// added by [stakerTxID] was rewarded. If no rewards were issued to [addr], nil
+// is returned.
+func (s *Service) getRewardHistoryEntry(stakerTxID ids.ID, addr ids.ShortID) (*RewardHistoryEntry, error) {
+	utxos, err := s.vm.state.GetRewardUTXOs(stakerTxID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get reward UTXOs of %s: %w", stakerTxID, err)
+	}
+
+	var (
+		found   bool
+		assetID ids.ID
+		amount  uint64
+	)
+	for _, utxo := range utxos {
+		out := utxo.Out
+		if lockOut, ok := out.(*stakeable.LockOut); ok {
+			out = lockOut.TransferableOut
+		}
+		transferOut, ok := out.(*secp256k1fx.TransferOutput)
+		if !ok || !slices.Contains(transferOut.Addrs, addr) {
+			continue
+		}
+
+		found = true
+		assetID = utxo.AssetID()
+		amount, err = safemath.Add64(amount, transferOut.Amount())
+		if err != nil {
+			amount = math.MaxUint64
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	tx, _, err := s.vm.state.GetTx(stakerTxID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get staker tx %s: %w", stakerTxID, err)
+	}
+	entry := &RewardHistoryEntry{
+		TxID:    stakerTxID,
+		AssetID: assetID,
+		Amount:  avajson.Uint64(amount),
+	}
+	switch stakerTx := tx.Unsigned.(type) {
+	case txs.ValidatorTx:
+		entry.NodeID = stakerTx.NodeID()
+		entry.SubnetID = stakerTx.SubnetID()
+		entry.Role = "validator"
+	case txs.DelegatorTx:
+		entry.NodeID = stakerTx.NodeID()
+		entry.SubnetID = stakerTx.SubnetID()
+		entry.Role = "delegator"
+	default:
+		return nil, fmt.Errorf("unexpected staker tx type %T", tx.Unsigned)
+	}
+	return entry, nil
+}
+
 // GetTimestampReply is the response from GetTimestamp
 type GetTimestampReply struct {
 	// Current timestamp
@@ -1626,6 +2165,144 @@ func (s *Service) GetTimestamp(_ *http.Request, _ *struct{}, reply *GetTimestamp
 	return nil
 }
 
+// GetFeeConfig returns the config used to calculate the dynamic fee state.
+func (s *Service) GetFeeConfig(_ *http.Request, _ *struct{}, reply *gas.Config) error {
+	s.vm.log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getFeeConfig"),
+	)
+
+	*reply = s.vm.DynamicFeeConfig
+	return nil
+}
+
+// GetFeeStateReply is the response from GetFeeState
+type GetFeeStateReply struct {
+	gas.State
+	Price gas.Price `json:"price"`
+	Time  time.Time `json:"timestamp"`
+}
+
+// GetFeeState returns the dynamic fee state as of the last accepted block.
+func (s *Service) GetFeeState(_ *http.Request, _ *struct{}, reply *GetFeeStateReply) error {
+	s.vm.log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getFeeState"),
+	)
+
+	s.vm.lock.Lock()
+	defer s.vm.lock.Unlock()
+
+	reply.State = s.vm.state.GetFeeState()
+	reply.Price = gas.CalculatePrice(
+		s.vm.DynamicFeeConfig.MinPrice,
+		reply.State.Excess,
+		s.vm.DynamicFeeConfig.ExcessConversionConstant,
+	)
+	reply.Time = s.vm.state.GetTimestamp()
+	return nil
+}
+
+// EstimateFeeArgs are the arguments for EstimateFee
+type EstimateFeeArgs struct {
+	// Tx is the byte representation of an unsigned transaction
+	Tx       string              `json:"tx"`
+	Encoding formatting.Encoding `json:"encoding"`
+	// Duration, in seconds, over which the price is projected
+	Duration avajson.Uint64 `json:"duration"`
+}
+
+// EstimateFeeReply is the response from EstimateFee
+type EstimateFeeReply struct {
+	// State is the fee state that the next block would be built on
+	gas.State
+	Complexity TxComplexity `json:"complexity"`
+	Gas        gas.Gas      `json:"gas"`
+	// Price is the current price of gas
+	Price gas.Price `json:"price"`
+	// Fee, in nLUX, of the transaction at [Price]
+	Fee avajson.Uint64 `json:"fee"`
+	// MaxPrice is the highest price of gas that can be reached within
+	// [Duration] seconds
+	MaxPrice gas.Price `json:"maxPrice"`
+	// MaxFee, in nLUX, of the transaction at [MaxPrice]
+	MaxFee avajson.Uint64 `json:"maxFee"`
+}
+
+// EstimateFee returns the current and the highest projected fee of an unsigned
+// transaction.
+func (s *Service) EstimateFee(_ *http.Request, args *EstimateFeeArgs, reply *EstimateFeeReply) error {
+	s.vm.log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "estimateFee"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	var utx txs.UnsignedTx
+	if _, err := txs.Codec.Unmarshal(txBytes, &utx); err != nil {
+		return fmt.Errorf("couldn't parse unsigned tx: %w", err)
+	}
+
+	complexity, err := fee.TxComplexity(utx)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate complexity: %w", err)
+	}
+
+	feeConfig := s.vm.DynamicFeeConfig
+	gasUsed, err := complexity.ToGas(feeConfig.Weights)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate gas: %w", err)
+	}
+
+	s.vm.lock.Lock()
+	defer s.vm.lock.Unlock()
+
+	// The next block advances the fee state to its timestamp before any
+	// transactions are executed.
+	chainTime := s.vm.state.GetTimestamp()
+	nextBlockTime, _, err := state.NextBlockTime(s.vm.state, &s.vm.nodeClock)
+	if err != nil {
+		return fmt.Errorf("couldn't get next block time: %w", err)
+	}
+	reply.State = s.vm.state.GetFeeState().AdvanceTime(
+		feeConfig.MaxCapacity,
+		feeConfig.MaxPerSecond,
+		feeConfig.TargetPerSecond,
+		uint64(nextBlockTime.Sub(chainTime)/time.Second),
+	)
+
+	maxExcess := reply.State.MaxExcess(
+		feeConfig.MaxPerSecond,
+		feeConfig.TargetPerSecond,
+		uint64(args.Duration),
+	)
+	reply.Complexity = newTxComplexity(complexity)
+	reply.Gas = gasUsed
+	reply.Price = gas.CalculatePrice(feeConfig.MinPrice, reply.State.Excess, feeConfig.ExcessConversionConstant)
+	reply.MaxPrice = gas.CalculatePrice(feeConfig.MinPrice, maxExcess, feeConfig.ExcessConversionConstant)
+
+	txFee, err := gasUsed.Cost(reply.Price)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate fee: %w", err)
+	}
+	maxFee, err := gasUsed.Cost(reply.MaxPrice)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate max fee: %w", err)
+	}
+	// Before Fortuna, the static fee is charged regardless of the gas price.
+	if !s.vm.UpgradeConfig.IsFortunaActivated(nextBlockTime) {
+		feeCalculator := fee.NewStaticCalculator(s.vm.StaticFeeConfig, s.vm.UpgradeConfig)
+		txFee = feeCalculator.CalculateFee(utx, nextBlockTime)
+		maxFee = txFee
+	}
+	reply.Fee = avajson.Uint64(txFee)
+	reply.MaxFee = avajson.Uint64(maxFee)
+	return nil
+}
+
 // GetValidatorsAtArgs is the response from GetValidatorsAt
 type GetValidatorsAtArgs struct {
 	Height   avajson.Uint64 `json:"height"`
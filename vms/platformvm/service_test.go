@@ -35,6 +35,7 @@ import (
 	"github.com/luxfi/node/utils/formatting"
 	"github.com/luxfi/node/vms/components/lux"
 	"github.com/luxfi/node/vms/platformvm/block"
+	"github.com/luxfi/node/vms/platformvm/reward"
 	"github.com/luxfi/node/vms/platformvm/signer"
 	"github.com/luxfi/node/vms/platformvm/state"
 	"github.com/luxfi/node/vms/platformvm/status"
@@ -730,6 +731,133 @@ func TestGetCurrentValidators(t *testing.T) {
 	}
 }
 
+func TestGetPotentialRewards(t *testing.T) {
+	require := require.New(t)
+	service, _, factory := defaultService(t)
+
+	// Exactly one of nodeID and txID must be provided
+	err := service.GetPotentialRewards(nil, &GetPotentialRewardsArgs{}, &GetPotentialRewardsReply{})
+	require.ErrorIs(err, errNodeIDOrTxID)
+
+	// Add a delegator
+	stakeAmount := service.vm.MinDelegatorStake + 12345
+	validatorNodeID := genesisNodeIDs[1]
+	delegatorStartTime := defaultValidateStartTime
+	delegatorEndTime := delegatorStartTime.Add(defaultMinStakingDuration)
+
+	service.vm.ctx.Lock.Lock()
+
+	builder, signer := factory.NewWallet(keys[0])
+	utx, err := builder.NewAddDelegatorTx(
+		&txs.Validator{
+			NodeID: validatorNodeID,
+			Start:  uint64(delegatorStartTime.Unix()),
+			End:    uint64(delegatorEndTime.Unix()),
+			Wght:   stakeAmount,
+		},
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+	)
+	require.NoError(err)
+	delTx, err := walletsigner.SignUnsigned(context.Background(), signer, utx)
+	require.NoError(err)
+
+	staker, err := state.NewCurrentStaker(
+		delTx.ID(),
+		delTx.Unsigned.(*txs.AddDelegatorTx),
+		delegatorStartTime,
+		1_000_000,
+	)
+	require.NoError(err)
+
+	service.vm.state.PutCurrentDelegator(staker)
+	service.vm.state.AddTx(delTx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+
+	currentSupply, err := service.vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+
+	service.vm.ctx.Lock.Unlock()
+
+	calculator := reward.NewCalculator(service.vm.RewardConfig)
+
+	// Query the validator and its delegators
+	reply := GetPotentialRewardsReply{}
+	require.NoError(service.GetPotentialRewards(nil, &GetPotentialRewardsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		NodeID:   validatorNodeID,
+	}, &reply))
+	require.Equal(avajson.Uint64(currentSupply), reply.CurrentSupply)
+	require.Len(reply.Stakers, 2)
+
+	validator := reply.Stakers[0]
+	require.Equal("validator", validator.Role)
+	require.Equal(validatorNodeID, validator.NodeID)
+	require.NotNil(validator.DelegationFee)
+	require.Equal(
+		avajson.Uint64(calculator.Calculate(
+			time.Duration(validator.EndTime-validator.StartTime)*time.Second,
+			uint64(validator.Weight),
+			currentSupply,
+		)),
+		validator.ProjectedReward,
+	)
+
+	delegator := reply.Stakers[1]
+	require.Equal("delegator", delegator.Role)
+	require.Equal(delTx.ID(), delegator.TxID)
+	require.Equal(avajson.Uint64(staker.PotentialReward), delegator.PotentialReward)
+	require.Equal(
+		avajson.Uint64(calculator.Calculate(defaultMinStakingDuration, stakeAmount, currentSupply)),
+		delegator.ProjectedReward,
+	)
+	require.NotNil(delegator.DelegateeReward)
+	require.NotNil(delegator.DelegatorReward)
+	require.Equal(staker.PotentialReward, uint64(*delegator.DelegateeReward+*delegator.DelegatorReward))
+	require.Equal(*delegator.DelegateeReward, *validator.PotentialDelegateeReward)
+
+	// Query only the delegator
+	reply = GetPotentialRewardsReply{}
+	require.NoError(service.GetPotentialRewards(nil, &GetPotentialRewardsArgs{
+		TxID: delTx.ID(),
+	}, &reply))
+	require.Len(reply.Stakers, 1)
+	require.Equal(delegator, reply.Stakers[0])
+}
+
+func TestGetRewardHistoryInvalidRange(t *testing.T) {
+	require := require.New(t)
+	service, _, _ := defaultService(t)
+
+	err := service.GetRewardHistory(nil, &GetRewardHistoryArgs{
+		Address:     testAddress,
+		StartHeight: 2,
+		EndHeight:   1,
+	}, &GetRewardHistoryReply{})
+	require.ErrorIs(err, errInvalidHeightRange)
+
+	err = service.GetRewardHistory(nil, &GetRewardHistoryArgs{
+		Address:   testAddress,
+		EndHeight: maxRewardHistoryBlocks,
+	}, &GetRewardHistoryReply{})
+	require.ErrorIs(err, errHeightRangeTooLarge)
+}
+
+func TestGetRewardHistory(t *testing.T) {
+	require := require.New(t)
+	service, _, _ := defaultService(t)
+
+	// The genesis block doesn't reward any stakers.
+	reply := GetRewardHistoryReply{}
+	require.NoError(service.GetRewardHistory(nil, &GetRewardHistoryArgs{
+		Address: testAddress,
+	}, &reply))
+	require.Empty(reply.Rewards)
+	require.Empty(reply.Totals)
+}
+
 func TestGetTimestamp(t *testing.T) {
 	require := require.New(t)
 	service, _, _ := defaultService(t)
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/api"
+	"github.com/luxfi/node/utils/formatting"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/platformvm/txs"
+	"github.com/luxfi/node/vms/platformvm/txs/txstest"
+	"github.com/luxfi/node/vms/secp256k1fx"
+
+	walletsigner "github.com/luxfi/node/wallet/chain/p/signer"
+)
+
+func newSimulatedCreateSubnetTx(t *testing.T, factory *txstest.WalletFactory) *txs.Tx {
+	require := require.New(t)
+
+	builder, signer := factory.NewWallet(keys[0])
+	utx, err := builder.NewCreateSubnetTx(
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{keys[0].Address()},
+		},
+	)
+	require.NoError(err)
+	tx, err := walletsigner.SignUnsigned(context.Background(), signer, utx)
+	require.NoError(err)
+	return tx
+}
+
+func TestManagerSimulateTx(t *testing.T) {
+	require := require.New(t)
+
+	vm, factory, _, _, ctx := defaultVM(t, latestFork)
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	tx := newSimulatedCreateSubnetTx(t, factory)
+	utx := tx.Unsigned.(*txs.CreateSubnetTx)
+	timestamp := vm.state.GetTimestamp()
+
+	sim, err := vm.manager.SimulateTx(tx)
+	require.NoError(err)
+	require.NoError(sim.Err)
+	require.Positive(sim.Fee)
+	require.Len(sim.Consumed, len(utx.Ins))
+	require.Len(sim.Produced, len(utx.Outs))
+
+	// The preferred state isn't modified.
+	require.Equal(timestamp, vm.state.GetTimestamp())
+	for _, in := range utx.Ins {
+		_, err := vm.state.GetUTXO(in.InputID())
+		require.NoError(err)
+	}
+	_, err = vm.state.GetSubnetOwner(tx.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The simulated tx can still be issued.
+	require.NoError(vm.manager.VerifyTx(tx))
+}
+
+func TestServiceSimulateTx(t *testing.T) {
+	require := require.New(t)
+
+	vm, factory, _, _, ctx := defaultVM(t, latestFork)
+	service := &Service{
+		vm:          vm,
+		addrManager: lux.NewAddressManager(vm.ctx),
+	}
+
+	ctx.Lock.Lock()
+	tx := newSimulatedCreateSubnetTx(t, factory)
+	utx := tx.Unsigned.(*txs.CreateSubnetTx)
+	expectedSim, err := vm.manager.SimulateTx(tx)
+	require.NoError(err)
+	ctx.Lock.Unlock()
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+	args := &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}
+
+	var reply SimulateTxReply
+	require.NoError(service.SimulateTx(nil, args, &reply))
+	require.Empty(reply.Error)
+	require.Equal(formatting.Hex, reply.Encoding)
+	require.Equal(expectedSim.Fee, uint64(reply.Fee))
+	require.Empty(reply.StakerChanges)
+	require.Empty(reply.L1ValidatorChanges)
+
+	require.Len(reply.ConsumedUTXOs, len(utx.Ins))
+	for i, utxoStr := range reply.ConsumedUTXOs {
+		utxoBytes, err := formatting.Decode(formatting.Hex, utxoStr)
+		require.NoError(err)
+		var utxo lux.UTXO
+		_, err = txs.Codec.Unmarshal(utxoBytes, &utxo)
+		require.NoError(err)
+		require.Equal(utx.Ins[i].InputID(), utxo.InputID())
+	}
+	require.Len(reply.ProducedUTXOs, len(utx.Outs))
+
+	// Once the tx is accepted, its inputs are spent and simulating it again
+	// reports why it would fail.
+	require.NoError(vm.issueTxFromRPC(tx))
+	ctx.Lock.Lock()
+	blk, err := vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+	ctx.Lock.Unlock()
+
+	reply = SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, args, &reply))
+	require.NotEmpty(reply.Error)
+	require.Empty(reply.ConsumedUTXOs)
+	require.Empty(reply.ProducedUTXOs)
+
+	// Malformed txs are rejected.
+	args.Tx = "0x00"
+	require.Error(service.SimulateTx(nil, args, &SimulateTxReply{}))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/luxfi/database"
 	"github.com/luxfi/ids"
 	"github.com/luxfi/node/utils/iterator"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/components/lux"
 	"github.com/luxfi/node/vms/platformvm/fx"
 	"github.com/luxfi/node/vms/platformvm/status"
@@ -35,6 +36,7 @@ type diff struct {
 	stateVersions Versions
 
 	timestamp time.Time
+	feeState  gas.State
 
 	// Subnet ID --> supply of native asset of the subnet
 	currentSupply map[ids.ID]uint64
@@ -72,6 +74,7 @@ func NewDiff(
 		parentID:      parentID,
 		stateVersions: stateVersions,
 		timestamp:     parentState.GetTimestamp(),
+		feeState:      parentState.GetFeeState(),
 		subnetOwners:  make(map[ids.ID]fx.Owner),
 	}, nil
 }
@@ -98,6 +101,14 @@ func (d *diff) SetTimestamp(timestamp time.Time) {
 	d.timestamp = timestamp
 }
 
+func (d *diff) GetFeeState() gas.State {
+	return d.feeState
+}
+
+func (d *diff) SetFeeState(feeState gas.State) {
+	d.feeState = feeState
+}
+
 func (d *diff) GetCurrentSupply(subnetID ids.ID) (uint64, error) {
 	supply, ok := d.currentSupply[subnetID]
 	if ok {
@@ -433,6 +444,7 @@ func (d *diff) PutL1Validator(validator L1Validator) error {
 
 func (d *diff) Apply(baseState Chain) error {
 	baseState.SetTimestamp(d.timestamp)
+	baseState.SetFeeState(d.feeState)
 	for subnetID, supply := range d.currentSupply {
 		baseState.SetCurrentSupply(subnetID, supply)
 	}
@@ -19,6 +19,7 @@ import (
 	database "github.com/luxfi/database"
 	ids "github.com/luxfi/ids"
 	log "github.com/luxfi/log"
+	gas "github.com/luxfi/node/vms/components/gas"
 	lux "github.com/luxfi/node/vms/components/lux"
 	block "github.com/luxfi/node/vms/platformvm/block"
 	fx "github.com/luxfi/node/vms/platformvm/fx"
@@ -175,6 +176,20 @@ func (mr *MockStateMockRecorder) ApplyValidatorWeightDiffs(ctx, arg1, startHeigh
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyValidatorWeightDiffs", reflect.TypeOf((*MockState)(nil).ApplyValidatorWeightDiffs), ctx, arg1, startHeight, endHeight, subnetID)
 }
 
+// BeginSync mocks base method.
+func (m *MockState) BeginSync(summary []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginSync", summary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BeginSync indicates an expected call of BeginSync.
+func (mr *MockStateMockRecorder) BeginSync(summary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginSync", reflect.TypeOf((*MockState)(nil).BeginSync), summary)
+}
+
 // Checksum mocks base method.
 func (m *MockState) Checksum() ids.ID {
 	m.ctrl.T.Helper()
@@ -292,6 +307,20 @@ func (mr *MockStateMockRecorder) DeleteUTXO(utxoID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockState)(nil).DeleteUTXO), utxoID)
 }
 
+// FinishSync mocks base method.
+func (m *MockState) FinishSync(blk block.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishSync", blk)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishSync indicates an expected call of FinishSync.
+func (mr *MockStateMockRecorder) FinishSync(blk any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishSync", reflect.TypeOf((*MockState)(nil).FinishSync), blk)
+}
+
 // GetBlockIDAtHeight mocks base method.
 func (m *MockState) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -397,6 +426,20 @@ func (mr *MockStateMockRecorder) GetDelegateeReward(subnetID, nodeID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockState)(nil).GetDelegateeReward), subnetID, nodeID)
 }
 
+// GetFeeState mocks base method.
+func (m *MockState) GetFeeState() gas.State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeState")
+	ret0, _ := ret[0].(gas.State)
+	return ret0
+}
+
+// GetFeeState indicates an expected call of GetFeeState.
+func (mr *MockStateMockRecorder) GetFeeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeState", reflect.TypeOf((*MockState)(nil).GetFeeState))
+}
+
 // GetL1Validator mocks base method.
 func (m *MockState) GetL1Validator(validationID ids.ID) (L1Validator, error) {
 	m.ctrl.T.Helper()
@@ -426,6 +469,36 @@ func (mr *MockStateMockRecorder) GetLastAccepted() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAccepted", reflect.TypeOf((*MockState)(nil).GetLastAccepted))
 }
 
+// GetLastSyncSnapshot mocks base method.
+func (m *MockState) GetLastSyncSnapshot() (*SyncSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastSyncSnapshot")
+	ret0, _ := ret[0].(*SyncSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastSyncSnapshot indicates an expected call of GetLastSyncSnapshot.
+func (mr *MockStateMockRecorder) GetLastSyncSnapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastSyncSnapshot", reflect.TypeOf((*MockState)(nil).GetLastSyncSnapshot))
+}
+
+// GetOngoingSync mocks base method.
+func (m *MockState) GetOngoingSync() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOngoingSync")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOngoingSync indicates an expected call of GetOngoingSync.
+func (mr *MockStateMockRecorder) GetOngoingSync() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOngoingSync", reflect.TypeOf((*MockState)(nil).GetOngoingSync))
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockState) GetPendingDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -561,6 +634,36 @@ func (mr *MockStateMockRecorder) GetSubnetTransformation(subnetID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockState)(nil).GetSubnetTransformation), subnetID)
 }
 
+// GetSyncChunk mocks base method.
+func (m *MockState) GetSyncChunk(height uint64, index uint32) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncChunk", height, index)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncChunk indicates an expected call of GetSyncChunk.
+func (mr *MockStateMockRecorder) GetSyncChunk(height, index any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncChunk", reflect.TypeOf((*MockState)(nil).GetSyncChunk), height, index)
+}
+
+// GetSyncSnapshot mocks base method.
+func (m *MockState) GetSyncSnapshot(height uint64) (*SyncSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncSnapshot", height)
+	ret0, _ := ret[0].(*SyncSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncSnapshot indicates an expected call of GetSyncSnapshot.
+func (mr *MockStateMockRecorder) GetSyncSnapshot(height any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncSnapshot", reflect.TypeOf((*MockState)(nil).GetSyncSnapshot), height)
+}
+
 // GetTimestamp mocks base method.
 func (m *MockState) GetTimestamp() time.Time {
 	m.ctrl.T.Helper()
@@ -739,6 +842,18 @@ func (mr *MockStateMockRecorder) SetDelegateeReward(subnetID, nodeID, amount any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockState)(nil).SetDelegateeReward), subnetID, nodeID, amount)
 }
 
+// SetFeeState mocks base method.
+func (m *MockState) SetFeeState(f gas.State) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeState", f)
+}
+
+// SetFeeState indicates an expected call of SetFeeState.
+func (mr *MockStateMockRecorder) SetFeeState(f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeState", reflect.TypeOf((*MockState)(nil).SetFeeState), f)
+}
+
 // SetHeight mocks base method.
 func (m *MockState) SetHeight(height uint64) {
 	m.ctrl.T.Helper()
@@ -830,3 +945,17 @@ func (mr *MockStateMockRecorder) WeightOfL1Validators(subnetID any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WeightOfL1Validators", reflect.TypeOf((*MockState)(nil).WeightOfL1Validators), subnetID)
 }
+
+// WriteSyncChunk mocks base method.
+func (m *MockState) WriteSyncChunk(chunk []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteSyncChunk", chunk)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteSyncChunk indicates an expected call of WriteSyncChunk.
+func (mr *MockStateMockRecorder) WriteSyncChunk(chunk any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteSyncChunk", reflect.TypeOf((*MockState)(nil).WriteSyncChunk), chunk)
+}
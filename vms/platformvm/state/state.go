@@ -34,6 +34,7 @@ import (
 	"github.com/luxfi/node/utils/hashing"
 	"github.com/luxfi/node/utils/timer"
 	"github.com/luxfi/node/utils/wrappers"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/components/lux"
 	"github.com/luxfi/node/vms/platformvm/block"
 	"github.com/luxfi/node/vms/platformvm/config"
@@ -80,13 +81,17 @@ var (
 	SupplyPrefix                  = []byte("supply")
 	ChainPrefix                   = []byte("chain")
 	SingletonPrefix               = []byte("singleton")
-
-	TimestampKey       = []byte("timestamp")
-	CurrentSupplyKey   = []byte("current supply")
-	LastAcceptedKey    = []byte("last accepted")
-	HeightsIndexedKey  = []byte("heights indexed")
-	InitializedKey     = []byte("initialized")
-	BlocksReindexedKey = []byte("blocks reindexed")
+	SyncSnapshotPrefix            = []byte("syncSnapshot")
+
+	TimestampKey        = []byte("timestamp")
+	FeeStateKey         = []byte("fee state")
+	CurrentSupplyKey    = []byte("current supply")
+	LastAcceptedKey     = []byte("last accepted")
+	HeightsIndexedKey   = []byte("heights indexed")
+	InitializedKey      = []byte("initialized")
+	BlocksReindexedKey  = []byte("blocks reindexed")
+	LastSyncSnapshotKey = []byte("last sync snapshot")
+	OngoingSyncKey      = []byte("ongoing sync")
 )
 
 // Chain collects all methods to manage the state of the chain for block
@@ -101,6 +106,9 @@ type Chain interface {
 	GetTimestamp() time.Time
 	SetTimestamp(tm time.Time)
 
+	GetFeeState() gas.State
+	SetFeeState(f gas.State)
+
 	GetCurrentSupply(subnetID ids.ID) (uint64, error)
 	SetCurrentSupply(subnetID ids.ID, cs uint64)
 
@@ -203,6 +211,36 @@ type State interface {
 
 	Checksum() ids.ID
 
+	// GetSyncSnapshot returns the snapshot of the state that was taken at
+	// [height]. If no snapshot was taken at [height], or it was pruned,
+	// [database.ErrNotFound] is returned.
+	GetSyncSnapshot(height uint64) (*SyncSnapshot, error)
+
+	// GetLastSyncSnapshot returns the most recent snapshot of the state. If no
+	// snapshot was taken, [database.ErrNotFound] is returned.
+	GetLastSyncSnapshot() (*SyncSnapshot, error)
+
+	// GetSyncChunk returns the serialized chunk [index] of the snapshot taken
+	// at [height].
+	GetSyncChunk(height uint64, index uint32) ([]byte, error)
+
+	// GetOngoingSync returns the summary passed to BeginSync if the state is
+	// being synced. Otherwise, [database.ErrNotFound] is returned.
+	GetOngoingSync() ([]byte, error)
+
+	// BeginSync marks [summary] as being synced and removes the parts of the
+	// state that are replaced by state sync. Until FinishSync returns, the
+	// state must only be modified with WriteSyncChunk.
+	BeginSync(summary []byte) error
+
+	// WriteSyncChunk writes the key/value pairs of the serialized [chunk]
+	// directly to disk.
+	WriteSyncChunk(chunk []byte) error
+
+	// FinishSync marks [blk] as the last accepted block and reloads the state
+	// from disk once all the chunks of the synced summary have been written.
+	FinishSync(blk block.Block) error
+
 	Close() error
 }
 
@@ -284,13 +322,19 @@ func init() {
  * | '-. subnetID
  * |   '-. list
  * |     '-- txID -> nil
+ * |-. syncSnapshots
+ * | |-- height -> snapshot
+ * | '-- height + index -> chunk
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- blocksReindexedKey -> nil
  *   |-- timestampKey -> timestamp
+ *   |-- feeStateKey -> feeState
  *   |-- currentSupplyKey -> currentSupply
  *   |-- lastAcceptedKey -> lastAccepted
- *   '-- heightsIndexKey -> startIndexHeight + endIndexHeight
+ *   |-- heightsIndexKey -> startIndexHeight + endIndexHeight
+ *   |-- lastSyncSnapshotKey -> height
+ *   '-- ongoingSyncKey -> summary
  */
 type state struct {
 	validatorState
@@ -378,11 +422,30 @@ type state struct {
 
 	// The persisted fields represent the current database value
 	timestamp, persistedTimestamp         time.Time
+	feeState, persistedFeeState           gas.State
 	currentSupply, persistedCurrentSupply uint64
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	indexedHeights *heightRange
 	singletonDB    database.Database
+
+	// A snapshot is taken when the next block at a multiple of
+	// [syncSummaryFrequency] is committed.
+	syncSummaryFrequency uint64
+	// syncChunkSize is [SyncChunkSize] outside of tests.
+	syncChunkSize       int
+	syncSnapshotPending bool
+	// Snapshots are written in the background, so they are written to the
+	// underlying database rather than to [baseDB].
+	syncSnapshotDB     database.Database
+	lastSyncSnapshotDB database.Database
+	// syncSnapshotLock is held while a snapshot is written.
+	syncSnapshotLock sync.Mutex
+	// syncSnapshotWG tracks the snapshots being written.
+	syncSnapshotWG sync.WaitGroup
+	// syncSnapshotCtx is cancelled on Close to stop writing snapshots.
+	syncSnapshotCtx    context.Context
+	syncSnapshotCancel context.CancelFunc
 }
 
 // heightRange is used to track which heights are safe to use the native DB
@@ -493,19 +556,22 @@ func newState(
 	metricsReg prometheus.Registerer,
 	rewards reward.Calculator,
 ) (*state, error) {
-	blockIDCache, err := metercacher.New[uint64, ids.ID](
+	blockIDCache, err := newMeteredCache[uint64, ids.ID](
 		"block_id_cache",
 		metricsReg,
-		&cache.LRU[uint64, ids.ID]{Size: execCfg.BlockIDCacheSize},
+		execCfg.CachePolicy,
+		execCfg.BlockIDCacheSize,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	blockCache, err := metercacher.New[ids.ID, block.Block](
+	blockCache, err := newSizedMeteredCache[ids.ID, block.Block](
 		"block_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, block.Block](execCfg.BlockCacheSize, blockSize),
+		execCfg.CachePolicy,
+		execCfg.BlockCacheSize,
+		blockSize,
 	)
 	if err != nil {
 		return nil, err
@@ -530,27 +596,30 @@ func newState(
 	validatorWeightDiffsDB := prefixdb.New(ValidatorWeightDiffsPrefix, validatorsDB)
 	validatorPublicKeyDiffsDB := prefixdb.New(ValidatorPublicKeyDiffsPrefix, validatorsDB)
 
-	txCache, err := metercacher.New(
+	txCache, err := newSizedMeteredCache[ids.ID, *txAndStatus](
 		"tx_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, *txAndStatus](execCfg.TxCacheSize, txAndStatusSize),
+		execCfg.CachePolicy,
+		execCfg.TxCacheSize,
+		txAndStatusSize,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	rewardUTXODB := prefixdb.New(RewardUTXOsPrefix, baseDB)
-	rewardUTXOsCache, err := metercacher.New[ids.ID, []*lux.UTXO](
+	rewardUTXOsCache, err := newMeteredCache[ids.ID, []*lux.UTXO](
 		"reward_utxos_cache",
 		metricsReg,
-		&cache.LRU[ids.ID, []*lux.UTXO]{Size: execCfg.RewardUTXOsCacheSize},
+		execCfg.CachePolicy,
+		execCfg.RewardUTXOsCacheSize,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	utxoDB := prefixdb.New(UTXOPrefix, baseDB)
-	utxoState, err := lux.NewMeteredUTXOState(utxoDB, txs.GenesisCodec, metricsReg, execCfg.ChecksumsEnabled)
+	utxoState, err := lux.NewMeteredUTXOState(utxoDB, txs.GenesisCodec, metricsReg, execCfg.CachePolicy, execCfg.ChecksumsEnabled)
 	if err != nil {
 		return nil, err
 	}
@@ -558,53 +627,61 @@ func newState(
 	subnetBaseDB := prefixdb.New(SubnetPrefix, baseDB)
 
 	subnetOwnerDB := prefixdb.New(SubnetOwnerPrefix, baseDB)
-	subnetOwnerCache, err := metercacher.New[ids.ID, fxOwnerAndSize](
+	subnetOwnerCache, err := newSizedMeteredCache[ids.ID, fxOwnerAndSize](
 		"subnet_owner_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, fxOwnerAndSize](execCfg.FxOwnerCacheSize, func(_ ids.ID, f fxOwnerAndSize) int {
+		execCfg.CachePolicy,
+		execCfg.FxOwnerCacheSize,
+		func(_ ids.ID, f fxOwnerAndSize) int {
 			return ids.IDLen + f.size
-		}),
+		},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	transformedSubnetCache, err := metercacher.New(
+	transformedSubnetCache, err := newSizedMeteredCache[ids.ID, *txs.Tx](
 		"transformed_subnet_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, *txs.Tx](execCfg.TransformedSubnetTxCacheSize, txSize),
+		execCfg.CachePolicy,
+		execCfg.TransformedSubnetTxCacheSize,
+		txSize,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	supplyCache, err := metercacher.New[ids.ID, *uint64](
+	supplyCache, err := newMeteredCache[ids.ID, *uint64](
 		"supply_cache",
 		metricsReg,
-		&cache.LRU[ids.ID, *uint64]{Size: execCfg.ChainCacheSize},
+		execCfg.CachePolicy,
+		execCfg.ChainCacheSize,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	chainCache, err := metercacher.New[ids.ID, []*txs.Tx](
+	chainCache, err := newMeteredCache[ids.ID, []*txs.Tx](
 		"chain_cache",
 		metricsReg,
-		&cache.LRU[ids.ID, []*txs.Tx]{Size: execCfg.ChainCacheSize},
+		execCfg.CachePolicy,
+		execCfg.ChainCacheSize,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	chainDBCache, err := metercacher.New[ids.ID, linkeddb.LinkedDB](
+	chainDBCache, err := newMeteredCache[ids.ID, linkeddb.LinkedDB](
 		"chain_db_cache",
 		metricsReg,
-		&cache.LRU[ids.ID, linkeddb.LinkedDB]{Size: execCfg.ChainDBCacheSize},
+		execCfg.CachePolicy,
+		execCfg.ChainDBCacheSize,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	syncSnapshotCtx, syncSnapshotCancel := context.WithCancel(context.Background())
 	return &state{
 		validatorState: newValidatorState(),
 
@@ -683,9 +760,47 @@ func newState(
 		chainDBCache: chainDBCache,
 
 		singletonDB: prefixdb.New(SingletonPrefix, baseDB),
+
+		syncSummaryFrequency: execCfg.StateSyncSummaryFrequency,
+		syncChunkSize:        SyncChunkSize,
+		syncSnapshotDB:       prefixdb.New(SyncSnapshotPrefix, db),
+		lastSyncSnapshotDB:   prefixdb.New(SingletonPrefix, db),
+		syncSnapshotCtx:      syncSnapshotCtx,
+		syncSnapshotCancel:   syncSnapshotCancel,
 	}, nil
 }
 
+// newMeteredCache returns a cache with [policy] that holds at most [size]
+// entries and reports its metrics under [namespace].
+func newMeteredCache[K comparable, V any](
+	namespace string,
+	metricsReg prometheus.Registerer,
+	policy cache.Policy,
+	size int,
+) (cache.Cacher[K, V], error) {
+	c, err := cache.NewCacher[K, V](policy, size)
+	if err != nil {
+		return nil, err
+	}
+	return metercacher.New(namespace, metricsReg, c)
+}
+
+// newSizedMeteredCache returns a cache with [policy] that holds entries with a
+// total size of at most [maxSize] and reports its metrics under [namespace].
+func newSizedMeteredCache[K comparable, V any](
+	namespace string,
+	metricsReg prometheus.Registerer,
+	policy cache.Policy,
+	maxSize int,
+	size func(K, V) int,
+) (cache.Cacher[K, V], error) {
+	c, err := cache.NewSizedCacher(policy, maxSize, size)
+	if err != nil {
+		return nil, err
+	}
+	return metercacher.New(namespace, metricsReg, c)
+}
+
 func (s *state) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
 	return s.currentStakers.GetValidator(subnetID, nodeID)
 }
@@ -1044,6 +1159,14 @@ func (s *state) SetTimestamp(tm time.Time) {
 	s.timestamp = tm
 }
 
+func (s *state) GetFeeState() gas.State {
+	return s.feeState
+}
+
+func (s *state) SetFeeState(feeState gas.State) {
+	s.feeState = feeState
+}
+
 func (s *state) GetLastAccepted() ids.ID {
 	return s.lastAccepted
 }
@@ -1326,6 +1449,13 @@ func (s *state) loadMetadata() error {
 	s.persistedTimestamp = timestamp
 	s.SetTimestamp(timestamp)
 
+	feeState, err := getFeeState(s.singletonDB)
+	if err != nil {
+		return err
+	}
+	s.persistedFeeState = feeState
+	s.SetFeeState(feeState)
+
 	currentSupply, err := database.GetUInt64(s.singletonDB, CurrentSupplyKey)
 	if err != nil {
 		return err
@@ -1687,6 +1817,10 @@ func (s *state) write(updateValidators bool, height uint64) error {
 }
 
 func (s *state) Close() error {
+	// Snapshots must stop being written before the database is closed.
+	s.syncSnapshotCancel()
+	s.syncSnapshotWG.Wait()
+
 	// All the prefix databases share the same underlying baseDB,
 	// so we only need to close the base database once.
 	return s.baseDB.Close()
@@ -1712,6 +1846,15 @@ func (s *state) sync(genesis []byte) error {
 		}
 	}
 
+	// If the state is being synced, it will be loaded once the sync finishes.
+	isSyncing, err := s.singletonDB.Has(OngoingSyncKey)
+	if err != nil {
+		return err
+	}
+	if isSyncing {
+		return nil
+	}
+
 	if err := s.load(); err != nil {
 		return fmt.Errorf(
 			"failed to load the database state: %w",
@@ -1764,6 +1907,12 @@ func (s *state) SetHeight(height uint64) {
 
 	s.indexedHeights.UpperBound = height
 	s.currentHeight = height
+
+	// If the block at a summary height is a proposal block, it is committed
+	// along with its option, so the snapshot is taken at the option's height.
+	if s.syncSummaryFrequency != 0 && height%s.syncSummaryFrequency == 0 {
+		s.syncSnapshotPending = true
+	}
 }
 
 func (s *state) Commit() error {
@@ -1789,6 +1938,12 @@ func (s *state) CommitBatch() (database.Batch, error) {
 	if err := s.write(true /*=updateValidators*/, s.currentHeight); err != nil {
 		return nil, err
 	}
+	if s.syncSnapshotPending {
+		if err := s.startSyncSnapshot(); err != nil {
+			return nil, err
+		}
+		s.syncSnapshotPending = false
+	}
 	return s.baseDB.CommitBatch()
 }
 
@@ -2284,6 +2439,12 @@ func (s *state) writeMetadata() error {
 		}
 		s.persistedTimestamp = s.timestamp
 	}
+	if s.persistedFeeState != s.feeState {
+		if err := putFeeState(s.singletonDB, s.feeState); err != nil {
+			return fmt.Errorf("failed to write fee state: %w", err)
+		}
+		s.persistedFeeState = s.feeState
+	}
 	if s.persistedCurrentSupply != s.currentSupply {
 		if err := database.PutUInt64(s.singletonDB, CurrentSupplyKey, s.currentSupply); err != nil {
 			return fmt.Errorf("failed to write current supply: %w", err)
@@ -2569,3 +2730,27 @@ func (s *state) NumActiveL1Validators() int {
 	}
 	return count
 }
+
+func getFeeState(db database.KeyValueReader) (gas.State, error) {
+	feeStateBytes, err := db.Get(FeeStateKey)
+	if err == database.ErrNotFound {
+		return gas.State{}, nil
+	}
+	if err != nil {
+		return gas.State{}, err
+	}
+
+	var feeState gas.State
+	if _, err := block.GenesisCodec.Unmarshal(feeStateBytes, &feeState); err != nil {
+		return gas.State{}, fmt.Errorf("failed to unmarshal fee state: %w", err)
+	}
+	return feeState, nil
+}
+
+func putFeeState(db database.KeyValueWriter, feeState gas.State) error {
+	feeStateBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, feeState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fee state: %w", err)
+	}
+	return db.Put(FeeStateKey, feeStateBytes)
+}
@@ -0,0 +1,660 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/database/linkeddb"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+	"github.com/luxfi/math/set"
+	"github.com/luxfi/node/utils"
+	"github.com/luxfi/node/utils/constants"
+	"github.com/luxfi/node/utils/hashing"
+	"github.com/luxfi/node/utils/units"
+	"github.com/luxfi/node/utils/wrappers"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/platformvm/block"
+	"github.com/luxfi/node/vms/platformvm/txs"
+)
+
+const (
+	// numRetainedSyncSnapshots is the number of the most recent snapshots that
+	// are kept on disk to serve to syncing nodes.
+	numRetainedSyncSnapshots = 2
+
+	// syncKeyValueOverhead is the number of bytes used to serialize a
+	// [SyncKeyValue] in addition to its key and value.
+	syncKeyValueOverhead = 1 + 2*wrappers.IntLen
+
+	syncClearWriteSize = 4096
+
+	// SyncChunkSize is the targeted number of bytes of state in each chunk of
+	// a snapshot. The chunk boundaries are committed to by the state summary,
+	// so every node must use the same size to produce the same summary.
+	SyncChunkSize = 256 * units.KiB
+)
+
+var (
+	errUnknownSyncSection     = errors.New("unknown sync section")
+	errUnexpectedSyncKey      = errors.New("unexpected sync key")
+	errSyncedBlockMismatch    = errors.New("synced block doesn't match the synced last accepted block")
+	errInvalidSyncSnapshotKey = errors.New("invalid sync snapshot key")
+
+	// syncedMetadataKeys are the singletons that are transferred during state
+	// sync. The remaining singletons describe the local database rather than
+	// the state of the chain.
+	syncedMetadataKeys = [][]byte{
+		TimestampKey,
+		FeeStateKey,
+		CurrentSupplyKey,
+		LastAcceptedKey,
+	}
+)
+
+// SyncSection identifies the part of the state that a [SyncKeyValue] belongs
+// to.
+type SyncSection byte
+
+const (
+	CurrentStakersSyncSection SyncSection = iota
+	PendingStakersSyncSection
+	UTXOsSyncSection
+	SubnetsSyncSection
+	SubnetOwnersSyncSection
+	TransformedSubnetsSyncSection
+	SuppliesSyncSection
+	ChainsSyncSection
+	TxsSyncSection
+	MetadataSyncSection
+	L1ValidatorsSyncSection
+)
+
+// SyncKeyValue is a key/value pair of the state that is transferred during
+// state sync.
+type SyncKeyValue struct {
+	Section SyncSection `serialize:"true"`
+	Key     []byte      `serialize:"true"`
+	Value   []byte      `serialize:"true"`
+}
+
+// SyncChunk is a contiguous part of a [SyncSnapshot].
+type SyncChunk struct {
+	KeyValues []SyncKeyValue `serialize:"true"`
+}
+
+// SyncSnapshot describes the state as of the accepted block [BlockID] at
+// [Height]. The state is split into chunks and [Chunks] contains the hash of
+// each serialized chunk, in order.
+type SyncSnapshot struct {
+	Height  uint64   `serialize:"true"`
+	BlockID ids.ID   `serialize:"true"`
+	Chunks  []ids.ID `serialize:"true"`
+}
+
+func syncSnapshotKey(height uint64) []byte {
+	return database.PackUInt64(height)
+}
+
+func syncChunkKey(height uint64, index uint32) []byte {
+	key := make([]byte, database.Uint64Size+wrappers.IntLen)
+	copy(key, database.PackUInt64(height))
+	copy(key[database.Uint64Size:], database.PackUInt32(index))
+	return key
+}
+
+func (s *state) GetSyncSnapshot(height uint64) (*SyncSnapshot, error) {
+	snapshotBytes, err := s.syncSnapshotDB.Get(syncSnapshotKey(height))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &SyncSnapshot{}
+	if _, err := block.GenesisCodec.Unmarshal(snapshotBytes, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sync snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+func (s *state) GetLastSyncSnapshot() (*SyncSnapshot, error) {
+	height, err := database.GetUInt64(s.lastSyncSnapshotDB, LastSyncSnapshotKey)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSyncSnapshot(height)
+}
+
+func (s *state) GetSyncChunk(height uint64, index uint32) ([]byte, error) {
+	return s.syncSnapshotDB.Get(syncChunkKey(height, index))
+}
+
+func (s *state) GetOngoingSync() ([]byte, error) {
+	return s.singletonDB.Get(OngoingSyncKey)
+}
+
+func (s *state) BeginSync(summary []byte) error {
+	if err := s.singletonDB.Put(OngoingSyncKey, summary); err != nil {
+		return err
+	}
+
+	// Deleting the UTXOs through [utxoState] keeps its caches and address
+	// index consistent.
+	utxoIt := lux.NewUTXOIterator(s.utxoDB, nil)
+	var utxoIDs []ids.ID
+	for utxoIt.Next() {
+		utxoID, err := ids.ToID(utxoIt.Key())
+		if err != nil {
+			utxoIt.Release()
+			return err
+		}
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	err := utxoIt.Error()
+	utxoIt.Release()
+	if err != nil {
+		return err
+	}
+	for _, utxoID := range utxoIDs {
+		if err := s.utxoState.DeleteUTXO(utxoID); err != nil {
+			return err
+		}
+	}
+
+	// Transactions are content addressed, so they are kept.
+	for _, db := range []database.Database{
+		s.currentValidatorsDB,
+		s.pendingValidatorsDB,
+		s.subnetBaseDB,
+		s.subnetOwnerDB,
+		s.transformedSubnetDB,
+		s.supplyDB,
+		s.chainDB,
+	} {
+		if err := database.Clear(db, syncClearWriteSize); err != nil {
+			return err
+		}
+	}
+	s.l1Validators = make(map[ids.ID]L1Validator)
+	return s.baseDB.Commit()
+}
+
+func (s *state) WriteSyncChunk(chunkBytes []byte) error {
+	chunk := &SyncChunk{}
+	if _, err := block.GenesisCodec.Unmarshal(chunkBytes, chunk); err != nil {
+		return fmt.Errorf("failed to unmarshal sync chunk: %w", err)
+	}
+
+	for _, kv := range chunk.KeyValues {
+		switch kv.Section {
+		case UTXOsSyncSection:
+			utxo := &lux.UTXO{}
+			if _, err := txs.GenesisCodec.Unmarshal(kv.Value, utxo); err != nil {
+				return fmt.Errorf("failed to unmarshal synced UTXO: %w", err)
+			}
+			if utxoID := utxo.InputID(); !bytes.Equal(utxoID[:], kv.Key) {
+				return fmt.Errorf("%w: UTXO %s stored at %x", errUnexpectedSyncKey, utxoID, kv.Key)
+			}
+			if err := s.utxoState.PutUTXO(utxo); err != nil {
+				return err
+			}
+		case L1ValidatorsSyncSection:
+			validationID, err := ids.ToID(kv.Key)
+			if err != nil {
+				return err
+			}
+			l1Validator := L1Validator{
+				ValidationID: validationID,
+			}
+			if _, err := block.GenesisCodec.Unmarshal(kv.Value, &l1Validator); err != nil {
+				return fmt.Errorf("failed to unmarshal synced L1 validator: %w", err)
+			}
+			s.l1Validators[validationID] = l1Validator
+		case MetadataSyncSection:
+			if !isSyncedMetadataKey(kv.Key) {
+				return fmt.Errorf("%w: %q", errUnexpectedSyncKey, kv.Key)
+			}
+			if err := s.singletonDB.Put(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		default:
+			db, err := s.syncSectionDB(kv.Section)
+			if err != nil {
+				return err
+			}
+			if err := db.Put(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return s.baseDB.Commit()
+}
+
+func (s *state) FinishSync(blk block.Block) error {
+	blkID := blk.ID()
+	lastAccepted, err := database.GetID(s.singletonDB, LastAcceptedKey)
+	if err != nil {
+		return err
+	}
+	if lastAccepted != blkID {
+		return fmt.Errorf("%w: expected %s but got %s", errSyncedBlockMismatch, lastAccepted, blkID)
+	}
+
+	// Remove the validators of the replaced state so that the validator sets
+	// can be initialized from the synced state.
+	for subnetID, validators := range s.currentStakers.validators {
+		for nodeID := range validators {
+			weight := s.validators.GetWeight(subnetID, nodeID)
+			if err := s.validators.RemoveWeight(subnetID, nodeID, weight); err != nil {
+				return err
+			}
+		}
+	}
+	s.validatorState = newValidatorState()
+
+	// The databases were modified without going through the caches, so any
+	// cached values may be stale.
+	s.blockIDCache.Flush()
+	s.txCache.Flush()
+	s.subnetOwnerCache.Flush()
+	s.transformedSubnetCache.Flush()
+	s.supplyCache.Flush()
+	s.chainCache.Flush()
+	s.chainDBCache.Flush()
+	s.cachedSubnetIDs = nil
+	s.currentValidatorList = linkeddb.NewDefault(s.currentValidatorBaseDB)
+	s.currentDelegatorList = linkeddb.NewDefault(s.currentDelegatorBaseDB)
+	s.currentSubnetValidatorList = linkeddb.NewDefault(s.currentSubnetValidatorBaseDB)
+	s.currentSubnetDelegatorList = linkeddb.NewDefault(s.currentSubnetDelegatorBaseDB)
+	s.pendingValidatorList = linkeddb.NewDefault(s.pendingValidatorBaseDB)
+	s.pendingDelegatorList = linkeddb.NewDefault(s.pendingDelegatorBaseDB)
+	s.pendingSubnetValidatorList = linkeddb.NewDefault(s.pendingSubnetValidatorBaseDB)
+	s.pendingSubnetDelegatorList = linkeddb.NewDefault(s.pendingSubnetDelegatorBaseDB)
+	s.subnetDB = linkeddb.NewDefault(s.subnetBaseDB)
+
+	// Validator set diffs are only available after the synced block.
+	height := blk.Height()
+	s.AddStatelessBlock(blk)
+	s.currentHeight = height
+	s.indexedHeights = &heightRange{
+		LowerBound: height,
+		UpperBound: height,
+	}
+	if err := s.singletonDB.Delete(OngoingSyncKey); err != nil {
+		return err
+	}
+	if err := s.Commit(); err != nil {
+		return err
+	}
+	return s.load()
+}
+
+// syncSectionDB returns the database that contains the key/value pairs of
+// [section].
+func (s *state) syncSectionDB(section SyncSection) (database.Database, error) {
+	switch section {
+	case CurrentStakersSyncSection:
+		return s.currentValidatorsDB, nil
+	case PendingStakersSyncSection:
+		return s.pendingValidatorsDB, nil
+	case SubnetsSyncSection:
+		return s.subnetBaseDB, nil
+	case SubnetOwnersSyncSection:
+		return s.subnetOwnerDB, nil
+	case TransformedSubnetsSyncSection:
+		return s.transformedSubnetDB, nil
+	case SuppliesSyncSection:
+		return s.supplyDB, nil
+	case ChainsSyncSection:
+		return s.chainDB, nil
+	case TxsSyncSection:
+		return s.txDB, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnknownSyncSection, section)
+	}
+}
+
+func isSyncedMetadataKey(key []byte) bool {
+	for _, syncedKey := range syncedMetadataKeys {
+		if bytes.Equal(key, syncedKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// syncStateView is a view of the state that is transferred during state sync,
+// as of when the view was created. The bulk of the state is read through
+// database iterators, which aren't affected by later writes, so the view can
+// be iterated while later blocks are accepted.
+type syncStateView struct {
+	// sections[i] is the section of the key/value pairs of iterators[i].
+	sections  []SyncSection
+	iterators []database.Iterator
+
+	// Transactions are never removed, so they are read when the view is
+	// iterated.
+	txDB  database.Database
+	txIDs []ids.ID
+
+	// metadata and l1Validators are small, so they are read when the view is
+	// created.
+	metadata     []SyncKeyValue
+	l1Validators []SyncKeyValue
+}
+
+// newSyncStateView returns a view of the state as of the last accepted block.
+// The view must be released once it is no longer needed.
+func (s *state) newSyncStateView() (*syncStateView, error) {
+	v := &syncStateView{
+		sections: []SyncSection{
+			UTXOsSyncSection,
+			CurrentStakersSyncSection,
+			PendingStakersSyncSection,
+			SubnetsSyncSection,
+			SubnetOwnersSyncSection,
+			TransformedSubnetsSyncSection,
+			SuppliesSyncSection,
+			ChainsSyncSection,
+		},
+		txDB: s.txDB,
+	}
+	v.iterators = append(v.iterators, lux.NewUTXOIterator(s.utxoDB, nil))
+	for _, section := range v.sections[1:] {
+		db, err := s.syncSectionDB(section)
+		if err != nil {
+			v.release()
+			return nil, err
+		}
+		v.iterators = append(v.iterators, db.NewIterator())
+	}
+
+	var err error
+	v.txIDs, err = s.syncedTxIDs()
+	if err != nil {
+		v.release()
+		return nil, err
+	}
+
+	for _, key := range syncedMetadataKeys {
+		value, err := s.singletonDB.Get(key)
+		if err != nil {
+			v.release()
+			return nil, fmt.Errorf("failed to get %q: %w", key, err)
+		}
+		v.metadata = append(v.metadata, SyncKeyValue{
+			Section: MetadataSyncSection,
+			Key:     key,
+			Value:   value,
+		})
+	}
+
+	validationIDs := maps.Keys(s.l1Validators)
+	utils.Sort(validationIDs)
+	for _, validationID := range validationIDs {
+		l1ValidatorBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, s.l1Validators[validationID])
+		if err != nil {
+			v.release()
+			return nil, fmt.Errorf("failed to marshal L1 validator: %w", err)
+		}
+		v.l1Validators = append(v.l1Validators, SyncKeyValue{
+			Section: L1ValidatorsSyncSection,
+			Key:     validationID[:],
+			Value:   l1ValidatorBytes,
+		})
+	}
+	return v, nil
+}
+
+// iterate calls [f] with every key/value pair of the view, in a deterministic
+// order. The view can only be iterated once.
+func (v *syncStateView) iterate(f func(SyncKeyValue) error) error {
+	for i, it := range v.iterators {
+		if err := iterateSyncSection(v.sections[i], it, f); err != nil {
+			return err
+		}
+	}
+
+	for _, txID := range v.txIDs {
+		txBytes, err := v.txDB.Get(txID[:])
+		if err != nil {
+			return fmt.Errorf("failed to get tx %s: %w", txID, err)
+		}
+		if err := f(SyncKeyValue{
+			Section: TxsSyncSection,
+			Key:     txID[:],
+			Value:   txBytes,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, kvs := range [][]SyncKeyValue{v.metadata, v.l1Validators} {
+		for _, kv := range kvs {
+			if err := f(kv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *syncStateView) release() {
+	for _, it := range v.iterators {
+		it.Release()
+	}
+}
+
+// iterateSyncState calls [f] with every key/value pair that is needed to
+// execute blocks on top of the last accepted block, in a deterministic order.
+// Historical data, such as previously accepted blocks, reward UTXOs and
+// validator set diffs, isn't included.
+func (s *state) iterateSyncState(f func(SyncKeyValue) error) error {
+	v, err := s.newSyncStateView()
+	if err != nil {
+		return err
+	}
+	defer v.release()
+	return v.iterate(f)
+}
+
+func iterateSyncSection(section SyncSection, it database.Iterator, f func(SyncKeyValue) error) error {
+	for it.Next() {
+		if err := f(SyncKeyValue{
+			Section: section,
+			Key:     it.Key(),
+			Value:   it.Value(),
+		}); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// syncedTxIDs returns the sorted IDs of the transactions that are referenced
+// by the current state.
+func (s *state) syncedTxIDs() ([]ids.ID, error) {
+	var txIDs set.Set[ids.ID]
+	for _, getIterator := range []func() (StakerIterator, error){
+		s.GetCurrentStakerIterator,
+		s.GetPendingStakerIterator,
+	} {
+		it, err := getIterator()
+		if err != nil {
+			return nil, err
+		}
+		for it.Next() {
+			txIDs.Add(it.Value().TxID)
+		}
+		it.Release()
+	}
+
+	subnetIDs, err := s.GetSubnetIDs()
+	if err != nil {
+		return nil, err
+	}
+	txIDs.Add(subnetIDs...)
+	for _, subnetID := range append(subnetIDs, constants.PrimaryNetworkID) {
+		chains, err := s.GetChains(subnetID)
+		if err != nil {
+			return nil, err
+		}
+		for _, chain := range chains {
+			txIDs.Add(chain.ID())
+		}
+
+		transformSubnetTx, err := s.GetSubnetTransformation(subnetID)
+		switch {
+		case err == nil:
+			txIDs.Add(transformSubnetTx.ID())
+		case !errors.Is(err, database.ErrNotFound):
+			return nil, err
+		}
+	}
+
+	sortedTxIDs := txIDs.List()
+	utils.Sort(sortedTxIDs)
+	return sortedTxIDs, nil
+}
+
+// startSyncSnapshot takes a view of the state as of the last accepted block
+// and writes the snapshot of it in the background, so that accepting the
+// block isn't delayed by reading the whole state.
+//
+// Invariant: All the changes to the state must have been written to
+// [baseDB].
+func (s *state) startSyncSnapshot() error {
+	v, err := s.newSyncStateView()
+	if err != nil {
+		return err
+	}
+	snapshot := &SyncSnapshot{
+		Height:  s.currentHeight,
+		BlockID: s.lastAccepted,
+	}
+
+	s.syncSnapshotWG.Add(1)
+	go func() {
+		defer s.syncSnapshotWG.Done()
+		defer v.release()
+
+		// Snapshots are written one at a time, in the order they were taken.
+		s.syncSnapshotLock.Lock()
+		defer s.syncSnapshotLock.Unlock()
+
+		err := s.writeSyncSnapshot(v, snapshot)
+		if err != nil && s.syncSnapshotCtx.Err() == nil {
+			log.Error("failed to write sync snapshot",
+				zap.Uint64("height", snapshot.Height),
+				zap.Error(err),
+			)
+		}
+	}()
+	return nil
+}
+
+// writeSyncSnapshot writes [snapshot] of the state in [v] and removes the
+// snapshots that are no longer retained. If the state is closed before the
+// snapshot is complete, the chunks written so far are left to be pruned along
+// with the older snapshots.
+func (s *state) writeSyncSnapshot(v *syncStateView, snapshot *SyncSnapshot) error {
+	var (
+		chunk     SyncChunk
+		chunkSize int
+	)
+	writeChunk := func() error {
+		chunkBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, &chunk)
+		if err != nil {
+			return fmt.Errorf("failed to marshal sync chunk: %w", err)
+		}
+		index := uint32(len(snapshot.Chunks))
+		if err := s.syncSnapshotDB.Put(syncChunkKey(snapshot.Height, index), chunkBytes); err != nil {
+			return err
+		}
+		snapshot.Chunks = append(snapshot.Chunks, hashing.ComputeHash256Array(chunkBytes))
+
+		chunk.KeyValues = nil
+		chunkSize = 0
+		return nil
+	}
+	err := v.iterate(func(kv SyncKeyValue) error {
+		if err := s.syncSnapshotCtx.Err(); err != nil {
+			return err
+		}
+		kv.Key = bytes.Clone(kv.Key)
+		kv.Value = bytes.Clone(kv.Value)
+		chunk.KeyValues = append(chunk.KeyValues, kv)
+		chunkSize += syncKeyValueOverhead + len(kv.Key) + len(kv.Value)
+		if chunkSize < s.syncChunkSize {
+			return nil
+		}
+		return writeChunk()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to iterate sync state: %w", err)
+	}
+	if len(chunk.KeyValues) > 0 {
+		if err := writeChunk(); err != nil {
+			return err
+		}
+	}
+
+	snapshotBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync snapshot: %w", err)
+	}
+	if err := s.syncSnapshotDB.Put(syncSnapshotKey(snapshot.Height), snapshotBytes); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(s.lastSyncSnapshotDB, LastSyncSnapshotKey, snapshot.Height); err != nil {
+		return err
+	}
+
+	retainedHeights := numRetainedSyncSnapshots * s.syncSummaryFrequency
+	if snapshot.Height < retainedHeights {
+		return nil
+	}
+	return s.deleteSyncSnapshotsBefore(snapshot.Height - retainedHeights + 1)
+}
+
+// deleteSyncSnapshotsBefore removes the snapshots that were taken before
+// [height].
+func (s *state) deleteSyncSnapshotsBefore(height uint64) error {
+	it := s.syncSnapshotDB.NewIterator()
+	var keys [][]byte
+	for it.Next() {
+		key := it.Key()
+		if len(key) < database.Uint64Size {
+			it.Release()
+			return fmt.Errorf("%w: %x", errInvalidSyncSnapshotKey, key)
+		}
+		snapshotHeight, err := database.ParseUInt64(key[:database.Uint64Size])
+		if err != nil {
+			it.Release()
+			return err
+		}
+		if snapshotHeight >= height {
+			break
+		}
+		keys = append(keys, bytes.Clone(key))
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := s.syncSnapshotDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
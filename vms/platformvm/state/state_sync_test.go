@@ -0,0 +1,271 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/constants"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/platformvm/block"
+	"github.com/luxfi/node/vms/platformvm/status"
+	"github.com/luxfi/node/vms/platformvm/txs"
+	"github.com/luxfi/node/vms/secp256k1fx"
+)
+
+// acceptTestBlock commits an empty block on top of the last accepted block of
+// [s] and waits for the snapshot it triggered, if any, to be written.
+func acceptTestBlock(require *require.Assertions, s *state, height uint64) block.Block {
+	blk := commitTestBlock(require, s, height)
+	s.syncSnapshotWG.Wait()
+	return blk
+}
+
+// commitTestBlock commits an empty block on top of the last accepted block of
+// [s].
+func commitTestBlock(require *require.Assertions, s *state, height uint64) block.Block {
+	blk, err := block.NewApricotCommitBlock(s.GetLastAccepted(), height)
+	require.NoError(err)
+
+	s.AddStatelessBlock(blk)
+	s.SetLastAccepted(blk.ID())
+	s.SetHeight(height)
+	require.NoError(s.Commit())
+	return blk
+}
+
+// syncSnapshotKeys returns the keys of [section] in the snapshot of [s] at
+// [height].
+func syncSnapshotKeys(require *require.Assertions, s *state, height uint64, section SyncSection) [][]byte {
+	snapshot, err := s.GetSyncSnapshot(height)
+	require.NoError(err)
+
+	var keys [][]byte
+	for index := range snapshot.Chunks {
+		chunkBytes, err := s.GetSyncChunk(height, uint32(index))
+		require.NoError(err)
+		chunk := &SyncChunk{}
+		_, err = block.GenesisCodec.Unmarshal(chunkBytes, chunk)
+		require.NoError(err)
+		for _, kv := range chunk.KeyValues {
+			if kv.Section == section {
+				keys = append(keys, kv.Key)
+			}
+		}
+	}
+	return keys
+}
+
+// collectSyncState returns every key/value pair that is transferred during
+// state sync.
+func collectSyncState(require *require.Assertions, s *state) []SyncKeyValue {
+	var kvs []SyncKeyValue
+	require.NoError(s.iterateSyncState(func(kv SyncKeyValue) error {
+		kv.Key = append([]byte(nil), kv.Key...)
+		kv.Value = append([]byte(nil), kv.Value...)
+		kvs = append(kvs, kv)
+		return nil
+	}))
+	return kvs
+}
+
+func TestStateSyncRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	source := newInitializedState(require).(*state)
+	source.syncSummaryFrequency = 1
+	source.syncChunkSize = 64
+
+	createSubnetTx := &txs.Tx{
+		Unsigned: &txs.CreateSubnetTx{
+			Owner: &secp256k1fx.OutputOwners{},
+		},
+	}
+	require.NoError(createSubnetTx.Initialize(txs.Codec))
+	subnetID := createSubnetTx.ID()
+	source.AddTx(createSubnetTx, status.Committed)
+	source.AddSubnet(subnetID)
+	source.SetSubnetOwner(subnetID, &secp256k1fx.OutputOwners{})
+
+	utxo := &lux.UTXO{
+		UTXOID: lux.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: lux.Asset{ID: ids.GenerateTestID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+		},
+	}
+	source.AddUTXO(utxo)
+
+	l1Validator := L1Validator{
+		ValidationID: ids.GenerateTestID(),
+		SubnetID:     subnetID,
+		NodeID:       ids.GenerateTestNodeID(),
+		Weight:       1,
+	}
+	require.NoError(source.PutL1Validator(l1Validator))
+
+	blk := acceptTestBlock(require, source, 1)
+
+	snapshot, err := source.GetLastSyncSnapshot()
+	require.NoError(err)
+	require.Equal(uint64(1), snapshot.Height)
+	require.Equal(blk.ID(), snapshot.BlockID)
+	require.Greater(len(snapshot.Chunks), 1)
+
+	// Sync a node that was initialized with a different genesis.
+	dest := newInitializedState(require).(*state)
+	summaryBytes := []byte("summary")
+	require.NoError(dest.BeginSync(summaryBytes))
+
+	ongoingSync, err := dest.GetOngoingSync()
+	require.NoError(err)
+	require.Equal(summaryBytes, ongoingSync)
+
+	for index := range snapshot.Chunks {
+		chunk, err := source.GetSyncChunk(snapshot.Height, uint32(index))
+		require.NoError(err)
+		require.NoError(dest.WriteSyncChunk(chunk))
+	}
+	require.NoError(dest.FinishSync(blk))
+
+	_, err = dest.GetOngoingSync()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.Equal(collectSyncState(require, source), collectSyncState(require, dest))
+	require.Equal(blk.ID(), dest.GetLastAccepted())
+	require.Equal(source.GetTimestamp(), dest.GetTimestamp())
+
+	gotBlk, err := dest.GetStatelessBlock(blk.ID())
+	require.NoError(err)
+	require.Equal(blk.ID(), gotBlk.ID())
+
+	blkID, err := dest.GetBlockIDAtHeight(1)
+	require.NoError(err)
+	require.Equal(blk.ID(), blkID)
+
+	gotUTXO, err := dest.GetUTXO(utxo.InputID())
+	require.NoError(err)
+	require.Equal(utxo.InputID(), gotUTXO.InputID())
+
+	subnetIDs, err := dest.GetSubnetIDs()
+	require.NoError(err)
+	require.Equal([]ids.ID{subnetID}, subnetIDs)
+
+	chains, err := dest.GetChains(constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Len(chains, 1)
+
+	gotL1Validator, err := dest.GetL1Validator(l1Validator.ValidationID)
+	require.NoError(err)
+	require.Equal(l1Validator, gotL1Validator)
+
+	require.Equal(
+		source.validators.GetWeight(constants.PrimaryNetworkID, initialNodeID),
+		dest.validators.GetWeight(constants.PrimaryNetworkID, initialNodeID),
+	)
+
+	// The synced state must be reloadable from disk.
+	rebuiltState := newStateFromDB(require, dest.baseDB)
+	require.NoError(rebuiltState.load())
+	require.Equal(blk.ID(), rebuiltState.GetLastAccepted())
+}
+
+func TestStateSyncFinishWrongBlock(t *testing.T) {
+	require := require.New(t)
+
+	s := newInitializedState(require).(*state)
+	require.NoError(s.BeginSync([]byte("summary")))
+
+	blk, err := block.NewApricotCommitBlock(ids.GenerateTestID(), 1)
+	require.NoError(err)
+	err = s.FinishSync(blk)
+	require.ErrorIs(err, errSyncedBlockMismatch)
+}
+
+func TestStateSyncWriteChunkInvalidMetadata(t *testing.T) {
+	require := require.New(t)
+
+	s := newInitializedState(require).(*state)
+	chunkBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, &SyncChunk{
+		KeyValues: []SyncKeyValue{
+			{
+				Section: MetadataSyncSection,
+				Key:     InitializedKey,
+			},
+		},
+	})
+	require.NoError(err)
+
+	err = s.WriteSyncChunk(chunkBytes)
+	require.ErrorIs(err, errUnexpectedSyncKey)
+}
+
+func TestStateSyncSnapshotPruning(t *testing.T) {
+	require := require.New(t)
+
+	s := newInitializedState(require).(*state)
+	s.syncSummaryFrequency = 2
+
+	for height := uint64(1); height <= 6; height++ {
+		acceptTestBlock(require, s, height)
+	}
+
+	// Only the last [numRetainedSyncSnapshots] snapshots are kept.
+	_, err := s.GetSyncSnapshot(2)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = s.GetSyncChunk(2, 0)
+	require.ErrorIs(err, database.ErrNotFound)
+	for _, height := range []uint64{4, 6} {
+		snapshot, err := s.GetSyncSnapshot(height)
+		require.NoError(err)
+		require.Equal(height, snapshot.Height)
+	}
+
+	lastSnapshot, err := s.GetLastSyncSnapshot()
+	require.NoError(err)
+	require.Equal(uint64(6), lastSnapshot.Height)
+}
+
+func TestStateSyncSnapshotWrittenInBackground(t *testing.T) {
+	require := require.New(t)
+
+	s := newInitializedState(require).(*state)
+	s.syncSummaryFrequency = 1
+
+	// Blocks are accepted while the snapshots can't be written.
+	s.syncSnapshotLock.Lock()
+	commitTestBlock(require, s, 1)
+	_, err := s.GetSyncSnapshot(1)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	utxo := &lux.UTXO{
+		UTXOID: lux.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: lux.Asset{ID: ids.GenerateTestID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+		},
+	}
+	s.AddUTXO(utxo)
+	commitTestBlock(require, s, 2)
+
+	s.syncSnapshotLock.Unlock()
+	s.syncSnapshotWG.Wait()
+
+	// Each snapshot reflects the state as of its block.
+	utxoID := utxo.InputID()
+	require.NotContains(syncSnapshotKeys(require, s, 1, UTXOsSyncSection), utxoID[:])
+	require.Contains(syncSnapshotKeys(require, s, 2, UTXOsSyncSection), utxoID[:])
+
+	lastSnapshot, err := s.GetLastSyncSnapshot()
+	require.NoError(err)
+	require.Equal(uint64(2), lastSnapshot.Height)
+}
@@ -36,6 +36,8 @@ import (
 
 	"github.com/luxfi/node/utils/wrappers"
 
+	"github.com/luxfi/node/vms/components/gas"
+
 	"github.com/luxfi/node/vms/components/lux"
 
 	"github.com/luxfi/node/vms/platformvm/block"
@@ -1457,6 +1459,27 @@ func TestStateSubnetOwner(t *testing.T) {
 	require.Equal(owner2, owner)
 }
 
+func TestStateFeeState(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newUninitializedState(require)
+
+	feeState, err := getFeeState(s.singletonDB)
+	require.NoError(err)
+	require.Zero(feeState)
+
+	expectedFeeState := gas.State{
+		Capacity: 1_000,
+		Excess:   500,
+	}
+	s.SetFeeState(expectedFeeState)
+	require.NoError(s.writeMetadata())
+
+	feeState, err = getFeeState(s.singletonDB)
+	require.NoError(err)
+	require.Equal(expectedFeeState, feeState)
+}
+
 func makeBlocks(require *require.Assertions) []block.Block {
 	var blks []block.Block
 	{
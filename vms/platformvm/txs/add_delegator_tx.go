@@ -19,6 +19,8 @@ import (
 	"github.com/luxfi/node/vms/secp256k1fx"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	_ DelegatorTx     = (*AddDelegatorTx)(nil)
 	_ ScheduledStaker = (*AddDelegatorTx)(nil)
@@ -0,0 +1,84 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*AddPermissionlessDelegatorTx)(nil)
+	_ codec.Unmarshaler = (*AddPermissionlessDelegatorTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*AddPermissionlessDelegatorTx) CodecType() any {
+	return (*AddPermissionlessDelegatorTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *AddPermissionlessDelegatorTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.BaseTx)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Validator)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += len(v.Subnet)
+	fieldSize, err = c.Size(&v.StakeOuts)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.DelegationRewardsOwner)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *AddPermissionlessDelegatorTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.BaseTx, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Validator, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.Subnet[:])
+	if err := c.MarshalInto(&v.StakeOuts, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.DelegationRewardsOwner, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *AddPermissionlessDelegatorTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.BaseTx); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Validator); err != nil {
+		return err
+	}
+	copy(v.Subnet[:], p.UnpackFixedBytes(len(v.Subnet)))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.StakeOuts); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.DelegationRewardsOwner); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*AddPermissionlessValidatorTx)(nil)
+	_ codec.Unmarshaler = (*AddPermissionlessValidatorTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*AddPermissionlessValidatorTx) CodecType() any {
+	return (*AddPermissionlessValidatorTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *AddPermissionlessValidatorTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.BaseTx)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Validator)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += len(v.Subnet)
+	fieldSize, err = c.Size(&v.Signer)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.StakeOuts)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.ValidatorRewardsOwner)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.DelegatorRewardsOwner)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += wrappers.IntLen
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *AddPermissionlessValidatorTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.BaseTx, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Validator, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.Subnet[:])
+	if err := c.MarshalInto(&v.Signer, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.StakeOuts, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.ValidatorRewardsOwner, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.DelegatorRewardsOwner, p); err != nil {
+		return err
+	}
+	p.PackInt(v.DelegationShares)
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *AddPermissionlessValidatorTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.BaseTx); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Validator); err != nil {
+		return err
+	}
+	copy(v.Subnet[:], p.UnpackFixedBytes(len(v.Subnet)))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.Signer); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.StakeOuts); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.ValidatorRewardsOwner); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.DelegatorRewardsOwner); err != nil {
+		return err
+	}
+	v.DelegationShares = p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
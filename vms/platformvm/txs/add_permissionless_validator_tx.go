@@ -20,6 +20,8 @@ import (
 	"github.com/luxfi/node/vms/secp256k1fx"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	_ ValidatorTx     = (*AddPermissionlessValidatorTx)(nil)
 	_ ScheduledStaker = (*AddPermissionlessDelegatorTx)(nil)
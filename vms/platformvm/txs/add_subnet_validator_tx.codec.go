@@ -0,0 +1,67 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*AddSubnetValidatorTx)(nil)
+	_ codec.Unmarshaler = (*AddSubnetValidatorTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*AddSubnetValidatorTx) CodecType() any {
+	return (*AddSubnetValidatorTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *AddSubnetValidatorTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.BaseTx)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.SubnetValidator)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.SubnetAuth)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *AddSubnetValidatorTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.BaseTx, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.SubnetValidator, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.SubnetAuth, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *AddSubnetValidatorTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.BaseTx); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.SubnetValidator); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.SubnetAuth); err != nil {
+		return err
+	}
+	return nil
+}
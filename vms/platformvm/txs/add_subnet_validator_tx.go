@@ -13,6 +13,8 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	_ StakerTx        = (*AddSubnetValidatorTx)(nil)
 	_ ScheduledStaker = (*AddSubnetValidatorTx)(nil)
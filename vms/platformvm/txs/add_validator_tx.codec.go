@@ -0,0 +1,84 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*AddValidatorTx)(nil)
+	_ codec.Unmarshaler = (*AddValidatorTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*AddValidatorTx) CodecType() any {
+	return (*AddValidatorTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *AddValidatorTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.BaseTx)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Validator)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.StakeOuts)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.RewardsOwner)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += wrappers.IntLen
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *AddValidatorTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.BaseTx, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Validator, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.StakeOuts, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.RewardsOwner, p); err != nil {
+		return err
+	}
+	p.PackInt(v.DelegationShares)
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *AddValidatorTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.BaseTx); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Validator); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.StakeOuts); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.RewardsOwner); err != nil {
+		return err
+	}
+	v.DelegationShares = p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
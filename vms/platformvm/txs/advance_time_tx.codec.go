@@ -0,0 +1,40 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*AdvanceTimeTx)(nil)
+	_ codec.Unmarshaler = (*AdvanceTimeTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*AdvanceTimeTx) CodecType() any {
+	return (*AdvanceTimeTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *AdvanceTimeTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.LongLen
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *AdvanceTimeTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackLong(v.Time)
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *AdvanceTimeTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.Time = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/luxfi/node/vms/components/lux"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var _ UnsignedTx = (*AdvanceTimeTx)(nil)
 
 // AdvanceTimeTx is a transaction to increase the chain's timestamp.
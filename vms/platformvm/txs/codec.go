@@ -35,18 +35,10 @@ func init() {
 	gc := linearcodec.NewDefault()
 
 	errs := wrappers.Errs{}
-	for _, c := range []linearcodec.Codec{c, gc} {
-		// Order in which type are registered affect the byte representation
-		// generated by marshalling ops. To maintain codec type ordering,
-		// we skip positions for the blocks.
-		c.SkipRegistrations(5)
-
-		errs.Add(RegisterUnsignedTxsTypes(c))
-
-		c.SkipRegistrations(4)
-
-		errs.Add(RegisterDUnsignedTxsTypes(c))
-	}
+	errs.Add(
+		registerTypes(c),
+		registerTypes(gc),
+	)
 
 	Codec = codec.NewDefaultManager()
 	GenesisCodec = codec.NewManager(math.MaxInt32)
@@ -59,6 +51,24 @@ func init() {
 	}
 }
 
+// registerTypes registers the tx types into [c] at the same type IDs as they
+// are registered by the block codec.
+func registerTypes(c linearcodec.Codec) error {
+	// Order in which type are registered affect the byte representation
+	// generated by marshalling ops. To maintain codec type ordering,
+	// we skip positions for the blocks.
+	c.SkipRegistrations(5)
+
+	err := RegisterUnsignedTxsTypes(c)
+
+	c.SkipRegistrations(4)
+
+	return errors.Join(
+		err,
+		RegisterDUnsignedTxsTypes(c),
+	)
+}
+
 // RegisterUnsignedTxsTypes allows registering relevant type of unsigned package
 // in the right sequence. Following repackaging of platformvm package, a few
 // subpackage-level codecs were introduced, each handling serialization of
@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/codec/codectest"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/components/verify"
+	"github.com/luxfi/node/vms/secp256k1fx"
+)
+
+func TestCodecSchema(t *testing.T) {
+	codectest.RequireCompatibleSchema(
+		t,
+		Codec,
+		filepath.Join("testdata", "codec_schema.json"),
+		(*Tx)(nil),
+	)
+}
+
+func FuzzCodecGenerated(f *testing.F) {
+	codectest.FuzzGenerated(
+		f,
+		registerTypes,
+		&Tx{
+			Unsigned: &BaseTx{
+				BaseTx: lux.BaseTx{
+					NetworkID:    1,
+					BlockchainID: ids.GenerateTestID(),
+					Outs: []*lux.TransferableOutput{{
+						Asset: lux.Asset{ID: ids.GenerateTestID()},
+						Out: &secp256k1fx.TransferOutput{
+							Amt: 1,
+							OutputOwners: secp256k1fx.OutputOwners{
+								Threshold: 1,
+								Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+							},
+						},
+					}},
+					Ins: []*lux.TransferableInput{{
+						UTXOID: lux.UTXOID{
+							TxID:        ids.GenerateTestID(),
+							OutputIndex: 1,
+						},
+						Asset: lux.Asset{ID: ids.GenerateTestID()},
+						In: &secp256k1fx.TransferInput{
+							Amt: 2,
+							Input: secp256k1fx.Input{
+								SigIndices: []uint32{0},
+							},
+						},
+					}},
+					Memo: []byte{1, 2, 3},
+				},
+			},
+			Creds: []verify.Verifiable{
+				&secp256k1fx.Credential{},
+			},
+		},
+	)
+}
@@ -0,0 +1,119 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*CreateChainTx)(nil)
+	_ codec.Unmarshaler = (*CreateChainTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*CreateChainTx) CodecType() any {
+	return (*CreateChainTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *CreateChainTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.BaseTx)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += len(v.SubnetID)
+	size += wrappers.StringLen(v.ChainName)
+	size += len(v.VMID)
+	size += wrappers.IntLen + len(v.FxIDs)*len(ids.ID{})
+	size += wrappers.IntLen + len(v.GenesisData)
+	fieldSize, err = c.Size(&v.SubnetAuth)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *CreateChainTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.BaseTx, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.SubnetID[:])
+	p.PackStr(v.ChainName)
+	p.PackFixedBytes(v.VMID[:])
+	if len(v.FxIDs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.FxIDs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.FxIDs)))
+	for _, elem := range v.FxIDs {
+		p.PackFixedBytes(elem[:])
+	}
+	if len(v.GenesisData) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.GenesisData), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.GenesisData)))
+	p.PackFixedBytes(v.GenesisData)
+	if err := c.MarshalInto(&v.SubnetAuth, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *CreateChainTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.BaseTx); err != nil {
+		return err
+	}
+	copy(v.SubnetID[:], p.UnpackFixedBytes(len(v.SubnetID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	v.ChainName = p.UnpackStr()
+	if p.Err != nil {
+		return p.Err
+	}
+	copy(v.VMID[:], p.UnpackFixedBytes(len(v.VMID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	numFxIDs := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numFxIDs > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numFxIDs, math.MaxInt32)
+	}
+	v.FxIDs = make([]ids.ID, 0, min(int(numFxIDs), len(p.Bytes)))
+	for i := uint32(0); i < numFxIDs; i++ {
+		var elem ids.ID
+		copy(elem[:], p.UnpackFixedBytes(len(elem)))
+		if p.Err != nil {
+			return p.Err
+		}
+		v.FxIDs = append(v.FxIDs, elem)
+	}
+	numGenesisData := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numGenesisData > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numGenesisData, math.MaxInt32)
+	}
+	v.GenesisData = p.UnpackFixedBytes(int(numGenesisData))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.SubnetAuth); err != nil {
+		return err
+	}
+	return nil
+}
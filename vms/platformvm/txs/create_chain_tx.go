@@ -15,6 +15,8 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 const (
 	MaxNameLen    = 128
 	MaxGenesisLen = units.MiB
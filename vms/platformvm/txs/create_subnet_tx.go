@@ -9,6 +9,8 @@ import (
 	"github.com/luxfi/node/vms/platformvm/fx"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var _ UnsignedTx = (*CreateSubnetTx)(nil)
 
 // CreateSubnetTx is an unsigned proposal to create a new subnet
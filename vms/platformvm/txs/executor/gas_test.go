@@ -0,0 +1,211 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luxfi/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/vms/components/gas"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/platformvm/config"
+	"github.com/luxfi/node/vms/platformvm/state"
+	"github.com/luxfi/node/vms/platformvm/txs"
+	"github.com/luxfi/node/vms/platformvm/txs/fee"
+	"github.com/luxfi/node/vms/platformvm/upgrade"
+)
+
+var (
+	gasTestEtnaTime    = time.Unix(1_000_000, 0)
+	gasTestFortunaTime = gasTestEtnaTime.Add(time.Hour)
+)
+
+func newGasTestBackend(maxCapacity gas.Gas) *Backend {
+	return &Backend{
+		Config: &config.Config{
+			DynamicFeeConfig: gas.Config{
+				Weights: gas.Dimensions{
+					gas.Bandwidth: 1,
+				},
+				MaxCapacity:              maxCapacity,
+				MaxPerSecond:             100,
+				TargetPerSecond:          50,
+				MinPrice:                 1,
+				ExcessConversionConstant: 1_000,
+			},
+			UpgradeConfig: upgrade.Config{
+				EtnaTime:    gasTestEtnaTime,
+				FortunaTime: gasTestFortunaTime,
+			},
+		},
+	}
+}
+
+func newGasTestTx(t *testing.T) (txs.UnsignedTx, gas.Gas) {
+	tx := &txs.BaseTx{
+		BaseTx: lux.BaseTx{
+			Memo: []byte("memo"),
+		},
+	}
+	complexity, err := fee.TxComplexity(tx)
+	require.NoError(t, err)
+	txGas, err := complexity.ToGas(gas.Dimensions{
+		gas.Bandwidth: 1,
+	})
+	require.NoError(t, err)
+	return tx, txGas
+}
+
+func TestConsumeGas(t *testing.T) {
+	tx, txGas := newGasTestTx(t)
+
+	tests := []struct {
+		name        string
+		stateFunc   func(*gomock.Controller) state.Chain
+		expectedErr error
+	}{
+		{
+			name: "before etna",
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(gasTestEtnaTime.Add(-time.Second))
+				return s
+			},
+		},
+		{
+			name: "before fortuna drains capacity",
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(gasTestEtnaTime)
+				s.EXPECT().GetFeeState().Return(gas.State{
+					Capacity: txGas - 1,
+					Excess:   10,
+				})
+				s.EXPECT().SetFeeState(gas.State{
+					Capacity: 0,
+					Excess:   10 + txGas,
+				})
+				return s
+			},
+		},
+		{
+			name: "sufficient capacity",
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(gasTestFortunaTime)
+				s.EXPECT().GetFeeState().Return(gas.State{
+					Capacity: txGas,
+					Excess:   10,
+				})
+				s.EXPECT().SetFeeState(gas.State{
+					Capacity: 0,
+					Excess:   10 + txGas,
+				})
+				return s
+			},
+		},
+		{
+			name: "insufficient capacity",
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(gasTestFortunaTime)
+				s.EXPECT().GetFeeState().Return(gas.State{
+					Capacity: txGas - 1,
+				})
+				return s
+			},
+			expectedErr: gas.ErrInsufficientCapacity,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			backend := newGasTestBackend(txGas)
+			err := ConsumeGas(backend, test.stateFunc(ctrl), tx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestVerifyMaxGas(t *testing.T) {
+	tx, txGas := newGasTestTx(t)
+
+	tests := []struct {
+		name        string
+		maxCapacity gas.Gas
+		timestamp   time.Time
+		expectedErr error
+	}{
+		{
+			name:        "before fortuna",
+			maxCapacity: txGas - 1,
+			timestamp:   gasTestFortunaTime.Add(-time.Second),
+		},
+		{
+			name:        "within max capacity",
+			maxCapacity: txGas,
+			timestamp:   gasTestFortunaTime,
+		},
+		{
+			name:        "exceeds max capacity",
+			maxCapacity: txGas - 1,
+			timestamp:   gasTestFortunaTime,
+			expectedErr: ErrExceedsMaxCapacity,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := newGasTestBackend(test.maxCapacity)
+			err := VerifyMaxGas(backend, test.timestamp, tx)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestCalculateFee(t *testing.T) {
+	tx, txGas := newGasTestTx(t)
+
+	const staticFee = 1_000
+	tests := []struct {
+		name        string
+		stateFunc   func(*gomock.Controller) state.Chain
+		expectedFee uint64
+	}{
+		{
+			name: "before fortuna",
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(gasTestFortunaTime.Add(-time.Second))
+				return s
+			},
+			expectedFee: staticFee,
+		},
+		{
+			name: "after fortuna",
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(gasTestFortunaTime)
+				s.EXPECT().GetFeeState().Return(gas.State{})
+				return s
+			},
+			expectedFee: uint64(txGas), // at the min price of 1
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			backend := newGasTestBackend(txGas)
+			backend.Config.StaticFeeConfig.TxFee = staticFee
+			fee, err := CalculateFee(backend, test.stateFunc(ctrl), tx)
+			require.NoError(err)
+			require.Equal(test.expectedFee, fee)
+		})
+	}
+}
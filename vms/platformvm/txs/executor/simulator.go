@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"fmt"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/vms/components/gas"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/platformvm/state"
+	"github.com/luxfi/node/vms/platformvm/txs"
+	"github.com/luxfi/node/vms/platformvm/txs/fee"
+)
+
+var _ state.Diff = (*recordingDiff)(nil)
+
+// Simulation describes the effects that a transaction would have if it were
+// executed on top of a state.
+type Simulation struct {
+	// Fee is the fee that the transaction is required to burn.
+	Fee uint64
+	// Complexity is the multi-dimensional gas complexity of the transaction.
+	Complexity gas.Dimensions
+	// Consumed are the UTXOs of this chain that the transaction would spend.
+	// UTXOs imported from other chains are not included.
+	Consumed []*lux.UTXO
+	// Produced are the UTXOs that the transaction would create.
+	Produced []*lux.UTXO
+	// StakerChanges are the stakers that the transaction would add or remove.
+	StakerChanges []StakerChange
+	// L1ValidatorChanges are the L1 validators that the transaction would
+	// write.
+	L1ValidatorChanges []state.L1Validator
+	// Err is the reason that the transaction would fail verification, or nil
+	// if the transaction is valid.
+	Err error
+}
+
+// StakerChange is a modification of the staker set.
+type StakerChange struct {
+	Staker *state.Staker
+	// Removed is true if [Staker] is removed from the staker set rather than
+	// added to it.
+	Removed bool
+}
+
+// Simulate executes [tx] on top of [diff] and reports the changes that it
+// would make.
+//
+// If the transaction is invalid, the verification error is reported in
+// [Simulation.Err]. The returned error is only non-nil if the simulation
+// itself could not be performed.
+//
+// [diff] is modified by the execution and should be discarded afterwards.
+func Simulate(backend *Backend, diff state.Diff, tx *txs.Tx) (*Simulation, error) {
+	if tx == nil || tx.Unsigned == nil {
+		return nil, txs.ErrNilSignedTx
+	}
+
+	sim := &Simulation{}
+	complexity, err := fee.TxComplexity(tx.Unsigned)
+	if err != nil {
+		sim.Err = fmt.Errorf("%w: %w", fee.ErrCalculatingComplexity, err)
+		return sim, nil
+	}
+	sim.Complexity = complexity
+
+	sim.Fee, err = CalculateFee(backend, diff, tx.Unsigned)
+	if err != nil {
+		sim.Err = err
+		return sim, nil
+	}
+
+	recorder := &recordingDiff{
+		Diff: diff,
+		sim:  sim,
+	}
+	sim.Err = tx.Unsigned.Visit(&StandardTxExecutor{
+		Backend: backend,
+		State:   recorder,
+		Tx:      tx,
+	})
+	return sim, recorder.err
+}
+
+// recordingDiff records the modifications made to the underlying diff into
+// the simulation.
+type recordingDiff struct {
+	state.Diff
+
+	sim *Simulation
+	// err is the first error encountered while recording.
+	err error
+}
+
+func (d *recordingDiff) AddUTXO(utxo *lux.UTXO) {
+	d.sim.Produced = append(d.sim.Produced, utxo)
+	d.Diff.AddUTXO(utxo)
+}
+
+func (d *recordingDiff) DeleteUTXO(utxoID ids.ID) {
+	utxo, err := d.Diff.GetUTXO(utxoID)
+	if err != nil {
+		if d.err == nil {
+			d.err = fmt.Errorf("failed to fetch consumed UTXO %s: %w", utxoID, err)
+		}
+	} else {
+		d.sim.Consumed = append(d.sim.Consumed, utxo)
+	}
+	d.Diff.DeleteUTXO(utxoID)
+}
+
+func (d *recordingDiff) PutCurrentValidator(staker *state.Staker) {
+	d.recordStaker(staker, false)
+	d.Diff.PutCurrentValidator(staker)
+}
+
+func (d *recordingDiff) DeleteCurrentValidator(staker *state.Staker) {
+	d.recordStaker(staker, true)
+	d.Diff.DeleteCurrentValidator(staker)
+}
+
+func (d *recordingDiff) PutCurrentDelegator(staker *state.Staker) {
+	d.recordStaker(staker, false)
+	d.Diff.PutCurrentDelegator(staker)
+}
+
+func (d *recordingDiff) DeleteCurrentDelegator(staker *state.Staker) {
+	d.recordStaker(staker, true)
+	d.Diff.DeleteCurrentDelegator(staker)
+}
+
+func (d *recordingDiff) PutPendingValidator(staker *state.Staker) {
+	d.recordStaker(staker, false)
+	d.Diff.PutPendingValidator(staker)
+}
+
+func (d *recordingDiff) DeletePendingValidator(staker *state.Staker) {
+	d.recordStaker(staker, true)
+	d.Diff.DeletePendingValidator(staker)
+}
+
+func (d *recordingDiff) PutPendingDelegator(staker *state.Staker) {
+	d.recordStaker(staker, false)
+	d.Diff.PutPendingDelegator(staker)
+}
+
+func (d *recordingDiff) DeletePendingDelegator(staker *state.Staker) {
+	d.recordStaker(staker, true)
+	d.Diff.DeletePendingDelegator(staker)
+}
+
+func (d *recordingDiff) PutL1Validator(validator state.L1Validator) error {
+	if err := d.Diff.PutL1Validator(validator); err != nil {
+		return err
+	}
+	d.sim.L1ValidatorChanges = append(d.sim.L1ValidatorChanges, validator)
+	return nil
+}
+
+func (d *recordingDiff) recordStaker(staker *state.Staker, removed bool) {
+	d.sim.StakerChanges = append(d.sim.StakerChanges, StakerChange{
+		Staker:  staker,
+		Removed: removed,
+	})
+}
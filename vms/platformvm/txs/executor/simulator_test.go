@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/node/vms/platformvm/state"
+	"github.com/luxfi/node/vms/platformvm/txs"
+	"github.com/luxfi/node/vms/platformvm/txs/fee"
+	"github.com/luxfi/node/vms/platformvm/txs/txstest"
+	"github.com/luxfi/node/vms/secp256k1fx"
+
+	walletsigner "github.com/luxfi/node/wallet/chain/p/signer"
+)
+
+func newSimulatedCreateSubnetTx(t *testing.T, env *environment) *txs.Tx {
+	require := require.New(t)
+
+	factory := txstest.NewWalletFactory(env.ctx.Context, env.ctx.SharedMemory, env.config, env.state)
+	builder, signer := factory.NewWallet(preFundedKeys[0])
+	utx, err := builder.NewCreateSubnetTx(
+		&secp256k1fx.OutputOwners{},
+	)
+	require.NoError(err)
+	tx, err := walletsigner.SignUnsigned(context.Background(), signer, utx)
+	require.NoError(err)
+	return tx
+}
+
+func TestSimulate(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t, eUpgrade)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	tx := newSimulatedCreateSubnetTx(t, env)
+	utx := tx.Unsigned.(*txs.CreateSubnetTx)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	sim, err := Simulate(&env.backend, stateDiff, tx)
+	require.NoError(err)
+	require.NoError(sim.Err)
+
+	expectedComplexity, err := fee.TxComplexity(utx)
+	require.NoError(err)
+	require.Equal(expectedComplexity, sim.Complexity)
+
+	expectedFee, err := CalculateFee(&env.backend, stateDiff, utx)
+	require.NoError(err)
+	require.Equal(expectedFee, sim.Fee)
+
+	require.Len(sim.Consumed, len(utx.Ins))
+	for i, in := range utx.Ins {
+		require.Equal(in.InputID(), sim.Consumed[i].InputID())
+	}
+	require.Len(sim.Produced, len(utx.Outs))
+	for _, utxo := range sim.Produced {
+		require.Equal(tx.ID(), utxo.TxID)
+	}
+	require.Empty(sim.StakerChanges)
+	require.Empty(sim.L1ValidatorChanges)
+}
+
+func TestSimulateInvalidTx(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t, eUpgrade)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	tx := newSimulatedCreateSubnetTx(t, env)
+	utx := tx.Unsigned.(*txs.CreateSubnetTx)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	// Spend an input of the tx, so that it can't be executed.
+	stateDiff.DeleteUTXO(utx.Ins[0].InputID())
+
+	sim, err := Simulate(&env.backend, stateDiff, tx)
+	require.NoError(err)
+	require.ErrorIs(sim.Err, database.ErrNotFound)
+
+	// The fee is still reported for invalid txs.
+	expectedFee, err := CalculateFee(&env.backend, stateDiff, utx)
+	require.NoError(err)
+	require.Equal(expectedFee, sim.Fee)
+	require.Empty(sim.Consumed)
+	require.Empty(sim.Produced)
+}
+
+func TestSimulateNilTx(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t, eUpgrade)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	_, err = Simulate(&env.backend, stateDiff, nil)
+	require.ErrorIs(err, txs.ErrNilSignedTx)
+}
+
+func TestSimulateDoesNotModifyState(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t, eUpgrade)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	tx := newSimulatedCreateSubnetTx(t, env)
+	utx := tx.Unsigned.(*txs.CreateSubnetTx)
+
+	stateDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	sim, err := Simulate(&env.backend, stateDiff, tx)
+	require.NoError(err)
+	require.NoError(sim.Err)
+
+	// Only the discarded diff was modified.
+	for _, in := range utx.Ins {
+		_, err := env.state.GetUTXO(in.InputID())
+		require.NoError(err)
+	}
+	for _, utxo := range sim.Produced {
+		_, err := env.state.GetUTXO(utxo.InputID())
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+	_, err = env.state.GetSubnetOwner(tx.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The same tx can be simulated again on top of the unmodified state.
+	stateDiff, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	sim, err = Simulate(&env.backend, stateDiff, tx)
+	require.NoError(err)
+	require.NoError(sim.Err)
+}
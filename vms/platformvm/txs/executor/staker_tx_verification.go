@@ -15,7 +15,6 @@ import (
 	"github.com/luxfi/node/vms/components/lux"
 	"github.com/luxfi/node/vms/platformvm/state"
 	"github.com/luxfi/node/vms/platformvm/txs"
-	"github.com/luxfi/node/vms/platformvm/txs/fee"
 
 	safemath "github.com/luxfi/math/math"
 )
@@ -164,8 +163,10 @@ func verifyAddValidatorTx(
 	}
 
 	// Verify the flowcheck
-	feeCalculator := fee.NewStaticCalculator(backend.Config.StaticFeeConfig, backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(backend, chainState, tx)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -255,8 +256,10 @@ func verifyAddSubnetValidatorTx(
 	}
 
 	// Verify the flowcheck
-	feeCalculator := fee.NewStaticCalculator(backend.Config.StaticFeeConfig, backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(backend, chainState, tx)
+	if err != nil {
+		return err
+	}
 
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -333,8 +336,10 @@ func verifyRemoveSubnetValidatorTx(
 	}
 
 	// Verify the flowcheck
-	feeCalculator := fee.NewStaticCalculator(backend.Config.StaticFeeConfig, backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(backend, chainState, tx)
+	if err != nil {
+		return nil, false, err
+	}
 
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -451,8 +456,10 @@ func verifyAddDelegatorTx(
 	}
 
 	// Verify the flowcheck
-	feeCalculator := fee.NewStaticCalculator(backend.Config.StaticFeeConfig, backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(backend, chainState, tx)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -571,8 +578,10 @@ func verifyAddPermissionlessValidatorTx(
 	copy(outs[len(tx.Outs):], tx.StakeOuts)
 
 	// Verify the flowcheck
-	feeCalculator := fee.NewStaticCalculator(backend.Config.StaticFeeConfig, backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(backend, chainState, tx)
+	if err != nil {
+		return err
+	}
 
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -716,8 +725,10 @@ func verifyAddPermissionlessDelegatorTx(
 	}
 
 	// Verify the flowcheck
-	feeCalculator := fee.NewStaticCalculator(backend.Config.StaticFeeConfig, backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(backend, chainState, tx)
+	if err != nil {
+		return err
+	}
 
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -770,9 +781,10 @@ func verifyTransferSubnetOwnershipTx(
 	}
 
 	// Verify the flowcheck
-	currentTimestamp := chainState.GetTimestamp()
-	feeCalculator := fee.NewStaticCalculator(backend.Config.StaticFeeConfig, backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(backend, chainState, tx)
+	if err != nil {
+		return err
+	}
 
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -17,7 +17,6 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 	"github.com/luxfi/node/vms/platformvm/state"
 	"github.com/luxfi/node/vms/platformvm/txs"
-	"github.com/luxfi/node/vms/platformvm/txs/fee"
 )
 
 var (
@@ -67,8 +66,10 @@ func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 	}
 
 	// Verify the flowcheck
-	feeCalculator := fee.NewStaticCalculator(e.Backend.Config.StaticFeeConfig, e.Backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(e.Backend, e.State, tx)
+	if err != nil {
+		return err
+	}
 
 	if err := e.FlowChecker.VerifySpend(
 		tx,
@@ -115,8 +116,10 @@ func (e *StandardTxExecutor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
 	}
 
 	// Verify the flowcheck
-	feeCalculator := fee.NewStaticCalculator(e.Backend.Config.StaticFeeConfig, e.Backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(e.Backend, e.State, tx)
+	if err != nil {
+		return err
+	}
 
 	if err := e.FlowChecker.VerifySpend(
 		tx,
@@ -198,8 +201,10 @@ func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
 		copy(ins[len(tx.Ins):], tx.ImportedInputs)
 
 		// Verify the flowcheck
-		feeCalculator := fee.NewStaticCalculator(e.Backend.Config.StaticFeeConfig, e.Backend.Config.UpgradeConfig)
-		fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+		fee, err := CalculateFee(e.Backend, e.State, tx)
+		if err != nil {
+			return err
+		}
 
 		if err := e.FlowChecker.VerifySpendUTXOs(
 			tx,
@@ -257,8 +262,10 @@ func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 	}
 
 	// Verify the flowcheck
-	feeCalculator := fee.NewStaticCalculator(e.Backend.Config.StaticFeeConfig, e.Backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(e.Backend, e.State, tx)
+	if err != nil {
+		return err
+	}
 
 	if err := e.FlowChecker.VerifySpend(
 		tx,
@@ -443,8 +450,10 @@ func (e *StandardTxExecutor) TransformSubnetTx(tx *txs.TransformSubnetTx) error
 	}
 
 	// Verify the flowcheck
-	feeCalculator := fee.NewStaticCalculator(e.Backend.Config.StaticFeeConfig, e.Backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(e.Backend, e.State, tx)
+	if err != nil {
+		return err
+	}
 
 	totalRewardAmount := tx.MaximumSupply - tx.InitialSupply
 	if err := e.Backend.FlowChecker.VerifySpend(
@@ -563,9 +572,10 @@ func (e *StandardTxExecutor) BaseTx(tx *txs.BaseTx) error {
 	}
 
 	// Verify the flowcheck
-	currentTimestamp := e.State.GetTimestamp()
-	feeCalculator := fee.NewStaticCalculator(e.Backend.Config.StaticFeeConfig, e.Backend.Config.UpgradeConfig)
-	fee := feeCalculator.CalculateFee(tx, currentTimestamp)
+	fee, err := CalculateFee(e.Backend, e.State, tx)
+	if err != nil {
+		return err
+	}
 
 	if err := e.FlowChecker.VerifySpend(
 		tx,
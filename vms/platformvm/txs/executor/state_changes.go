@@ -10,14 +10,17 @@ import (
 
 	"github.com/luxfi/ids"
 	"github.com/luxfi/node/utils/constants"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/platformvm/reward"
 	"github.com/luxfi/node/vms/platformvm/state"
 	"github.com/luxfi/node/vms/platformvm/txs"
+	"github.com/luxfi/node/vms/platformvm/txs/fee"
 )
 
 var (
 	ErrChildBlockAfterStakerChangeTime = errors.New("proposed timestamp later than next staker change time")
 	ErrChildBlockBeyondSyncBound       = errors.New("proposed timestamp is too far in the future relative to local time")
+	ErrExceedsMaxCapacity              = errors.New("tx exceeds the maximum gas capacity")
 )
 
 // VerifyNewChainTime returns nil if the [newChainTime] is a valid chain time
@@ -165,6 +168,21 @@ func AdvanceTimeTo(
 		changed = true
 	}
 
+	if backend.Config.UpgradeConfig.IsEtnaActivated(newChainTime) {
+		var (
+			previousChainTime = changes.GetTimestamp()
+			duration          = uint64(newChainTime.Sub(previousChainTime) / time.Second)
+			feeConfig         = backend.Config.DynamicFeeConfig
+			feeState          = changes.GetFeeState()
+		)
+		changes.SetFeeState(feeState.AdvanceTime(
+			feeConfig.MaxCapacity,
+			feeConfig.MaxPerSecond,
+			feeConfig.TargetPerSecond,
+			duration,
+		))
+	}
+
 	if err := changes.Apply(parentState); err != nil {
 		return false, err
 	}
@@ -173,6 +191,112 @@ func AdvanceTimeTo(
 	return changed, nil
 }
 
+// CalculateFee returns the fee that [tx] must burn when executed on top of
+// [chainState].
+//
+// Once Fortuna is activated, [tx] is charged for the gas it uses at the
+// current gas price of [chainState]. Before Fortuna, and for transactions that
+// don't have a complexity, the static fee is charged.
+func CalculateFee(
+	backend *Backend,
+	chainState state.Chain,
+	tx txs.UnsignedTx,
+) (uint64, error) {
+	timestamp := chainState.GetTimestamp()
+	if backend.Config.UpgradeConfig.IsFortunaActivated(timestamp) {
+		feeConfig := backend.Config.DynamicFeeConfig
+		price := gas.CalculatePrice(
+			feeConfig.MinPrice,
+			chainState.GetFeeState().Excess,
+			feeConfig.ExcessConversionConstant,
+		)
+		calculator := fee.NewDynamicCalculator(feeConfig.Weights, price)
+		txFee, err := calculator.CalculateFee(tx)
+		if !errors.Is(err, fee.ErrUnsupportedTx) {
+			return txFee, err
+		}
+	}
+
+	calculator := fee.NewStaticCalculator(backend.Config.StaticFeeConfig, backend.Config.UpgradeConfig)
+	return calculator.CalculateFee(tx, timestamp), nil
+}
+
+// ConsumeGas removes the gas used by [tx] from the capacity of [chainState]
+// and adds it to the excess.
+//
+// Once Fortuna is activated, returns [gas.ErrInsufficientCapacity] if [tx]
+// uses more gas than the remaining capacity. Before Fortuna, the capacity is
+// not enforced. Consuming more than the remaining capacity drains it, and the
+// excess keeps growing, which raises the price reported to fee estimators.
+func ConsumeGas(
+	backend *Backend,
+	chainState state.Chain,
+	tx txs.UnsignedTx,
+) error {
+	timestamp := chainState.GetTimestamp()
+	if !backend.Config.UpgradeConfig.IsEtnaActivated(timestamp) {
+		return nil
+	}
+
+	gasUsed, err := txGas(backend, tx)
+	if errors.Is(err, fee.ErrUnsupportedTx) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	feeState := chainState.GetFeeState()
+	if !backend.Config.UpgradeConfig.IsFortunaActivated(timestamp) {
+		feeState.Capacity = max(feeState.Capacity, gasUsed)
+	}
+	feeState, err = feeState.ConsumeGas(gasUsed)
+	if err != nil {
+		return err
+	}
+	chainState.SetFeeState(feeState)
+	return nil
+}
+
+// VerifyMaxGas verifies that [tx] could be included in a block at [timestamp],
+// which requires that it doesn't use more gas than the maximum capacity once
+// Fortuna is activated.
+func VerifyMaxGas(
+	backend *Backend,
+	timestamp time.Time,
+	tx txs.UnsignedTx,
+) error {
+	if !backend.Config.UpgradeConfig.IsFortunaActivated(timestamp) {
+		return nil
+	}
+
+	gasUsed, err := txGas(backend, tx)
+	if errors.Is(err, fee.ErrUnsupportedTx) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if maxCapacity := backend.Config.DynamicFeeConfig.MaxCapacity; gasUsed > maxCapacity {
+		return fmt.Errorf("%w: %d > %d", ErrExceedsMaxCapacity, gasUsed, maxCapacity)
+	}
+	return nil
+}
+
+// txGas returns the gas used by [tx]. Transactions without a complexity return
+// [fee.ErrUnsupportedTx].
+func txGas(backend *Backend, tx txs.UnsignedTx) (gas.Gas, error) {
+	complexity, err := fee.TxComplexity(tx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", fee.ErrCalculatingComplexity, err)
+	}
+	gasUsed, err := complexity.ToGas(backend.Config.DynamicFeeConfig.Weights)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", fee.ErrCalculatingGas, err)
+	}
+	return gasUsed, nil
+}
+
 func GetRewardsCalculator(
 	backend *Backend,
 	parentState state.Chain,
@@ -0,0 +1,62 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*ImportTx)(nil)
+	_ codec.Unmarshaler = (*ImportTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*ImportTx) CodecType() any {
+	return (*ImportTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *ImportTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.BaseTx)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += len(v.SourceChain)
+	fieldSize, err = c.Size(&v.ImportedInputs)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *ImportTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.BaseTx, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.SourceChain[:])
+	if err := c.MarshalInto(&v.ImportedInputs, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *ImportTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.BaseTx); err != nil {
+		return err
+	}
+	copy(v.SourceChain[:], p.UnpackFixedBytes(len(v.SourceChain)))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.ImportedInputs); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*RemoveSubnetValidatorTx)(nil)
+	_ codec.Unmarshaler = (*RemoveSubnetValidatorTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*RemoveSubnetValidatorTx) CodecType() any {
+	return (*RemoveSubnetValidatorTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *RemoveSubnetValidatorTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.BaseTx)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += len(v.NodeID)
+	size += len(v.Subnet)
+	fieldSize, err = c.Size(&v.SubnetAuth)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *RemoveSubnetValidatorTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.BaseTx, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.NodeID[:])
+	p.PackFixedBytes(v.Subnet[:])
+	if err := c.MarshalInto(&v.SubnetAuth, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *RemoveSubnetValidatorTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.BaseTx); err != nil {
+		return err
+	}
+	copy(v.NodeID[:], p.UnpackFixedBytes(len(v.NodeID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	copy(v.Subnet[:], p.UnpackFixedBytes(len(v.Subnet)))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.SubnetAuth); err != nil {
+		return err
+	}
+	return nil
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	_ UnsignedTx = (*RemoveSubnetValidatorTx)(nil)
 
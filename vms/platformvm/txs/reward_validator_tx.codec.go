@@ -0,0 +1,40 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*RewardValidatorTx)(nil)
+	_ codec.Unmarshaler = (*RewardValidatorTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*RewardValidatorTx) CodecType() any {
+	return (*RewardValidatorTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *RewardValidatorTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += len(v.TxID)
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *RewardValidatorTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackFixedBytes(v.TxID[:])
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *RewardValidatorTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	copy(v.TxID[:], p.UnpackFixedBytes(len(v.TxID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
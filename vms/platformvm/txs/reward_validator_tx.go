@@ -11,6 +11,8 @@ import (
 	"github.com/luxfi/node/vms/components/lux"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var _ UnsignedTx = (*RewardValidatorTx)(nil)
 
 // RewardValidatorTx is a transaction that represents a proposal to
@@ -0,0 +1,51 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*SubnetValidator)(nil)
+	_ codec.Unmarshaler = (*SubnetValidator)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*SubnetValidator) CodecType() any {
+	return (*SubnetValidator)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *SubnetValidator) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.Validator)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += len(v.Subnet)
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *SubnetValidator) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.Validator, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.Subnet[:])
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *SubnetValidator) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.Validator); err != nil {
+		return err
+	}
+	copy(v.Subnet[:], p.UnpackFixedBytes(len(v.Subnet)))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
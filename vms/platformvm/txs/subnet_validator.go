@@ -8,6 +8,8 @@ import (
 	"github.com/luxfi/node/utils/constants"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 // SubnetValidator validates a subnet on the Lux network.
 type SubnetValidator struct {
 	Validator `serialize:"true"`
@@ -13,6 +13,8 @@ import (
 	"github.com/luxfi/node/vms/platformvm/fx"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	_ UnsignedTx = (*TransferSubnetOwnershipTx)(nil)
 
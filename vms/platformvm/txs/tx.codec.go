@@ -0,0 +1,56 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*Tx)(nil)
+	_ codec.Unmarshaler = (*Tx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*Tx) CodecType() any {
+	return (*Tx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Tx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.Unsigned)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Creds)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Tx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.Unsigned, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Creds, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Tx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.Unsigned); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Creds); err != nil {
+		return err
+	}
+	return nil
+}
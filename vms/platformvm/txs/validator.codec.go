@@ -0,0 +1,58 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*Validator)(nil)
+	_ codec.Unmarshaler = (*Validator)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*Validator) CodecType() any {
+	return (*Validator)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Validator) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += len(v.NodeID)
+	size += wrappers.LongLen
+	size += wrappers.LongLen
+	size += wrappers.LongLen
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Validator) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackFixedBytes(v.NodeID[:])
+	p.PackLong(v.Start)
+	p.PackLong(v.End)
+	p.PackLong(v.Wght)
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Validator) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	copy(v.NodeID[:], p.UnpackFixedBytes(len(v.NodeID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Start = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.End = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Wght = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/luxfi/ids"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	ErrWeightTooSmall = errors.New("weight of this validator is too low")
 	errBadSubnetID    = errors.New("subnet ID can't be primary network ID")
@@ -26,6 +26,9 @@ type Config struct {
 
 	// Time of the Etna network upgrade
 	EtnaTime time.Time
+
+	// Time of the Fortuna network upgrade
+	FortunaTime time.Time
 }
 
 func (c *Config) IsApricotPhase3Activated(timestamp time.Time) bool {
@@ -51,3 +54,7 @@ func (c *Config) IsDurangoActivated(timestamp time.Time) bool {
 func (c *Config) IsEtnaActivated(timestamp time.Time) bool {
 	return !timestamp.Before(c.EtnaTime)
 }
+
+func (c *Config) IsFortunaActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.FortunaTime)
+}
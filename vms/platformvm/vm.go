@@ -15,7 +15,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/gorilla/rpc/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
@@ -29,6 +28,7 @@ import (
 	"github.com/luxfi/node/cache"
 	"github.com/luxfi/node/codec"
 	"github.com/luxfi/node/codec/linearcodec"
+	"github.com/luxfi/node/network/p2p"
 	consensusutils "github.com/luxfi/consensus/utils"
 	consensusset "github.com/luxfi/consensus/utils/set"
 	"github.com/luxfi/node/utils"
@@ -39,6 +39,7 @@ import (
 	"github.com/luxfi/node/utils/timer/mockable"
 	"github.com/luxfi/node/version"
 	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/components/statesync"
 	"github.com/luxfi/node/vms/platformvm/block"
 	"github.com/luxfi/node/vms/platformvm/config"
 	"github.com/luxfi/node/vms/platformvm/fx"
@@ -138,10 +139,18 @@ type VM struct {
 
 	manager blockexecutor.Manager
 
+	stateSyncEnabled bool
+	stateSyncClient  *p2p.Client
+	// Signaled once a state sync started by accepting a summary finishes.
+	stateSyncDone chan struct{}
+	// stateSyncErr is the error that caused the last state sync to fail.
+	stateSyncErr error
+
 	// Cancelled on shutdown
 	onShutdownCtx context.Context
 	// Call [onShutdownCtxCancel] to cancel [onShutdownCtx] during Shutdown()
 	onShutdownCtxCancel context.CancelFunc
+	awaitShutdown       sync.WaitGroup
 }
 
 // Initialize this blockchain.
@@ -268,6 +277,14 @@ func (vm *VM) Initialize(
 		return fmt.Errorf("failed to initialize network: %w", err)
 	}
 
+	stateSyncHandler := statesync.NewHandler(vm.log, &vm.lock, vm.state)
+	if err := vm.Network.AddHandler(statesync.HandlerID, stateSyncHandler); err != nil {
+		return fmt.Errorf("failed to register state sync handler: %w", err)
+	}
+	vm.stateSyncEnabled = execConfig.StateSyncEnabled
+	vm.stateSyncClient = vm.Network.NewClient(statesync.HandlerID)
+	vm.stateSyncDone = make(chan struct{}, 1)
+
 	vm.onShutdownCtx, vm.onShutdownCtxCancel = context.WithCancel(context.Background())
 	// has better control of the context lock.
 	go vm.Network.PushGossip(vm.onShutdownCtx)
@@ -279,21 +296,31 @@ func (vm *VM) Initialize(
 		vm.manager,
 	)
 
-	// Create all of the chains that the database says exist
-	vm.log.Info("about to call initBlockchains")
-	if err := vm.initBlockchains(); err != nil {
-		return fmt.Errorf(
-			"failed to initialize blockchains: %w",
-			err,
-		)
-	}
+	// If a state sync was interrupted, the state isn't loaded until the sync
+	// is resumed and finishes.
+	_, err = vm.state.GetOngoingSync()
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		// Create all of the chains that the database says exist
+		vm.log.Info("about to call initBlockchains")
+		if err := vm.initBlockchains(); err != nil {
+			return fmt.Errorf(
+				"failed to initialize blockchains: %w",
+				err,
+			)
+		}
 
-	lastAcceptedID := vm.state.GetLastAccepted()
-	vm.log.Info("initializing last accepted",
-		zap.Stringer("blkID", lastAcceptedID),
-	)
-	if err := vm.SetPreference(ctx, lastAcceptedID); err != nil {
+		lastAcceptedID := vm.state.GetLastAccepted()
+		vm.log.Info("initializing last accepted",
+			zap.Stringer("blkID", lastAcceptedID),
+		)
+		if err := vm.SetPreference(ctx, lastAcceptedID); err != nil {
+			return err
+		}
+	case err != nil:
 		return err
+	default:
+		vm.log.Info("waiting for the ongoing state sync to resume")
 	}
 
 	// Incrementing [awaitShutdown] would cause a deadlock since
@@ -588,6 +615,13 @@ func (vm *VM) createSubnet(subnetID ids.ID) error {
 
 // onBootstrapStarted marks this VM as bootstrapping
 func (vm *VM) onBootstrapStarted() error {
+	vm.lock.RLock()
+	err := vm.stateSyncErr
+	vm.lock.RUnlock()
+	if err != nil {
+		return fmt.Errorf("state sync failed: %w", err)
+	}
+
 	vm.bootstrapped.Set(false)
 	vm.bootstrappedConsensus.Set(false)
 	return vm.fx.Bootstrapping()
@@ -655,6 +689,7 @@ func (vm *VM) Shutdown(context.Context) error {
 		vm.onShutdownCtxCancel()
 		vm.onShutdownCtxCancel = nil // Prevent multiple calls
 	}
+	vm.awaitShutdown.Wait()
 
 	// Builder might be nil if Initialize failed or wasn't fully completed
 	if vm.Builder != nil {
@@ -739,7 +774,7 @@ func (*VM) Version(context.Context) (string, error) {
 // * keys are API endpoint extensions
 // * values are API handlers
 func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	server.RegisterInterceptFunc(vm.metrics.InterceptRequest)
@@ -827,7 +862,10 @@ func (vm *VM) NewHTTPHandler(context.Context) (http.Handler, error) {
 // WaitForEvent blocks until either the given context is cancelled, or a message is returned
 // This is required by the linearblock.ChainVM interface
 func (vm *VM) WaitForEvent(ctx context.Context) (core.MessageType, error) {
-	// For now, just block until context is cancelled
-	<-ctx.Done()
-	return core.MessageType(0), ctx.Err()
+	select {
+	case <-vm.stateSyncDone:
+		return core.StateSyncDone, nil
+	case <-ctx.Done():
+		return core.MessageType(0), ctx.Err()
+	}
 }
@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/luxfi/node/codec/codectest"
+)
+
+func TestCodecSchema(t *testing.T) {
+	codectest.RequireCompatibleSchema(
+		t,
+		Codec,
+		filepath.Join("testdata", "codec_schema.json"),
+		(*Block)(nil),
+		(*statelessHeader)(nil),
+	)
+}
@@ -0,0 +1,62 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package secp256k1fx
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/luxfi/crypto/secp256k1"
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*Credential)(nil)
+	_ codec.Unmarshaler = (*Credential)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*Credential) CodecType() any {
+	return (*Credential)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Credential) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.IntLen + len(v.Sigs)*len([secp256k1.SignatureLen]byte{})
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Credential) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if len(v.Sigs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Sigs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Sigs)))
+	for _, elem := range v.Sigs {
+		p.PackFixedBytes(elem[:])
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Credential) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	numSigs := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numSigs > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numSigs, math.MaxInt32)
+	}
+	v.Sigs = make([][secp256k1.SignatureLen]byte, 0, min(int(numSigs), len(p.Bytes)))
+	for i := uint32(0); i < numSigs; i++ {
+		var elem [secp256k1.SignatureLen]byte
+		copy(elem[:], p.UnpackFixedBytes(len(elem)))
+		if p.Err != nil {
+			return p.Err
+		}
+		v.Sigs = append(v.Sigs, elem)
+	}
+	return nil
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/luxfi/node/utils/formatting"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var ErrNilCredential = errors.New("nil credential")
 
 type Credential struct {
@@ -4,6 +4,7 @@
 package secp256k1fx
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/luxfi/crypto/secp256k1"
 	"github.com/luxfi/ids"
 	"github.com/luxfi/log"
+	"github.com/luxfi/node/codec/codectest"
 	"github.com/luxfi/node/codec/linearcodec"
 	"github.com/luxfi/node/utils/cb58"
 )
@@ -1136,3 +1138,37 @@ func TestVerifyPermission(t *testing.T) {
 		})
 	}
 }
+
+func FuzzCodecGenerated(f *testing.F) {
+	codectest.FuzzGenerated(
+		f,
+		func(c linearcodec.Codec) error {
+			return errors.Join(
+				c.RegisterType(&TransferInput{}),
+				c.RegisterType(&MintOutput{}),
+				c.RegisterType(&TransferOutput{}),
+				c.RegisterType(&MintOperation{}),
+				c.RegisterType(&Credential{}),
+				c.RegisterType(&Input{}),
+				c.RegisterType(&OutputOwners{}),
+			)
+		},
+		&TransferInput{
+			Amt: 1,
+			Input: Input{
+				SigIndices: []uint32{0, 1},
+			},
+		},
+		&TransferOutput{
+			Amt: 1,
+			OutputOwners: OutputOwners{
+				Locktime:  2,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+		&Credential{
+			Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+		},
+	)
+}
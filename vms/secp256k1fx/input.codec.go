@@ -0,0 +1,60 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package secp256k1fx
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*Input)(nil)
+	_ codec.Unmarshaler = (*Input)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*Input) CodecType() any {
+	return (*Input)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Input) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.IntLen + len(v.SigIndices)*wrappers.IntLen
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Input) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if len(v.SigIndices) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.SigIndices), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.SigIndices)))
+	for _, elem := range v.SigIndices {
+		p.PackInt(elem)
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Input) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	numSigIndices := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numSigIndices > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numSigIndices, math.MaxInt32)
+	}
+	v.SigIndices = make([]uint32, 0, min(int(numSigIndices), len(p.Bytes)))
+	for i := uint32(0); i < numSigIndices; i++ {
+		elem := p.UnpackInt()
+		if p.Err != nil {
+			return p.Err
+		}
+		v.SigIndices = append(v.SigIndices, elem)
+	}
+	return nil
+}
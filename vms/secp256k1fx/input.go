@@ -10,6 +10,8 @@ import (
 	"github.com/luxfi/math/math"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 const (
 	CostPerSignature uint64 = 1000
 )
@@ -0,0 +1,67 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package secp256k1fx
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*MintOperation)(nil)
+	_ codec.Unmarshaler = (*MintOperation)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*MintOperation) CodecType() any {
+	return (*MintOperation)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *MintOperation) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.MintInput)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.MintOutput)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.TransferOutput)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *MintOperation) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.MintInput, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.MintOutput, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.TransferOutput, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *MintOperation) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.MintInput); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.MintOutput); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.TransferOutput); err != nil {
+		return err
+	}
+	return nil
+}
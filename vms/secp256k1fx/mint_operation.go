@@ -10,6 +10,8 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var errNilMintOperation = errors.New("nil mint operation")
 
 type MintOperation struct {
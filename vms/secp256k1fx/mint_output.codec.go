@@ -0,0 +1,45 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package secp256k1fx
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*MintOutput)(nil)
+	_ codec.Unmarshaler = (*MintOutput)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*MintOutput) CodecType() any {
+	return (*MintOutput)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *MintOutput) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.OutputOwners)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *MintOutput) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.OutputOwners, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *MintOutput) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.OutputOwners); err != nil {
+		return err
+	}
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var _ verify.State = (*MintOutput)(nil)
 
 type MintOutput struct {
@@ -0,0 +1,74 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package secp256k1fx
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*OutputOwners)(nil)
+	_ codec.Unmarshaler = (*OutputOwners)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*OutputOwners) CodecType() any {
+	return (*OutputOwners)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *OutputOwners) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.LongLen
+	size += wrappers.IntLen
+	size += wrappers.IntLen + len(v.Addrs)*len(ids.ShortID{})
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *OutputOwners) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackLong(v.Locktime)
+	p.PackInt(v.Threshold)
+	if len(v.Addrs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Addrs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Addrs)))
+	for _, elem := range v.Addrs {
+		p.PackFixedBytes(elem[:])
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *OutputOwners) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.Locktime = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Threshold = p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	numAddrs := p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if numAddrs > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numAddrs, math.MaxInt32)
+	}
+	v.Addrs = make([]ids.ShortID, 0, min(int(numAddrs), len(p.Bytes)))
+	for i := uint32(0); i < numAddrs; i++ {
+		var elem ids.ShortID
+		copy(elem[:], p.UnpackFixedBytes(len(elem)))
+		if p.Err != nil {
+			return p.Err
+		}
+		v.Addrs = append(v.Addrs, elem)
+	}
+	return nil
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	ErrNilOutput            = errors.New("nil output")
 	ErrOutputUnspendable    = errors.New("output is unspendable")
@@ -0,0 +1,51 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package secp256k1fx
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*TransferInput)(nil)
+	_ codec.Unmarshaler = (*TransferInput)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*TransferInput) CodecType() any {
+	return (*TransferInput)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *TransferInput) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.LongLen
+	fieldSize, err := c.Size(&v.Input)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *TransferInput) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackLong(v.Amt)
+	if err := c.MarshalInto(&v.Input, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *TransferInput) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.Amt = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.Input); err != nil {
+		return err
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 	"errors"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var ErrNoValueInput = errors.New("input has no value")
 
 type TransferInput struct {
@@ -0,0 +1,51 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package secp256k1fx
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*TransferOutput)(nil)
+	_ codec.Unmarshaler = (*TransferOutput)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*TransferOutput) CodecType() any {
+	return (*TransferOutput)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *TransferOutput) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.LongLen
+	fieldSize, err := c.Size(&v.OutputOwners)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *TransferOutput) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackLong(v.Amt)
+	if err := c.MarshalInto(&v.OutputOwners, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *TransferOutput) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.Amt = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.OutputOwners); err != nil {
+		return err
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/luxfi/math/set"
 	"github.com/luxfi/node/utils/timer/mockable"
 	"github.com/luxfi/node/utils/units"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/xvm/block"
 	"github.com/luxfi/node/vms/xvm/state"
 	"github.com/luxfi/node/vms/xvm/txs"
@@ -85,6 +86,7 @@ func (b *builder) BuildBlock(context.Context) (chain.Block, error) {
 	if err != nil {
 		return nil, err
 	}
+	txexecutor.AdvanceTimeTo(b.backend, stateDiff, nextTimestamp)
 
 	var (
 		blockTxs      []*txs.Tx
@@ -100,15 +102,35 @@ func (b *builder) BuildBlock(context.Context) (chain.Block, error) {
 		if !exists || len(tx.Bytes()) > remainingSize {
 			break
 		}
-		b.mempool.Remove(tx)
-
-		// Invariant: [tx] has already been syntactically verified.
 
 		txDiff, err := state.NewDiffOn(stateDiff)
 		if err != nil {
 			return nil, err
 		}
 
+		err = txexecutor.VerifyDynamicFee(b.backend, txDiff, tx.Unsigned)
+		if err != nil {
+			b.mempool.Remove(tx)
+			txID := tx.ID()
+			b.mempool.MarkDropped(txID, err)
+			continue
+		}
+
+		// If the remaining gas capacity is exhausted, [tx] is left in the
+		// mempool to be included in a later block.
+		err = txexecutor.ConsumeGas(b.backend, txDiff, tx.Unsigned)
+		if errors.Is(err, gas.ErrInsufficientCapacity) {
+			break
+		}
+		b.mempool.Remove(tx)
+		if err != nil {
+			txID := tx.ID()
+			b.mempool.MarkDropped(txID, err)
+			continue
+		}
+
+		// Invariant: [tx] has already been syntactically verified.
+
 		err = tx.Unsigned.Visit(&txexecutor.SemanticVerifier{
 			Backend: b.backend,
 			State:   txDiff,
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/luxfi/mock/gomock"
 
+	"github.com/luxfi/node/cache"
 	"github.com/luxfi/node/codec"
 
 	"github.com/luxfi/consensus"
@@ -33,12 +34,16 @@ import (
 
 	"github.com/luxfi/node/utils/timer/mockable"
 
+	"github.com/luxfi/node/vms/components/gas"
+
 	"github.com/luxfi/node/vms/components/lux"
 
 	"github.com/luxfi/node/vms/secp256k1fx"
 
 	"github.com/luxfi/node/vms/xvm/block"
 
+	"github.com/luxfi/node/vms/xvm/config"
+
 	"github.com/luxfi/node/vms/xvm/fxs"
 
 	"github.com/luxfi/node/vms/xvm/state"
@@ -56,6 +61,12 @@ const trackChecksums = false
 
 var (
 	errTest = errors.New("test error")
+
+	testConfig = &config.Config{
+		EtnaTime:    mockable.MaxTime,
+		FortunaTime: mockable.MaxTime,
+	}
+
 	chainID = ids.GenerateTestID()
 	keys    = secp256k1.TestKeys()
 )
@@ -83,7 +94,8 @@ func TestBuilderBuildBlock(t *testing.T) {
 				ctx = consensus.WithLogger(ctx, log.NewNoOpLogger())
 				return New(
 					&txexecutor.Backend{
-						Ctx: ctx,
+						Ctx:    ctx,
+						Config: testConfig,
 					},
 					manager,
 					&mockable.Clock{},
@@ -114,7 +126,8 @@ func TestBuilderBuildBlock(t *testing.T) {
 				ctx = consensus.WithLogger(ctx, log.NewNoOpLogger())
 				return New(
 					&txexecutor.Backend{
-						Ctx: ctx,
+						Ctx:    ctx,
+						Config: testConfig,
 					},
 					manager,
 					&mockable.Clock{},
@@ -136,6 +149,7 @@ func TestBuilderBuildBlock(t *testing.T) {
 				preferredState := state.NewMockChain(ctrl)
 				preferredState.EXPECT().GetLastAccepted().Return(preferredID)
 				preferredState.EXPECT().GetTimestamp().Return(preferredTimestamp)
+				preferredState.EXPECT().GetFeeState().Return(gas.State{})
 
 				manager := blkexecutor.NewMockManager(ctrl)
 				manager.EXPECT().Preferred().Return(preferredID)
@@ -158,7 +172,8 @@ func TestBuilderBuildBlock(t *testing.T) {
 				ctx = consensus.WithLogger(ctx, log.NewNoOpLogger())
 				return New(
 					&txexecutor.Backend{
-						Ctx: ctx,
+						Ctx:    ctx,
+						Config: testConfig,
 					},
 					manager,
 					&mockable.Clock{},
@@ -180,6 +195,7 @@ func TestBuilderBuildBlock(t *testing.T) {
 				preferredState := state.NewMockChain(ctrl)
 				preferredState.EXPECT().GetLastAccepted().Return(preferredID)
 				preferredState.EXPECT().GetTimestamp().Return(preferredTimestamp)
+				preferredState.EXPECT().GetFeeState().Return(gas.State{})
 
 				manager := blkexecutor.NewMockManager(ctrl)
 				manager.EXPECT().Preferred().Return(preferredID)
@@ -203,7 +219,8 @@ func TestBuilderBuildBlock(t *testing.T) {
 				ctx = consensus.WithLogger(ctx, log.NewNoOpLogger())
 				return New(
 					&txexecutor.Backend{
-						Ctx: ctx,
+						Ctx:    ctx,
+						Config: testConfig,
 					},
 					manager,
 					&mockable.Clock{},
@@ -225,6 +242,7 @@ func TestBuilderBuildBlock(t *testing.T) {
 				preferredState := state.NewMockChain(ctrl)
 				preferredState.EXPECT().GetLastAccepted().Return(preferredID)
 				preferredState.EXPECT().GetTimestamp().Return(preferredTimestamp)
+				preferredState.EXPECT().GetFeeState().Return(gas.State{})
 
 				manager := blkexecutor.NewMockManager(ctrl)
 				manager.EXPECT().Preferred().Return(preferredID)
@@ -249,7 +267,8 @@ func TestBuilderBuildBlock(t *testing.T) {
 				ctx = consensus.WithLogger(ctx, log.NewNoOpLogger())
 				return New(
 					&txexecutor.Backend{
-						Ctx: ctx,
+						Ctx:    ctx,
+						Config: testConfig,
 					},
 					manager,
 					&mockable.Clock{},
@@ -271,6 +290,7 @@ func TestBuilderBuildBlock(t *testing.T) {
 				preferredState := state.NewMockChain(ctrl)
 				preferredState.EXPECT().GetLastAccepted().Return(preferredID)
 				preferredState.EXPECT().GetTimestamp().Return(preferredTimestamp)
+				preferredState.EXPECT().GetFeeState().Return(gas.State{})
 
 				// tx1 and tx2 both consume [inputID].
 				// tx1 is added to the block first, so tx2 should be dropped.
@@ -339,8 +359,9 @@ func TestBuilderBuildBlock(t *testing.T) {
 
 				return New(
 					&txexecutor.Backend{
-						Codec: codec,
-						Ctx: consensus.WithLogger(context.Background(), log.NewNoOpLogger()),
+						Codec:  codec,
+						Ctx:    consensus.WithLogger(context.Background(), log.NewNoOpLogger()),
+						Config: testConfig,
 					},
 					manager,
 					&mockable.Clock{},
@@ -367,6 +388,7 @@ func TestBuilderBuildBlock(t *testing.T) {
 				preferredState := state.NewMockChain(ctrl)
 				preferredState.EXPECT().GetLastAccepted().Return(preferredID)
 				preferredState.EXPECT().GetTimestamp().Return(preferredTimestamp)
+				preferredState.EXPECT().GetFeeState().Return(gas.State{})
 
 				manager := blkexecutor.NewMockManager(ctrl)
 				manager.EXPECT().Preferred().Return(preferredID)
@@ -409,8 +431,9 @@ func TestBuilderBuildBlock(t *testing.T) {
 
 				return New(
 					&txexecutor.Backend{
-						Codec: codec,
-						Ctx: consensus.WithLogger(context.Background(), log.NewNoOpLogger()),
+						Codec:  codec,
+						Ctx:    consensus.WithLogger(context.Background(), log.NewNoOpLogger()),
+						Config: testConfig,
 					},
 					manager,
 					clock,
@@ -439,6 +462,7 @@ func TestBuilderBuildBlock(t *testing.T) {
 				preferredState := state.NewMockChain(ctrl)
 				preferredState.EXPECT().GetLastAccepted().Return(preferredID)
 				preferredState.EXPECT().GetTimestamp().Return(preferredTimestamp)
+				preferredState.EXPECT().GetFeeState().Return(gas.State{})
 
 				manager := blkexecutor.NewMockManager(ctrl)
 				manager.EXPECT().Preferred().Return(preferredID)
@@ -481,8 +505,9 @@ func TestBuilderBuildBlock(t *testing.T) {
 
 				return New(
 					&txexecutor.Backend{
-						Codec: codec,
-						Ctx: consensus.WithLogger(context.Background(), log.NewNoOpLogger()),
+						Codec:  codec,
+						Ctx:    consensus.WithLogger(context.Background(), log.NewNoOpLogger()),
+						Config: testConfig,
 					},
 					manager,
 					clock,
@@ -529,13 +554,14 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 	require.NoError(err)
 
 	backend := &txexecutor.Backend{
-		Ctx:   consensus.WithLogger(context.Background(), log.NewNoOpLogger()),
-		Codec: parser.Codec(),
+		Ctx:    consensus.WithLogger(context.Background(), log.NewNoOpLogger()),
+		Config: testConfig,
+		Codec:  parser.Codec(),
 	}
 
 	baseDB := versiondb.New(memdb.New())
 
-	state, err := state.New(baseDB, parser, registerer, trackChecksums)
+	state, err := state.New(baseDB, parser, registerer, cache.LRUPolicy, trackChecksums, state.SyncConfig{})
 	require.NoError(err)
 
 	clk := &mockable.Clock{}
@@ -87,8 +87,9 @@ func (b *Block) Verify(ctx context.Context) error {
 	// before performing any possible DB reads.
 	for _, tx := range txs {
 		err := tx.Unsigned.Visit(&executor.SyntacticVerifier{
-			Backend: b.manager.backend,
-			Tx:      tx,
+			Backend:   b.manager.backend,
+			Tx:        tx,
+			Timestamp: newChainTime,
 		})
 		if err != nil {
 			txID := tx.ID()
@@ -132,7 +133,7 @@ func (b *Block) Verify(ctx context.Context) error {
 		)
 	}
 
-	stateDiff.SetTimestamp(newChainTime)
+	executor.AdvanceTimeTo(b.manager.backend, stateDiff, newChainTime)
 
 	blockState := &blockState{
 		statelessBlock: b.Block,
@@ -154,6 +155,19 @@ func (b *Block) Verify(ctx context.Context) error {
 			return err
 		}
 
+		err = executor.VerifyDynamicFee(b.manager.backend, stateDiff, tx.Unsigned)
+		if err != nil {
+			txID := tx.ID()
+			b.manager.mempool.MarkDropped(txID, err)
+			return err
+		}
+
+		// The block must not use more gas than the chain has capacity for.
+		// This doesn't invalidate the tx, so it isn't marked as dropped.
+		if err := executor.ConsumeGas(b.manager.backend, stateDiff, tx.Unsigned); err != nil {
+			return err
+		}
+
 		// Apply the txs state changes to the state.
 		//
 		// Note: This must be done inside the same loop as semantic verification
@@ -19,6 +19,7 @@ import (
 	"github.com/luxfi/node/utils"
 	"github.com/luxfi/math/set"
 	"github.com/luxfi/node/utils/timer/mockable"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/xvm/block"
 	"github.com/luxfi/node/vms/xvm/config"
 	"github.com/luxfi/node/vms/xvm/state"
@@ -234,6 +235,7 @@ func TestBlockVerify(t *testing.T) {
 				mockParentState := state.NewMockDiff(ctrl)
 				mockParentState.EXPECT().GetLastAccepted().Return(parentID)
 				mockParentState.EXPECT().GetTimestamp().Return(blockTimestamp.Add(1))
+				mockParentState.EXPECT().GetFeeState().Return(gas.State{})
 
 				return &Block{
 					Block: mockBlock,
@@ -280,6 +282,7 @@ func TestBlockVerify(t *testing.T) {
 				mockParentState := state.NewMockDiff(ctrl)
 				mockParentState.EXPECT().GetLastAccepted().Return(parentID)
 				mockParentState.EXPECT().GetTimestamp().Return(blockTimestamp)
+				mockParentState.EXPECT().GetFeeState().Return(gas.State{})
 
 				mempool := mempool.NewMockMempool(ctrl)
 				mempool.EXPECT().MarkDropped(tx.ID(), errTest).Times(1)
@@ -331,6 +334,7 @@ func TestBlockVerify(t *testing.T) {
 				mockParentState := state.NewMockDiff(ctrl)
 				mockParentState.EXPECT().GetLastAccepted().Return(parentID)
 				mockParentState.EXPECT().GetTimestamp().Return(blockTimestamp)
+				mockParentState.EXPECT().GetFeeState().Return(gas.State{})
 
 				mempool := mempool.NewMockMempool(ctrl)
 				mempool.EXPECT().MarkDropped(tx.ID(), errTest).Times(1)
@@ -409,6 +413,7 @@ func TestBlockVerify(t *testing.T) {
 				mockParentState := state.NewMockDiff(ctrl)
 				mockParentState.EXPECT().GetLastAccepted().Return(parentID)
 				mockParentState.EXPECT().GetTimestamp().Return(blockTimestamp)
+				mockParentState.EXPECT().GetFeeState().Return(gas.State{})
 
 				mempool := mempool.NewMockMempool(ctrl)
 				mempool.EXPECT().MarkDropped(tx2.ID(), ErrConflictingBlockTxs).Times(1)
@@ -471,6 +476,7 @@ func TestBlockVerify(t *testing.T) {
 				mockParentState := state.NewMockDiff(ctrl)
 				mockParentState.EXPECT().GetLastAccepted().Return(parentID)
 				mockParentState.EXPECT().GetTimestamp().Return(blockTimestamp)
+				mockParentState.EXPECT().GetFeeState().Return(gas.State{})
 
 				return &Block{
 					Block: mockBlock,
@@ -519,6 +525,7 @@ func TestBlockVerify(t *testing.T) {
 				mockParentState := state.NewMockDiff(ctrl)
 				mockParentState.EXPECT().GetLastAccepted().Return(parentID)
 				mockParentState.EXPECT().GetTimestamp().Return(blockTimestamp)
+				mockParentState.EXPECT().GetFeeState().Return(gas.State{})
 
 				mockMempool := mempool.NewMockMempool(ctrl)
 				mockMempool.EXPECT().Remove([]*txs.Tx{tx})
@@ -847,6 +854,7 @@ func TestBlockReject(t *testing.T) {
 				mockState := state.NewMockState(ctrl)
 				mockState.EXPECT().GetLastAccepted().Return(lastAcceptedID).AnyTimes()
 				mockState.EXPECT().GetTimestamp().Return(time.Now()).AnyTimes()
+				mockState.EXPECT().GetFeeState().Return(gas.State{}).AnyTimes()
 
 				return &Block{
 					Block: mockBlock,
@@ -900,6 +908,7 @@ func TestBlockReject(t *testing.T) {
 				mockState := state.NewMockState(ctrl)
 				mockState.EXPECT().GetLastAccepted().Return(lastAcceptedID).AnyTimes()
 				mockState.EXPECT().GetTimestamp().Return(time.Now()).AnyTimes()
+				mockState.EXPECT().GetFeeState().Return(gas.State{}).AnyTimes()
 
 				return &Block{
 					Block: mockBlock,
@@ -1047,6 +1056,7 @@ func defaultTestBackend(bootstrapped bool, sharedMemory atomic.SharedMemory) *ex
 		Ctx:          ctx,
 		Config: &config.Config{
 			EtnaTime:         mockable.MaxTime,
+			FortunaTime:      mockable.MaxTime,
 			TxFee:            0,
 			CreateAssetTxFee: 0,
 		},
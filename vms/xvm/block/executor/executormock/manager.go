@@ -132,6 +132,18 @@ func (mr *ManagerMockRecorder) Preferred() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preferred", reflect.TypeOf((*Manager)(nil).Preferred))
 }
 
+// ResetLastAccepted mocks base method.
+func (m *Manager) ResetLastAccepted() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetLastAccepted")
+}
+
+// ResetLastAccepted indicates an expected call of ResetLastAccepted.
+func (mr *ManagerMockRecorder) ResetLastAccepted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLastAccepted", reflect.TypeOf((*Manager)(nil).ResetLastAccepted))
+}
+
 // SetPreference mocks base method.
 func (m *Manager) SetPreference(blkID ids.ID) {
 	m.ctrl.T.Helper()
@@ -35,6 +35,11 @@ type Manager interface {
 	SetPreference(blkID ids.ID)
 	Preferred() ids.ID
 
+	// ResetLastAccepted drops all processing blocks and prefers the last
+	// accepted block of the state. It must be called after the state was
+	// replaced by state sync.
+	ResetLastAccepted()
+
 	GetBlock(blkID ids.ID) (chain.Block, error)
 	GetStatelessBlock(blkID ids.ID) (block.Block, error)
 	NewBlock(block.Block) chain.Block
@@ -118,6 +123,12 @@ func (m *manager) Preferred() ids.ID {
 	return m.preferred
 }
 
+func (m *manager) ResetLastAccepted() {
+	m.lastAccepted = m.state.GetLastAccepted()
+	m.blkIDToState = map[ids.ID]*blockState{}
+	m.preferred = m.lastAccepted
+}
+
 func (m *manager) GetBlock(blkID ids.ID) (chain.Block, error) {
 	blk, err := m.GetStatelessBlock(blkID)
 	if err != nil {
@@ -147,9 +158,11 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 		return ErrChainNotSynced
 	}
 
+	now := m.clk.Time()
 	err := tx.Unsigned.Visit(&executor.SyntacticVerifier{
-		Backend: m.backend,
-		Tx:      tx,
+		Backend:   m.backend,
+		Tx:        tx,
+		Timestamp: now,
 	})
 	if err != nil {
 		return err
@@ -160,6 +173,14 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 		return err
 	}
 
+	// The tx will be included in a block no earlier than the current time, so
+	// it must pay at least the gas price as of the current time.
+	nextTimestamp := now
+	if parentTimestamp := stateDiff.GetTimestamp(); parentTimestamp.After(now) {
+		nextTimestamp = parentTimestamp
+	}
+	executor.AdvanceTimeTo(m.backend, stateDiff, nextTimestamp)
+
 	err = tx.Unsigned.Visit(&executor.SemanticVerifier{
 		Backend: m.backend,
 		State:   stateDiff,
@@ -169,6 +190,13 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 		return err
 	}
 
+	if err := executor.VerifyDynamicFee(m.backend, stateDiff, tx.Unsigned); err != nil {
+		return err
+	}
+	if err := executor.VerifyMaxGas(m.backend, nextTimestamp, tx.Unsigned); err != nil {
+		return err
+	}
+
 	executor := &executor.Executor{
 		Codec: m.backend.Codec,
 		State: stateDiff,
@@ -13,6 +13,8 @@ import (
 
 	"github.com/luxfi/ids"
 	"github.com/luxfi/math/set"
+	"github.com/luxfi/node/utils/timer/mockable"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/xvm/block"
 	"github.com/luxfi/node/vms/xvm/state"
 	"github.com/luxfi/node/vms/xvm/txs"
@@ -106,6 +108,29 @@ func TestManagerGetState(t *testing.T) {
 	}
 }
 
+func TestManagerResetLastAccepted(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	processingID := ids.GenerateTestID()
+	syncedID := ids.GenerateTestID()
+	state := state.NewMockState(ctrl)
+	m := &manager{
+		state:        state,
+		lastAccepted: ids.GenerateTestID(),
+		preferred:    processingID,
+		blkIDToState: map[ids.ID]*blockState{
+			processingID: {},
+		},
+	}
+
+	state.EXPECT().GetLastAccepted().Return(syncedID)
+	m.ResetLastAccepted()
+	require.Equal(syncedID, m.LastAccepted())
+	require.Equal(syncedID, m.Preferred())
+	require.Empty(m.blkIDToState)
+}
+
 func TestManagerVerifyTx(t *testing.T) {
 	type test struct {
 		name        string
@@ -139,6 +164,7 @@ func TestManagerVerifyTx(t *testing.T) {
 			managerF: func(*gomock.Controller) *manager {
 				return &manager{
 					backend: defaultTestBackend(true, nil),
+					clk:     &mockable.Clock{},
 				}
 			},
 			expectedErr: errTestSyntacticVerifyFail,
@@ -162,11 +188,13 @@ func TestManagerVerifyTx(t *testing.T) {
 				state := state.NewMockState(ctrl)
 				state.EXPECT().GetLastAccepted().Return(lastAcceptedID)
 				state.EXPECT().GetTimestamp().Return(time.Time{})
+				state.EXPECT().GetFeeState().Return(gas.State{})
 
 				return &manager{
 					backend:      defaultTestBackend(true, nil),
 					state:        state,
 					lastAccepted: lastAcceptedID,
+					clk:          &mockable.Clock{},
 				}
 			},
 			expectedErr: errTestSemanticVerifyFail,
@@ -192,11 +220,13 @@ func TestManagerVerifyTx(t *testing.T) {
 				state := state.NewMockState(ctrl)
 				state.EXPECT().GetLastAccepted().Return(lastAcceptedID)
 				state.EXPECT().GetTimestamp().Return(time.Time{})
+				state.EXPECT().GetFeeState().Return(gas.State{})
 
 				return &manager{
 					backend:      defaultTestBackend(true, nil),
 					state:        state,
 					lastAccepted: lastAcceptedID,
+					clk:          &mockable.Clock{},
 				}
 			},
 			expectedErr: errTestExecutionFail,
@@ -222,11 +252,13 @@ func TestManagerVerifyTx(t *testing.T) {
 				state := state.NewMockState(ctrl)
 				state.EXPECT().GetLastAccepted().Return(lastAcceptedID)
 				state.EXPECT().GetTimestamp().Return(time.Time{})
+				state.EXPECT().GetFeeState().Return(gas.State{})
 
 				return &manager{
 					backend:      defaultTestBackend(true, nil),
 					state:        state,
 					lastAccepted: lastAcceptedID,
+					clk:          &mockable.Clock{},
 				}
 			},
 			expectedErr: nil,
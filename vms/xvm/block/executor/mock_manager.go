@@ -131,6 +131,18 @@ func (mr *MockManagerMockRecorder) Preferred() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preferred", reflect.TypeOf((*MockManager)(nil).Preferred))
 }
 
+// ResetLastAccepted mocks base method.
+func (m *MockManager) ResetLastAccepted() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetLastAccepted")
+}
+
+// ResetLastAccepted indicates an expected call of ResetLastAccepted.
+func (mr *MockManagerMockRecorder) ResetLastAccepted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLastAccepted", reflect.TypeOf((*MockManager)(nil).ResetLastAccepted))
+}
+
 // SetPreference mocks base method.
 func (m *MockManager) SetPreference(blkID ids.ID) {
 	m.ctrl.T.Helper()
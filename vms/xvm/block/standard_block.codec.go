@@ -0,0 +1,69 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package block
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*StandardBlock)(nil)
+	_ codec.Unmarshaler = (*StandardBlock)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*StandardBlock) CodecType() any {
+	return (*StandardBlock)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *StandardBlock) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += len(v.PrntID)
+	size += wrappers.LongLen
+	size += wrappers.LongLen
+	size += len(v.Root)
+	fieldSize, err := c.Size(&v.Transactions)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *StandardBlock) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackFixedBytes(v.PrntID[:])
+	p.PackLong(v.Hght)
+	p.PackLong(v.Time)
+	p.PackFixedBytes(v.Root[:])
+	if err := c.MarshalInto(&v.Transactions, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *StandardBlock) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	copy(v.PrntID[:], p.UnpackFixedBytes(len(v.PrntID)))
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Hght = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Time = p.UnpackLong()
+	if p.Err != nil {
+		return p.Err
+	}
+	copy(v.Root[:], p.UnpackFixedBytes(len(v.Root)))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.Transactions); err != nil {
+		return err
+	}
+	return nil
+}
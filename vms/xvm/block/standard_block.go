@@ -14,6 +14,8 @@ import (
 	"github.com/luxfi/node/vms/xvm/txs"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var _ Block = (*StandardBlock)(nil)
 
 type StandardBlock struct {
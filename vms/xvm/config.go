@@ -6,21 +6,28 @@ package xvm
 import (
 	"encoding/json"
 
+	"github.com/luxfi/node/cache"
 	"github.com/luxfi/node/vms/xvm/network"
 )
 
 var DefaultConfig = Config{
-	Network:              network.DefaultConfig,
-	IndexTransactions:    false,
-	IndexAllowIncomplete: false,
-	ChecksumsEnabled:     false,
+	Network:                   network.DefaultConfig,
+	IndexTransactions:         false,
+	IndexAllowIncomplete:      false,
+	ChecksumsEnabled:          false,
+	CachePolicy:               cache.LRUPolicy,
+	StateSyncEnabled:          false,
+	StateSyncSummaryFrequency: 16_384,
 }
 
 type Config struct {
-	Network              network.Config `json:"network"`
-	IndexTransactions    bool           `json:"index-transactions"`
-	IndexAllowIncomplete bool           `json:"index-allow-incomplete"`
-	ChecksumsEnabled     bool           `json:"checksums-enabled"`
+	Network                   network.Config `json:"network"`
+	IndexTransactions         bool           `json:"index-transactions"`
+	IndexAllowIncomplete      bool           `json:"index-allow-incomplete"`
+	ChecksumsEnabled          bool           `json:"checksums-enabled"`
+	CachePolicy               cache.Policy   `json:"cache-policy"`
+	StateSyncEnabled          bool           `json:"state-sync-enabled"`
+	StateSyncSummaryFrequency uint64         `json:"state-sync-summary-frequency"`
 }
 
 func ParseConfig(configBytes []byte) (Config, error) {
@@ -29,6 +36,8 @@ func ParseConfig(configBytes []byte) (Config, error) {
 	}
 
 	config := DefaultConfig
-	err := json.Unmarshal(configBytes, &config)
-	return config, err
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return config, err
+	}
+	return config, config.CachePolicy.Verify()
 }
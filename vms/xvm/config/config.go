@@ -3,7 +3,11 @@
 
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/luxfi/node/vms/components/gas"
+)
 
 // Struct collecting all the foundational parameters of the XVM
 type Config struct {
@@ -13,10 +17,23 @@ type Config struct {
 	// Fee that must be burned by every asset creating transaction
 	CreateAssetTxFee uint64
 
+	// Dynamic fee state parameters active after Fortuna
+	DynamicFeeConfig gas.Config
+
+	// Time of the Cortina network upgrade, when the X-Chain was linearized
+	CortinaTime time.Time
+
 	// Time of the Etna network upgrade
 	EtnaTime time.Time
+
+	// Time of the Fortuna network upgrade
+	FortunaTime time.Time
 }
 
 func (c *Config) IsEtnaActivated(timestamp time.Time) bool {
 	return !timestamp.Before(c.EtnaTime)
 }
+
+func (c *Config) IsFortunaActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.FortunaTime)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/luxfi/node/cache"
 	"github.com/luxfi/node/vms/xvm/network"
 )
 
@@ -27,10 +28,13 @@ func TestParseConfig(t *testing.T) {
 			name:        "manually specified checksums enabled",
 			configBytes: []byte(`{"checksums-enabled":true}`),
 			expectedConfig: Config{
-				Network:              network.DefaultConfig,
-				IndexTransactions:    DefaultConfig.IndexTransactions,
-				IndexAllowIncomplete: DefaultConfig.IndexAllowIncomplete,
-				ChecksumsEnabled:     true,
+				Network:                   network.DefaultConfig,
+				IndexTransactions:         DefaultConfig.IndexTransactions,
+				IndexAllowIncomplete:      DefaultConfig.IndexAllowIncomplete,
+				ChecksumsEnabled:          true,
+				CachePolicy:               DefaultConfig.CachePolicy,
+				StateSyncEnabled:          DefaultConfig.StateSyncEnabled,
+				StateSyncSummaryFrequency: DefaultConfig.StateSyncSummaryFrequency,
 			},
 		},
 		{
@@ -56,9 +60,25 @@ func TestParseConfig(t *testing.T) {
 					ExpectedBloomFilterFalsePositiveProbability: network.DefaultConfig.ExpectedBloomFilterFalsePositiveProbability,
 					MaxBloomFilterFalsePositiveProbability:      network.DefaultConfig.MaxBloomFilterFalsePositiveProbability,
 				},
-				IndexTransactions:    DefaultConfig.IndexTransactions,
-				IndexAllowIncomplete: DefaultConfig.IndexAllowIncomplete,
-				ChecksumsEnabled:     DefaultConfig.ChecksumsEnabled,
+				IndexTransactions:         DefaultConfig.IndexTransactions,
+				IndexAllowIncomplete:      DefaultConfig.IndexAllowIncomplete,
+				ChecksumsEnabled:          DefaultConfig.ChecksumsEnabled,
+				CachePolicy:               DefaultConfig.CachePolicy,
+				StateSyncEnabled:          DefaultConfig.StateSyncEnabled,
+				StateSyncSummaryFrequency: DefaultConfig.StateSyncSummaryFrequency,
+			},
+		},
+		{
+			name:        "manually specified state sync values",
+			configBytes: []byte(`{"state-sync-enabled":true,"state-sync-summary-frequency":1024}`),
+			expectedConfig: Config{
+				Network:                   network.DefaultConfig,
+				IndexTransactions:         DefaultConfig.IndexTransactions,
+				IndexAllowIncomplete:      DefaultConfig.IndexAllowIncomplete,
+				ChecksumsEnabled:          DefaultConfig.ChecksumsEnabled,
+				CachePolicy:               DefaultConfig.CachePolicy,
+				StateSyncEnabled:          true,
+				StateSyncSummaryFrequency: 1024,
 			},
 		},
 	}
@@ -72,3 +92,8 @@ func TestParseConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestParseConfigUnknownCachePolicy(t *testing.T) {
+	_, err := ParseConfig([]byte(`{"cache-policy":"mru"}`))
+	require.ErrorIs(t, err, cache.ErrUnknownPolicy)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/luxfi/database/prefixdb"
 	"github.com/luxfi/ids"
 	"github.com/luxfi/node/chains/atomic"
+	"github.com/luxfi/node/upgrade"
 	"github.com/luxfi/node/utils/constants"
 	"github.com/luxfi/node/utils/formatting"
 	"github.com/luxfi/node/utils/formatting/address"
@@ -212,7 +213,9 @@ func staticConfig(tb testing.TB, f fork) config.Config {
 	c := config.Config{
 		TxFee:            testTxFee,
 		CreateAssetTxFee: testTxFee,
+		CortinaTime:      upgrade.InitiallyActiveTime,
 		EtnaTime:         mockable.MaxTime,
+		FortunaTime:      mockable.MaxTime,
 	}
 
 	switch f {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/luxfi/node/utils"
 	"github.com/luxfi/node/utils/formatting"
 	"github.com/luxfi/math/set"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/components/lux"
 	"github.com/luxfi/node/vms/components/verify"
 	"github.com/luxfi/node/vms/nftfx"
@@ -198,6 +200,48 @@ func (s *Service) GetHeight(_ *http.Request, _ *struct{}, reply *api.GetHeightRe
 	return nil
 }
 
+// GetFeeConfig returns the config used to calculate the dynamic fee state.
+func (s *Service) GetFeeConfig(_ *http.Request, _ *struct{}, reply *gas.Config) error {
+	s.vm.log.Debug("API called",
+		zap.String("service", "xvm"),
+		zap.String("method", "getFeeConfig"),
+	)
+
+	*reply = s.vm.DynamicFeeConfig
+	return nil
+}
+
+// GetFeeStateReply is the response from GetFeeState
+type GetFeeStateReply struct {
+	gas.State
+	Price gas.Price `json:"price"`
+	Time  time.Time `json:"timestamp"`
+}
+
+// GetFeeState returns the dynamic fee state as of the last accepted block.
+func (s *Service) GetFeeState(_ *http.Request, _ *struct{}, reply *GetFeeStateReply) error {
+	s.vm.log.Debug("API called",
+		zap.String("service", "xvm"),
+		zap.String("method", "getFeeState"),
+	)
+
+	s.vm.lock.Lock()
+	defer s.vm.lock.Unlock()
+
+	if s.vm.chainManager == nil {
+		return errNotLinearized
+	}
+
+	reply.State = s.vm.state.GetFeeState()
+	reply.Price = gas.CalculatePrice(
+		s.vm.DynamicFeeConfig.MinPrice,
+		reply.State.Excess,
+		s.vm.DynamicFeeConfig.ExcessConversionConstant,
+	)
+	reply.Time = s.vm.state.GetTimestamp()
+	return nil
+}
+
 // IssueTx attempts to issue a transaction into consensus
 func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, reply *api.JSONTxID) error {
 	s.vm.log.Debug("API called",
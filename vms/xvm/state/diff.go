@@ -10,6 +10,7 @@ import (
 
 	"github.com/luxfi/database"
 	"github.com/luxfi/ids"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/components/lux"
 	"github.com/luxfi/node/vms/xvm/block"
 	"github.com/luxfi/node/vms/xvm/txs"
@@ -40,6 +41,7 @@ type diff struct {
 
 	lastAccepted ids.ID
 	timestamp    time.Time
+	feeState     gas.State
 }
 
 func NewDiff(
@@ -59,6 +61,7 @@ func NewDiff(
 		addedBlocks:   make(map[ids.ID]block.Block),
 		lastAccepted:  parentState.GetLastAccepted(),
 		timestamp:     parentState.GetTimestamp(),
+		feeState:      parentState.GetFeeState(),
 	}, nil
 }
 
@@ -161,6 +164,14 @@ func (d *diff) SetTimestamp(t time.Time) {
 	d.timestamp = t
 }
 
+func (d *diff) GetFeeState() gas.State {
+	return d.feeState
+}
+
+func (d *diff) SetFeeState(feeState gas.State) {
+	d.feeState = feeState
+}
+
 func (d *diff) Apply(state Chain) {
 	for utxoID, utxo := range d.modifiedUTXOs {
 		if utxo != nil {
@@ -180,4 +191,5 @@ func (d *diff) Apply(state Chain) {
 
 	state.SetLastAccepted(d.lastAccepted)
 	state.SetTimestamp(d.timestamp)
+	state.SetFeeState(d.feeState)
 }
@@ -15,6 +15,7 @@ import (
 
 	database "github.com/luxfi/database"
 	ids "github.com/luxfi/ids"
+	gas "github.com/luxfi/node/vms/components/gas"
 	lux "github.com/luxfi/node/vms/components/lux"
 	block "github.com/luxfi/node/vms/xvm/block"
 	txs "github.com/luxfi/node/vms/xvm/txs"
@@ -80,6 +81,20 @@ func (mr *MockChainMockRecorder) AddUTXO(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUTXO", reflect.TypeOf((*MockChain)(nil).AddUTXO), arg0)
 }
 
+// BeginSync mocks base method.
+func (m *MockState) BeginSync(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginSync", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BeginSync indicates an expected call of BeginSync.
+func (mr *MockStateMockRecorder) BeginSync(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginSync", reflect.TypeOf((*MockState)(nil).BeginSync), arg0)
+}
+
 // DeleteUTXO mocks base method.
 func (m *MockChain) DeleteUTXO(arg0 ids.ID) {
 	m.ctrl.T.Helper()
@@ -92,6 +107,20 @@ func (mr *MockChainMockRecorder) DeleteUTXO(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockChain)(nil).DeleteUTXO), arg0)
 }
 
+// FinishSync mocks base method.
+func (m *MockState) FinishSync(arg0 block.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishSync", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishSync indicates an expected call of FinishSync.
+func (mr *MockStateMockRecorder) FinishSync(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishSync", reflect.TypeOf((*MockState)(nil).FinishSync), arg0)
+}
+
 // GetBlock mocks base method.
 func (m *MockChain) GetBlock(arg0 ids.ID) (block.Block, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +151,20 @@ func (mr *MockChainMockRecorder) GetBlockIDAtHeight(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*MockChain)(nil).GetBlockIDAtHeight), arg0)
 }
 
+// GetFeeState mocks base method.
+func (m *MockChain) GetFeeState() gas.State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeState")
+	ret0, _ := ret[0].(gas.State)
+	return ret0
+}
+
+// GetFeeState indicates an expected call of GetFeeState.
+func (mr *MockChainMockRecorder) GetFeeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeState", reflect.TypeOf((*MockChain)(nil).GetFeeState))
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockChain) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -136,6 +179,66 @@ func (mr *MockChainMockRecorder) GetLastAccepted() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAccepted", reflect.TypeOf((*MockChain)(nil).GetLastAccepted))
 }
 
+// GetLastSyncSnapshot mocks base method.
+func (m *MockState) GetLastSyncSnapshot() (*SyncSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastSyncSnapshot")
+	ret0, _ := ret[0].(*SyncSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastSyncSnapshot indicates an expected call of GetLastSyncSnapshot.
+func (mr *MockStateMockRecorder) GetLastSyncSnapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastSyncSnapshot", reflect.TypeOf((*MockState)(nil).GetLastSyncSnapshot))
+}
+
+// GetOngoingSync mocks base method.
+func (m *MockState) GetOngoingSync() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOngoingSync")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOngoingSync indicates an expected call of GetOngoingSync.
+func (mr *MockStateMockRecorder) GetOngoingSync() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOngoingSync", reflect.TypeOf((*MockState)(nil).GetOngoingSync))
+}
+
+// GetSyncChunk mocks base method.
+func (m *MockState) GetSyncChunk(arg0 uint64, arg1 uint32) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncChunk", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncChunk indicates an expected call of GetSyncChunk.
+func (mr *MockStateMockRecorder) GetSyncChunk(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncChunk", reflect.TypeOf((*MockState)(nil).GetSyncChunk), arg0, arg1)
+}
+
+// GetSyncSnapshot mocks base method.
+func (m *MockState) GetSyncSnapshot(arg0 uint64) (*SyncSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncSnapshot", arg0)
+	ret0, _ := ret[0].(*SyncSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncSnapshot indicates an expected call of GetSyncSnapshot.
+func (mr *MockStateMockRecorder) GetSyncSnapshot(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncSnapshot", reflect.TypeOf((*MockState)(nil).GetSyncSnapshot), arg0)
+}
+
 // GetTimestamp mocks base method.
 func (m *MockChain) GetTimestamp() time.Time {
 	m.ctrl.T.Helper()
@@ -180,6 +283,18 @@ func (mr *MockChainMockRecorder) GetUTXO(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*MockChain)(nil).GetUTXO), arg0)
 }
 
+// SetFeeState mocks base method.
+func (m *MockChain) SetFeeState(arg0 gas.State) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeState", arg0)
+}
+
+// SetFeeState indicates an expected call of SetFeeState.
+func (mr *MockChainMockRecorder) SetFeeState(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeState", reflect.TypeOf((*MockChain)(nil).SetFeeState), arg0)
+}
+
 // SetLastAccepted mocks base method.
 func (m *MockChain) SetLastAccepted(arg0 ids.ID) {
 	m.ctrl.T.Helper()
@@ -375,6 +490,20 @@ func (mr *MockStateMockRecorder) GetBlockIDAtHeight(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*MockState)(nil).GetBlockIDAtHeight), arg0)
 }
 
+// GetFeeState mocks base method.
+func (m *MockState) GetFeeState() gas.State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeState")
+	ret0, _ := ret[0].(gas.State)
+	return ret0
+}
+
+// GetFeeState indicates an expected call of GetFeeState.
+func (mr *MockStateMockRecorder) GetFeeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeState", reflect.TypeOf((*MockState)(nil).GetFeeState))
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockState) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -476,6 +605,18 @@ func (mr *MockStateMockRecorder) SetInitialized() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInitialized", reflect.TypeOf((*MockState)(nil).SetInitialized))
 }
 
+// SetFeeState mocks base method.
+func (m *MockState) SetFeeState(arg0 gas.State) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeState", arg0)
+}
+
+// SetFeeState indicates an expected call of SetFeeState.
+func (mr *MockStateMockRecorder) SetFeeState(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeState", reflect.TypeOf((*MockState)(nil).SetFeeState), arg0)
+}
+
 // SetLastAccepted mocks base method.
 func (m *MockState) SetLastAccepted(arg0 ids.ID) {
 	m.ctrl.T.Helper()
@@ -628,6 +769,20 @@ func (mr *MockDiffMockRecorder) GetBlockIDAtHeight(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*MockDiff)(nil).GetBlockIDAtHeight), arg0)
 }
 
+// GetFeeState mocks base method.
+func (m *MockDiff) GetFeeState() gas.State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeState")
+	ret0, _ := ret[0].(gas.State)
+	return ret0
+}
+
+// GetFeeState indicates an expected call of GetFeeState.
+func (mr *MockDiffMockRecorder) GetFeeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeState", reflect.TypeOf((*MockDiff)(nil).GetFeeState))
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockDiff) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -686,6 +841,18 @@ func (mr *MockDiffMockRecorder) GetUTXO(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*MockDiff)(nil).GetUTXO), arg0)
 }
 
+// SetFeeState mocks base method.
+func (m *MockDiff) SetFeeState(arg0 gas.State) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeState", arg0)
+}
+
+// SetFeeState indicates an expected call of SetFeeState.
+func (mr *MockDiffMockRecorder) SetFeeState(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeState", reflect.TypeOf((*MockDiff)(nil).SetFeeState), arg0)
+}
+
 // SetLastAccepted mocks base method.
 func (m *MockDiff) SetLastAccepted(arg0 ids.ID) {
 	m.ctrl.T.Helper()
@@ -709,3 +876,17 @@ func (mr *MockDiffMockRecorder) SetTimestamp(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockDiff)(nil).SetTimestamp), arg0)
 }
+
+// WriteSyncChunk mocks base method.
+func (m *MockState) WriteSyncChunk(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteSyncChunk", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteSyncChunk indicates an expected call of WriteSyncChunk.
+func (mr *MockStateMockRecorder) WriteSyncChunk(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteSyncChunk", reflect.TypeOf((*MockState)(nil).WriteSyncChunk), arg0)
+}
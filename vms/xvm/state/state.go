@@ -16,6 +16,7 @@ import (
 	"github.com/luxfi/ids"
 	"github.com/luxfi/node/cache"
 	"github.com/luxfi/node/cache/metercacher"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/components/lux"
 	"github.com/luxfi/node/vms/xvm/block"
 	"github.com/luxfi/node/vms/xvm/txs"
@@ -28,15 +29,19 @@ const (
 )
 
 var (
-	utxoPrefix      = []byte("utxo")
-	txPrefix        = []byte("tx")
-	blockIDPrefix   = []byte("blockID")
-	blockPrefix     = []byte("block")
-	singletonPrefix = []byte("singleton")
-
-	isInitializedKey = []byte{0x00}
-	timestampKey     = []byte{0x01}
-	lastAcceptedKey  = []byte{0x02}
+	utxoPrefix         = []byte("utxo")
+	txPrefix           = []byte("tx")
+	blockIDPrefix      = []byte("blockID")
+	blockPrefix        = []byte("block")
+	singletonPrefix    = []byte("singleton")
+	syncSnapshotPrefix = []byte("syncSnapshot")
+
+	isInitializedKey    = []byte{0x00}
+	timestampKey        = []byte{0x01}
+	lastAcceptedKey     = []byte{0x02}
+	feeStateKey         = []byte{0x03}
+	lastSyncSnapshotKey = []byte{0x04}
+	ongoingSyncKey      = []byte{0x05}
 
 	_ State = (*state)(nil)
 )
@@ -49,6 +54,7 @@ type ReadOnlyChain interface {
 	GetBlock(blkID ids.ID) (block.Block, error)
 	GetLastAccepted() ids.ID
 	GetTimestamp() time.Time
+	GetFeeState() gas.State
 }
 
 type Chain interface {
@@ -60,6 +66,7 @@ type Chain interface {
 	AddBlock(block block.Block)
 	SetLastAccepted(blkID ids.ID)
 	SetTimestamp(t time.Time)
+	SetFeeState(f gas.State)
 }
 
 // State persistently maintains a set of UTXOs, transaction, statuses, and
@@ -92,9 +99,47 @@ type State interface {
 	// Checksums returns the current TxChecksum and UTXOChecksum.
 	Checksums() (txChecksum ids.ID, utxoChecksum ids.ID)
 
+	// GetSyncSnapshot returns the snapshot of the state that was taken at
+	// [height]. If no snapshot was taken at [height], [database.ErrNotFound]
+	// is returned.
+	GetSyncSnapshot(height uint64) (*SyncSnapshot, error)
+
+	// GetLastSyncSnapshot returns the most recent snapshot of the state. If no
+	// snapshot has been taken, [database.ErrNotFound] is returned.
+	GetLastSyncSnapshot() (*SyncSnapshot, error)
+
+	// GetSyncChunk returns the serialized chunk [index] of the snapshot taken
+	// at [height].
+	GetSyncChunk(height uint64, index uint32) ([]byte, error)
+
+	// GetOngoingSync returns the summary passed to BeginSync if the state is
+	// being synced. Otherwise, [database.ErrNotFound] is returned.
+	GetOngoingSync() ([]byte, error)
+
+	// BeginSync marks [summary] as being synced and removes the UTXO set.
+	// Until FinishSync returns, the state must only be modified with
+	// WriteSyncChunk.
+	BeginSync(summary []byte) error
+
+	// WriteSyncChunk writes the UTXOs and assets of the serialized [chunk] to
+	// disk and sets the fee state to the fee state of [chunk].
+	WriteSyncChunk(chunk []byte) error
+
+	// FinishSync verifies that every asset referenced by the synced UTXO set
+	// was synced. If so, [blk] is marked as the last accepted block.
+	FinishSync(blk block.Block) error
+
 	Close() error
 }
 
+// SyncConfig configures the snapshots of the state that are served to syncing
+// nodes.
+type SyncConfig struct {
+	// SummaryFrequency is the number of blocks between snapshots. If 0, no
+	// snapshots are taken.
+	SummaryFrequency uint64
+}
+
 /*
  * VMDB
  * |- utxos
@@ -105,10 +150,16 @@ type State interface {
  * | '-- height -> blockID
  * |-. blocks
  * | '-- blockID -> block bytes
+ * |-. syncSnapshots
+ * | |-- height -> snapshot
+ * | '-- height + index -> chunk
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- timestampKey -> timestamp
- *   '-- lastAcceptedKey -> lastAccepted
+ *   |-- feeStateKey -> feeState
+ *   |-- lastAcceptedKey -> lastAccepted
+ *   |-- lastSyncSnapshotKey -> height
+ *   '-- ongoingSyncKey -> summary
  */
 type state struct {
 	parser block.Parser
@@ -133,17 +184,29 @@ type state struct {
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	timestamp, persistedTimestamp       time.Time
+	feeState, persistedFeeState         gas.State
 	singletonDB                         database.Database
 
 	trackChecksum bool
 	txChecksum    ids.ID
+
+	// [syncSnapshotBlock] is set when a block at a multiple of
+	// [syncConfig.SummaryFrequency] is added, so that a snapshot of the state
+	// is taken once it is committed.
+	syncConfig        SyncConfig
+	syncSnapshotBlock block.Block
+	syncSnapshotDB    database.Database
+	// syncChunkSize is [SyncChunkSize] outside of tests.
+	syncChunkSize int
 }
 
 func New(
 	db *versiondb.Database,
 	parser block.Parser,
 	metrics prometheus.Registerer,
+	cachePolicy cache.Policy,
 	trackChecksums bool,
+	syncConfig SyncConfig,
 ) (State, error) {
 	utxoDB := prefixdb.New(utxoPrefix, db)
 	txDB := prefixdb.New(txPrefix, db)
@@ -151,34 +214,46 @@ func New(
 	blockDB := prefixdb.New(blockPrefix, db)
 	singletonDB := prefixdb.New(singletonPrefix, db)
 
-	txCache, err := metercacher.New[ids.ID, *txs.Tx](
+	txCacher, err := cache.NewCacher[ids.ID, *txs.Tx](cachePolicy, txCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	txCache, err := metercacher.New(
 		"tx_cache",
 		metrics,
-		&cache.LRU[ids.ID, *txs.Tx]{Size: txCacheSize},
+		txCacher,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	blockIDCache, err := metercacher.New[uint64, ids.ID](
+	blockIDCacher, err := cache.NewCacher[uint64, ids.ID](cachePolicy, blockIDCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	blockIDCache, err := metercacher.New(
 		"block_id_cache",
 		metrics,
-		&cache.LRU[uint64, ids.ID]{Size: blockIDCacheSize},
+		blockIDCacher,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	blockCache, err := metercacher.New[ids.ID, block.Block](
+	blockCacher, err := cache.NewCacher[ids.ID, block.Block](cachePolicy, blockCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	blockCache, err := metercacher.New(
 		"block_cache",
 		metrics,
-		&cache.LRU[ids.ID, block.Block]{Size: blockCacheSize},
+		blockCacher,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	utxoState, err := lux.NewMeteredUTXOState(utxoDB, parser.Codec(), metrics, trackChecksums)
+	utxoState, err := lux.NewMeteredUTXOState(utxoDB, parser.Codec(), metrics, cachePolicy, trackChecksums)
 	if err != nil {
 		return nil, err
 	}
@@ -206,6 +281,10 @@ func New(
 		singletonDB: singletonDB,
 
 		trackChecksum: trackChecksums,
+
+		syncConfig:     syncConfig,
+		syncSnapshotDB: prefixdb.New(syncSnapshotPrefix, db),
+		syncChunkSize:  SyncChunkSize,
 	}
 	return s, s.initTxChecksum()
 }
@@ -327,8 +406,13 @@ func (s *state) GetBlock(blkID ids.ID) (block.Block, error) {
 
 func (s *state) AddBlock(block block.Block) {
 	blkID := block.ID()
-	s.addedBlockIDs[block.Height()] = blkID
+	height := block.Height()
+	s.addedBlockIDs[height] = blkID
 	s.addedBlocks[blkID] = block
+
+	if freq := s.syncConfig.SummaryFrequency; freq != 0 && height != 0 && height%freq == 0 {
+		s.syncSnapshotBlock = block
+	}
 }
 
 func (s *state) InitializeChainState(stopVertexID ids.ID, genesisTimestamp time.Time) error {
@@ -342,7 +426,12 @@ func (s *state) InitializeChainState(stopVertexID ids.ID, genesisTimestamp time.
 	s.lastAccepted = lastAccepted
 	s.persistedLastAccepted = lastAccepted
 	s.timestamp, err = database.GetTimestamp(s.singletonDB, timestampKey)
+	if err != nil {
+		return err
+	}
 	s.persistedTimestamp = s.timestamp
+	s.feeState, err = s.getFeeState()
+	s.persistedFeeState = s.feeState
 	return err
 }
 
@@ -388,6 +477,14 @@ func (s *state) SetTimestamp(t time.Time) {
 	s.timestamp = t
 }
 
+func (s *state) GetFeeState() gas.State {
+	return s.feeState
+}
+
+func (s *state) SetFeeState(feeState gas.State) {
+	s.feeState = feeState
+}
+
 func (s *state) Commit() error {
 	defer s.Abort()
 	batch, err := s.CommitBatch()
@@ -405,6 +502,12 @@ func (s *state) CommitBatch() (database.Batch, error) {
 	if err := s.write(); err != nil {
 		return nil, err
 	}
+	if s.syncSnapshotBlock != nil {
+		if err := s.writeSyncSnapshot(s.syncSnapshotBlock); err != nil {
+			return nil, err
+		}
+		s.syncSnapshotBlock = nil
+	}
 	return s.db.CommitBatch()
 }
 
@@ -415,6 +518,7 @@ func (s *state) Close() error {
 		s.blockIDDB.Close(),
 		s.blockDB.Close(),
 		s.singletonDB.Close(),
+		s.syncSnapshotDB.Close(),
 		s.db.Close(),
 	)
 }
@@ -495,6 +599,12 @@ func (s *state) writeMetadata() error {
 		}
 		s.persistedTimestamp = s.timestamp
 	}
+	if s.persistedFeeState != s.feeState {
+		if err := s.putFeeState(s.feeState); err != nil {
+			return fmt.Errorf("failed to write fee state: %w", err)
+		}
+		s.persistedFeeState = s.feeState
+	}
 	if s.persistedLastAccepted != s.lastAccepted {
 		lastAcceptedArray := ([32]byte)(s.lastAccepted)
 		if err := database.PutID(s.singletonDB, lastAcceptedKey, lastAcceptedArray); err != nil {
@@ -505,6 +615,32 @@ func (s *state) writeMetadata() error {
 	return nil
 }
 
+// getFeeState returns the persisted fee state. Chains that were initialized
+// before the fee state was tracked start with the zero fee state.
+func (s *state) getFeeState() (gas.State, error) {
+	feeStateBytes, err := s.singletonDB.Get(feeStateKey)
+	if err == database.ErrNotFound {
+		return gas.State{}, nil
+	}
+	if err != nil {
+		return gas.State{}, err
+	}
+
+	var feeState gas.State
+	if _, err := s.parser.Codec().Unmarshal(feeStateBytes, &feeState); err != nil {
+		return gas.State{}, fmt.Errorf("failed to unmarshal fee state: %w", err)
+	}
+	return feeState, nil
+}
+
+func (s *state) putFeeState(feeState gas.State) error {
+	feeStateBytes, err := s.parser.Codec().Marshal(block.CodecVersion, feeState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fee state: %w", err)
+	}
+	return s.singletonDB.Put(feeStateKey, feeStateBytes)
+}
+
 func (s *state) Checksums() (ids.ID, ids.ID) {
 	return s.txChecksum, s.utxoState.Checksum()
 }
@@ -0,0 +1,363 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/math/set"
+	"github.com/luxfi/node/utils"
+	"github.com/luxfi/node/utils/hashing"
+	"github.com/luxfi/node/utils/units"
+	"github.com/luxfi/node/utils/wrappers"
+	"github.com/luxfi/node/vms/components/gas"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/xvm/block"
+	"github.com/luxfi/node/vms/xvm/txs"
+)
+
+const (
+	// numRetainedSyncSnapshots is the number of the most recent snapshots that
+	// are kept on disk to serve to syncing nodes.
+	numRetainedSyncSnapshots = 2
+
+	// syncElementOverhead is the number of bytes used to serialize an element
+	// of a [SyncChunk] in addition to its contents.
+	syncElementOverhead = wrappers.IntLen
+
+	// SyncChunkSize is the targeted number of bytes of state in each chunk of
+	// a snapshot. The chunk boundaries are committed to by the state summary,
+	// so every node must use the same size to produce the same summary.
+	SyncChunkSize = 256 * units.KiB
+)
+
+var (
+	errUnsortedSyncedUTXOs    = errors.New("synced UTXOs must be strictly increasing")
+	errUnexpectedSyncedAsset  = errors.New("synced asset isn't a create asset tx")
+	errMissingSyncedAsset     = errors.New("synced UTXO references an asset that wasn't synced")
+	errInvalidSyncSnapshotKey = errors.New("invalid sync snapshot key")
+)
+
+// SyncChunk is a contiguous part of a [SyncSnapshot].
+//
+// The UTXOs of a snapshot are split across its chunks in increasing UTXO ID
+// order. The create asset txs of the assets referenced by the UTXOs follow
+// the UTXOs in increasing asset ID order. Every chunk includes the fee state of
+// the snapshot, so that chunks can be written in any order.
+type SyncChunk struct {
+	FeeState gas.State `serialize:"true"`
+	UTXOs    [][]byte  `serialize:"true"`
+	Assets   [][]byte  `serialize:"true"`
+}
+
+// SyncSnapshot describes the state as of the accepted block [BlockID] at
+// [Height]. The state is split into at least one chunk and [Chunks] contains
+// the hash of each serialized chunk, in order.
+type SyncSnapshot struct {
+	Height  uint64   `serialize:"true"`
+	BlockID ids.ID   `serialize:"true"`
+	Chunks  []ids.ID `serialize:"true"`
+}
+
+func syncSnapshotKey(height uint64) []byte {
+	return database.PackUInt64(height)
+}
+
+func syncChunkKey(height uint64, index uint32) []byte {
+	key := make([]byte, database.Uint64Size+wrappers.IntLen)
+	copy(key, database.PackUInt64(height))
+	copy(key[database.Uint64Size:], database.PackUInt32(index))
+	return key
+}
+
+func (s *state) GetSyncSnapshot(height uint64) (*SyncSnapshot, error) {
+	snapshotBytes, err := s.syncSnapshotDB.Get(syncSnapshotKey(height))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &SyncSnapshot{}
+	if _, err := s.parser.Codec().Unmarshal(snapshotBytes, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sync snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+func (s *state) GetLastSyncSnapshot() (*SyncSnapshot, error) {
+	height, err := database.GetUInt64(s.singletonDB, lastSyncSnapshotKey)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSyncSnapshot(height)
+}
+
+func (s *state) GetSyncChunk(height uint64, index uint32) ([]byte, error) {
+	return s.syncSnapshotDB.Get(syncChunkKey(height, index))
+}
+
+func (s *state) GetOngoingSync() ([]byte, error) {
+	return s.singletonDB.Get(ongoingSyncKey)
+}
+
+func (s *state) BeginSync(summary []byte) error {
+	if err := s.singletonDB.Put(ongoingSyncKey, summary); err != nil {
+		return err
+	}
+
+	// Deleting the UTXOs through [utxoState] keeps its caches, address index
+	// and checksum consistent.
+	utxoIt := lux.NewUTXOIterator(s.utxoDB, nil)
+	var utxoIDs []ids.ID
+	for utxoIt.Next() {
+		utxoID, err := ids.ToID(utxoIt.Key())
+		if err != nil {
+			utxoIt.Release()
+			return err
+		}
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	err := utxoIt.Error()
+	utxoIt.Release()
+	if err != nil {
+		return err
+	}
+	for _, utxoID := range utxoIDs {
+		if err := s.utxoState.DeleteUTXO(utxoID); err != nil {
+			return err
+		}
+	}
+
+	// Transactions and blocks are content addressed, so they are kept.
+	return s.db.Commit()
+}
+
+func (s *state) WriteSyncChunk(chunkBytes []byte) error {
+	chunk := &SyncChunk{}
+	if _, err := s.parser.Codec().Unmarshal(chunkBytes, chunk); err != nil {
+		return fmt.Errorf("failed to unmarshal sync chunk: %w", err)
+	}
+
+	var lastUTXOID ids.ID
+	for i, utxoBytes := range chunk.UTXOs {
+		utxo := &lux.UTXO{}
+		if _, err := s.parser.Codec().Unmarshal(utxoBytes, utxo); err != nil {
+			return fmt.Errorf("failed to unmarshal synced UTXO: %w", err)
+		}
+		utxoID := utxo.InputID()
+		if i > 0 && bytes.Compare(lastUTXOID[:], utxoID[:]) >= 0 {
+			return fmt.Errorf("%w: %s after %s", errUnsortedSyncedUTXOs, utxoID, lastUTXOID)
+		}
+		lastUTXOID = utxoID
+
+		if err := s.utxoState.PutUTXO(utxo); err != nil {
+			return err
+		}
+	}
+
+	for _, txBytes := range chunk.Assets {
+		tx, err := s.parser.ParseGenesisTx(txBytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse synced asset: %w", err)
+		}
+		if _, ok := tx.Unsigned.(*txs.CreateAssetTx); !ok {
+			return fmt.Errorf("%w: %s", errUnexpectedSyncedAsset, tx.ID())
+		}
+
+		txID := tx.ID()
+		has, err := s.txDB.Has(txID[:])
+		if err != nil {
+			return err
+		}
+		if has {
+			continue
+		}
+		if err := s.txDB.Put(txID[:], tx.Bytes()); err != nil {
+			return err
+		}
+		s.txCache.Put(txID, tx)
+		s.updateTxChecksum(txID)
+	}
+
+	// The fee state is persisted by FinishSync.
+	s.SetFeeState(chunk.FeeState)
+	return s.db.Commit()
+}
+
+func (s *state) FinishSync(blk block.Block) error {
+	assetIDs, err := s.utxoAssetIDs()
+	if err != nil {
+		return err
+	}
+	for assetID := range assetIDs {
+		tx, err := s.GetTx(assetID)
+		if err == database.ErrNotFound {
+			return fmt.Errorf("%w: %s", errMissingSyncedAsset, assetID)
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := tx.Unsigned.(*txs.CreateAssetTx); !ok {
+			return fmt.Errorf("%w: %s", errUnexpectedSyncedAsset, assetID)
+		}
+	}
+
+	s.AddBlock(blk)
+	s.SetLastAccepted(blk.ID())
+	s.SetTimestamp(blk.Timestamp())
+	if err := s.singletonDB.Delete(ongoingSyncKey); err != nil {
+		return err
+	}
+	return s.Commit()
+}
+
+// utxoAssetIDs returns the IDs of the assets referenced by the UTXOs on disk.
+func (s *state) utxoAssetIDs() (set.Set[ids.ID], error) {
+	var assetIDs set.Set[ids.ID]
+	utxoIt := lux.NewUTXOIterator(s.utxoDB, nil)
+	defer utxoIt.Release()
+	for utxoIt.Next() {
+		utxo := &lux.UTXO{}
+		if _, err := s.parser.Codec().Unmarshal(utxoIt.Value(), utxo); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal UTXO: %w", err)
+		}
+		assetIDs.Add(utxo.AssetID())
+	}
+	return assetIDs, utxoIt.Error()
+}
+
+// writeSyncSnapshot writes a snapshot of the state as of [blk] and removes the
+// snapshots that are no longer retained.
+//
+// Invariant: All the changes to the state must have been written to [db].
+func (s *state) writeSyncSnapshot(blk block.Block) error {
+	snapshot := &SyncSnapshot{
+		Height:  blk.Height(),
+		BlockID: blk.ID(),
+	}
+
+	var (
+		chunk = SyncChunk{
+			FeeState: s.feeState,
+		}
+		chunkSize int
+	)
+	writeChunk := func() error {
+		chunkBytes, err := s.parser.Codec().Marshal(block.CodecVersion, &chunk)
+		if err != nil {
+			return fmt.Errorf("failed to marshal sync chunk: %w", err)
+		}
+		index := uint32(len(snapshot.Chunks))
+		if err := s.syncSnapshotDB.Put(syncChunkKey(snapshot.Height, index), chunkBytes); err != nil {
+			return err
+		}
+		snapshot.Chunks = append(snapshot.Chunks, hashing.ComputeHash256Array(chunkBytes))
+
+		chunk = SyncChunk{
+			FeeState: s.feeState,
+		}
+		chunkSize = 0
+		return nil
+	}
+	addToChunk := func(elements *[][]byte, element []byte) error {
+		*elements = append(*elements, bytes.Clone(element))
+		chunkSize += syncElementOverhead + len(element)
+		if chunkSize < s.syncChunkSize {
+			return nil
+		}
+		return writeChunk()
+	}
+
+	assetIDs, err := s.utxoAssetIDs()
+	if err != nil {
+		return err
+	}
+
+	utxoIt := lux.NewUTXOIterator(s.utxoDB, nil)
+	for utxoIt.Next() {
+		if err := addToChunk(&chunk.UTXOs, utxoIt.Value()); err != nil {
+			utxoIt.Release()
+			return err
+		}
+	}
+	err = utxoIt.Error()
+	utxoIt.Release()
+	if err != nil {
+		return err
+	}
+
+	sortedAssetIDs := assetIDs.List()
+	utils.Sort(sortedAssetIDs)
+	for _, assetID := range sortedAssetIDs {
+		txBytes, err := s.txDB.Get(assetID[:])
+		if err != nil {
+			return fmt.Errorf("failed to get asset %s: %w", assetID, err)
+		}
+		if err := addToChunk(&chunk.Assets, txBytes); err != nil {
+			return err
+		}
+	}
+	// The last chunk is written even if it is empty, so that the fee state is
+	// synced along with an empty UTXO set.
+	if chunkSize > 0 || len(snapshot.Chunks) == 0 {
+		if err := writeChunk(); err != nil {
+			return err
+		}
+	}
+
+	snapshotBytes, err := s.parser.Codec().Marshal(block.CodecVersion, snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync snapshot: %w", err)
+	}
+	if err := s.syncSnapshotDB.Put(syncSnapshotKey(snapshot.Height), snapshotBytes); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(s.singletonDB, lastSyncSnapshotKey, snapshot.Height); err != nil {
+		return err
+	}
+
+	retainedHeights := numRetainedSyncSnapshots * s.syncConfig.SummaryFrequency
+	if snapshot.Height < retainedHeights {
+		return nil
+	}
+	return s.deleteSyncSnapshotsBefore(snapshot.Height - retainedHeights + 1)
+}
+
+// deleteSyncSnapshotsBefore removes the snapshots that were taken before
+// [height].
+func (s *state) deleteSyncSnapshotsBefore(height uint64) error {
+	it := s.syncSnapshotDB.NewIterator()
+	var keys [][]byte
+	for it.Next() {
+		key := it.Key()
+		if len(key) < database.Uint64Size {
+			it.Release()
+			return fmt.Errorf("%w: %x", errInvalidSyncSnapshotKey, key)
+		}
+		snapshotHeight, err := database.ParseUInt64(key[:database.Uint64Size])
+		if err != nil {
+			it.Release()
+			return err
+		}
+		if snapshotHeight >= height {
+			break
+		}
+		keys = append(keys, bytes.Clone(key))
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := s.syncSnapshotDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/database/memdb"
+	"github.com/luxfi/database/versiondb"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/metric"
+	"github.com/luxfi/node/cache"
+	"github.com/luxfi/node/version"
+	"github.com/luxfi/node/vms/components/gas"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/secp256k1fx"
+	"github.com/luxfi/node/vms/xvm/block"
+	"github.com/luxfi/node/vms/xvm/txs"
+)
+
+// newSyncTestState returns an initialized state that takes snapshots with
+// [syncConfig].
+func newSyncTestState(require *require.Assertions, syncConfig SyncConfig) *state {
+	s, err := New(
+		versiondb.New(memdb.New()),
+		parser,
+		metric.NewNoOpMetrics("test").Registry(),
+		cache.LRUPolicy,
+		trackChecksums,
+		syncConfig,
+	)
+	require.NoError(err)
+	require.NoError(s.InitializeChainState(ids.GenerateTestID(), version.DefaultUpgradeTime))
+	return s.(*state)
+}
+
+// acceptTestBlock commits an empty block on top of the last accepted block of
+// [s].
+func acceptTestBlock(require *require.Assertions, s *state, height uint64) block.Block {
+	blk, err := block.NewStandardBlock(
+		s.GetLastAccepted(),
+		height,
+		s.GetTimestamp(),
+		nil,
+		parser.Codec(),
+	)
+	require.NoError(err)
+
+	s.AddBlock(blk)
+	s.SetLastAccepted(blk.ID())
+	require.NoError(s.Commit())
+	return blk
+}
+
+// newTestAsset returns a create asset tx and a UTXO of the created asset.
+func newTestAsset(require *require.Assertions) (*txs.Tx, *lux.UTXO) {
+	createAssetTx := &txs.Tx{Unsigned: &txs.CreateAssetTx{
+		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+			BlockchainID: ids.GenerateTestID(),
+		}},
+		Name:         "asset",
+		Symbol:       "A",
+		Denomination: 0,
+	}}
+	require.NoError(createAssetTx.Initialize(parser.Codec()))
+
+	utxo := &lux.UTXO{
+		UTXOID: lux.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: lux.Asset{
+			ID: createAssetTx.ID(),
+		},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+		},
+	}
+	return createAssetTx, utxo
+}
+
+func marshalTestUTXO(require *require.Assertions, utxo *lux.UTXO) []byte {
+	utxoBytes, err := parser.Codec().Marshal(txs.CodecVersion, utxo)
+	require.NoError(err)
+	return utxoBytes
+}
+
+func TestStateSyncRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	source := newSyncTestState(require, SyncConfig{
+		SummaryFrequency: 1,
+	})
+	source.syncChunkSize = 64
+
+	var utxos []*lux.UTXO
+	for i := 0; i < 3; i++ {
+		createAssetTx, utxo := newTestAsset(require)
+		source.AddTx(createAssetTx)
+		source.AddUTXO(utxo)
+		utxos = append(utxos, utxo)
+	}
+	feeState := gas.State{
+		Capacity: 1_000,
+		Excess:   500,
+	}
+	source.SetFeeState(feeState)
+
+	blk := acceptTestBlock(require, source, 1)
+
+	snapshot, err := source.GetLastSyncSnapshot()
+	require.NoError(err)
+	require.Equal(uint64(1), snapshot.Height)
+	require.Equal(blk.ID(), snapshot.BlockID)
+	require.Greater(len(snapshot.Chunks), 1)
+
+	// Sync a node that was initialized with a different UTXO set.
+	dest := newSyncTestState(require, SyncConfig{})
+	_, staleUTXO := newTestAsset(require)
+	dest.AddUTXO(staleUTXO)
+	require.NoError(dest.Commit())
+
+	summaryBytes := []byte("summary")
+	require.NoError(dest.BeginSync(summaryBytes))
+
+	ongoingSync, err := dest.GetOngoingSync()
+	require.NoError(err)
+	require.Equal(summaryBytes, ongoingSync)
+
+	for index := range snapshot.Chunks {
+		chunk, err := source.GetSyncChunk(snapshot.Height, uint32(index))
+		require.NoError(err)
+		require.NoError(dest.WriteSyncChunk(chunk))
+	}
+	require.NoError(dest.FinishSync(blk))
+
+	_, err = dest.GetOngoingSync()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.Equal(blk.ID(), dest.GetLastAccepted())
+	require.Equal(blk.Timestamp().UnixNano(), dest.GetTimestamp().UnixNano())
+	require.Equal(feeState, dest.GetFeeState())
+
+	blkID, err := dest.GetBlockIDAtHeight(1)
+	require.NoError(err)
+	require.Equal(blk.ID(), blkID)
+
+	for _, utxo := range utxos {
+		gotUTXO, err := dest.GetUTXO(utxo.InputID())
+		require.NoError(err)
+		require.Equal(utxo.InputID(), gotUTXO.InputID())
+
+		gotAsset, err := dest.GetTx(utxo.AssetID())
+		require.NoError(err)
+		require.IsType(&txs.CreateAssetTx{}, gotAsset.Unsigned)
+	}
+	_, err = dest.GetUTXO(staleUTXO.InputID())
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStateSyncEmptyUTXOSet(t *testing.T) {
+	require := require.New(t)
+
+	source := newSyncTestState(require, SyncConfig{
+		SummaryFrequency: 1,
+	})
+	feeState := gas.State{
+		Capacity: 1_000,
+		Excess:   500,
+	}
+	source.SetFeeState(feeState)
+
+	blk := acceptTestBlock(require, source, 1)
+
+	// The fee state must be synced even though there are no UTXOs.
+	snapshot, err := source.GetLastSyncSnapshot()
+	require.NoError(err)
+	require.Len(snapshot.Chunks, 1)
+
+	dest := newSyncTestState(require, SyncConfig{})
+	require.NoError(dest.BeginSync([]byte("summary")))
+	chunk, err := source.GetSyncChunk(snapshot.Height, 0)
+	require.NoError(err)
+	require.NoError(dest.WriteSyncChunk(chunk))
+	require.NoError(dest.FinishSync(blk))
+	require.Equal(feeState, dest.GetFeeState())
+}
+
+func TestStateSyncFinishMissingAsset(t *testing.T) {
+	require := require.New(t)
+
+	s := newSyncTestState(require, SyncConfig{})
+	require.NoError(s.BeginSync([]byte("summary")))
+
+	_, utxo := newTestAsset(require)
+	utxoBytes := marshalTestUTXO(require, utxo)
+	chunkBytes, err := parser.Codec().Marshal(block.CodecVersion, &SyncChunk{
+		UTXOs: [][]byte{
+			utxoBytes,
+		},
+	})
+	require.NoError(err)
+	require.NoError(s.WriteSyncChunk(chunkBytes))
+
+	blk := acceptTestBlock(require, newSyncTestState(require, SyncConfig{}), 1)
+	err = s.FinishSync(blk)
+	require.ErrorIs(err, errMissingSyncedAsset)
+}
+
+func TestStateSyncWriteChunkUnsortedUTXOs(t *testing.T) {
+	require := require.New(t)
+
+	s := newSyncTestState(require, SyncConfig{})
+
+	_, utxo := newTestAsset(require)
+	utxoBytes := marshalTestUTXO(require, utxo)
+	chunkBytes, err := parser.Codec().Marshal(block.CodecVersion, &SyncChunk{
+		UTXOs: [][]byte{
+			utxoBytes,
+			utxoBytes,
+		},
+	})
+	require.NoError(err)
+
+	err = s.WriteSyncChunk(chunkBytes)
+	require.ErrorIs(err, errUnsortedSyncedUTXOs)
+}
+
+func TestStateSyncSnapshotPruning(t *testing.T) {
+	require := require.New(t)
+
+	s := newSyncTestState(require, SyncConfig{
+		SummaryFrequency: 2,
+	})
+
+	for height := uint64(1); height <= 6; height++ {
+		acceptTestBlock(require, s, height)
+	}
+
+	// Only the last [numRetainedSyncSnapshots] snapshots are kept.
+	_, err := s.GetSyncSnapshot(2)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = s.GetSyncChunk(2, 0)
+	require.ErrorIs(err, database.ErrNotFound)
+	for _, height := range []uint64{4, 6} {
+		snapshot, err := s.GetSyncSnapshot(height)
+		require.NoError(err)
+		require.Equal(height, snapshot.Height)
+	}
+
+	lastSnapshot, err := s.GetLastSyncSnapshot()
+	require.NoError(err)
+	require.Equal(uint64(6), lastSnapshot.Height)
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/luxfi/ids"
 
+	"github.com/luxfi/node/cache"
 	"github.com/luxfi/node/version"
 
 	"github.com/luxfi/node/vms/xvm/block"
@@ -26,6 +27,8 @@ import (
 
 	"github.com/luxfi/node/vms/xvm/txs"
 
+	"github.com/luxfi/node/vms/components/gas"
+
 	"github.com/luxfi/node/vms/components/lux"
 
 	"github.com/luxfi/node/vms/secp256k1fx"
@@ -111,7 +114,7 @@ func TestState(t *testing.T) {
 
 	db := memdb.New()
 	vdb := versiondb.New(db)
-	s, err := New(vdb, parser, metric.NewNoOpMetrics("test").Registry(), trackChecksums)
+	s, err := New(vdb, parser, metric.NewNoOpMetrics("test").Registry(), cache.LRUPolicy, trackChecksums, SyncConfig{})
 	require.NoError(err)
 
 	s.AddUTXO(populatedUTXO)
@@ -119,7 +122,7 @@ func TestState(t *testing.T) {
 	s.AddBlock(populatedBlk)
 	require.NoError(s.Commit())
 
-	s, err = New(vdb, parser, metric.NewNoOpMetrics("test").Registry(), trackChecksums)
+	s, err = New(vdb, parser, metric.NewNoOpMetrics("test").Registry(), cache.LRUPolicy, trackChecksums, SyncConfig{})
 	require.NoError(err)
 
 	ChainUTXOTest(t, s)
@@ -132,7 +135,7 @@ func TestDiff(t *testing.T) {
 
 	db := memdb.New()
 	vdb := versiondb.New(db)
-	s, err := New(vdb, parser, metric.NewNoOpMetrics("test").Registry(), trackChecksums)
+	s, err := New(vdb, parser, metric.NewNoOpMetrics("test").Registry(), cache.LRUPolicy, trackChecksums, SyncConfig{})
 	require.NoError(err)
 
 	s.AddUTXO(populatedUTXO)
@@ -292,7 +295,7 @@ func TestInitializeChainState(t *testing.T) {
 
 	db := memdb.New()
 	vdb := versiondb.New(db)
-	s, err := New(vdb, parser, metric.NewNoOpMetrics("test").Registry(), trackChecksums)
+	s, err := New(vdb, parser, metric.NewNoOpMetrics("test").Registry(), cache.LRUPolicy, trackChecksums, SyncConfig{})
 	require.NoError(err)
 
 	stopVertexID := ids.GenerateTestID()
@@ -325,3 +328,48 @@ func TestInitializeChainState(t *testing.T) {
 	require.NoError(err)
 	require.Equal(genesis.ID(), lastAccepted.Parent())
 }
+
+func TestFeeState(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	vdb := versiondb.New(db)
+	s, err := New(vdb, parser, metric.NewNoOpMetrics("test").Registry(), cache.LRUPolicy, trackChecksums, SyncConfig{})
+	require.NoError(err)
+
+	stopVertexID := ids.GenerateTestID()
+	genesisTimestamp := version.DefaultUpgradeTime
+	require.NoError(s.InitializeChainState(stopVertexID, genesisTimestamp))
+	require.Zero(s.GetFeeState())
+
+	expectedFeeState := gas.State{
+		Capacity: 1_000,
+		Excess:   500,
+	}
+	s.SetFeeState(expectedFeeState)
+	require.NoError(s.Commit())
+
+	s, err = New(vdb, parser, metric.NewNoOpMetrics("test").Registry(), cache.LRUPolicy, trackChecksums, SyncConfig{})
+	require.NoError(err)
+	require.NoError(s.InitializeChainState(stopVertexID, genesisTimestamp))
+	require.Equal(expectedFeeState, s.GetFeeState())
+
+	parentID := ids.GenerateTestID()
+	d, err := NewDiff(parentID, &versions{
+		chains: map[ids.ID]Chain{
+			parentID: s,
+		},
+	})
+	require.NoError(err)
+	require.Equal(expectedFeeState, d.GetFeeState())
+
+	newFeeState := gas.State{
+		Capacity: 100,
+		Excess:   1_400,
+	}
+	d.SetFeeState(newFeeState)
+	require.Equal(expectedFeeState, s.GetFeeState())
+
+	d.Apply(s)
+	require.Equal(newFeeState, s.GetFeeState())
+}
@@ -14,6 +14,7 @@ import (
 	time "time"
 
 	ids "github.com/luxfi/ids"
+	gas "github.com/luxfi/node/vms/components/gas"
 	lux "github.com/luxfi/node/vms/components/lux"
 	block "github.com/luxfi/node/vms/xvm/block"
 	txs "github.com/luxfi/node/vms/xvm/txs"
@@ -122,6 +123,20 @@ func (mr *ChainMockRecorder) GetBlockIDAtHeight(height any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*Chain)(nil).GetBlockIDAtHeight), height)
 }
 
+// GetFeeState mocks base method.
+func (m *Chain) GetFeeState() gas.State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeState")
+	ret0, _ := ret[0].(gas.State)
+	return ret0
+}
+
+// GetFeeState indicates an expected call of GetFeeState.
+func (mr *ChainMockRecorder) GetFeeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeState", reflect.TypeOf((*Chain)(nil).GetFeeState))
+}
+
 // GetLastAccepted mocks base method.
 func (m *Chain) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -180,6 +195,18 @@ func (mr *ChainMockRecorder) GetUTXO(utxoID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*Chain)(nil).GetUTXO), utxoID)
 }
 
+// SetFeeState mocks base method.
+func (m *Chain) SetFeeState(f gas.State) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeState", f)
+}
+
+// SetFeeState indicates an expected call of SetFeeState.
+func (mr *ChainMockRecorder) SetFeeState(f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeState", reflect.TypeOf((*Chain)(nil).SetFeeState), f)
+}
+
 // SetLastAccepted mocks base method.
 func (m *Chain) SetLastAccepted(blkID ids.ID) {
 	m.ctrl.T.Helper()
@@ -14,6 +14,7 @@ import (
 	time "time"
 
 	ids "github.com/luxfi/ids"
+	gas "github.com/luxfi/node/vms/components/gas"
 	lux "github.com/luxfi/node/vms/components/lux"
 	block "github.com/luxfi/node/vms/xvm/block"
 	state "github.com/luxfi/node/vms/xvm/state"
@@ -135,6 +136,20 @@ func (mr *DiffMockRecorder) GetBlockIDAtHeight(height any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*Diff)(nil).GetBlockIDAtHeight), height)
 }
 
+// GetFeeState mocks base method.
+func (m *Diff) GetFeeState() gas.State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeState")
+	ret0, _ := ret[0].(gas.State)
+	return ret0
+}
+
+// GetFeeState indicates an expected call of GetFeeState.
+func (mr *DiffMockRecorder) GetFeeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeState", reflect.TypeOf((*Diff)(nil).GetFeeState))
+}
+
 // GetLastAccepted mocks base method.
 func (m *Diff) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -193,6 +208,18 @@ func (mr *DiffMockRecorder) GetUTXO(utxoID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*Diff)(nil).GetUTXO), utxoID)
 }
 
+// SetFeeState mocks base method.
+func (m *Diff) SetFeeState(f gas.State) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeState", f)
+}
+
+// SetFeeState indicates an expected call of SetFeeState.
+func (mr *DiffMockRecorder) SetFeeState(f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeState", reflect.TypeOf((*Diff)(nil).SetFeeState), f)
+}
+
 // SetLastAccepted mocks base method.
 func (m *Diff) SetLastAccepted(blkID ids.ID) {
 	m.ctrl.T.Helper()
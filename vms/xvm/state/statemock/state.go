@@ -15,8 +15,10 @@ import (
 
 	database "github.com/luxfi/database"
 	ids "github.com/luxfi/ids"
+	gas "github.com/luxfi/node/vms/components/gas"
 	lux "github.com/luxfi/node/vms/components/lux"
 	block "github.com/luxfi/node/vms/xvm/block"
+	state "github.com/luxfi/node/vms/xvm/state"
 	txs "github.com/luxfi/node/vms/xvm/txs"
 	gomock "github.com/luxfi/mock/gomock"
 )
@@ -93,6 +95,20 @@ func (mr *StateMockRecorder) AddUTXO(utxo any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUTXO", reflect.TypeOf((*State)(nil).AddUTXO), utxo)
 }
 
+// BeginSync mocks base method.
+func (m *State) BeginSync(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginSync", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BeginSync indicates an expected call of BeginSync.
+func (mr *StateMockRecorder) BeginSync(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginSync", reflect.TypeOf((*State)(nil).BeginSync), arg0)
+}
+
 // Checksum mocks base method.
 func (m *State) Checksum() ids.ID {
 	m.ctrl.T.Helper()
@@ -162,6 +178,20 @@ func (mr *StateMockRecorder) DeleteUTXO(utxoID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*State)(nil).DeleteUTXO), utxoID)
 }
 
+// FinishSync mocks base method.
+func (m *State) FinishSync(arg0 block.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishSync", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishSync indicates an expected call of FinishSync.
+func (mr *StateMockRecorder) FinishSync(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishSync", reflect.TypeOf((*State)(nil).FinishSync), arg0)
+}
+
 // GetBlock mocks base method.
 func (m *State) GetBlock(blkID ids.ID) (block.Block, error) {
 	m.ctrl.T.Helper()
@@ -192,6 +222,20 @@ func (mr *StateMockRecorder) GetBlockIDAtHeight(height any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*State)(nil).GetBlockIDAtHeight), height)
 }
 
+// GetFeeState mocks base method.
+func (m *State) GetFeeState() gas.State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeState")
+	ret0, _ := ret[0].(gas.State)
+	return ret0
+}
+
+// GetFeeState indicates an expected call of GetFeeState.
+func (mr *StateMockRecorder) GetFeeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeState", reflect.TypeOf((*State)(nil).GetFeeState))
+}
+
 // GetLastAccepted mocks base method.
 func (m *State) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -206,6 +250,66 @@ func (mr *StateMockRecorder) GetLastAccepted() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAccepted", reflect.TypeOf((*State)(nil).GetLastAccepted))
 }
 
+// GetLastSyncSnapshot mocks base method.
+func (m *State) GetLastSyncSnapshot() (*state.SyncSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastSyncSnapshot")
+	ret0, _ := ret[0].(*state.SyncSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastSyncSnapshot indicates an expected call of GetLastSyncSnapshot.
+func (mr *StateMockRecorder) GetLastSyncSnapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastSyncSnapshot", reflect.TypeOf((*State)(nil).GetLastSyncSnapshot))
+}
+
+// GetOngoingSync mocks base method.
+func (m *State) GetOngoingSync() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOngoingSync")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOngoingSync indicates an expected call of GetOngoingSync.
+func (mr *StateMockRecorder) GetOngoingSync() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOngoingSync", reflect.TypeOf((*State)(nil).GetOngoingSync))
+}
+
+// GetSyncChunk mocks base method.
+func (m *State) GetSyncChunk(arg0 uint64, arg1 uint32) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncChunk", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncChunk indicates an expected call of GetSyncChunk.
+func (mr *StateMockRecorder) GetSyncChunk(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncChunk", reflect.TypeOf((*State)(nil).GetSyncChunk), arg0, arg1)
+}
+
+// GetSyncSnapshot mocks base method.
+func (m *State) GetSyncSnapshot(arg0 uint64) (*state.SyncSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncSnapshot", arg0)
+	ret0, _ := ret[0].(*state.SyncSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncSnapshot indicates an expected call of GetSyncSnapshot.
+func (mr *StateMockRecorder) GetSyncSnapshot(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncSnapshot", reflect.TypeOf((*State)(nil).GetSyncSnapshot), arg0)
+}
+
 // GetTimestamp mocks base method.
 func (m *State) GetTimestamp() time.Time {
 	m.ctrl.T.Helper()
@@ -293,6 +397,18 @@ func (mr *StateMockRecorder) SetInitialized() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInitialized", reflect.TypeOf((*State)(nil).SetInitialized))
 }
 
+// SetFeeState mocks base method.
+func (m *State) SetFeeState(f gas.State) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeState", f)
+}
+
+// SetFeeState indicates an expected call of SetFeeState.
+func (mr *StateMockRecorder) SetFeeState(f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeState", reflect.TypeOf((*State)(nil).SetFeeState), f)
+}
+
 // SetLastAccepted mocks base method.
 func (m *State) SetLastAccepted(blkID ids.ID) {
 	m.ctrl.T.Helper()
@@ -331,3 +447,17 @@ func (mr *StateMockRecorder) UTXOIDs(addr, previous, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UTXOIDs", reflect.TypeOf((*State)(nil).UTXOIDs), addr, previous, limit)
 }
+
+// WriteSyncChunk mocks base method.
+func (m *State) WriteSyncChunk(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteSyncChunk", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteSyncChunk indicates an expected call of WriteSyncChunk.
+func (mr *StateMockRecorder) WriteSyncChunk(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteSyncChunk", reflect.TypeOf((*State)(nil).WriteSyncChunk), arg0)
+}
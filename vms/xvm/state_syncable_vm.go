@@ -0,0 +1,203 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package xvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/node/vms/components/statesync"
+	"github.com/luxfi/node/vms/xvm/block"
+	"github.com/luxfi/node/vms/xvm/state"
+
+	linearblock "github.com/luxfi/consensus/engine/chain/block"
+)
+
+const (
+	stateSyncSimultaneousRequests = 8
+	stateSyncRetryFrequency       = time.Second
+)
+
+var (
+	_ linearblock.StateSyncableVM = (*VM)(nil)
+	_ linearblock.StateSummary    = (*stateSummary)(nil)
+
+	errSummaryHeightMismatch = errors.New("summary height doesn't match the block height")
+)
+
+// stateSummary is a [statesync.Summary] that can be accepted by the engine.
+type stateSummary struct {
+	*statesync.Summary
+
+	block block.Block
+	vm    *VM
+}
+
+func (s *stateSummary) Height() uint64 {
+	return s.Summary.Height
+}
+
+// Accept starts syncing the UTXO set described by the summary in the
+// background. Once the sync finishes, [core.StateSyncDone] is returned from
+// WaitForEvent.
+func (s *stateSummary) Accept(context.Context) (linearblock.StateSyncMode, error) {
+	vm := s.vm
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	// If a sync was interrupted, the local UTXO set is incomplete and must be
+	// replaced regardless of its height.
+	_, err := vm.state.GetOngoingSync()
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		lastAccepted, err := vm.chainManager.GetStatelessBlock(vm.chainManager.LastAccepted())
+		if err != nil {
+			return linearblock.StateSyncSkipped, err
+		}
+		// If we have already synced up to or past this state summary, we do
+		// not want to sync to it.
+		if lastAccepted.Height() >= s.Height() {
+			return linearblock.StateSyncSkipped, nil
+		}
+	case err != nil:
+		return linearblock.StateSyncSkipped, err
+	}
+
+	vm.log.Info("starting state sync",
+		zap.Stringer("summaryID", s.ID()),
+		zap.Uint64("height", s.Height()),
+		zap.Int("numChunks", len(s.Chunks)),
+	)
+	if err := vm.state.BeginSync(s.Bytes()); err != nil {
+		return linearblock.StateSyncSkipped, err
+	}
+
+	vm.awaitShutdown.Add(1)
+	go vm.syncState(s.Summary, s.block)
+	return linearblock.StateSyncStatic, nil
+}
+
+func (vm *VM) StateSyncEnabled(context.Context) (bool, error) {
+	return vm.stateSyncEnabled, nil
+}
+
+func (vm *VM) GetOngoingSyncStateSummary(ctx context.Context) (linearblock.StateSummary, error) {
+	summaryBytes, err := vm.state.GetOngoingSync()
+	if err != nil {
+		return nil, err // includes database.ErrNotFound case
+	}
+	return vm.ParseStateSummary(ctx, summaryBytes)
+}
+
+func (vm *VM) GetLastStateSummary(context.Context) (linearblock.StateSummary, error) {
+	snapshot, err := vm.state.GetLastSyncSnapshot()
+	if err != nil {
+		return nil, err // includes database.ErrNotFound case
+	}
+	return vm.newStateSummary(snapshot)
+}
+
+func (vm *VM) ParseStateSummary(_ context.Context, summaryBytes []byte) (linearblock.StateSummary, error) {
+	summary, err := statesync.Parse(summaryBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	blk, err := vm.parser.ParseBlock(summary.BlockBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse summary block: %w", err)
+	}
+	if blk.Height() != summary.Height {
+		return nil, fmt.Errorf("%w: %d != %d", errSummaryHeightMismatch, summary.Height, blk.Height())
+	}
+	return &stateSummary{
+		Summary: summary,
+		block:   blk,
+		vm:      vm,
+	}, nil
+}
+
+func (vm *VM) GetStateSummary(_ context.Context, height uint64) (linearblock.StateSummary, error) {
+	snapshot, err := vm.state.GetSyncSnapshot(height)
+	if err != nil {
+		return nil, err // includes database.ErrNotFound case
+	}
+	return vm.newStateSummary(snapshot)
+}
+
+func (vm *VM) newStateSummary(snapshot *state.SyncSnapshot) (*stateSummary, error) {
+	blk, err := vm.state.GetBlock(snapshot.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get summary block %s: %w", snapshot.BlockID, err)
+	}
+	summary, err := statesync.Build(snapshot.Height, blk.Bytes(), snapshot.Chunks)
+	if err != nil {
+		return nil, err
+	}
+	return &stateSummary{
+		Summary: summary,
+		block:   blk,
+		vm:      vm,
+	}, nil
+}
+
+// syncState fetches the UTXO set described by [summary] from peers and
+// notifies the engine once the state has been replaced.
+func (vm *VM) syncState(summary *statesync.Summary, blk block.Block) {
+	defer vm.awaitShutdown.Done()
+
+	err := statesync.Sync(
+		vm.onShutdownCtx,
+		statesync.SyncerConfig{
+			Log:                  vm.log,
+			Client:               vm.stateSyncClient,
+			Lock:                 &vm.lock,
+			Writer:               vm.state,
+			SimultaneousRequests: stateSyncSimultaneousRequests,
+			RetryFrequency:       stateSyncRetryFrequency,
+		},
+		summary,
+	)
+	if vm.onShutdownCtx.Err() != nil {
+		// The sync will be resumed after the restart.
+		return
+	}
+
+	vm.lock.Lock()
+	if err == nil {
+		err = vm.finishStateSync(blk)
+	}
+	vm.stateSyncErr = err
+	vm.lock.Unlock()
+
+	if err != nil {
+		vm.log.Error("state sync failed",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Error(err),
+		)
+	} else {
+		vm.log.Info("finished state sync",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Uint64("height", summary.Height),
+		)
+	}
+	vm.stateSyncDone <- struct{}{}
+}
+
+// finishStateSync verifies the synced UTXO set and marks [blk] as the last
+// accepted block.
+//
+// Invariant: [vm.lock] is held.
+func (vm *VM) finishStateSync(blk block.Block) error {
+	if err := vm.state.FinishSync(blk); err != nil {
+		return err
+	}
+	vm.chainManager.ResetLastAccepted()
+	return vm.SetPreference(context.Background(), blk.ID())
+}
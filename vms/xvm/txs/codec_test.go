@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/log"
+	"github.com/luxfi/node/codec/codectest"
+	"github.com/luxfi/node/codec/linearcodec"
+	"github.com/luxfi/node/utils/timer/mockable"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/nftfx"
+	"github.com/luxfi/node/vms/propertyfx"
+	"github.com/luxfi/node/vms/secp256k1fx"
+	"github.com/luxfi/node/vms/xvm/fxs"
+)
+
+func TestCodecSchema(t *testing.T) {
+	// The fxs are registered in the same order as on the X-Chain, which
+	// determines the type IDs of their types.
+	parser, err := NewParser(
+		[]fxs.Fx{
+			&secp256k1fx.Fx{},
+			&nftfx.Fx{},
+			&propertyfx.Fx{},
+		},
+	)
+	require.NoError(t, err)
+
+	codectest.RequireCompatibleSchema(
+		t,
+		parser.Codec(),
+		filepath.Join("testdata", "codec_schema.json"),
+		(*Tx)(nil),
+	)
+}
+
+func FuzzCodecGenerated(f *testing.F) {
+	codectest.FuzzGenerated(
+		f,
+		func(c linearcodec.Codec) error {
+			// The types are registered exactly as they are by the X-Chain
+			// parser, including the fxs, so that the fuzzed type IDs match
+			// the production codec.
+			_, err := newCustomParser(
+				c,
+				linearcodec.NewDefault(),
+				make(map[reflect.Type]int),
+				&mockable.Clock{},
+				log.NewNoOpLogger(),
+				[]fxs.Fx{
+					&secp256k1fx.Fx{},
+					&nftfx.Fx{},
+					&propertyfx.Fx{},
+				},
+			)
+			return err
+		},
+		&Tx{
+			Unsigned: &BaseTx{
+				BaseTx: lux.BaseTx{
+					NetworkID:    1,
+					BlockchainID: ids.GenerateTestID(),
+					Outs: []*lux.TransferableOutput{{
+						Asset: lux.Asset{ID: ids.GenerateTestID()},
+						Out: &secp256k1fx.TransferOutput{
+							Amt: 1,
+							OutputOwners: secp256k1fx.OutputOwners{
+								Threshold: 1,
+								Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+							},
+						},
+					}},
+					Ins: []*lux.TransferableInput{{
+						UTXOID: lux.UTXOID{
+							TxID:        ids.GenerateTestID(),
+							OutputIndex: 1,
+						},
+						Asset: lux.Asset{ID: ids.GenerateTestID()},
+						In: &secp256k1fx.TransferInput{
+							Amt: 2,
+							Input: secp256k1fx.Input{
+								SigIndices: []uint32{0},
+							},
+						},
+					}},
+					Memo: []byte{1, 2, 3},
+				},
+			},
+			Creds: []*fxs.FxCredential{{
+				Credential: &secp256k1fx.Credential{},
+			}},
+		},
+		&lux.UTXO{
+			UTXOID: lux.UTXOID{
+				TxID:        ids.GenerateTestID(),
+				OutputIndex: 1,
+			},
+			Asset: lux.Asset{ID: ids.GenerateTestID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+			},
+		},
+	)
+}
@@ -0,0 +1,74 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*CreateAssetTx)(nil)
+	_ codec.Unmarshaler = (*CreateAssetTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*CreateAssetTx) CodecType() any {
+	return (*CreateAssetTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *CreateAssetTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.BaseTx)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += wrappers.StringLen(v.Name)
+	size += wrappers.StringLen(v.Symbol)
+	size += wrappers.ByteLen
+	fieldSize, err = c.Size(&v.States)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *CreateAssetTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.BaseTx, p); err != nil {
+		return err
+	}
+	p.PackStr(v.Name)
+	p.PackStr(v.Symbol)
+	p.PackByte(v.Denomination)
+	if err := c.MarshalInto(&v.States, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *CreateAssetTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.BaseTx); err != nil {
+		return err
+	}
+	v.Name = p.UnpackStr()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Symbol = p.UnpackStr()
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Denomination = p.UnpackByte()
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.States); err != nil {
+		return err
+	}
+	return nil
+}
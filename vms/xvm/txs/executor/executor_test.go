@@ -17,6 +17,7 @@ import (
 
 	"github.com/luxfi/ids"
 
+	"github.com/luxfi/node/cache"
 	"github.com/luxfi/node/utils/constants"
 
 	"github.com/luxfi/crypto/secp256k1"
@@ -58,7 +59,7 @@ func TestBaseTxExecutor(t *testing.T) {
 	db := memdb.New()
 	vdb := versiondb.New(db)
 	registerer := metric.NewNoOpMetrics("test").Registry()
-	state, err := state.New(vdb, parser, registerer, trackChecksums)
+	state, err := state.New(vdb, parser, registerer, cache.LRUPolicy, trackChecksums, state.SyncConfig{})
 	require.NoError(err)
 
 	utxoID := lux.UTXOID{
@@ -165,7 +166,7 @@ func TestCreateAssetTxExecutor(t *testing.T) {
 	db := memdb.New()
 	vdb := versiondb.New(db)
 	registerer := metric.NewNoOpMetrics("test").Registry()
-	state, err := state.New(vdb, parser, registerer, trackChecksums)
+	state, err := state.New(vdb, parser, registerer, cache.LRUPolicy, trackChecksums, state.SyncConfig{})
 	require.NoError(err)
 
 	utxoID := lux.UTXOID{
@@ -310,7 +311,7 @@ func TestOperationTxExecutor(t *testing.T) {
 	db := memdb.New()
 	vdb := versiondb.New(db)
 	registerer := metric.NewNoOpMetrics("test").Registry()
-	state, err := state.New(vdb, parser, registerer, trackChecksums)
+	state, err := state.New(vdb, parser, registerer, cache.LRUPolicy, trackChecksums, state.SyncConfig{})
 	require.NoError(err)
 
 	outputOwners := secp256k1fx.OutputOwners{
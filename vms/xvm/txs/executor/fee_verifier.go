@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/xvm/state"
+	"github.com/luxfi/node/vms/xvm/txs"
+	"github.com/luxfi/node/vms/xvm/txs/fee"
+)
+
+var (
+	_ txs.Visitor = (*feeVerifier)(nil)
+
+	ErrExceedsMaxCapacity = errors.New("tx exceeds the maximum gas capacity")
+)
+
+// VerifyDynamicFee verifies that [tx] burns the fee required by the current
+// gas price of [chainState].
+//
+// Prior to Fortuna, the static fee is verified during syntactic verification
+// instead.
+func VerifyDynamicFee(
+	backend *Backend,
+	chainState state.ReadOnlyChain,
+	tx txs.UnsignedTx,
+) error {
+	if !backend.Config.IsFortunaActivated(chainState.GetTimestamp()) {
+		return nil
+	}
+
+	fee, err := DynamicFee(backend, chainState, tx)
+	if err != nil {
+		return err
+	}
+	return tx.Visit(&feeVerifier{
+		Backend: backend,
+		fee:     fee,
+	})
+}
+
+// VerifyMaxGas verifies that [tx] could be included in a block at [timestamp],
+// which requires that it doesn't use more gas than the maximum capacity.
+func VerifyMaxGas(
+	backend *Backend,
+	timestamp time.Time,
+	tx txs.UnsignedTx,
+) error {
+	if !backend.Config.IsFortunaActivated(timestamp) {
+		return nil
+	}
+
+	feeConfig := backend.Config.DynamicFeeConfig
+	gasUsed, err := fee.TxGas(backend.Codec, feeConfig.Weights, tx)
+	if err != nil {
+		return err
+	}
+	if gasUsed > feeConfig.MaxCapacity {
+		return fmt.Errorf("%w: %d > %d", ErrExceedsMaxCapacity, gasUsed, feeConfig.MaxCapacity)
+	}
+	return nil
+}
+
+// feeVerifier verifies that the inputs of a transaction cover its outputs and
+// [fee].
+type feeVerifier struct {
+	*Backend
+	fee uint64
+}
+
+func (v *feeVerifier) BaseTx(tx *txs.BaseTx) error {
+	return v.verify(
+		[][]*lux.TransferableInput{tx.Ins},
+		[][]*lux.TransferableOutput{tx.Outs},
+	)
+}
+
+func (v *feeVerifier) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *feeVerifier) OperationTx(tx *txs.OperationTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *feeVerifier) ImportTx(tx *txs.ImportTx) error {
+	return v.verify(
+		[][]*lux.TransferableInput{
+			tx.Ins,
+			tx.ImportedIns,
+		},
+		[][]*lux.TransferableOutput{tx.Outs},
+	)
+}
+
+func (v *feeVerifier) ExportTx(tx *txs.ExportTx) error {
+	return v.verify(
+		[][]*lux.TransferableInput{tx.Ins},
+		[][]*lux.TransferableOutput{
+			tx.Outs,
+			tx.ExportedOuts,
+		},
+	)
+}
+
+func (v *feeVerifier) verify(
+	ins [][]*lux.TransferableInput,
+	outs [][]*lux.TransferableOutput,
+) error {
+	return lux.VerifyTx(
+		v.fee,
+		v.FeeAssetID,
+		ins,
+		outs,
+		v.Codec,
+	)
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"time"
+
+	"github.com/luxfi/node/vms/components/gas"
+	"github.com/luxfi/node/vms/xvm/state"
+	"github.com/luxfi/node/vms/xvm/txs"
+	"github.com/luxfi/node/vms/xvm/txs/fee"
+)
+
+// AdvanceTimeTo sets the timestamp of [chainState] to [newChainTime] and, once
+// Fortuna is activated, replenishes the gas capacity and decays the gas excess
+// for the elapsed time.
+//
+// Invariant: [newChainTime] is not before the current timestamp.
+func AdvanceTimeTo(
+	backend *Backend,
+	chainState state.Chain,
+	newChainTime time.Time,
+) {
+	if backend.Config.IsFortunaActivated(newChainTime) {
+		var (
+			previousChainTime = chainState.GetTimestamp()
+			duration          = uint64(newChainTime.Sub(previousChainTime) / time.Second)
+			feeConfig         = backend.Config.DynamicFeeConfig
+			feeState          = chainState.GetFeeState()
+		)
+		chainState.SetFeeState(feeState.AdvanceTime(
+			feeConfig.MaxCapacity,
+			feeConfig.MaxPerSecond,
+			feeConfig.TargetPerSecond,
+			duration,
+		))
+	}
+	chainState.SetTimestamp(newChainTime)
+}
+
+// GasPrice returns the current gas price of [chainState].
+func GasPrice(backend *Backend, chainState state.ReadOnlyChain) gas.Price {
+	feeConfig := backend.Config.DynamicFeeConfig
+	return gas.CalculatePrice(
+		feeConfig.MinPrice,
+		chainState.GetFeeState().Excess,
+		feeConfig.ExcessConversionConstant,
+	)
+}
+
+// DynamicFee returns the fee that [tx] must burn at the current gas price of
+// [chainState].
+func DynamicFee(
+	backend *Backend,
+	chainState state.ReadOnlyChain,
+	tx txs.UnsignedTx,
+) (uint64, error) {
+	calculator := fee.NewCalculator(
+		backend.Codec,
+		backend.Config.DynamicFeeConfig.Weights,
+		GasPrice(backend, chainState),
+	)
+	return calculator.CalculateFee(tx)
+}
+
+// ConsumeGas removes the gas used by [tx] from the capacity of [chainState]
+// and adds it to the excess.
+//
+// Returns [gas.ErrInsufficientCapacity] if [tx] uses more gas than the
+// remaining capacity.
+func ConsumeGas(
+	backend *Backend,
+	chainState state.Chain,
+	tx txs.UnsignedTx,
+) error {
+	if !backend.Config.IsFortunaActivated(chainState.GetTimestamp()) {
+		return nil
+	}
+
+	gasUsed, err := fee.TxGas(
+		backend.Codec,
+		backend.Config.DynamicFeeConfig.Weights,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	feeState, err := chainState.GetFeeState().ConsumeGas(gasUsed)
+	if err != nil {
+		return err
+	}
+	chainState.SetFeeState(feeState)
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/mock/gomock"
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+	"github.com/luxfi/node/vms/components/gas"
+	"github.com/luxfi/node/vms/xvm/config"
+	"github.com/luxfi/node/vms/xvm/state"
+	"github.com/luxfi/node/vms/xvm/txs"
+)
+
+var (
+	fortunaTime = time.Unix(1_000_000, 0)
+
+	dynamicFeeConfig = config.Config{
+		DynamicFeeConfig: gas.Config{
+			Weights: gas.Dimensions{
+				gas.Bandwidth: 1,
+			},
+			MaxCapacity:              1_000,
+			MaxPerSecond:             100,
+			TargetPerSecond:          50,
+			MinPrice:                 1,
+			ExcessConversionConstant: 1_000,
+		},
+		FortunaTime: fortunaTime,
+	}
+)
+
+func TestAdvanceTimeTo(t *testing.T) {
+	tests := []struct {
+		name      string
+		stateFunc func(*gomock.Controller, time.Time) state.Chain
+		newTime   time.Time
+	}{
+		{
+			name: "before fortuna",
+			stateFunc: func(ctrl *gomock.Controller, newTime time.Time) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().SetTimestamp(newTime)
+				return s
+			},
+			newTime: fortunaTime.Add(-time.Second),
+		},
+		{
+			name: "after fortuna",
+			stateFunc: func(ctrl *gomock.Controller, newTime time.Time) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(newTime.Add(-2 * time.Second))
+				s.EXPECT().GetFeeState().Return(gas.State{
+					Capacity: 900,
+					Excess:   200,
+				})
+				s.EXPECT().SetFeeState(gas.State{
+					Capacity: 1_000, // capped at the max capacity
+					Excess:   100,
+				})
+				s.EXPECT().SetTimestamp(newTime)
+				return s
+			},
+			newTime: fortunaTime.Add(time.Second),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			backend := &Backend{
+				Config: &dynamicFeeConfig,
+			}
+			AdvanceTimeTo(backend, test.stateFunc(ctrl, test.newTime), test.newTime)
+		})
+	}
+}
+
+func TestConsumeGas(t *testing.T) {
+	const txSize = 100
+	txGas := gas.Gas(txSize + wrappers.IntLen) // num credentials
+
+	tests := []struct {
+		name        string
+		stateFunc   func(*gomock.Controller) state.Chain
+		expectedErr error
+	}{
+		{
+			name: "before fortuna",
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(fortunaTime.Add(-time.Second))
+				return s
+			},
+		},
+		{
+			name: "sufficient capacity",
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(fortunaTime)
+				s.EXPECT().GetFeeState().Return(gas.State{
+					Capacity: 1_000,
+					Excess:   10,
+				})
+				s.EXPECT().SetFeeState(gas.State{
+					Capacity: 1_000 - txGas,
+					Excess:   10 + txGas,
+				})
+				return s
+			},
+		},
+		{
+			name: "insufficient capacity",
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(fortunaTime)
+				s.EXPECT().GetFeeState().Return(gas.State{
+					Capacity: txGas - 1,
+				})
+				return s
+			},
+			expectedErr: gas.ErrInsufficientCapacity,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			c := codec.NewMockManager(ctrl)
+			c.EXPECT().Size(gomock.Any(), gomock.Any()).Return(txSize, nil).AnyTimes()
+
+			backend := &Backend{
+				Config: &dynamicFeeConfig,
+				Codec:  c,
+			}
+			err := ConsumeGas(backend, test.stateFunc(ctrl), &txs.BaseTx{})
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
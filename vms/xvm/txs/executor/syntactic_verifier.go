@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/luxfi/ids"
@@ -48,6 +49,10 @@ var (
 type SyntacticVerifier struct {
 	*Backend
 	Tx *txs.Tx
+	// Timestamp is the chain time that [Tx] is being verified at. Once Fortuna
+	// is activated, the static fees are no longer charged and the dynamic fee
+	// is verified during semantic verification.
+	Timestamp time.Time
 }
 
 func (v *SyntacticVerifier) BaseTx(tx *txs.BaseTx) error {
@@ -56,7 +61,7 @@ func (v *SyntacticVerifier) BaseTx(tx *txs.BaseTx) error {
 	}
 
 	err := lux.VerifyTx(
-		v.Config.TxFee,
+		v.staticFee(v.Config.TxFee),
 		v.FeeAssetID,
 		[][]*lux.TransferableInput{tx.Ins},
 		[][]*lux.TransferableOutput{tx.Outs},
@@ -119,7 +124,7 @@ func (v *SyntacticVerifier) CreateAssetTx(tx *txs.CreateAssetTx) error {
 	}
 
 	err := lux.VerifyTx(
-		v.Config.CreateAssetTxFee,
+		v.staticFee(v.Config.CreateAssetTxFee),
 		v.FeeAssetID,
 		[][]*lux.TransferableInput{tx.Ins},
 		[][]*lux.TransferableOutput{tx.Outs},
@@ -167,7 +172,7 @@ func (v *SyntacticVerifier) OperationTx(tx *txs.OperationTx) error {
 	}
 
 	err := lux.VerifyTx(
-		v.Config.TxFee,
+		v.staticFee(v.Config.TxFee),
 		v.FeeAssetID,
 		[][]*lux.TransferableInput{tx.Ins},
 		[][]*lux.TransferableOutput{tx.Outs},
@@ -227,7 +232,7 @@ func (v *SyntacticVerifier) ImportTx(tx *txs.ImportTx) error {
 	}
 
 	err := lux.VerifyTx(
-		v.Config.TxFee,
+		v.staticFee(v.Config.TxFee),
 		v.FeeAssetID,
 		[][]*lux.TransferableInput{
 			tx.Ins,
@@ -269,7 +274,7 @@ func (v *SyntacticVerifier) ExportTx(tx *txs.ExportTx) error {
 	}
 
 	err := lux.VerifyTx(
-		v.Config.TxFee,
+		v.staticFee(v.Config.TxFee),
 		v.FeeAssetID,
 		[][]*lux.TransferableInput{tx.Ins},
 		[][]*lux.TransferableOutput{
@@ -300,3 +305,11 @@ func (v *SyntacticVerifier) ExportTx(tx *txs.ExportTx) error {
 
 	return nil
 }
+
+// staticFee returns [fee] if the static fees are still active at [Timestamp].
+func (v *SyntacticVerifier) staticFee(fee uint64) uint64 {
+	if v.Config.IsFortunaActivated(v.Timestamp) {
+		return 0
+	}
+	return fee
+}
@@ -31,6 +31,7 @@ var (
 		TxFee:            2,
 		CreateAssetTxFee: 3,
 		EtnaTime:         mockable.MaxTime,
+		FortunaTime:      mockable.MaxTime,
 	}
 )
 
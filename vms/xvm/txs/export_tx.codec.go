@@ -0,0 +1,62 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*ExportTx)(nil)
+	_ codec.Unmarshaler = (*ExportTx)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*ExportTx) CodecType() any {
+	return (*ExportTx)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *ExportTx) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.BaseTx)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += len(v.DestinationChain)
+	fieldSize, err = c.Size(&v.ExportedOuts)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *ExportTx) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.BaseTx, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.DestinationChain[:])
+	if err := c.MarshalInto(&v.ExportedOuts, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *ExportTx) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.BaseTx); err != nil {
+		return err
+	}
+	copy(v.DestinationChain[:], p.UnpackFixedBytes(len(v.DestinationChain)))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.ExportedOuts); err != nil {
+		return err
+	}
+	return nil
+}
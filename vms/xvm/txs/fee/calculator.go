@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/vms/components/gas"
+	"github.com/luxfi/node/vms/xvm/txs"
+)
+
+var (
+	ErrCalculatingComplexity = errors.New("error calculating complexity")
+	ErrCalculatingGas        = errors.New("error calculating gas")
+	ErrCalculatingCost       = errors.New("error calculating cost")
+)
+
+// Calculator calculates the fee of X-Chain transactions using the dynamic fee
+// mechanism.
+type Calculator struct {
+	codec   codec.Manager
+	weights gas.Dimensions
+	price   gas.Price
+}
+
+func NewCalculator(
+	c codec.Manager,
+	weights gas.Dimensions,
+	price gas.Price,
+) *Calculator {
+	return &Calculator{
+		codec:   c,
+		weights: weights,
+		price:   price,
+	}
+}
+
+// CalculateGas returns the gas consumed by [tx].
+func (c *Calculator) CalculateGas(tx txs.UnsignedTx) (gas.Gas, error) {
+	return TxGas(c.codec, c.weights, tx)
+}
+
+// CalculateFee returns the fee that must be burned by [tx].
+func (c *Calculator) CalculateFee(tx txs.UnsignedTx) (uint64, error) {
+	gasUsed, err := c.CalculateGas(tx)
+	if err != nil {
+		return 0, err
+	}
+	fee, err := gasUsed.Cost(c.price)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"%w with gas (%d) and price (%d): %w",
+			ErrCalculatingCost,
+			gasUsed,
+			c.price,
+			err,
+		)
+	}
+	return fee, nil
+}
+
+// TxGas returns the gas consumed by [tx] with the provided [weights].
+func TxGas(c codec.Manager, weights gas.Dimensions, tx txs.UnsignedTx) (gas.Gas, error) {
+	complexity, err := TxComplexity(c, tx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrCalculatingComplexity, err)
+	}
+	gasUsed, err := complexity.ToGas(weights)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"%w with complexity (%v) and weights (%v): %w",
+			ErrCalculatingGas,
+			complexity,
+			weights,
+			err,
+		)
+	}
+	return gasUsed, nil
+}
@@ -0,0 +1,238 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"errors"
+
+	"github.com/luxfi/crypto/secp256k1"
+	"github.com/luxfi/math/math"
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+	"github.com/luxfi/node/vms/components/gas"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/nftfx"
+	"github.com/luxfi/node/vms/propertyfx"
+	"github.com/luxfi/node/vms/secp256k1fx"
+	"github.com/luxfi/node/vms/xvm/txs"
+)
+
+// Signature verification costs were conservatively based on benchmarks run on
+// an AWS c5.xlarge instance.
+const (
+	intrinsicCredentialsBandwidth = wrappers.IntLen // num credentials
+
+	intrinsicCredentialBandwidth = wrappers.IntLen + // credential typeID
+		wrappers.IntLen // num signatures
+
+	intrinsicSECP256k1FxSignatureBandwidth = secp256k1.SignatureLen
+
+	intrinsicSECP256k1FxSignatureCompute = 200 // secp256k1 signature verification time is around 200us
+
+	intrinsicInputDBRead   = 1
+	intrinsicInputDBWrite  = 1
+	intrinsicOutputDBWrite = 1
+)
+
+var (
+	_ txs.Visitor = (*complexityVisitor)(nil)
+
+	errUnsupportedInput     = errors.New("unsupported input type")
+	errUnsupportedOperation = errors.New("unsupported operation type")
+)
+
+// TxComplexity returns the complexity of [tx], including the complexity that
+// the credentials of [tx] will add once it is signed.
+//
+// The bandwidth is the serialized size of [tx] using [c].
+func TxComplexity(c codec.Manager, tx txs.UnsignedTx) (gas.Dimensions, error) {
+	size, err := c.Size(txs.CodecVersion, &tx)
+	if err != nil {
+		return gas.Dimensions{}, err
+	}
+
+	visitor := complexityVisitor{
+		output: gas.Dimensions{
+			gas.Bandwidth: uint64(size) + intrinsicCredentialsBandwidth,
+		},
+	}
+	if err := tx.Visit(&visitor); err != nil {
+		return gas.Dimensions{}, err
+	}
+	return visitor.output, nil
+}
+
+// InputComplexity returns the complexity inputs add to a transaction, excluding
+// their serialized size. It includes the complexity that the corresponding
+// credentials will add.
+func InputComplexity(ins ...*lux.TransferableInput) (gas.Dimensions, error) {
+	var complexity gas.Dimensions
+	for _, in := range ins {
+		secp256k1In, ok := in.In.(*secp256k1fx.TransferInput)
+		if !ok {
+			return gas.Dimensions{}, errUnsupportedInput
+		}
+
+		inputComplexity, err := credentialComplexity(&secp256k1In.Input)
+		if err != nil {
+			return gas.Dimensions{}, err
+		}
+		inputComplexity[gas.DBRead] = intrinsicInputDBRead
+		inputComplexity[gas.DBWrite] = intrinsicInputDBWrite
+
+		complexity, err = complexity.Add(&inputComplexity)
+		if err != nil {
+			return gas.Dimensions{}, err
+		}
+	}
+	return complexity, nil
+}
+
+// OperationComplexity returns the complexity operations add to a transaction,
+// excluding their serialized size. It includes the complexity that the
+// corresponding credentials will add.
+func OperationComplexity(ops ...*txs.Operation) (gas.Dimensions, error) {
+	var complexity gas.Dimensions
+	for _, op := range ops {
+		var in *secp256k1fx.Input
+		switch fxOp := op.Op.(type) {
+		case *secp256k1fx.MintOperation:
+			in = &fxOp.MintInput
+		case *nftfx.MintOperation:
+			in = &fxOp.MintInput
+		case *nftfx.TransferOperation:
+			in = &fxOp.Input
+		case *propertyfx.MintOperation:
+			in = &fxOp.MintInput
+		case *propertyfx.BurnOperation:
+			in = &fxOp.Input
+		default:
+			return gas.Dimensions{}, errUnsupportedOperation
+		}
+
+		opComplexity, err := credentialComplexity(in)
+		if err != nil {
+			return gas.Dimensions{}, err
+		}
+
+		numUTXOs := uint64(len(op.UTXOIDs))
+		numOuts := uint64(len(op.Op.Outs()))
+		opComplexity[gas.DBRead] = numUTXOs * intrinsicInputDBRead
+		opComplexity[gas.DBWrite], err = math.Add64(
+			numUTXOs*intrinsicInputDBWrite,
+			numOuts*intrinsicOutputDBWrite,
+		)
+		if err != nil {
+			return gas.Dimensions{}, err
+		}
+
+		complexity, err = complexity.Add(&opComplexity)
+		if err != nil {
+			return gas.Dimensions{}, err
+		}
+	}
+	return complexity, nil
+}
+
+// credentialComplexity returns the complexity of the credential that will
+// authorize [in].
+func credentialComplexity(in *secp256k1fx.Input) (gas.Dimensions, error) {
+	numSignatures := uint64(len(in.SigIndices))
+	signatureBandwidth, err := math.Mul64(numSignatures, intrinsicSECP256k1FxSignatureBandwidth)
+	if err != nil {
+		return gas.Dimensions{}, err
+	}
+	bandwidth, err := math.Add64(intrinsicCredentialBandwidth, signatureBandwidth)
+	if err != nil {
+		return gas.Dimensions{}, err
+	}
+	compute, err := math.Mul64(numSignatures, intrinsicSECP256k1FxSignatureCompute)
+	if err != nil {
+		return gas.Dimensions{}, err
+	}
+	return gas.Dimensions{
+		gas.Bandwidth: bandwidth,
+		gas.Compute:   compute,
+	}, nil
+}
+
+type complexityVisitor struct {
+	output gas.Dimensions
+}
+
+func (c *complexityVisitor) BaseTx(tx *txs.BaseTx) error {
+	return c.addBaseTx(tx)
+}
+
+func (c *complexityVisitor) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	if err := c.addBaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+
+	var numOuts uint64
+	for _, state := range tx.States {
+		numOuts += uint64(len(state.Outs))
+	}
+	return c.add(&gas.Dimensions{
+		gas.DBWrite: numOuts*intrinsicOutputDBWrite + 1, // asset definition
+	})
+}
+
+func (c *complexityVisitor) OperationTx(tx *txs.OperationTx) error {
+	if err := c.addBaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+
+	opsComplexity, err := OperationComplexity(tx.Ops...)
+	if err != nil {
+		return err
+	}
+	return c.add(&opsComplexity)
+}
+
+func (c *complexityVisitor) ImportTx(tx *txs.ImportTx) error {
+	if err := c.addBaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+
+	// Imported inputs are removed from shared memory when the block is
+	// accepted, so they are charged the same as local inputs.
+	importedComplexity, err := InputComplexity(tx.ImportedIns...)
+	if err != nil {
+		return err
+	}
+	return c.add(&importedComplexity)
+}
+
+func (c *complexityVisitor) ExportTx(tx *txs.ExportTx) error {
+	if err := c.addBaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+
+	numExportedOuts := uint64(len(tx.ExportedOuts))
+	return c.add(&gas.Dimensions{
+		gas.DBWrite: numExportedOuts * intrinsicOutputDBWrite,
+	})
+}
+
+func (c *complexityVisitor) addBaseTx(tx *txs.BaseTx) error {
+	inputsComplexity, err := InputComplexity(tx.Ins...)
+	if err != nil {
+		return err
+	}
+
+	numOuts := uint64(len(tx.Outs))
+	return c.add(
+		&inputsComplexity,
+		&gas.Dimensions{
+			gas.DBWrite: numOuts * intrinsicOutputDBWrite,
+		},
+	)
+}
+
+func (c *complexityVisitor) add(complexities ...*gas.Dimensions) error {
+	var err error
+	c.output, err = c.output.Add(complexities...)
+	return err
+}
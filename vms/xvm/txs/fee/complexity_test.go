@@ -0,0 +1,185 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/constants"
+	"github.com/luxfi/node/vms/components/gas"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/nftfx"
+	"github.com/luxfi/node/vms/propertyfx"
+	"github.com/luxfi/node/vms/secp256k1fx"
+	"github.com/luxfi/node/vms/xvm/fxs"
+	"github.com/luxfi/node/vms/xvm/txs"
+)
+
+var (
+	chainID = ids.ID{5, 4, 3, 2, 1}
+	assetID = ids.ID{1, 2, 3}
+
+	testWeights = gas.Dimensions{
+		gas.Bandwidth: 1,
+		gas.DBRead:    1_000,
+		gas.DBWrite:   1_000,
+		gas.Compute:   4,
+	}
+)
+
+func newBaseTx() *txs.BaseTx {
+	return &txs.BaseTx{BaseTx: lux.BaseTx{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: chainID,
+		Outs: []*lux.TransferableOutput{{
+			Asset: lux.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 12345,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{{1}},
+				},
+			},
+		}},
+		Ins: []*lux.TransferableInput{{
+			UTXOID: lux.UTXOID{
+				TxID:        ids.ID{0xff},
+				OutputIndex: 1,
+			},
+			Asset: lux.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: 54321,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{2},
+				},
+			},
+		}},
+		Memo: []byte{0x00, 0x01, 0x02, 0x03},
+	}}
+}
+
+func newCodec(t *testing.T) txs.Parser {
+	parser, err := txs.NewParser(
+		[]fxs.Fx{
+			&secp256k1fx.Fx{},
+			&nftfx.Fx{},
+			&propertyfx.Fx{},
+		},
+	)
+	require.NoError(t, err)
+	return parser
+}
+
+func TestTxComplexity(t *testing.T) {
+	c := newCodec(t).Codec()
+
+	baseTx := newBaseTx()
+	tests := []struct {
+		name        string
+		tx          txs.UnsignedTx
+		expected    gas.Dimensions
+		expectedErr error
+	}{
+		{
+			name: "BaseTx",
+			tx:   baseTx,
+			expected: gas.Dimensions{
+				gas.Bandwidth: 226 + // unsigned tx
+					intrinsicCredentialsBandwidth +
+					intrinsicCredentialBandwidth +
+					intrinsicSECP256k1FxSignatureBandwidth,
+				gas.DBRead:  1,
+				gas.DBWrite: 2,
+				gas.Compute: intrinsicSECP256k1FxSignatureCompute,
+			},
+		},
+		{
+			name: "ExportTx",
+			tx: &txs.ExportTx{
+				BaseTx:           *baseTx,
+				DestinationChain: constants.PlatformChainID,
+				ExportedOuts:     baseTx.Outs,
+			},
+			expected: gas.Dimensions{
+				gas.Bandwidth: 226 + // unsigned tx
+					ids.IDLen + // destination chain
+					4 + 80 + // exported outputs
+					intrinsicCredentialsBandwidth +
+					intrinsicCredentialBandwidth +
+					intrinsicSECP256k1FxSignatureBandwidth,
+				gas.DBRead:  1,
+				gas.DBWrite: 3,
+				gas.Compute: intrinsicSECP256k1FxSignatureCompute,
+			},
+		},
+		{
+			name: "OperationTx",
+			tx: &txs.OperationTx{
+				BaseTx: *baseTx,
+				Ops: []*txs.Operation{{
+					Asset: lux.Asset{ID: assetID},
+					UTXOIDs: []*lux.UTXOID{{
+						TxID: ids.ID{0xfe},
+					}},
+					Op: &propertyfx.BurnOperation{
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0, 1},
+						},
+					},
+				}},
+			},
+			expected: gas.Dimensions{
+				gas.Bandwidth: 226 + // unsigned tx
+					4 + // num operations
+					ids.IDLen + // assetID
+					4 + ids.IDLen + 4 + // utxoIDs
+					4 + 4 + 2*4 + // burn operation
+					intrinsicCredentialsBandwidth +
+					2*intrinsicCredentialBandwidth +
+					3*intrinsicSECP256k1FxSignatureBandwidth,
+				gas.DBRead:  2,
+				gas.DBWrite: 3,
+				gas.Compute: 3 * intrinsicSECP256k1FxSignatureCompute,
+			},
+		},
+		{
+			name: "unsupported input",
+			tx: &txs.BaseTx{BaseTx: lux.BaseTx{
+				Ins: []*lux.TransferableInput{{
+					Asset: lux.Asset{ID: assetID},
+					In:    &lux.TestTransferable{},
+				}},
+			}},
+			expectedErr: errUnsupportedInput,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			complexity, err := TxComplexity(c, test.tx)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, complexity)
+		})
+	}
+}
+
+func TestCalculatorCalculateFee(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		c        = newCodec(t).Codec()
+		tx       = newBaseTx()
+		price    = gas.Price(10)
+		expected = 10 * (303 + 1*1_000 + 2*1_000 + 200*4)
+	)
+	calculator := NewCalculator(c, testWeights, price)
+
+	fee, err := calculator.CalculateFee(tx)
+	require.NoError(err)
+	require.Equal(uint64(expected), fee)
+}
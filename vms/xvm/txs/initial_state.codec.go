@@ -0,0 +1,51 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*InitialState)(nil)
+	_ codec.Unmarshaler = (*InitialState)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*InitialState) CodecType() any {
+	return (*InitialState)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *InitialState) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	size += wrappers.IntLen
+	fieldSize, err := c.Size(&v.Outs)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *InitialState) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	p.PackInt(v.FxIndex)
+	if err := c.MarshalInto(&v.Outs, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *InitialState) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	v.FxIndex = p.UnpackInt()
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalFrom(p, &v.Outs); err != nil {
+		return err
+	}
+	return nil
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/luxfi/node/vms/components/verify"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	ErrNilInitialState  = errors.New("nil initial state is not valid")
 	ErrNilFxOutput      = errors.New("nil feature extension output is not valid")
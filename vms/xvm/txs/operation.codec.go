@@ -0,0 +1,67 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"github.com/luxfi/node/codec"
+	"github.com/luxfi/node/utils/wrappers"
+)
+
+var (
+	_ codec.Marshaler   = (*Operation)(nil)
+	_ codec.Unmarshaler = (*Operation)(nil)
+)
+
+// CodecType implements [codec.Marshaler].
+func (*Operation) CodecType() any {
+	return (*Operation)(nil)
+}
+
+// CodecSize implements [codec.Marshaler].
+func (v *Operation) CodecSize(c codec.Codec) (int, error) {
+	size := 0
+	fieldSize, err := c.Size(&v.Asset)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.UTXOIDs)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.Size(&v.Op)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+// MarshalCodec implements [codec.Marshaler].
+func (v *Operation) MarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.MarshalInto(&v.Asset, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.UTXOIDs, p); err != nil {
+		return err
+	}
+	if err := c.MarshalInto(&v.Op, p); err != nil {
+		return err
+	}
+	return p.Err
+}
+
+// UnmarshalCodec implements [codec.Unmarshaler].
+func (v *Operation) UnmarshalCodec(c codec.Codec, p *wrappers.Packer) error {
+	if err := c.UnmarshalFrom(p, &v.Asset); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.UTXOIDs); err != nil {
+		return err
+	}
+	if err := c.UnmarshalFrom(p, &v.Op); err != nil {
+		return err
+	}
+	return nil
+}
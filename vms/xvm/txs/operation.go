@@ -17,6 +17,8 @@ import (
 	"github.com/luxfi/node/vms/xvm/fxs"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	ErrNilOperation              = errors.New("nil operation is not valid")
 	ErrNilFxOperation            = errors.New("nil fx operation is not valid")
@@ -12,6 +12,8 @@ import (
 	"github.com/luxfi/node/vms/secp256k1fx"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var (
 	_ UnsignedTx             = (*OperationTx)(nil)
 	_ secp256k1fx.UnsignedTx = (*OperationTx)(nil)
@@ -56,9 +56,26 @@ func NewCustomParser(
 	log log.Logger,
 	fxs []fxs.Fx,
 ) (Parser, error) {
-	gc := linearcodec.NewDefault()
-	c := linearcodec.NewDefault()
+	return newCustomParser(
+		linearcodec.NewDefault(),
+		linearcodec.NewDefault(),
+		typeToFxIndex,
+		clock,
+		log,
+		fxs,
+	)
+}
 
+// newCustomParser registers the tx types, followed by the types of [fxs], into
+// the codec [c] and the genesis codec [gc].
+func newCustomParser(
+	c linearcodec.Codec,
+	gc linearcodec.Codec,
+	typeToFxIndex map[reflect.Type]int,
+	clock *mockable.Clock,
+	log log.Logger,
+	fxs []fxs.Fx,
+) (Parser, error) {
 	gcm := codec.NewManager(math.MaxInt32)
 	cm := codec.NewDefaultManager()
 
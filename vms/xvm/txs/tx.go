@@ -20,6 +20,8 @@ import (
 	"github.com/luxfi/node/vms/xvm/fxs"
 )
 
+//go:generate go run github.com/luxfi/node/codec/codecgen $GOFILE
+
 var _ gossip.Gossipable = (*Tx)(nil)
 
 type UnsignedTx interface {
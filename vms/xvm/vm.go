@@ -12,7 +12,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/gorilla/rpc/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
@@ -29,6 +28,7 @@ import (
 	"github.com/luxfi/ids"
 	"github.com/luxfi/log"
 	"github.com/luxfi/node/cache"
+	"github.com/luxfi/node/network/p2p"
 	"github.com/luxfi/node/pubsub"
 	"github.com/luxfi/node/utils/json"
 	"github.com/luxfi/node/utils/linked"
@@ -37,6 +37,7 @@ import (
 	"github.com/luxfi/node/version"
 	"github.com/luxfi/node/vms/components/index"
 	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/components/statesync"
 	"github.com/luxfi/node/vms/secp256k1fx"
 	"github.com/luxfi/node/vms/txs/mempool"
 	"github.com/luxfi/node/vms/xvm/block"
@@ -142,6 +143,14 @@ type VM struct {
 
 	// Channel for receiving messages from mempool
 	toEngine chan core.MessageType
+
+	stateSyncEnabled bool
+	// stateSyncClient is only initialized after the chain has been linearized.
+	stateSyncClient *p2p.Client
+	// Signaled once a state sync started by accepting a summary finishes.
+	stateSyncDone chan struct{}
+	// stateSyncErr is the error that caused the last state sync to fail.
+	stateSyncErr error
 }
 
 func (vm *VM) Connected(ctx context.Context, nodeID ids.NodeID, version *version.Application) error {
@@ -297,7 +306,11 @@ func (vm *VM) initialize(
 		vm.db,
 		vm.parser,
 		vm.registerer,
+		xvmConfig.CachePolicy,
 		xvmConfig.ChecksumsEnabled,
+		state.SyncConfig{
+			SummaryFrequency: xvmConfig.StateSyncSummaryFrequency,
+		},
 	)
 	if err != nil {
 		return err
@@ -339,11 +352,20 @@ func (vm *VM) initialize(
 
 	vm.onShutdownCtx, vm.onShutdownCtxCancel = context.WithCancel(context.Background())
 	vm.networkConfig = xvmConfig.Network
+	vm.stateSyncEnabled = xvmConfig.StateSyncEnabled
+	vm.stateSyncDone = make(chan struct{}, 1)
 	return vm.state.Commit()
 }
 
 // onBootstrapStarted is called by the consensus engine when it starts bootstrapping this chain
 func (vm *VM) onBootstrapStarted() error {
+	vm.lock.RLock()
+	err := vm.stateSyncErr
+	vm.lock.RUnlock()
+	if err != nil {
+		return fmt.Errorf("state sync failed: %w", err)
+	}
+
 	vm.txBackend.Bootstrapped = false
 	for _, fx := range vm.fxs {
 		if err := fx.Fx.Bootstrapping(); err != nil {
@@ -397,7 +419,7 @@ func (*VM) Version(context.Context) (string, error) {
 func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 	codec := json.NewCodec()
 
-	rpcServer := rpc.NewServer()
+	rpcServer := json.NewServer()
 	rpcServer.RegisterCodec(codec, "application/json")
 	rpcServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	rpcServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
@@ -407,7 +429,7 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 		return nil, err
 	}
 
-	walletServer := rpc.NewServer()
+	walletServer := json.NewServer()
 	walletServer.RegisterCodec(codec, "application/json")
 	walletServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	walletServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
@@ -460,8 +482,7 @@ func (vm *VM) GetBlockIDAtHeight(_ context.Context, height uint64) (ids.ID, erro
  */
 
 func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID) error {
-	time := version.GetCortinaTime(consensus.GetNetworkID(vm.ctx))
-	err := vm.state.InitializeChainState(stopVertexID, time)
+	err := vm.state.InitializeChainState(stopVertexID, vm.CortinaTime)
 	if err != nil {
 		return err
 	}
@@ -517,6 +538,12 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID) error {
 		return fmt.Errorf("failed to initialize network: %w", err)
 	}
 
+	stateSyncHandler := statesync.NewHandler(vm.log, &vm.lock, vm.state)
+	if err := vm.network.AddHandler(statesync.HandlerID, stateSyncHandler); err != nil {
+		return fmt.Errorf("failed to register state sync handler: %w", err)
+	}
+	vm.stateSyncClient = vm.network.NewClient(statesync.HandlerID)
+
 	// Notify the network of our current peers
 	for nodeID, version := range vm.connectedPeers {
 		// Convert to consensus version type
@@ -565,8 +592,9 @@ func (vm *VM) ParseTx(_ context.Context, bytes []byte) (dag.Tx, error) {
 	}
 
 	err = tx.Unsigned.Visit(&txexecutor.SyntacticVerifier{
-		Backend: vm.txBackend,
-		Tx:      tx,
+		Backend:   vm.txBackend,
+		Tx:        tx,
+		Timestamp: vm.state.GetTimestamp(),
 	})
 	if err != nil {
 		return nil, err
@@ -770,6 +798,8 @@ func (vm *VM) WaitForEvent(ctx context.Context) (core.MessageType, error) {
 	select {
 	case msgType := <-vm.toEngine:
 		return msgType, nil
+	case <-vm.stateSyncDone:
+		return core.StateSyncDone, nil
 	case <-ctx.Done():
 		return core.PendingTxs, ctx.Err()
 	}
@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/luxfi/node/utils/rpc"
+	"github.com/luxfi/node/vms/components/gas"
+)
+
+// DynamicFeeClient is the subset of the P-Chain and X-Chain API clients that is
+// used to fetch the dynamic fee state.
+type DynamicFeeClient interface {
+	GetFeeConfig(ctx context.Context, options ...rpc.Option) (*gas.Config, error)
+	GetFeeState(ctx context.Context, options ...rpc.Option) (gas.State, gas.Price, time.Time, error)
+}
+
+// DynamicFeeContext is the dynamic fee state of a chain as of the block
+// accepted at [Time].
+type DynamicFeeContext struct {
+	Config gas.Config
+	State  gas.State
+	Time   time.Time
+}
+
+func NewDynamicFeeContextFromClient(
+	ctx context.Context,
+	client DynamicFeeClient,
+) (*DynamicFeeContext, error) {
+	config, err := client.GetFeeConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state, _, timestamp, err := client.GetFeeState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DynamicFeeContext{
+		Config: *config,
+		State:  state,
+		Time:   timestamp,
+	}, nil
+}
+
+// Price returns the gas price as of [Time].
+func (c *DynamicFeeContext) Price() gas.Price {
+	return gas.CalculatePrice(
+		c.Config.MinPrice,
+		c.State.Excess,
+		c.Config.ExcessConversionConstant,
+	)
+}
+
+// MaxPrice returns the highest gas price that can be reached by [until],
+// regardless of how much gas is consumed by other transactions.
+func (c *DynamicFeeContext) MaxPrice(until time.Time) gas.Price {
+	var duration uint64
+	if until.After(c.Time) {
+		duration = uint64(until.Sub(c.Time) / time.Second)
+	}
+	maxExcess := c.State.MaxExcess(
+		c.Config.MaxPerSecond,
+		c.Config.TargetPerSecond,
+		duration,
+	)
+	return gas.CalculatePrice(
+		c.Config.MinPrice,
+		maxExcess,
+		c.Config.ExcessConversionConstant,
+	)
+}
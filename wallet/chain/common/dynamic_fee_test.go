@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/vms/components/gas"
+)
+
+func TestDynamicFeeContextMaxPrice(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		now = time.Unix(1_000, 0)
+		c   = &DynamicFeeContext{
+			Config: gas.Config{
+				Weights: gas.Dimensions{
+					gas.Bandwidth: 1,
+					gas.DBRead:    1_000,
+					gas.DBWrite:   1_000,
+					gas.Compute:   4,
+				},
+				MaxCapacity:              1_000_000,
+				MaxPerSecond:             100_000,
+				TargetPerSecond:          50_000,
+				MinPrice:                 1,
+				ExcessConversionConstant: 2_164_043,
+			},
+			State: gas.State{
+				Capacity: 1_000_000,
+			},
+			Time: now,
+		}
+	)
+
+	// A timestamp before the fee state is treated as the fee state time.
+	require.Equal(c.MaxPrice(now), c.MaxPrice(now.Add(-time.Minute)))
+	require.LessOrEqual(c.Price(), c.MaxPrice(now))
+	require.Less(c.MaxPrice(now), c.MaxPrice(now.Add(time.Minute)))
+}
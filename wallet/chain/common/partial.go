@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/crypto/secp256k1"
+	"github.com/luxfi/ids"
+)
+
+var (
+	ErrMismatchedSigners = errors.New("partially signed txs expect different signers")
+	ErrUnknownSigner     = errors.New("signature has no known signer")
+	ErrInvalidSignature  = errors.New("signature wasn't produced by the expected signer")
+
+	emptySig [secp256k1.SignatureLen]byte
+)
+
+// MergeSignatures merges the signatures and signer addresses of another
+// partially signed tx into [signers] and [sigs], where signers[i][j] is the
+// address expected to provide sigs[i][j]. Missing signatures are empty, and
+// unknown signers are [ids.ShortEmpty].
+//
+// Every signature that is added, or whose signer becomes known, must recover to
+// its signer's address from [unsignedHash]. Either everything is merged or, if
+// an error is returned, nothing is modified.
+func MergeSignatures(
+	unsignedHash []byte,
+	signers [][]ids.ShortID,
+	sigs [][][secp256k1.SignatureLen]byte,
+	otherSigners [][]ids.ShortID,
+	otherSigs [][][secp256k1.SignatureLen]byte,
+) error {
+	if len(signers) != len(otherSigners) {
+		return ErrMismatchedSigners
+	}
+
+	mergedSigners := make([][]ids.ShortID, len(signers))
+	mergedSigs := make([][][secp256k1.SignatureLen]byte, len(sigs))
+	for credIndex, inputSigners := range signers {
+		otherInputSigners := otherSigners[credIndex]
+		if len(inputSigners) != len(otherInputSigners) {
+			return ErrMismatchedSigners
+		}
+
+		mergedSigners[credIndex] = make([]ids.ShortID, len(inputSigners))
+		mergedSigs[credIndex] = make([][secp256k1.SignatureLen]byte, len(inputSigners))
+		for sigIndex, addr := range inputSigners {
+			otherAddr := otherInputSigners[sigIndex]
+			sig := sigs[credIndex][sigIndex]
+
+			changed := false
+			switch {
+			case addr == ids.ShortEmpty && otherAddr != ids.ShortEmpty:
+				addr = otherAddr
+				changed = true
+			case otherAddr != ids.ShortEmpty && addr != otherAddr:
+				return fmt.Errorf("%w: credential %d signature %d expects %s and %s",
+					ErrMismatchedSigners,
+					credIndex,
+					sigIndex,
+					addr,
+					otherAddr,
+				)
+			}
+			if sig == emptySig {
+				sig = otherSigs[credIndex][sigIndex]
+				changed = true
+			}
+
+			if changed && sig != emptySig {
+				if err := verifySignature(unsignedHash, addr, sig); err != nil {
+					return fmt.Errorf("credential %d signature %d: %w", credIndex, sigIndex, err)
+				}
+			}
+			mergedSigners[credIndex][sigIndex] = addr
+			mergedSigs[credIndex][sigIndex] = sig
+		}
+	}
+
+	for credIndex := range signers {
+		copy(signers[credIndex], mergedSigners[credIndex])
+		copy(sigs[credIndex], mergedSigs[credIndex])
+	}
+	return nil
+}
+
+// verifySignature checks that [sig] was produced over [unsignedHash] by [addr].
+func verifySignature(
+	unsignedHash []byte,
+	addr ids.ShortID,
+	sig [secp256k1.SignatureLen]byte,
+) error {
+	if addr == ids.ShortEmpty {
+		return ErrUnknownSigner
+	}
+	pk, err := secp256k1.RecoverPublicKeyFromHash(unsignedHash, sig[:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if recovered := pk.Address(); recovered != addr {
+		return fmt.Errorf("%w: expected %s but got %s", ErrInvalidSignature, addr, recovered)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/crypto/secp256k1"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/hashing"
+)
+
+func TestMergeSignatures(t *testing.T) {
+	var (
+		keys         = secp256k1.TestKeys()
+		keyA         = keys[0]
+		keyB         = keys[1]
+		unsignedHash = hashing.ComputeHash256([]byte("unsigned tx"))
+		otherHash    = hashing.ComputeHash256([]byte("other unsigned tx"))
+	)
+	sign := func(key *secp256k1.PrivateKey, hash []byte) [secp256k1.SignatureLen]byte {
+		sig, err := key.SignHashArray(hash)
+		require.NoError(t, err)
+		return sig
+	}
+
+	tests := []struct {
+		name            string
+		signers         [][]ids.ShortID
+		sigs            [][][secp256k1.SignatureLen]byte
+		otherSigners    [][]ids.ShortID
+		otherSigs       [][][secp256k1.SignatureLen]byte
+		expectedSigners [][]ids.ShortID
+		expectedSigs    [][][secp256k1.SignatureLen]byte
+		expectedErr     error
+	}{
+		{
+			name:            "adds missing signature",
+			signers:         [][]ids.ShortID{{keyA.Address(), keyB.Address()}},
+			sigs:            [][][secp256k1.SignatureLen]byte{{sign(keyA, unsignedHash), {}}},
+			otherSigners:    [][]ids.ShortID{{keyA.Address(), keyB.Address()}},
+			otherSigs:       [][][secp256k1.SignatureLen]byte{{{}, sign(keyB, unsignedHash)}},
+			expectedSigners: [][]ids.ShortID{{keyA.Address(), keyB.Address()}},
+			expectedSigs:    [][][secp256k1.SignatureLen]byte{{sign(keyA, unsignedHash), sign(keyB, unsignedHash)}},
+		},
+		{
+			name:            "adds missing signer",
+			signers:         [][]ids.ShortID{{ids.ShortEmpty}},
+			sigs:            [][][secp256k1.SignatureLen]byte{{{}}},
+			otherSigners:    [][]ids.ShortID{{keyA.Address()}},
+			otherSigs:       [][][secp256k1.SignatureLen]byte{{sign(keyA, unsignedHash)}},
+			expectedSigners: [][]ids.ShortID{{keyA.Address()}},
+			expectedSigs:    [][][secp256k1.SignatureLen]byte{{sign(keyA, unsignedHash)}},
+		},
+		{
+			name:         "signature of the wrong signer",
+			signers:      [][]ids.ShortID{{keyA.Address()}, {keyB.Address()}},
+			sigs:         [][][secp256k1.SignatureLen]byte{{{}}, {{}}},
+			otherSigners: [][]ids.ShortID{{keyA.Address()}, {keyB.Address()}},
+			otherSigs:    [][][secp256k1.SignatureLen]byte{{sign(keyA, unsignedHash)}, {sign(keyA, unsignedHash)}},
+			expectedErr:  ErrInvalidSignature,
+		},
+		{
+			name:         "signature of the wrong tx",
+			signers:      [][]ids.ShortID{{keyA.Address()}},
+			sigs:         [][][secp256k1.SignatureLen]byte{{{}}},
+			otherSigners: [][]ids.ShortID{{keyA.Address()}},
+			otherSigs:    [][][secp256k1.SignatureLen]byte{{sign(keyA, otherHash)}},
+			expectedErr:  ErrInvalidSignature,
+		},
+		{
+			name:         "signature without a signer",
+			signers:      [][]ids.ShortID{{ids.ShortEmpty}},
+			sigs:         [][][secp256k1.SignatureLen]byte{{{}}},
+			otherSigners: [][]ids.ShortID{{ids.ShortEmpty}},
+			otherSigs:    [][][secp256k1.SignatureLen]byte{{sign(keyA, unsignedHash)}},
+			expectedErr:  ErrUnknownSigner,
+		},
+		{
+			name:         "existing signature of the wrong signer",
+			signers:      [][]ids.ShortID{{ids.ShortEmpty}},
+			sigs:         [][][secp256k1.SignatureLen]byte{{sign(keyB, unsignedHash)}},
+			otherSigners: [][]ids.ShortID{{keyA.Address()}},
+			otherSigs:    [][][secp256k1.SignatureLen]byte{{{}}},
+			expectedErr:  ErrInvalidSignature,
+		},
+		{
+			name:         "different signers",
+			signers:      [][]ids.ShortID{{keyA.Address()}},
+			sigs:         [][][secp256k1.SignatureLen]byte{{{}}},
+			otherSigners: [][]ids.ShortID{{keyB.Address()}},
+			otherSigs:    [][][secp256k1.SignatureLen]byte{{{}}},
+			expectedErr:  ErrMismatchedSigners,
+		},
+		{
+			name:         "different number of credentials",
+			signers:      [][]ids.ShortID{{keyA.Address()}},
+			sigs:         [][][secp256k1.SignatureLen]byte{{{}}},
+			otherSigners: [][]ids.ShortID{},
+			otherSigs:    [][][secp256k1.SignatureLen]byte{},
+			expectedErr:  ErrMismatchedSigners,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				initialSigners = cloneSlices(test.signers)
+				initialSigs    = cloneSlices(test.sigs)
+			)
+			err := MergeSignatures(
+				unsignedHash,
+				test.signers,
+				test.sigs,
+				test.otherSigners,
+				test.otherSigs,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				// Nothing is modified if the merge fails.
+				require.Equal(initialSigners, test.signers)
+				require.Equal(initialSigs, test.sigs)
+				return
+			}
+			require.Equal(test.expectedSigners, test.signers)
+			require.Equal(test.expectedSigs, test.sigs)
+		})
+	}
+}
+
+func cloneSlices[T any](s [][]T) [][]T {
+	clone := make([][]T, len(s))
+	for i := range s {
+		clone[i] = append([]T(nil), s[i]...)
+	}
+	return clone
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/luxfi/crypto/bls"
@@ -352,35 +353,37 @@ func (b *builder) NewBaseTx(
 	outputs []*lux.TransferableOutput,
 	options ...common.Option,
 ) (*txs.BaseTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.BaseTxFee,
-	}
-	for _, out := range outputs {
-		assetID := out.AssetID()
-		amountToBurn, err := math.Add64(toBurn[assetID], out.Out.Amount())
+	ops := common.NewOptions(options)
+	return buildWithFee(ops, b.context.BaseTxFee, func(txFee uint64) (*txs.BaseTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+		}
+		for _, out := range outputs {
+			assetID := out.AssetID()
+			amountToBurn, err := math.Add64(toBurn[assetID], out.Out.Amount())
+			if err != nil {
+				return nil, err
+			}
+			toBurn[assetID] = amountToBurn
+		}
+		toStake := map[ids.ID]uint64{}
+
+		inputs, changeOutputs, _, err := b.spend(toBurn, toStake, ops)
 		if err != nil {
 			return nil, err
 		}
-		toBurn[assetID] = amountToBurn
-	}
-	toStake := map[ids.ID]uint64{}
+		outputs := slices.Concat(outputs, changeOutputs)
+		lux.SortTransferableOutputs(outputs, txs.Codec) // sort the outputs
 
-	ops := common.NewOptions(options)
-	inputs, changeOutputs, _, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
-	}
-	outputs = append(outputs, changeOutputs...)
-	lux.SortTransferableOutputs(outputs, txs.Codec) // sort the outputs
-
-	tx := &txs.BaseTx{BaseTx: lux.BaseTx{
-		NetworkID:    b.context.NetworkID,
-		BlockchainID: b.getBlockchainID(),
-		Ins:          inputs,
-		Outs:         outputs,
-		Memo:         ops.Memo(),
-	}}
-	return tx, b.initCtx(tx)
+		tx := &txs.BaseTx{BaseTx: lux.BaseTx{
+			NetworkID:    b.context.NetworkID,
+			BlockchainID: b.getBlockchainID(),
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewAddValidatorTx(
@@ -389,73 +392,77 @@ func (b *builder) NewAddValidatorTx(
 	shares uint32,
 	options ...common.Option,
 ) (*txs.AddValidatorTx, error) {
-	luxAssetID := b.context.LUXAssetID
-	toBurn := map[ids.ID]uint64{
-		luxAssetID: b.context.AddPrimaryNetworkValidatorFee,
-	}
-	toStake := map[ids.ID]uint64{
-		luxAssetID: vdr.Wght,
-	}
 	ops := common.NewOptions(options)
-	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.AddPrimaryNetworkValidatorFee, func(txFee uint64) (*txs.AddValidatorTx, error) {
+		luxAssetID := b.context.LUXAssetID
+		toBurn := map[ids.ID]uint64{
+			luxAssetID: txFee,
+		}
+		toStake := map[ids.ID]uint64{
+			luxAssetID: vdr.Wght,
+		}
+		inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	utils.Sort(rewardsOwner.Addrs)
-	// Use BlockchainID from context if set, otherwise use default PlatformChainID
-	blockchainID := b.context.BlockchainID
-	if blockchainID == ids.Empty {
-		blockchainID = constants.PlatformChainID
-	}
-	
-	tx := &txs.AddValidatorTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: blockchainID,
-			Ins:          inputs,
-			Outs:         baseOutputs,
-			Memo:         ops.Memo(),
-		}},
-		Validator:        *vdr,
-		StakeOuts:        stakeOutputs,
-		RewardsOwner:     rewardsOwner,
-		DelegationShares: shares,
-	}
-	return tx, b.initCtx(tx)
+		utils.Sort(rewardsOwner.Addrs)
+		// Use BlockchainID from context if set, otherwise use default PlatformChainID
+		blockchainID := b.context.BlockchainID
+		if blockchainID == ids.Empty {
+			blockchainID = constants.PlatformChainID
+		}
+
+		tx := &txs.AddValidatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: blockchainID,
+				Ins:          inputs,
+				Outs:         baseOutputs,
+				Memo:         ops.Memo(),
+			}},
+			Validator:        *vdr,
+			StakeOuts:        stakeOutputs,
+			RewardsOwner:     rewardsOwner,
+			DelegationShares: shares,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewAddSubnetValidatorTx(
 	vdr *txs.SubnetValidator,
 	options ...common.Option,
 ) (*txs.AddSubnetValidatorTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.AddSubnetValidatorFee,
-	}
-	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
-	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.AddSubnetValidatorFee, func(txFee uint64) (*txs.AddSubnetValidatorTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+		}
+		toStake := map[ids.ID]uint64{}
+		inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	subnetAuth, err := b.authorizeSubnet(vdr.Subnet, ops)
-	if err != nil {
-		return nil, err
-	}
+		subnetAuth, err := b.authorizeSubnet(vdr.Subnet, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	tx := &txs.AddSubnetValidatorTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.getBlockchainID(),
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
-		SubnetValidator: *vdr,
-		SubnetAuth:      subnetAuth,
-	}
-	return tx, b.initCtx(tx)
+		tx := &txs.AddSubnetValidatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.getBlockchainID(),
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			}},
+			SubnetValidator: *vdr,
+			SubnetAuth:      subnetAuth,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewRemoveSubnetValidatorTx(
@@ -463,34 +470,36 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	subnetID ids.ID,
 	options ...common.Option,
 ) (*txs.RemoveSubnetValidatorTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.BaseTxFee,
-	}
-	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
-	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.BaseTxFee, func(txFee uint64) (*txs.RemoveSubnetValidatorTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+		}
+		toStake := map[ids.ID]uint64{}
+		inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
-	if err != nil {
-		return nil, err
-	}
+		subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	tx := &txs.RemoveSubnetValidatorTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.getBlockchainID(),
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
-		Subnet:     subnetID,
-		NodeID:     nodeID,
-		SubnetAuth: subnetAuth,
-	}
-	return tx, b.initCtx(tx)
+		tx := &txs.RemoveSubnetValidatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.getBlockchainID(),
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			}},
+			Subnet:     subnetID,
+			NodeID:     nodeID,
+			SubnetAuth: subnetAuth,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewAddDelegatorTx(
@@ -498,33 +507,35 @@ func (b *builder) NewAddDelegatorTx(
 	rewardsOwner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.AddDelegatorTx, error) {
-	luxAssetID := b.context.LUXAssetID
-	toBurn := map[ids.ID]uint64{
-		luxAssetID: b.context.AddPrimaryNetworkDelegatorFee,
-	}
-	toStake := map[ids.ID]uint64{
-		luxAssetID: vdr.Wght,
-	}
 	ops := common.NewOptions(options)
-	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.AddPrimaryNetworkDelegatorFee, func(txFee uint64) (*txs.AddDelegatorTx, error) {
+		luxAssetID := b.context.LUXAssetID
+		toBurn := map[ids.ID]uint64{
+			luxAssetID: txFee,
+		}
+		toStake := map[ids.ID]uint64{
+			luxAssetID: vdr.Wght,
+		}
+		inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	utils.Sort(rewardsOwner.Addrs)
-	tx := &txs.AddDelegatorTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.getBlockchainID(),
-			Ins:          inputs,
-			Outs:         baseOutputs,
-			Memo:         ops.Memo(),
-		}},
-		Validator:              *vdr,
-		StakeOuts:              stakeOutputs,
-		DelegationRewardsOwner: rewardsOwner,
-	}
-	return tx, b.initCtx(tx)
+		utils.Sort(rewardsOwner.Addrs)
+		tx := &txs.AddDelegatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.getBlockchainID(),
+				Ins:          inputs,
+				Outs:         baseOutputs,
+				Memo:         ops.Memo(),
+			}},
+			Validator:              *vdr,
+			StakeOuts:              stakeOutputs,
+			DelegationRewardsOwner: rewardsOwner,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewCreateChainTx(
@@ -535,66 +546,70 @@ func (b *builder) NewCreateChainTx(
 	chainName string,
 	options ...common.Option,
 ) (*txs.CreateChainTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.CreateBlockchainTxFee,
-	}
-	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
-	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.CreateBlockchainTxFee, func(txFee uint64) (*txs.CreateChainTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+		}
+		toStake := map[ids.ID]uint64{}
+		inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
-	if err != nil {
-		return nil, err
-	}
+		subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	utils.Sort(fxIDs)
-	tx := &txs.CreateChainTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.getBlockchainID(),
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
-		SubnetID:    subnetID,
-		ChainName:   chainName,
-		VMID:        vmID,
-		FxIDs:       fxIDs,
-		GenesisData: genesis,
-		SubnetAuth:  subnetAuth,
-	}
-	return tx, b.initCtx(tx)
+		utils.Sort(fxIDs)
+		tx := &txs.CreateChainTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.getBlockchainID(),
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			}},
+			SubnetID:    subnetID,
+			ChainName:   chainName,
+			VMID:        vmID,
+			FxIDs:       fxIDs,
+			GenesisData: genesis,
+			SubnetAuth:  subnetAuth,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewCreateSubnetTx(
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.CreateSubnetTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.CreateSubnetTxFee,
-	}
-	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
-	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.CreateSubnetTxFee, func(txFee uint64) (*txs.CreateSubnetTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+		}
+		toStake := map[ids.ID]uint64{}
+		inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	utils.Sort(owner.Addrs)
-	tx := &txs.CreateSubnetTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.getBlockchainID(),
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
-		Owner: owner,
-	}
-	return tx, b.initCtx(tx)
+		utils.Sort(owner.Addrs)
+		tx := &txs.CreateSubnetTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.getBlockchainID(),
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			}},
+			Owner: owner,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewTransferSubnetOwnershipTx(
@@ -602,35 +617,37 @@ func (b *builder) NewTransferSubnetOwnershipTx(
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.TransferSubnetOwnershipTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.BaseTxFee,
-	}
-	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
-	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.BaseTxFee, func(txFee uint64) (*txs.TransferSubnetOwnershipTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+		}
+		toStake := map[ids.ID]uint64{}
+		inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
-	if err != nil {
-		return nil, err
-	}
+		subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	utils.Sort(owner.Addrs)
-	tx := &txs.TransferSubnetOwnershipTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.getBlockchainID(),
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
-		Subnet:     subnetID,
-		Owner:      owner,
-		SubnetAuth: subnetAuth,
-	}
-	return tx, b.initCtx(tx)
+		utils.Sort(owner.Addrs)
+		tx := &txs.TransferSubnetOwnershipTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.getBlockchainID(),
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			}},
+			Subnet:     subnetID,
+			Owner:      owner,
+			SubnetAuth: subnetAuth,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewImportTx(
@@ -639,105 +656,106 @@ func (b *builder) NewImportTx(
 	options ...common.Option,
 ) (*txs.ImportTx, error) {
 	ops := common.NewOptions(options)
-	utxos, err := b.backend.UTXOs(ops.Context(), sourceChainID)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.BaseTxFee, func(txFee uint64) (*txs.ImportTx, error) {
+		utxos, err := b.backend.UTXOs(ops.Context(), sourceChainID)
+		if err != nil {
+			return nil, err
+		}
 
-	var (
-		addrs           = ops.Addresses(b.addrs)
-		minIssuanceTime = ops.MinIssuanceTime()
-		luxAssetID      = b.context.LUXAssetID
-		txFee           = b.context.BaseTxFee
+		var (
+			addrs           = ops.Addresses(b.addrs)
+			minIssuanceTime = ops.MinIssuanceTime()
+			luxAssetID      = b.context.LUXAssetID
 
-		importedInputs  = make([]*lux.TransferableInput, 0, len(utxos))
-		importedAmounts = make(map[ids.ID]uint64)
-	)
-	// Iterate over the unlocked UTXOs
-	for _, utxo := range utxos {
-		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
-		if !ok {
-			continue
-		}
+			importedInputs  = make([]*lux.TransferableInput, 0, len(utxos))
+			importedAmounts = make(map[ids.ID]uint64)
+		)
+		// Iterate over the unlocked UTXOs
+		for _, utxo := range utxos {
+			out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+			if !ok {
+				continue
+			}
 
-		inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
-		if !ok {
-			// We couldn't spend this UTXO, so we skip to the next one
-			continue
-		}
+			inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+			if !ok {
+				// We couldn't spend this UTXO, so we skip to the next one
+				continue
+			}
 
-		importedInputs = append(importedInputs, &lux.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  utxo.Asset,
-			In: &secp256k1fx.TransferInput{
-				Amt: out.Amt,
-				Input: secp256k1fx.Input{
-					SigIndices: inputSigIndices,
+			importedInputs = append(importedInputs, &lux.TransferableInput{
+				UTXOID: utxo.UTXOID,
+				Asset:  utxo.Asset,
+				In: &secp256k1fx.TransferInput{
+					Amt: out.Amt,
+					Input: secp256k1fx.Input{
+						SigIndices: inputSigIndices,
+					},
 				},
-			},
-		})
+			})
 
-		assetID := utxo.AssetID()
-		newImportedAmount, err := math.Add64(importedAmounts[assetID], out.Amt)
-		if err != nil {
-			return nil, err
+			assetID := utxo.AssetID()
+			newImportedAmount, err := math.Add64(importedAmounts[assetID], out.Amt)
+			if err != nil {
+				return nil, err
+			}
+			importedAmounts[assetID] = newImportedAmount
 		}
-		importedAmounts[assetID] = newImportedAmount
-	}
-	utils.Sort(importedInputs) // sort imported inputs
+		utils.Sort(importedInputs) // sort imported inputs
 
-	if len(importedInputs) == 0 {
-		return nil, fmt.Errorf(
-			"%w: no UTXOs available to import",
-			ErrInsufficientFunds,
-		)
-	}
+		if len(importedInputs) == 0 {
+			return nil, fmt.Errorf(
+				"%w: no UTXOs available to import",
+				ErrInsufficientFunds,
+			)
+		}
 
-	var (
-		inputs      []*lux.TransferableInput
-		outputs     = make([]*lux.TransferableOutput, 0, len(importedAmounts))
-		importedLUX = importedAmounts[luxAssetID]
-	)
-	if importedLUX > txFee {
-		importedAmounts[luxAssetID] -= txFee
-	} else {
-		if importedLUX < txFee { // imported amount goes toward paying tx fee
-			toBurn := map[ids.ID]uint64{
-				luxAssetID: txFee - importedLUX,
-			}
-			toStake := map[ids.ID]uint64{}
-			var err error
-			inputs, outputs, _, err = b.spend(toBurn, toStake, ops)
-			if err != nil {
-				return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		var (
+			inputs      []*lux.TransferableInput
+			outputs     = make([]*lux.TransferableOutput, 0, len(importedAmounts))
+			importedLUX = importedAmounts[luxAssetID]
+		)
+		if importedLUX > txFee {
+			importedAmounts[luxAssetID] -= txFee
+		} else {
+			if importedLUX < txFee { // imported amount goes toward paying tx fee
+				toBurn := map[ids.ID]uint64{
+					luxAssetID: txFee - importedLUX,
+				}
+				toStake := map[ids.ID]uint64{}
+				var err error
+				inputs, outputs, _, err = b.spend(toBurn, toStake, ops)
+				if err != nil {
+					return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+				}
 			}
+			delete(importedAmounts, luxAssetID)
 		}
-		delete(importedAmounts, luxAssetID)
-	}
 
-	for assetID, amount := range importedAmounts {
-		outputs = append(outputs, &lux.TransferableOutput{
-			Asset: lux.Asset{ID: assetID},
-			Out: &secp256k1fx.TransferOutput{
-				Amt:          amount,
-				OutputOwners: *to,
-			},
-		})
-	}
+		for assetID, amount := range importedAmounts {
+			outputs = append(outputs, &lux.TransferableOutput{
+				Asset: lux.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          amount,
+					OutputOwners: *to,
+				},
+			})
+		}
 
-	lux.SortTransferableOutputs(outputs, txs.Codec) // sort imported outputs
-	tx := &txs.ImportTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.getBlockchainID(),
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
-		SourceChain:    sourceChainID,
-		ImportedInputs: importedInputs,
-	}
-	return tx, b.initCtx(tx)
+		lux.SortTransferableOutputs(outputs, txs.Codec) // sort imported outputs
+		tx := &txs.ImportTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.getBlockchainID(),
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			}},
+			SourceChain:    sourceChainID,
+			ImportedInputs: importedInputs,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewExportTx(
@@ -745,38 +763,40 @@ func (b *builder) NewExportTx(
 	outputs []*lux.TransferableOutput,
 	options ...common.Option,
 ) (*txs.ExportTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.BaseTxFee,
-	}
-	for _, out := range outputs {
-		assetID := out.AssetID()
-		amountToBurn, err := math.Add64(toBurn[assetID], out.Out.Amount())
+	ops := common.NewOptions(options)
+	return buildWithFee(ops, b.context.BaseTxFee, func(txFee uint64) (*txs.ExportTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+		}
+		for _, out := range outputs {
+			assetID := out.AssetID()
+			amountToBurn, err := math.Add64(toBurn[assetID], out.Out.Amount())
+			if err != nil {
+				return nil, err
+			}
+			toBurn[assetID] = amountToBurn
+		}
+
+		toStake := map[ids.ID]uint64{}
+		inputs, changeOutputs, _, err := b.spend(toBurn, toStake, ops)
 		if err != nil {
 			return nil, err
 		}
-		toBurn[assetID] = amountToBurn
-	}
-
-	toStake := map[ids.ID]uint64{}
-	ops := common.NewOptions(options)
-	inputs, changeOutputs, _, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
-	}
 
-	lux.SortTransferableOutputs(outputs, txs.Codec) // sort exported outputs
-	tx := &txs.ExportTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.getBlockchainID(),
-			Ins:          inputs,
-			Outs:         changeOutputs,
-			Memo:         ops.Memo(),
-		}},
-		DestinationChain: chainID,
-		ExportedOutputs:  outputs,
-	}
-	return tx, b.initCtx(tx)
+		lux.SortTransferableOutputs(outputs, txs.Codec) // sort exported outputs
+		tx := &txs.ExportTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.getBlockchainID(),
+				Ins:          inputs,
+				Outs:         changeOutputs,
+				Memo:         ops.Memo(),
+			}},
+			DestinationChain: chainID,
+			ExportedOutputs:  outputs,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewTransformSubnetTx(
@@ -796,47 +816,49 @@ func (b *builder) NewTransformSubnetTx(
 	uptimeRequirement uint32,
 	options ...common.Option,
 ) (*txs.TransformSubnetTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.TransformSubnetTxFee,
-		assetID:              maxSupply - initialSupply,
-	}
-	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
-	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.TransformSubnetTxFee, func(txFee uint64) (*txs.TransformSubnetTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+			assetID:              maxSupply - initialSupply,
+		}
+		toStake := map[ids.ID]uint64{}
+		inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
-	if err != nil {
-		return nil, err
-	}
+		subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	tx := &txs.TransformSubnetTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.getBlockchainID(),
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
-		Subnet:                   subnetID,
-		AssetID:                  assetID,
-		InitialSupply:            initialSupply,
-		MaximumSupply:            maxSupply,
-		MinConsumptionRate:       minConsumptionRate,
-		MaxConsumptionRate:       maxConsumptionRate,
-		MinValidatorStake:        minValidatorStake,
-		MaxValidatorStake:        maxValidatorStake,
-		MinStakeDuration:         uint32(minStakeDuration / time.Second),
-		MaxStakeDuration:         uint32(maxStakeDuration / time.Second),
-		MinDelegationFee:         minDelegationFee,
-		MinDelegatorStake:        minDelegatorStake,
-		MaxValidatorWeightFactor: maxValidatorWeightFactor,
-		UptimeRequirement:        uptimeRequirement,
-		SubnetAuth:               subnetAuth,
-	}
-	return tx, b.initCtx(tx)
+		tx := &txs.TransformSubnetTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.getBlockchainID(),
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			}},
+			Subnet:                   subnetID,
+			AssetID:                  assetID,
+			InitialSupply:            initialSupply,
+			MaximumSupply:            maxSupply,
+			MinConsumptionRate:       minConsumptionRate,
+			MaxConsumptionRate:       maxConsumptionRate,
+			MinValidatorStake:        minValidatorStake,
+			MaxValidatorStake:        maxValidatorStake,
+			MinStakeDuration:         uint32(minStakeDuration / time.Second),
+			MaxStakeDuration:         uint32(maxStakeDuration / time.Second),
+			MinDelegationFee:         minDelegationFee,
+			MinDelegatorStake:        minDelegatorStake,
+			MaxValidatorWeightFactor: maxValidatorWeightFactor,
+			UptimeRequirement:        uptimeRequirement,
+			SubnetAuth:               subnetAuth,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewAddPermissionlessValidatorTx(
@@ -848,41 +870,44 @@ func (b *builder) NewAddPermissionlessValidatorTx(
 	shares uint32,
 	options ...common.Option,
 ) (*txs.AddPermissionlessValidatorTx, error) {
-	luxAssetID := b.context.LUXAssetID
-	toBurn := map[ids.ID]uint64{}
-	if vdr.Subnet == constants.PrimaryNetworkID {
-		toBurn[luxAssetID] = b.context.AddPrimaryNetworkValidatorFee
-	} else {
-		toBurn[luxAssetID] = b.context.AddSubnetValidatorFee
-	}
-	toStake := map[ids.ID]uint64{
-		assetID: vdr.Wght,
-	}
 	ops := common.NewOptions(options)
-	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
+	staticFee := b.context.AddSubnetValidatorFee
+	if vdr.Subnet == constants.PrimaryNetworkID {
+		staticFee = b.context.AddPrimaryNetworkValidatorFee
 	}
+	return buildWithFee(ops, staticFee, func(txFee uint64) (*txs.AddPermissionlessValidatorTx, error) {
+		luxAssetID := b.context.LUXAssetID
+		toBurn := map[ids.ID]uint64{
+			luxAssetID: txFee,
+		}
+		toStake := map[ids.ID]uint64{
+			assetID: vdr.Wght,
+		}
+		inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	utils.Sort(validationRewardsOwner.Addrs)
-	utils.Sort(delegationRewardsOwner.Addrs)
-	tx := &txs.AddPermissionlessValidatorTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.getBlockchainID(),
-			Ins:          inputs,
-			Outs:         baseOutputs,
-			Memo:         ops.Memo(),
-		}},
-		Validator:             vdr.Validator,
-		Subnet:                vdr.Subnet,
-		Signer:                signer,
-		StakeOuts:             stakeOutputs,
-		ValidatorRewardsOwner: validationRewardsOwner,
-		DelegatorRewardsOwner: delegationRewardsOwner,
-		DelegationShares:      shares,
-	}
-	return tx, b.initCtx(tx)
+		utils.Sort(validationRewardsOwner.Addrs)
+		utils.Sort(delegationRewardsOwner.Addrs)
+		tx := &txs.AddPermissionlessValidatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.getBlockchainID(),
+				Ins:          inputs,
+				Outs:         baseOutputs,
+				Memo:         ops.Memo(),
+			}},
+			Validator:             vdr.Validator,
+			Subnet:                vdr.Subnet,
+			Signer:                signer,
+			StakeOuts:             stakeOutputs,
+			ValidatorRewardsOwner: validationRewardsOwner,
+			DelegatorRewardsOwner: delegationRewardsOwner,
+			DelegationShares:      shares,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewAddPermissionlessDelegatorTx(
@@ -891,37 +916,40 @@ func (b *builder) NewAddPermissionlessDelegatorTx(
 	rewardsOwner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.AddPermissionlessDelegatorTx, error) {
-	luxAssetID := b.context.LUXAssetID
-	toBurn := map[ids.ID]uint64{}
-	if vdr.Subnet == constants.PrimaryNetworkID {
-		toBurn[luxAssetID] = b.context.AddPrimaryNetworkDelegatorFee
-	} else {
-		toBurn[luxAssetID] = b.context.AddSubnetDelegatorFee
-	}
-	toStake := map[ids.ID]uint64{
-		assetID: vdr.Wght,
-	}
 	ops := common.NewOptions(options)
-	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
-	if err != nil {
-		return nil, err
+	staticFee := b.context.AddSubnetDelegatorFee
+	if vdr.Subnet == constants.PrimaryNetworkID {
+		staticFee = b.context.AddPrimaryNetworkDelegatorFee
 	}
+	return buildWithFee(ops, staticFee, func(txFee uint64) (*txs.AddPermissionlessDelegatorTx, error) {
+		luxAssetID := b.context.LUXAssetID
+		toBurn := map[ids.ID]uint64{
+			luxAssetID: txFee,
+		}
+		toStake := map[ids.ID]uint64{
+			assetID: vdr.Wght,
+		}
+		inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	utils.Sort(rewardsOwner.Addrs)
-	tx := &txs.AddPermissionlessDelegatorTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.getBlockchainID(),
-			Ins:          inputs,
-			Outs:         baseOutputs,
-			Memo:         ops.Memo(),
-		}},
-		Validator:              vdr.Validator,
-		Subnet:                 vdr.Subnet,
-		StakeOuts:              stakeOutputs,
-		DelegationRewardsOwner: rewardsOwner,
-	}
-	return tx, b.initCtx(tx)
+		utils.Sort(rewardsOwner.Addrs)
+		tx := &txs.AddPermissionlessDelegatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.getBlockchainID(),
+				Ins:          inputs,
+				Outs:         baseOutputs,
+				Memo:         ops.Memo(),
+			}},
+			Validator:              vdr.Validator,
+			Subnet:                 vdr.Subnet,
+			StakeOuts:              stakeOutputs,
+			DelegationRewardsOwner: rewardsOwner,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) getBalance(
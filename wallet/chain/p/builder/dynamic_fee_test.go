@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/node/utils/constants"
+	"github.com/luxfi/node/vms/components/gas"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/platformvm/txs"
+
+	chaincommon "github.com/luxfi/node/wallet/chain/common"
+)
+
+func TestDynamicFeeContextEstimateFee(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		now = time.Unix(1_000, 0)
+		c   = &chaincommon.DynamicFeeContext{
+			Config: gas.Config{
+				Weights: gas.Dimensions{
+					gas.Bandwidth: 1,
+					gas.DBRead:    1_000,
+					gas.DBWrite:   1_000,
+					gas.Compute:   4,
+				},
+				MaxCapacity:              1_000_000,
+				MaxPerSecond:             100_000,
+				TargetPerSecond:          50_000,
+				MinPrice:                 1,
+				ExcessConversionConstant: 2_164_043,
+			},
+			State: gas.State{
+				Capacity: 1_000_000,
+			},
+			Time: now,
+		}
+		utx = &txs.BaseTx{
+			BaseTx: lux.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: constants.PlatformChainID,
+			},
+		}
+	)
+
+	currentFee, err := EstimateFee(c, utx, now)
+	require.NoError(err)
+	laterFee, err := EstimateFee(c, utx, now.Add(time.Minute))
+	require.NoError(err)
+	require.Less(currentFee, laterFee)
+}
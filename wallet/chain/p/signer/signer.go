@@ -45,12 +45,14 @@ func New(kc keychain.Keychain, backend Backend) Signer {
 }
 
 func (s *txSigner) Sign(ctx stdcontext.Context, tx *txs.Tx) error {
-	return tx.Unsigned.Visit(&visitor{
-		kc:      s.kc,
+	v := &visitor{
 		backend: s.backend,
 		ctx:     ctx,
-		tx:      tx,
-	})
+	}
+	if err := tx.Unsigned.Visit(v); err != nil {
+		return err
+	}
+	return sign(tx, v.signHash, getKeys(s.kc, v.signers))
 }
 
 func SignUnsigned(
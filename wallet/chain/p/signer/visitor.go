@@ -35,12 +35,20 @@ var (
 	emptySig [secp256k1.SignatureLen]byte
 )
 
-// visitor handles signing transactions for the signer
+// visitor determines, for every credential of a transaction, the addresses
+// that are expected to provide each of its signatures.
+//
+// If an address can't be determined, because the UTXO or owner isn't known by
+// the backend, the address is left empty.
 type visitor struct {
-	kc      keychain.Keychain
 	backend Backend
 	ctx     context.Context
-	tx      *txs.Tx
+
+	// signHash is true if the signatures must be produced over the hash of
+	// the unsigned tx rather than the unsigned tx bytes.
+	signHash bool
+	// signers[credIndex][sigIndex] is the address expected to sign.
+	signers [][]ids.ShortID
 }
 
 func (*visitor) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
@@ -56,7 +64,7 @@ func (s *visitor) BaseTx(tx *txs.BaseTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, false, txSigners)
+	return s.record(false, txSigners)
 }
 
 func (s *visitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
@@ -64,7 +72,7 @@ func (s *visitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, false, txSigners)
+	return s.record(false, txSigners)
 }
 
 func (s *visitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
@@ -77,7 +85,7 @@ func (s *visitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
 		return err
 	}
 	txSigners = append(txSigners, subnetAuthSigners)
-	return sign(s.tx, false, txSigners)
+	return s.record(false, txSigners)
 }
 
 func (s *visitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
@@ -85,7 +93,7 @@ func (s *visitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, false, txSigners)
+	return s.record(false, txSigners)
 }
 
 func (s *visitor) CreateChainTx(tx *txs.CreateChainTx) error {
@@ -98,7 +106,7 @@ func (s *visitor) CreateChainTx(tx *txs.CreateChainTx) error {
 		return err
 	}
 	txSigners = append(txSigners, subnetAuthSigners)
-	return sign(s.tx, false, txSigners)
+	return s.record(false, txSigners)
 }
 
 func (s *visitor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
@@ -106,7 +114,7 @@ func (s *visitor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, false, txSigners)
+	return s.record(false, txSigners)
 }
 
 func (s *visitor) ImportTx(tx *txs.ImportTx) error {
@@ -119,7 +127,7 @@ func (s *visitor) ImportTx(tx *txs.ImportTx) error {
 		return err
 	}
 	txSigners = append(txSigners, txImportSigners...)
-	return sign(s.tx, false, txSigners)
+	return s.record(false, txSigners)
 }
 
 func (s *visitor) ExportTx(tx *txs.ExportTx) error {
@@ -127,7 +135,7 @@ func (s *visitor) ExportTx(tx *txs.ExportTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, false, txSigners)
+	return s.record(false, txSigners)
 }
 
 func (s *visitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
@@ -140,7 +148,7 @@ func (s *visitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error
 		return err
 	}
 	txSigners = append(txSigners, subnetAuthSigners)
-	return sign(s.tx, true, txSigners)
+	return s.record(true, txSigners)
 }
 
 func (s *visitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
@@ -153,7 +161,7 @@ func (s *visitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) e
 		return err
 	}
 	txSigners = append(txSigners, subnetAuthSigners)
-	return sign(s.tx, true, txSigners)
+	return s.record(true, txSigners)
 }
 
 func (s *visitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
@@ -166,7 +174,7 @@ func (s *visitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
 		return err
 	}
 	txSigners = append(txSigners, subnetAuthSigners)
-	return sign(s.tx, true, txSigners)
+	return s.record(true, txSigners)
 }
 
 func (s *visitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
@@ -174,7 +182,7 @@ func (s *visitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidato
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, true, txSigners)
+	return s.record(true, txSigners)
 }
 
 func (s *visitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
@@ -182,7 +190,7 @@ func (s *visitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegato
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, true, txSigners)
+	return s.record(true, txSigners)
 }
 
 func (s *visitor) DisableL1ValidatorTx(tx *txs.DisableL1ValidatorTx) error {
@@ -190,7 +198,7 @@ func (s *visitor) DisableL1ValidatorTx(tx *txs.DisableL1ValidatorTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, true, txSigners)
+	return s.record(true, txSigners)
 }
 
 func (s *visitor) IncreaseL1ValidatorBalanceTx(tx *txs.IncreaseL1ValidatorBalanceTx) error {
@@ -198,7 +206,7 @@ func (s *visitor) IncreaseL1ValidatorBalanceTx(tx *txs.IncreaseL1ValidatorBalanc
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, true, txSigners)
+	return s.record(true, txSigners)
 }
 
 func (s *visitor) RegisterL1ValidatorTx(tx *txs.RegisterL1ValidatorTx) error {
@@ -206,7 +214,7 @@ func (s *visitor) RegisterL1ValidatorTx(tx *txs.RegisterL1ValidatorTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, true, txSigners)
+	return s.record(true, txSigners)
 }
 
 func (s *visitor) SetL1ValidatorWeightTx(tx *txs.SetL1ValidatorWeightTx) error {
@@ -214,7 +222,7 @@ func (s *visitor) SetL1ValidatorWeightTx(tx *txs.SetL1ValidatorWeightTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, true, txSigners)
+	return s.record(true, txSigners)
 }
 
 func (s *visitor) ConvertSubnetToL1Tx(tx *txs.ConvertSubnetToL1Tx) error {
@@ -227,11 +235,11 @@ func (s *visitor) ConvertSubnetToL1Tx(tx *txs.ConvertSubnetToL1Tx) error {
 		return err
 	}
 	txSigners = append(txSigners, subnetAuthSigners)
-	return sign(s.tx, true, txSigners)
+	return s.record(true, txSigners)
 }
 
-func (s *visitor) getSigners(sourceChainID ids.ID, ins []*lux.TransferableInput) ([][]keychain.Signer, error) {
-	txSigners := make([][]keychain.Signer, len(ins))
+func (s *visitor) getSigners(sourceChainID ids.ID, ins []*lux.TransferableInput) ([][]ids.ShortID, error) {
+	txSigners := make([][]ids.ShortID, len(ins))
 	for credIndex, transferInput := range ins {
 		inIntf := transferInput.In
 		if stakeableIn, ok := inIntf.(*stakeable.LockIn); ok {
@@ -243,7 +251,7 @@ func (s *visitor) getSigners(sourceChainID ids.ID, ins []*lux.TransferableInput)
 			return nil, ErrUnknownInputType
 		}
 
-		inputSigners := make([]keychain.Signer, len(input.SigIndices))
+		inputSigners := make([]ids.ShortID, len(input.SigIndices))
 		txSigners[credIndex] = inputSigners
 
 		utxoID := transferInput.InputID()
@@ -272,20 +280,13 @@ func (s *visitor) getSigners(sourceChainID ids.ID, ins []*lux.TransferableInput)
 				return nil, ErrInvalidUTXOSigIndex
 			}
 
-			addr := out.Addrs[addrIndex]
-			key, ok := s.kc.Get(addr)
-			if !ok {
-				// If we don't have access to the key, then we can't sign this
-				// transaction. However, we can attempt to partially sign it.
-				continue
-			}
-			inputSigners[sigIndex] = key
+			inputSigners[sigIndex] = out.Addrs[addrIndex]
 		}
 	}
 	return txSigners, nil
 }
 
-func (s *visitor) getSubnetSigners(subnetID ids.ID, subnetAuth verify.Verifiable) ([]keychain.Signer, error) {
+func (s *visitor) getSubnetSigners(subnetID ids.ID, subnetAuth verify.Verifiable) ([]ids.ShortID, error) {
 	subnetInput, ok := subnetAuth.(*secp256k1fx.Input)
 	if !ok {
 		return nil, ErrUnknownSubnetAuthType
@@ -304,22 +305,43 @@ func (s *visitor) getSubnetSigners(subnetID ids.ID, subnetAuth verify.Verifiable
 		return nil, ErrUnknownOwnerType
 	}
 
-	authSigners := make([]keychain.Signer, len(subnetInput.SigIndices))
+	authSigners := make([]ids.ShortID, len(subnetInput.SigIndices))
 	for sigIndex, addrIndex := range subnetInput.SigIndices {
 		if addrIndex >= uint32(len(owner.Addrs)) {
 			return nil, ErrInvalidUTXOSigIndex
 		}
+		authSigners[sigIndex] = owner.Addrs[addrIndex]
+	}
+	return authSigners, nil
+}
 
-		addr := owner.Addrs[addrIndex]
-		key, ok := s.kc.Get(addr)
-		if !ok {
-			// If we don't have access to the key, then we can't sign this
-			// transaction. However, we can attempt to partially sign it.
-			continue
+func (s *visitor) record(signHash bool, txSigners [][]ids.ShortID) error {
+	s.signHash = signHash
+	s.signers = txSigners
+	return nil
+}
+
+// getKeys returns the signers in [kc] for the provided addresses. If [kc]
+// doesn't have access to a key, then the corresponding signer is left nil.
+func getKeys(kc keychain.Keychain, txSigners [][]ids.ShortID) [][]keychain.Signer {
+	txKeys := make([][]keychain.Signer, len(txSigners))
+	for credIndex, inputSigners := range txSigners {
+		inputKeys := make([]keychain.Signer, len(inputSigners))
+		txKeys[credIndex] = inputKeys
+		for sigIndex, addr := range inputSigners {
+			if addr == ids.ShortEmpty {
+				continue
+			}
+			key, ok := kc.Get(addr)
+			if !ok {
+				// If we don't have access to the key, then we can't sign this
+				// transaction. However, we can attempt to partially sign it.
+				continue
+			}
+			inputKeys[sigIndex] = key
 		}
-		authSigners[sigIndex] = key
 	}
-	return authSigners, nil
+	return txKeys
 }
 
 func sign(tx *txs.Tx, signHash bool, txSigners [][]keychain.Signer) error {
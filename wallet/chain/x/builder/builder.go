@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/luxfi/ids"
 	"github.com/luxfi/node/utils"
@@ -208,34 +209,36 @@ func (b *builder) NewBaseTx(
 	outputs []*lux.TransferableOutput,
 	options ...common.Option,
 ) (*txs.BaseTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.BaseTxFee,
-	}
-	for _, out := range outputs {
-		assetID := out.AssetID()
-		amountToBurn, err := math.Add64(toBurn[assetID], out.Out.Amount())
+	ops := common.NewOptions(options)
+	return buildWithFee(ops, b.context.BaseTxFee, func(txFee uint64) (*txs.BaseTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+		}
+		for _, out := range outputs {
+			assetID := out.AssetID()
+			amountToBurn, err := math.Add64(toBurn[assetID], out.Out.Amount())
+			if err != nil {
+				return nil, err
+			}
+			toBurn[assetID] = amountToBurn
+		}
+
+		inputs, changeOutputs, err := b.spend(toBurn, ops)
 		if err != nil {
 			return nil, err
 		}
-		toBurn[assetID] = amountToBurn
-	}
+		outputs := slices.Concat(outputs, changeOutputs)
+		lux.SortTransferableOutputs(outputs, Parser.Codec()) // sort the outputs
 
-	ops := common.NewOptions(options)
-	inputs, changeOutputs, err := b.spend(toBurn, ops)
-	if err != nil {
-		return nil, err
-	}
-	outputs = append(outputs, changeOutputs...)
-	lux.SortTransferableOutputs(outputs, Parser.Codec()) // sort the outputs
-
-	tx := &txs.BaseTx{BaseTx: lux.BaseTx{
-		NetworkID:    b.context.NetworkID,
-		BlockchainID: b.context.BlockchainID,
-		Ins:          inputs,
-		Outs:         outputs,
-		Memo:         ops.Memo(),
-	}}
-	return tx, b.initCtx(tx)
+		tx := &txs.BaseTx{BaseTx: lux.BaseTx{
+			NetworkID:    b.context.NetworkID,
+			BlockchainID: b.context.BlockchainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewCreateAssetTx(
@@ -245,69 +248,73 @@ func (b *builder) NewCreateAssetTx(
 	initialState map[uint32][]verify.State,
 	options ...common.Option,
 ) (*txs.CreateAssetTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.CreateAssetTxFee,
-	}
 	ops := common.NewOptions(options)
-	inputs, outputs, err := b.spend(toBurn, ops)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.CreateAssetTxFee, func(txFee uint64) (*txs.CreateAssetTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+		}
+		inputs, outputs, err := b.spend(toBurn, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	codec := Parser.Codec()
-	states := make([]*txs.InitialState, 0, len(initialState))
-	for fxIndex, outs := range initialState {
-		state := &txs.InitialState{
-			FxIndex: fxIndex,
-			FxID:    fxIndexToID[fxIndex],
-			Outs:    outs,
+		codec := Parser.Codec()
+		states := make([]*txs.InitialState, 0, len(initialState))
+		for fxIndex, outs := range initialState {
+			state := &txs.InitialState{
+				FxIndex: fxIndex,
+				FxID:    fxIndexToID[fxIndex],
+				Outs:    outs,
+			}
+			state.Sort(codec) // sort the outputs
+			states = append(states, state)
 		}
-		state.Sort(codec) // sort the outputs
-		states = append(states, state)
-	}
 
-	utils.Sort(states) // sort the initial states
-	tx := &txs.CreateAssetTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.context.BlockchainID,
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
-		Name:         name,
-		Symbol:       symbol,
-		Denomination: denomination,
-		States:       states,
-	}
-	return tx, b.initCtx(tx)
+		utils.Sort(states) // sort the initial states
+		tx := &txs.CreateAssetTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.context.BlockchainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			}},
+			Name:         name,
+			Symbol:       symbol,
+			Denomination: denomination,
+			States:       states,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewOperationTx(
 	operations []*txs.Operation,
 	options ...common.Option,
 ) (*txs.OperationTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.BaseTxFee,
-	}
 	ops := common.NewOptions(options)
-	inputs, outputs, err := b.spend(toBurn, ops)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.BaseTxFee, func(txFee uint64) (*txs.OperationTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+		}
+		inputs, outputs, err := b.spend(toBurn, ops)
+		if err != nil {
+			return nil, err
+		}
 
-	txs.SortOperations(operations, Parser.Codec())
-	tx := &txs.OperationTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.context.BlockchainID,
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
-		Ops: operations,
-	}
-	return tx, b.initCtx(tx)
+		txs.SortOperations(operations, Parser.Codec())
+		tx := &txs.OperationTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.context.BlockchainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			}},
+			Ops: operations,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewOperationTxMintFT(
@@ -367,107 +374,108 @@ func (b *builder) NewImportTx(
 	options ...common.Option,
 ) (*txs.ImportTx, error) {
 	ops := common.NewOptions(options)
-	utxos, err := b.backend.UTXOs(ops.Context(), chainID)
-	if err != nil {
-		return nil, err
-	}
+	return buildWithFee(ops, b.context.BaseTxFee, func(txFee uint64) (*txs.ImportTx, error) {
+		utxos, err := b.backend.UTXOs(ops.Context(), chainID)
+		if err != nil {
+			return nil, err
+		}
 
-	var (
-		addrs           = ops.Addresses(b.addrs)
-		minIssuanceTime = ops.MinIssuanceTime()
-		luxAssetID      = b.context.LUXAssetID
-		txFee           = b.context.BaseTxFee
+		var (
+			addrs           = ops.Addresses(b.addrs)
+			minIssuanceTime = ops.MinIssuanceTime()
+			luxAssetID      = b.context.LUXAssetID
 
-		importedInputs  = make([]*lux.TransferableInput, 0, len(utxos))
-		importedAmounts = make(map[ids.ID]uint64)
-	)
-	// Iterate over the unlocked UTXOs
-	for _, utxo := range utxos {
-		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
-		if !ok {
-			// Can't import an unknown transfer output type
-			continue
-		}
+			importedInputs  = make([]*lux.TransferableInput, 0, len(utxos))
+			importedAmounts = make(map[ids.ID]uint64)
+		)
+		// Iterate over the unlocked UTXOs
+		for _, utxo := range utxos {
+			out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+			if !ok {
+				// Can't import an unknown transfer output type
+				continue
+			}
 
-		inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
-		if !ok {
-			// We couldn't spend this UTXO, so we skip to the next one
-			continue
-		}
+			inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+			if !ok {
+				// We couldn't spend this UTXO, so we skip to the next one
+				continue
+			}
 
-		importedInputs = append(importedInputs, &lux.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  utxo.Asset,
-			FxID:   secp256k1fx.ID,
-			In: &secp256k1fx.TransferInput{
-				Amt: out.Amt,
-				Input: secp256k1fx.Input{
-					SigIndices: inputSigIndices,
+			importedInputs = append(importedInputs, &lux.TransferableInput{
+				UTXOID: utxo.UTXOID,
+				Asset:  utxo.Asset,
+				FxID:   secp256k1fx.ID,
+				In: &secp256k1fx.TransferInput{
+					Amt: out.Amt,
+					Input: secp256k1fx.Input{
+						SigIndices: inputSigIndices,
+					},
 				},
-			},
-		})
+			})
 
-		assetID := utxo.AssetID()
-		newImportedAmount, err := math.Add64(importedAmounts[assetID], out.Amt)
-		if err != nil {
-			return nil, err
+			assetID := utxo.AssetID()
+			newImportedAmount, err := math.Add64(importedAmounts[assetID], out.Amt)
+			if err != nil {
+				return nil, err
+			}
+			importedAmounts[assetID] = newImportedAmount
 		}
-		importedAmounts[assetID] = newImportedAmount
-	}
-	utils.Sort(importedInputs) // sort imported inputs
+		utils.Sort(importedInputs) // sort imported inputs
 
-	if len(importedAmounts) == 0 {
-		return nil, fmt.Errorf(
-			"%w: no UTXOs available to import",
-			errInsufficientFunds,
-		)
-	}
+		if len(importedAmounts) == 0 {
+			return nil, fmt.Errorf(
+				"%w: no UTXOs available to import",
+				errInsufficientFunds,
+			)
+		}
 
-	var (
-		inputs      []*lux.TransferableInput
-		outputs     = make([]*lux.TransferableOutput, 0, len(importedAmounts))
-		importedLUX = importedAmounts[luxAssetID]
-	)
-	if importedLUX > txFee {
-		importedAmounts[luxAssetID] -= txFee
-	} else {
-		if importedLUX < txFee { // imported amount goes toward paying tx fee
-			toBurn := map[ids.ID]uint64{
-				luxAssetID: txFee - importedLUX,
-			}
-			var err error
-			inputs, outputs, err = b.spend(toBurn, ops)
-			if err != nil {
-				return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		var (
+			inputs      []*lux.TransferableInput
+			outputs     = make([]*lux.TransferableOutput, 0, len(importedAmounts))
+			importedLUX = importedAmounts[luxAssetID]
+		)
+		if importedLUX > txFee {
+			importedAmounts[luxAssetID] -= txFee
+		} else {
+			if importedLUX < txFee { // imported amount goes toward paying tx fee
+				toBurn := map[ids.ID]uint64{
+					luxAssetID: txFee - importedLUX,
+				}
+				var err error
+				inputs, outputs, err = b.spend(toBurn, ops)
+				if err != nil {
+					return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+				}
 			}
+			delete(importedAmounts, luxAssetID)
 		}
-		delete(importedAmounts, luxAssetID)
-	}
 
-	for assetID, amount := range importedAmounts {
-		outputs = append(outputs, &lux.TransferableOutput{
-			Asset: lux.Asset{ID: assetID},
-			FxID:  secp256k1fx.ID,
-			Out: &secp256k1fx.TransferOutput{
-				Amt:          amount,
-				OutputOwners: *to,
-			},
-		})
-	}
+		for assetID, amount := range importedAmounts {
+			outputs = append(outputs, &lux.TransferableOutput{
+				Asset: lux.Asset{ID: assetID},
+				FxID:  secp256k1fx.ID,
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          amount,
+					OutputOwners: *to,
+				},
+			})
+		}
 
-	lux.SortTransferableOutputs(outputs, Parser.Codec())
-	tx := &txs.ImportTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.context.BlockchainID,
-			Ins:          inputs,
-			Outs:         outputs,
-			Memo:         ops.Memo(),
-		}},
-		SourceChain: chainID,
-		ImportedIns: importedInputs,
-	}
-	return tx, b.initCtx(tx)
+		lux.SortTransferableOutputs(outputs, Parser.Codec())
+		tx := &txs.ImportTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.context.BlockchainID,
+				Ins:          inputs,
+				Outs:         outputs,
+				Memo:         ops.Memo(),
+			}},
+			SourceChain: chainID,
+			ImportedIns: importedInputs,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) NewExportTx(
@@ -475,37 +483,39 @@ func (b *builder) NewExportTx(
 	outputs []*lux.TransferableOutput,
 	options ...common.Option,
 ) (*txs.ExportTx, error) {
-	toBurn := map[ids.ID]uint64{
-		b.context.LUXAssetID: b.context.BaseTxFee,
-	}
-	for _, out := range outputs {
-		assetID := out.AssetID()
-		amountToBurn, err := math.Add64(toBurn[assetID], out.Out.Amount())
+	ops := common.NewOptions(options)
+	return buildWithFee(ops, b.context.BaseTxFee, func(txFee uint64) (*txs.ExportTx, error) {
+		toBurn := map[ids.ID]uint64{
+			b.context.LUXAssetID: txFee,
+		}
+		for _, out := range outputs {
+			assetID := out.AssetID()
+			amountToBurn, err := math.Add64(toBurn[assetID], out.Out.Amount())
+			if err != nil {
+				return nil, err
+			}
+			toBurn[assetID] = amountToBurn
+		}
+
+		inputs, changeOutputs, err := b.spend(toBurn, ops)
 		if err != nil {
 			return nil, err
 		}
-		toBurn[assetID] = amountToBurn
-	}
 
-	ops := common.NewOptions(options)
-	inputs, changeOutputs, err := b.spend(toBurn, ops)
-	if err != nil {
-		return nil, err
-	}
-
-	lux.SortTransferableOutputs(outputs, Parser.Codec())
-	tx := &txs.ExportTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.context.NetworkID,
-			BlockchainID: b.context.BlockchainID,
-			Ins:          inputs,
-			Outs:         changeOutputs,
-			Memo:         ops.Memo(),
-		}},
-		DestinationChain: chainID,
-		ExportedOuts:     outputs,
-	}
-	return tx, b.initCtx(tx)
+		lux.SortTransferableOutputs(outputs, Parser.Codec())
+		tx := &txs.ExportTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.context.NetworkID,
+				BlockchainID: b.context.BlockchainID,
+				Ins:          inputs,
+				Outs:         changeOutputs,
+				Memo:         ops.Memo(),
+			}},
+			DestinationChain: chainID,
+			ExportedOuts:     outputs,
+		}
+		return tx, b.initCtx(tx)
+	})
 }
 
 func (b *builder) getBalance(
@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"time"
+
+	"github.com/luxfi/node/vms/xvm/txs"
+	"github.com/luxfi/node/vms/xvm/txs/fee"
+	"github.com/luxfi/node/wallet/subnet/primary/common"
+
+	chaincommon "github.com/luxfi/node/wallet/chain/common"
+)
+
+// EstimateFee returns a fee for [utx] that is sufficient if the transaction is
+// accepted by [until], even if the X-Chain gas price spikes in the meantime.
+func EstimateFee(
+	feeContext *chaincommon.DynamicFeeContext,
+	utx txs.UnsignedTx,
+	until time.Time,
+) (uint64, error) {
+	calculator := fee.NewCalculator(Parser.Codec(), feeContext.Config.Weights, feeContext.MaxPrice(until))
+	return calculator.CalculateFee(utx)
+}
+
+// buildWithFee returns the transaction built by [buildTx] with the fee it must
+// burn.
+//
+// If [options] provide a dynamic fee context, the fee is estimated with
+// [EstimateFee]. Because the fee depends on the inputs and outputs that are
+// selected to pay it, the transaction is rebuilt until it burns at least its
+// estimated fee. Otherwise, [staticFee] is burned.
+func buildWithFee[T txs.UnsignedTx](
+	options *common.Options,
+	staticFee uint64,
+	buildTx func(txFee uint64) (T, error),
+) (T, error) {
+	feeContext, until := options.DynamicFee()
+	if feeContext == nil {
+		return buildTx(staticFee)
+	}
+
+	var txFee uint64
+	for {
+		utx, err := buildTx(txFee)
+		if err != nil {
+			return utx, err
+		}
+
+		estimatedFee, err := EstimateFee(feeContext, utx, until)
+		if err != nil {
+			return utx, err
+		}
+		// The estimated fee only grows as more inputs are consumed, so this
+		// terminates once the fee is covered or the funds run out.
+		if estimatedFee <= txFee {
+			return utx, nil
+		}
+		txFee = estimatedFee
+	}
+}
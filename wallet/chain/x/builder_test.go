@@ -7,6 +7,7 @@ package x
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/luxfi/node/utils/constants"
 	"github.com/luxfi/math/set"
 	"github.com/luxfi/node/utils/units"
+	"github.com/luxfi/node/vms/components/gas"
 	"github.com/luxfi/node/vms/components/lux"
 	"github.com/luxfi/node/vms/components/verify"
 	"github.com/luxfi/node/vms/nftfx"
@@ -22,6 +24,8 @@ import (
 	"github.com/luxfi/node/vms/secp256k1fx"
 	"github.com/luxfi/node/wallet/chain/x/builder"
 	"github.com/luxfi/node/wallet/subnet/primary/common"
+
+	chaincommon "github.com/luxfi/node/wallet/chain/common"
 )
 
 var (
@@ -95,6 +99,84 @@ func TestBaseTx(t *testing.T) {
 	require.Equal(outputsToMove[0], outs[1])
 }
 
+func TestBaseTxDynamicFee(t *testing.T) {
+	var (
+		require = require.New(t)
+
+		// backend
+		utxosKey       = testKeys[1]
+		utxos          = makeTestUTXOs(utxosKey)
+		genericBackend = common.NewDeterministicChainUTXOs(
+			require,
+			map[ids.ID][]*lux.UTXO{
+				xChainID: utxos,
+			},
+		)
+		backend = NewBackend(testContext, genericBackend)
+
+		// builder
+		utxoAddr = utxosKey.Address()
+		builder  = builder.New(set.Of(utxoAddr), testContext, backend)
+
+		// fees
+		now        = time.Unix(1_000, 0)
+		until      = now.Add(time.Minute)
+		feeContext = &chaincommon.DynamicFeeContext{
+			Config: gas.Config{
+				Weights: gas.Dimensions{
+					gas.Bandwidth: 1,
+					gas.DBRead:    1_000,
+					gas.DBWrite:   1_000,
+					gas.Compute:   4,
+				},
+				MaxCapacity:              1_000_000,
+				MaxPerSecond:             100_000,
+				TargetPerSecond:          50_000,
+				MinPrice:                 1,
+				ExcessConversionConstant: 2_164_043,
+			},
+			State: gas.State{
+				Capacity: 1_000_000,
+				Excess:   10_000_000,
+			},
+			Time: now,
+		}
+
+		// data to build the transaction
+		outputsToMove = []*lux.TransferableOutput{{
+			Asset: lux.Asset{ID: luxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 7 * units.Lux,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{utxoAddr},
+				},
+			},
+		}}
+	)
+
+	utx, err := builder.NewBaseTx(
+		outputsToMove,
+		common.WithDynamicFee(feeContext, until),
+	)
+	require.NoError(err)
+
+	expectedFee, err := builder.EstimateFee(feeContext, utx, until)
+	require.NoError(err)
+	require.NotEqual(testContext.BaseTxFee, expectedFee)
+
+	// check that the estimated fee, rather than the static fee, is burned
+	var consumed, produced uint64
+	for _, in := range utx.Ins {
+		consumed += in.In.Amount()
+	}
+	for _, out := range utx.Outs {
+		produced += out.Out.Amount()
+	}
+	require.Equal(expectedFee, consumed-produced)
+	require.Contains(utx.Outs, outputsToMove[0])
+}
+
 func TestCreateAssetTx(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,249 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/crypto/secp256k1"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/math/set"
+	"github.com/luxfi/node/utils/hashing"
+	"github.com/luxfi/node/vms/nftfx"
+	"github.com/luxfi/node/vms/propertyfx"
+	"github.com/luxfi/node/vms/secp256k1fx"
+	"github.com/luxfi/node/vms/xvm/fxs"
+	"github.com/luxfi/node/vms/xvm/txs"
+	"github.com/luxfi/node/wallet/chain/common"
+	"github.com/luxfi/node/wallet/chain/x/builder"
+	"github.com/luxfi/node/wallet/keychain"
+)
+
+var (
+	ErrMismatchedTx         = errors.New("partially signed txs are for different transactions")
+	ErrMismatchedSigners    = common.ErrMismatchedSigners
+	ErrMissingSignatures    = errors.New("missing signatures")
+	ErrWrongNumCredentials  = errors.New("wrong number of credentials")
+	ErrWrongNumSignatures   = errors.New("wrong number of signatures")
+	errNilPartialTx         = errors.New("nil partially signed tx")
+	errNilPartialTxUnsigned = errors.New("partially signed tx is missing its unsigned tx")
+)
+
+// PartialTx is a transaction that may still be missing signatures.
+//
+// Alongside the transaction, it records which address is expected to provide
+// each signature. This allows every holder of a required key, such as the
+// owners of a multisig output, to add their signatures using only their own
+// keychain, without access to the UTXOs that the transaction consumes.
+type PartialTx struct {
+	// Tx contains every signature that has been provided so far. Missing
+	// signatures are left empty.
+	Tx *txs.Tx `serialize:"true" json:"tx"`
+	// Signers[credIndex][sigIndex] is the address that is expected to provide
+	// the signature at index [sigIndex] of Tx.Creds[credIndex].
+	//
+	// If the address couldn't be determined when the PartialTx was created,
+	// it is left empty and the signature can only be provided by merging in a
+	// PartialTx that knows the address.
+	Signers [][]ids.ShortID `serialize:"true" json:"signers"`
+}
+
+// NewPartialTx creates a PartialTx for [utx] without any signatures.
+//
+// [backend] is used to look up the owners of the consumed UTXOs.
+func NewPartialTx(
+	ctx context.Context,
+	backend Backend,
+	utx txs.UnsignedTx,
+) (*PartialTx, error) {
+	v := &visitor{
+		backend: backend,
+		ctx:     ctx,
+	}
+	if err := utx.Visit(v); err != nil {
+		return nil, err
+	}
+
+	tx := &txs.Tx{Unsigned: utx}
+	txKeys := make([][]keychain.Signer, len(v.signers))
+	for credIndex, inputSigners := range v.signers {
+		txKeys[credIndex] = make([]keychain.Signer, len(inputSigners))
+	}
+	// Signing without any keys populates the credentials with empty
+	// signatures.
+	if err := sign(tx, v.creds, txKeys); err != nil {
+		return nil, err
+	}
+	return &PartialTx{
+		Tx:      tx,
+		Signers: v.signers,
+	}, nil
+}
+
+// ParsePartialTx parses a PartialTx from the output of [PartialTx.Bytes].
+func ParsePartialTx(b []byte) (*PartialTx, error) {
+	codec := builder.Parser.Codec()
+	p := &PartialTx{}
+	if _, err := codec.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("couldn't parse partially signed tx: %w", err)
+	}
+	if err := p.verify(); err != nil {
+		return nil, err
+	}
+	if err := p.Tx.Initialize(codec); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Bytes returns the canonical serialization of the PartialTx.
+func (p *PartialTx) Bytes() ([]byte, error) {
+	return builder.Parser.Codec().Marshal(txs.CodecVersion, p)
+}
+
+// Sign adds every missing signature that [kc] is able to provide.
+//
+// Signatures that were already provided are never modified.
+func (p *PartialTx) Sign(kc keychain.Keychain) error {
+	return sign(p.Tx, nil, getKeys(kc, p.Signers))
+}
+
+// Merge adds the signatures and signer addresses known by [other] that are
+// missing from [p]. Both PartialTxs must be for the same unsigned tx, and every
+// added signature must have been produced by its expected signer. If an error
+// is returned, [p] is left unmodified.
+func (p *PartialTx) Merge(other *PartialTx) error {
+	if err := other.verify(); err != nil {
+		return err
+	}
+
+	codec := builder.Parser.Codec()
+	unsignedBytes, err := codec.Marshal(txs.CodecVersion, &p.Tx.Unsigned)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal unsigned tx: %w", err)
+	}
+	otherUnsignedBytes, err := codec.Marshal(txs.CodecVersion, &other.Tx.Unsigned)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal unsigned tx: %w", err)
+	}
+	if !bytes.Equal(unsignedBytes, otherUnsignedBytes) {
+		return ErrMismatchedTx
+	}
+	sigs, err := txSigs(p.Tx)
+	if err != nil {
+		return err
+	}
+	otherSigs, err := txSigs(other.Tx)
+	if err != nil {
+		return err
+	}
+	if err := common.MergeSignatures(
+		hashing.ComputeHash256(unsignedBytes),
+		p.Signers,
+		sigs,
+		other.Signers,
+		otherSigs,
+	); err != nil {
+		return err
+	}
+	return p.Tx.Initialize(codec)
+}
+
+// Missing returns the addresses that still need to provide a signature. If the
+// address of a missing signature is unknown, [ids.ShortEmpty] is included.
+func (p *PartialTx) Missing() (set.Set[ids.ShortID], error) {
+	missing := set.Set[ids.ShortID]{}
+	for credIndex, inputSigners := range p.Signers {
+		sigs, err := credentialSigs(p.Tx.Creds[credIndex])
+		if err != nil {
+			return nil, err
+		}
+		for sigIndex, addr := range inputSigners {
+			if sigs[sigIndex] == emptySig {
+				missing.Add(addr)
+			}
+		}
+	}
+	return missing, nil
+}
+
+// Finalize returns the signed tx once every required signature has been
+// provided.
+func (p *PartialTx) Finalize() (*txs.Tx, error) {
+	missing, err := p.Missing()
+	if err != nil {
+		return nil, err
+	}
+	if missing.Len() != 0 {
+		return nil, fmt.Errorf("%w: %d signers haven't signed", ErrMissingSignatures, missing.Len())
+	}
+	if err := p.Tx.Initialize(builder.Parser.Codec()); err != nil {
+		return nil, err
+	}
+	return p.Tx, nil
+}
+
+// verify checks that the credentials of the tx match the expected signers.
+func (p *PartialTx) verify() error {
+	switch {
+	case p == nil || p.Tx == nil:
+		return errNilPartialTx
+	case p.Tx.Unsigned == nil:
+		return errNilPartialTxUnsigned
+	case len(p.Tx.Creds) != len(p.Signers):
+		return fmt.Errorf("%w: expected %d but got %d",
+			ErrWrongNumCredentials,
+			len(p.Signers),
+			len(p.Tx.Creds),
+		)
+	}
+	for credIndex, inputSigners := range p.Signers {
+		sigs, err := credentialSigs(p.Tx.Creds[credIndex])
+		if err != nil {
+			return err
+		}
+		if len(sigs) != len(inputSigners) {
+			return fmt.Errorf("%w: credential %d expected %d but got %d",
+				ErrWrongNumSignatures,
+				credIndex,
+				len(inputSigners),
+				len(sigs),
+			)
+		}
+	}
+	return nil
+}
+
+// txSigs returns the signatures of every credential of [tx].
+func txSigs(tx *txs.Tx) ([][][secp256k1.SignatureLen]byte, error) {
+	sigs := make([][][secp256k1.SignatureLen]byte, len(tx.Creds))
+	for credIndex, cred := range tx.Creds {
+		credSigs, err := credentialSigs(cred)
+		if err != nil {
+			return nil, err
+		}
+		sigs[credIndex] = credSigs
+	}
+	return sigs, nil
+}
+
+func credentialSigs(fxCred *fxs.FxCredential) ([][secp256k1.SignatureLen]byte, error) {
+	if fxCred == nil {
+		return nil, ErrUnknownCredentialType
+	}
+
+	switch cred := fxCred.Credential.(type) {
+	case *secp256k1fx.Credential:
+		return cred.Sigs, nil
+	case *nftfx.Credential:
+		return cred.Sigs, nil
+	case *propertyfx.Credential:
+		return cred.Sigs, nil
+	default:
+		return nil, ErrUnknownCredentialType
+	}
+}
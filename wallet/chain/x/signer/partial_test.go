@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/crypto/secp256k1"
+	"github.com/luxfi/database"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/math/set"
+	"github.com/luxfi/node/utils/constants"
+	"github.com/luxfi/node/vms/components/lux"
+	"github.com/luxfi/node/vms/secp256k1fx"
+	"github.com/luxfi/node/vms/xvm/txs"
+	"github.com/luxfi/node/wallet/chain/common"
+	"github.com/luxfi/node/wallet/keychain"
+)
+
+type testBackend struct {
+	utxos map[ids.ID]*lux.UTXO
+}
+
+func (b *testBackend) GetUTXO(_ context.Context, _, utxoID ids.ID) (*lux.UTXO, error) {
+	utxo, ok := b.utxos[utxoID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return utxo, nil
+}
+
+func newTestKeychain(keys ...*secp256k1.PrivateKey) keychain.Keychain {
+	return keychain.NewSecp256k1fxKeychain(secp256k1fx.NewKeychain(keys...))
+}
+
+// newMultisigTx returns a tx that spends a UTXO owned by both [keyA] and
+// [keyB], and a backend that knows the UTXO.
+func newMultisigTx(keyA, keyB *secp256k1.PrivateKey) (*testBackend, txs.UnsignedTx) {
+	utxo := &lux.UTXO{
+		UTXOID: lux.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  lux.Asset{ID: ids.GenerateTestID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1000,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{keyA.Address(), keyB.Address()},
+			},
+		},
+	}
+	backend := &testBackend{
+		utxos: map[ids.ID]*lux.UTXO{
+			utxo.InputID(): utxo,
+		},
+	}
+	utx := &txs.BaseTx{
+		BaseTx: lux.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: ids.GenerateTestID(),
+			Ins: []*lux.TransferableInput{{
+				UTXOID: utxo.UTXOID,
+				Asset:  utxo.Asset,
+				In: &secp256k1fx.TransferInput{
+					Amt: 1000,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0, 1},
+					},
+				},
+			}},
+		},
+	}
+	return backend, utx
+}
+
+func TestPartialTxMultisig(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		keys         = secp256k1.TestKeys()
+		keyA         = keys[0]
+		keyB         = keys[1]
+		backend, utx = newMultisigTx(keyA, keyB)
+	)
+
+	partial, err := NewPartialTx(context.Background(), backend, utx)
+	require.NoError(err)
+	require.Equal([][]ids.ShortID{{keyA.Address(), keyB.Address()}}, partial.Signers)
+
+	_, err = partial.Finalize()
+	require.ErrorIs(err, ErrMissingSignatures)
+
+	partialBytes, err := partial.Bytes()
+	require.NoError(err)
+
+	// Each holder signs their own copy of the partially signed tx.
+	partialA, err := ParsePartialTx(partialBytes)
+	require.NoError(err)
+	require.NoError(partialA.Sign(newTestKeychain(keyA)))
+
+	partialB, err := ParsePartialTx(partialBytes)
+	require.NoError(err)
+	require.NoError(partialB.Sign(newTestKeychain(keyB)))
+
+	missing, err := partialA.Missing()
+	require.NoError(err)
+	require.Equal(set.Of(keyB.Address()), missing)
+
+	require.NoError(partialA.Merge(partialB))
+
+	tx, err := partialA.Finalize()
+	require.NoError(err)
+
+	// The merged result must match signing with both keys at once.
+	expectedTx, err := SignUnsigned(
+		context.Background(),
+		New(newTestKeychain(keyA, keyB), backend),
+		utx,
+	)
+	require.NoError(err)
+	require.Equal(expectedTx.Bytes(), tx.Bytes())
+	require.Equal(expectedTx.ID(), tx.ID())
+}
+
+func TestPartialTxMergeInvalidSignature(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		keys         = secp256k1.TestKeys()
+		keyA         = keys[0]
+		keyB         = keys[1]
+		backend, utx = newMultisigTx(keyA, keyB)
+	)
+
+	partial, err := NewPartialTx(context.Background(), backend, utx)
+	require.NoError(err)
+	partialBytes, err := partial.Bytes()
+	require.NoError(err)
+
+	// The signature expected from keyB is provided by keyA.
+	forged, err := ParsePartialTx(partialBytes)
+	require.NoError(err)
+	require.NoError(forged.Sign(newTestKeychain(keyA)))
+	sigs := forged.Tx.Creds[0].Credential.(*secp256k1fx.Credential).Sigs
+	sigs[1] = sigs[0]
+	sigs[0] = [secp256k1.SignatureLen]byte{}
+
+	err = partial.Merge(forged)
+	require.ErrorIs(err, common.ErrInvalidSignature)
+
+	// The failed merge didn't add any signatures.
+	missing, err := partial.Missing()
+	require.NoError(err)
+	require.Equal(set.Of(keyA.Address(), keyB.Address()), missing)
+}
+
+func TestPartialTxMergeMismatchedTx(t *testing.T) {
+	require := require.New(t)
+
+	newPartial := func(memo byte) *PartialTx {
+		partial, err := NewPartialTx(
+			context.Background(),
+			&testBackend{},
+			&txs.BaseTx{
+				BaseTx: lux.BaseTx{
+					NetworkID: constants.UnitTestID,
+					Memo:      []byte{memo},
+				},
+			},
+		)
+		require.NoError(err)
+		return partial
+	}
+
+	partial := newPartial(0)
+	err := partial.Merge(newPartial(1))
+	require.ErrorIs(err, ErrMismatchedTx)
+}
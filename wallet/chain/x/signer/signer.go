@@ -43,12 +43,14 @@ func New(kc keychain.Keychain, backend Backend) Signer {
 }
 
 func (s *signer) Sign(ctx context.Context, tx *txs.Tx) error {
-	return tx.Unsigned.Visit(&visitor{
-		kc:      s.kc,
+	v := &visitor{
 		backend: s.backend,
 		ctx:     ctx,
-		tx:      tx,
-	})
+	}
+	if err := tx.Unsigned.Visit(v); err != nil {
+		return err
+	}
+	return sign(tx, v.creds, getKeys(s.kc, v.signers))
 }
 
 func SignUnsigned(
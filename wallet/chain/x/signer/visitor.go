@@ -35,12 +35,18 @@ var (
 	emptySig [secp256k1.SignatureLen]byte
 )
 
-// visitor handles signing transactions for the signer
+// visitor determines, for every credential of a transaction, the credential
+// type and the addresses that are expected to provide each of its signatures.
+//
+// If an address can't be determined, because the UTXO isn't known by the
+// backend, the address is left empty.
 type visitor struct {
-	kc      keychain.Keychain
 	backend Backend
 	ctx     context.Context
-	tx      *txs.Tx
+
+	creds []verify.Verifiable
+	// signers[credIndex][sigIndex] is the address expected to sign.
+	signers [][]ids.ShortID
 }
 
 func (s *visitor) BaseTx(tx *txs.BaseTx) error {
@@ -48,7 +54,7 @@ func (s *visitor) BaseTx(tx *txs.BaseTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, txCreds, txSigners)
+	return s.record(txCreds, txSigners)
 }
 
 func (s *visitor) CreateAssetTx(tx *txs.CreateAssetTx) error {
@@ -56,7 +62,7 @@ func (s *visitor) CreateAssetTx(tx *txs.CreateAssetTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, txCreds, txSigners)
+	return s.record(txCreds, txSigners)
 }
 
 func (s *visitor) OperationTx(tx *txs.OperationTx) error {
@@ -70,7 +76,7 @@ func (s *visitor) OperationTx(tx *txs.OperationTx) error {
 	}
 	txCreds = append(txCreds, txOpsCreds...)
 	txSigners = append(txSigners, txOpsSigners...)
-	return sign(s.tx, txCreds, txSigners)
+	return s.record(txCreds, txSigners)
 }
 
 func (s *visitor) ImportTx(tx *txs.ImportTx) error {
@@ -84,7 +90,7 @@ func (s *visitor) ImportTx(tx *txs.ImportTx) error {
 	}
 	txCreds = append(txCreds, txImportCreds...)
 	txSigners = append(txSigners, txImportSigners...)
-	return sign(s.tx, txCreds, txSigners)
+	return s.record(txCreds, txSigners)
 }
 
 func (s *visitor) ExportTx(tx *txs.ExportTx) error {
@@ -92,12 +98,12 @@ func (s *visitor) ExportTx(tx *txs.ExportTx) error {
 	if err != nil {
 		return err
 	}
-	return sign(s.tx, txCreds, txSigners)
+	return s.record(txCreds, txSigners)
 }
 
-func (s *visitor) getSigners(ctx context.Context, sourceChainID ids.ID, ins []*lux.TransferableInput) ([]verify.Verifiable, [][]keychain.Signer, error) {
+func (s *visitor) getSigners(ctx context.Context, sourceChainID ids.ID, ins []*lux.TransferableInput) ([]verify.Verifiable, [][]ids.ShortID, error) {
 	txCreds := make([]verify.Verifiable, len(ins))
-	txSigners := make([][]keychain.Signer, len(ins))
+	txSigners := make([][]ids.ShortID, len(ins))
 	for credIndex, transferInput := range ins {
 		txCreds[credIndex] = &secp256k1fx.Credential{}
 		input, ok := transferInput.In.(*secp256k1fx.TransferInput)
@@ -105,7 +111,7 @@ func (s *visitor) getSigners(ctx context.Context, sourceChainID ids.ID, ins []*l
 			return nil, nil, ErrUnknownInputType
 		}
 
-		inputSigners := make([]keychain.Signer, len(input.SigIndices))
+		inputSigners := make([]ids.ShortID, len(input.SigIndices))
 		txSigners[credIndex] = inputSigners
 
 		utxoID := transferInput.InputID()
@@ -129,22 +135,15 @@ func (s *visitor) getSigners(ctx context.Context, sourceChainID ids.ID, ins []*l
 				return nil, nil, ErrInvalidUTXOSigIndex
 			}
 
-			addr := out.Addrs[addrIndex]
-			key, ok := s.kc.Get(addr)
-			if !ok {
-				// If we don't have access to the key, then we can't sign this
-				// transaction. However, we can attempt to partially sign it.
-				continue
-			}
-			inputSigners[sigIndex] = key
+			inputSigners[sigIndex] = out.Addrs[addrIndex]
 		}
 	}
 	return txCreds, txSigners, nil
 }
 
-func (s *visitor) getOpsSigners(ctx context.Context, sourceChainID ids.ID, ops []*txs.Operation) ([]verify.Verifiable, [][]keychain.Signer, error) {
+func (s *visitor) getOpsSigners(ctx context.Context, sourceChainID ids.ID, ops []*txs.Operation) ([]verify.Verifiable, [][]ids.ShortID, error) {
 	txCreds := make([]verify.Verifiable, len(ops))
-	txSigners := make([][]keychain.Signer, len(ops))
+	txSigners := make([][]ids.ShortID, len(ops))
 	for credIndex, op := range ops {
 		var input *secp256k1fx.Input
 		switch op := op.Op.(type) {
@@ -167,7 +166,7 @@ func (s *visitor) getOpsSigners(ctx context.Context, sourceChainID ids.ID, ops [
 			return nil, nil, ErrUnknownOpType
 		}
 
-		inputSigners := make([]keychain.Signer, len(input.SigIndices))
+		inputSigners := make([]ids.ShortID, len(input.SigIndices))
 		txSigners[credIndex] = inputSigners
 
 		if len(op.UTXOIDs) != 1 {
@@ -205,17 +204,39 @@ func (s *visitor) getOpsSigners(ctx context.Context, sourceChainID ids.ID, ops [
 				return nil, nil, ErrInvalidUTXOSigIndex
 			}
 
-			addr := addrs[addrIndex]
-			key, ok := s.kc.Get(addr)
+			inputSigners[sigIndex] = addrs[addrIndex]
+		}
+	}
+	return txCreds, txSigners, nil
+}
+
+func (s *visitor) record(creds []verify.Verifiable, txSigners [][]ids.ShortID) error {
+	s.creds = creds
+	s.signers = txSigners
+	return nil
+}
+
+// getKeys returns the signers in [kc] for the provided addresses. If [kc]
+// doesn't have access to a key, then the corresponding signer is left nil.
+func getKeys(kc keychain.Keychain, txSigners [][]ids.ShortID) [][]keychain.Signer {
+	txKeys := make([][]keychain.Signer, len(txSigners))
+	for credIndex, inputSigners := range txSigners {
+		inputKeys := make([]keychain.Signer, len(inputSigners))
+		txKeys[credIndex] = inputKeys
+		for sigIndex, addr := range inputSigners {
+			if addr == ids.ShortEmpty {
+				continue
+			}
+			key, ok := kc.Get(addr)
 			if !ok {
 				// If we don't have access to the key, then we can't sign this
 				// transaction. However, we can attempt to partially sign it.
 				continue
 			}
-			inputSigners[sigIndex] = key
+			inputKeys[sigIndex] = key
 		}
 	}
-	return txCreds, txSigners, nil
+	return txKeys
 }
 
 func sign(tx *txs.Tx, creds []verify.Verifiable, txSigners [][]keychain.Signer) error {
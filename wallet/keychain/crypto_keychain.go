@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keychain
+
+import (
+	"github.com/luxfi/ids"
+
+	cryptokeychain "github.com/luxfi/node/utils/crypto/keychain"
+)
+
+// CryptoKeychain adapts a utils/crypto/keychain.Keychain, such as a ledger
+// keychain, to implement the Keychain interface
+type CryptoKeychain struct {
+	kc cryptokeychain.Keychain
+}
+
+// Ensure we implement the interface
+var _ Keychain = (*CryptoKeychain)(nil)
+
+// NewCryptoKeychain creates a new adapter from a utils/crypto/keychain.Keychain
+func NewCryptoKeychain(kc cryptokeychain.Keychain) *CryptoKeychain {
+	return &CryptoKeychain{kc: kc}
+}
+
+// Addresses implements Keychain
+func (a *CryptoKeychain) Addresses() []ids.ShortID {
+	return a.kc.Addresses().List()
+}
+
+// Get implements Keychain
+func (a *CryptoKeychain) Get(addr ids.ShortID) (Signer, bool) {
+	signer, found := a.kc.Get(addr)
+	if !found {
+		return nil, false
+	}
+	return signer, true
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/luxfi/node/vms/secp256k1fx"
 
 	ethcommon "github.com/luxfi/geth/common"
+	chaincommon "github.com/luxfi/node/wallet/chain/common"
 )
 
 const defaultPollFrequency = 100 * time.Millisecond
@@ -46,6 +47,9 @@ type Options struct {
 
 	baseFee *big.Int
 
+	dynamicFeeContext *chaincommon.DynamicFeeContext
+	dynamicFeeUntil   time.Time
+
 	minIssuanceTimeSet bool
 	minIssuanceTime    uint64
 
@@ -112,6 +116,13 @@ func (o *Options) BaseFee(defaultBaseFee *big.Int) *big.Int {
 	return defaultBaseFee
 }
 
+// DynamicFee returns the dynamic fee context that fees should be estimated
+// with, and the time by which the transaction is expected to be accepted. If
+// no context was provided, nil is returned and the static fees are used.
+func (o *Options) DynamicFee() (*chaincommon.DynamicFeeContext, time.Time) {
+	return o.dynamicFeeContext, o.dynamicFeeUntil
+}
+
 func (o *Options) MinIssuanceTime() uint64 {
 	if o.minIssuanceTimeSet {
 		return o.minIssuanceTime
@@ -179,6 +190,15 @@ func WithBaseFee(baseFee *big.Int) Option {
 	}
 }
 
+// WithDynamicFee makes the builder burn the fee estimated with [feeContext],
+// which is sufficient if the transaction is accepted by [until].
+func WithDynamicFee(feeContext *chaincommon.DynamicFeeContext, until time.Time) Option {
+	return func(o *Options) {
+		o.dynamicFeeContext = feeContext
+		o.dynamicFeeUntil = until
+	}
+}
+
 func WithMinIssuanceTime(minIssuanceTime uint64) Option {
 	return func(o *Options) {
 		o.minIssuanceTimeSet = true
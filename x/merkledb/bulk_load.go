@@ -0,0 +1,250 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/maybe"
+	"github.com/luxfi/node/utils/units"
+)
+
+const bulkLoadBatchSize = units.MiB
+
+var (
+	errBulkLoadNonEmpty = errors.New("bulk load requires an empty database")
+	errBulkLoadUnsorted = errors.New("bulk load keys must be strictly increasing")
+)
+
+// BulkLoad populates the empty database with the key/value pairs of [it].
+//
+// The trie is built bottom-up: each node is hashed and written to disk as
+// soon as all of its children are known, so only the nodes on the path to
+// the most recently loaded key are held in memory. The resulting root is the
+// same as if the key/value pairs had been inserted with a batch.
+//
+// The change history is reset, so change proofs can only be served for
+// changes made after the load.
+//
+// If the load fails, the database is left empty.
+//
+// Assumes [db.lock] and [db.commitLock] aren't held.
+func (db *merkleDB) BulkLoad(ctx context.Context, it database.Iterator) error {
+	ctx, span := db.infoTracer.Start(ctx, "MerkleDB.BulkLoad")
+	defer span.End()
+
+	db.commitLock.Lock()
+	defer db.commitLock.Unlock()
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	switch {
+	case db.closed:
+		return database.ErrClosed
+	case db.root.HasValue():
+		return errBulkLoadNonEmpty
+	}
+
+	// Views of the empty trie can't be updated to reflect the load.
+	db.invalidateChildrenExcept(nil)
+
+	// The trie is empty, but the caches may still contain stale entries for
+	// deleted nodes which would shadow the nodes written by the load.
+	if err := db.clearNodes(); err != nil {
+		return err
+	}
+	if err := db.bulkLoad(ctx, it); err != nil {
+		// Remove the nodes that were written before the failure.
+		return errors.Join(err, db.clearNodes())
+	}
+
+	// Reset the history, as the changes made by the load aren't recorded.
+	if err := atomicClearPrefix(db.baseDB, historyPrefix); err != nil {
+		return err
+	}
+	db.nextHistoryNumber = 0
+	db.history = newTrieHistory(db.history.maxHistoryLen)
+	db.history.record(&changeSummary{
+		rootID: db.rootID,
+		rootChange: change[maybe.Maybe[*node]]{
+			after: db.root,
+		},
+		values: map[Key]*change[maybe.Maybe[[]byte]]{},
+		nodes:  map[Key]*change[*node]{},
+	})
+	return nil
+}
+
+// bulkLoad writes the trie containing the key/value pairs of [it] and sets
+// it as the root.
+//
+// Assumes [db.lock] is held.
+func (db *merkleDB) bulkLoad(ctx context.Context, it database.Iterator) error {
+	loader := &bulkLoader{
+		db:    db,
+		batch: db.baseDB.NewBatch(),
+	}
+	var (
+		lastKey []byte
+		numKeys int
+	)
+	for it.Next() {
+		key := it.Key()
+		if numKeys > 0 && bytes.Compare(lastKey, key) >= 0 {
+			return errBulkLoadUnsorted
+		}
+		lastKey = append(lastKey[:0], key...)
+		numKeys++
+
+		if err := loader.add(ToKey(key), slices.Clone(it.Value())); err != nil {
+			return err
+		}
+
+		// Checking for cancellation on every key would dominate the cost of
+		// small loads, so only check when the batch is flushed.
+		if loader.batch.Size() < bulkLoadBatchSize {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := loader.flush(); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	root, rootID, err := loader.finish()
+	if err != nil {
+		return err
+	}
+	if err := loader.batch.Write(); err != nil {
+		return err
+	}
+
+	db.root = root
+	db.rootID = rootID
+	return nil
+}
+
+// clearNodes removes all of the nodes of the trie from disk and the caches.
+//
+// Assumes [db.lock] is held.
+func (db *merkleDB) clearNodes() error {
+	if err := db.valueNodeDB.Clear(); err != nil {
+		return err
+	}
+	return db.intermediateNodeDB.Clear()
+}
+
+// bulkLoader builds a trie from key/value pairs that are added in increasing
+// key order.
+//
+// [stack] contains the nodes on the path from the root to the most recently
+// added key. Every node in [stack] is a prefix of the next one. A node is
+// only popped from [stack] once no more children can be added to it, at
+// which point it is hashed, written to [batch] and added as a child of its
+// parent.
+type bulkLoader struct {
+	db    *merkleDB
+	batch database.Batch
+	stack []*node
+}
+
+// add adds [key] with [value] to the trie.
+//
+// Assumes [key] is greater than all previously added keys.
+func (l *bulkLoader) add(key Key, value []byte) error {
+	if len(l.stack) > 0 {
+		var (
+			last               = l.stack[len(l.stack)-1]
+			commonPrefixLength = getLengthOfCommonPrefix(last.key, key, 0 /*offset*/, l.db.tokenSize)
+		)
+		if err := l.popUntil(key.Take(commonPrefixLength)); err != nil {
+			return err
+		}
+	}
+
+	n := newNode(key)
+	n.setValue(l.db.hasher, maybe.Some(value))
+	l.stack = append(l.stack, n)
+	return nil
+}
+
+// popUntil pops every node in [stack] that is longer than [prefix] and
+// ensures that the last node in [stack] is [prefix].
+//
+// Assumes that the last node in [stack] has [prefix] as a prefix.
+func (l *bulkLoader) popUntil(prefix Key) error {
+	for {
+		last := l.stack[len(l.stack)-1]
+		if last.key.length <= prefix.length {
+			return nil
+		}
+
+		l.stack = l.stack[:len(l.stack)-1]
+		lastID, err := l.write(last)
+		if err != nil {
+			return err
+		}
+
+		// If the parent of [last] isn't in [stack], [last] is the first child
+		// of a new branch node.
+		var parent *node
+		if len(l.stack) > 0 && l.stack[len(l.stack)-1].key.length >= prefix.length {
+			parent = l.stack[len(l.stack)-1]
+		} else {
+			parent = newNode(prefix)
+			l.stack = append(l.stack, parent)
+		}
+		parent.addChildWithID(last, l.db.tokenSize, lastID)
+	}
+}
+
+// finish writes all of the remaining nodes and returns the root of the trie.
+func (l *bulkLoader) finish() (maybe.Maybe[*node], ids.ID, error) {
+	if len(l.stack) == 0 {
+		return maybe.Nothing[*node](), ids.Empty, nil
+	}
+
+	if err := l.popUntil(l.stack[0].key); err != nil {
+		return maybe.Nothing[*node](), ids.Empty, err
+	}
+	root := l.stack[0]
+	rootID, err := l.write(root)
+	if err != nil {
+		return maybe.Nothing[*node](), ids.Empty, err
+	}
+	return maybe.Some(root), rootID, nil
+}
+
+// write adds [n] to [batch] and returns its ID.
+//
+// Assumes all of [n]'s children have been added.
+func (l *bulkLoader) write(n *node) (ids.ID, error) {
+	id := l.db.hasher.HashNode(n)
+	l.db.metrics.HashCalculated()
+
+	if n.hasValue() {
+		return id, l.db.valueNodeDB.addToBatch(l.batch, n.key, n)
+	}
+	return id, l.db.intermediateNodeDB.addToBatch(l.batch, n.key, n)
+}
+
+// flush writes [batch] to disk.
+func (l *bulkLoader) flush() error {
+	if err := l.batch.Write(); err != nil {
+		return err
+	}
+	l.batch.Reset()
+	return nil
+}
@@ -0,0 +1,328 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build test
+
+package merkledb
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/database/memdb"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/maybe"
+)
+
+// newSortedIterator returns an iterator over [kvs] in increasing key order.
+func newSortedIterator(t *testing.T, kvs map[string][]byte) database.Iterator {
+	require := require.New(t)
+
+	db := memdb.New()
+	for key, value := range kvs {
+		require.NoError(db.Put([]byte(key), value))
+	}
+	return db.NewIterator()
+}
+
+func Test_BulkLoad_MatchesIncrementalInsertion(t *testing.T) {
+	tests := []struct {
+		name string
+		kvs  map[string][]byte
+	}{
+		{
+			name: "empty",
+			kvs:  map[string][]byte{},
+		},
+		{
+			name: "single key",
+			kvs: map[string][]byte{
+				"key": []byte("value"),
+			},
+		},
+		{
+			name: "empty key",
+			kvs: map[string][]byte{
+				"":    []byte("empty"),
+				"key": []byte("value"),
+			},
+		},
+		{
+			name: "keys are prefixes of each other",
+			kvs: map[string][]byte{
+				"a":   []byte("a"),
+				"ab":  []byte("ab"),
+				"abc": []byte("abc"),
+				"abd": []byte("abd"),
+				"b":   {},
+			},
+		},
+		{
+			name: "shared prefix without value",
+			kvs: map[string][]byte{
+				"prefix1": []byte("1"),
+				"prefix2": []byte("2"),
+				"prefix3": make([]byte, HashLength),
+			},
+		},
+	}
+	for _, bf := range validBranchFactors {
+		for _, test := range tests {
+			t.Run(test.name+"/"+strconv.Itoa(int(bf)), func(t *testing.T) {
+				testBulkLoad(t, bf, test.kvs)
+			})
+		}
+	}
+}
+
+func Test_BulkLoad_MatchesIncrementalInsertion_Random(t *testing.T) {
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	for _, bf := range validBranchFactors {
+		kvs := make(map[string][]byte)
+		for i := 0; i < 1_000; i++ {
+			key := make([]byte, r.Intn(8))
+			_, _ = r.Read(key)
+			value := make([]byte, r.Intn(2*HashLength))
+			_, _ = r.Read(value)
+			kvs[string(key)] = value
+		}
+		t.Run(strconv.Itoa(int(bf)), func(t *testing.T) {
+			testBulkLoad(t, bf, kvs)
+		})
+	}
+}
+
+func testBulkLoad(t *testing.T, bf BranchFactor, kvs map[string][]byte) {
+	require := require.New(t)
+
+	config := newDefaultConfig()
+	config.BranchFactor = bf
+
+	expectedDB, err := newDatabase(
+		context.Background(),
+		memdb.New(),
+		config,
+		&mockMetrics{},
+	)
+	require.NoError(err)
+
+	batch := expectedDB.NewBatch()
+	for key, value := range kvs {
+		require.NoError(batch.Put([]byte(key), value))
+	}
+	require.NoError(batch.Write())
+
+	baseDB := memdb.New()
+	db, err := newDatabase(
+		context.Background(),
+		baseDB,
+		config,
+		&mockMetrics{},
+	)
+	require.NoError(err)
+
+	it := newSortedIterator(t, kvs)
+	defer it.Release()
+	require.NoError(db.BulkLoad(context.Background(), it))
+
+	expectedRoot := expectedDB.getMerkleRoot()
+	require.Equal(expectedRoot, db.getMerkleRoot())
+	for key, value := range kvs {
+		gotValue, err := db.Get([]byte(key))
+		require.NoError(err)
+		require.Equal(value, gotValue)
+	}
+
+	if len(kvs) != 0 {
+		proof, err := db.GetRangeProof(
+			context.Background(),
+			maybe.Nothing[[]byte](),
+			maybe.Nothing[[]byte](),
+			len(kvs),
+		)
+		require.NoError(err)
+		require.Len(proof.KeyValues, len(kvs))
+		require.NoError(proof.Verify(
+			context.Background(),
+			maybe.Nothing[[]byte](),
+			maybe.Nothing[[]byte](),
+			expectedRoot,
+			db.tokenSize,
+			db.hasher,
+		))
+	}
+
+	// The trie must remain consistent after a restart, including if the
+	// intermediate nodes need to be rebuilt.
+	require.NoError(db.Close())
+	db, err = newDatabase(
+		context.Background(),
+		baseDB,
+		config,
+		&mockMetrics{},
+	)
+	require.NoError(err)
+	require.Equal(expectedRoot, db.getMerkleRoot())
+
+	require.NoError(db.rebuild(context.Background(), int(config.ValueNodeCacheSize)))
+	require.Equal(expectedRoot, db.getMerkleRoot())
+
+	// Modifications after the load must result in the same root as the same
+	// modifications to the incrementally built trie.
+	var keyToDelete []byte
+	for key := range kvs {
+		keyToDelete = []byte(key)
+		break
+	}
+	for _, db := range []*merkleDB{expectedDB, db} {
+		batch := db.NewBatch()
+		require.NoError(batch.Put([]byte("new key"), []byte("new value")))
+		require.NoError(batch.Delete(keyToDelete))
+		require.NoError(batch.Write())
+	}
+	require.Equal(expectedDB.getMerkleRoot(), db.getMerkleRoot())
+}
+
+func Test_BulkLoad_NonEmpty(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+
+	it := newSortedIterator(t, map[string][]byte{
+		"other key": []byte("value"),
+	})
+	defer it.Release()
+	err = db.BulkLoad(context.Background(), it)
+	require.ErrorIs(err, errBulkLoadNonEmpty)
+}
+
+func Test_BulkLoad_Unsorted(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	it := &unsortedIterator{
+		keys: [][]byte{
+			[]byte("key0"),
+			[]byte("key2"),
+			[]byte("key1"),
+		},
+	}
+	err = db.BulkLoad(context.Background(), it)
+	require.ErrorIs(err, errBulkLoadUnsorted)
+
+	// The failed load must not leave any keys behind.
+	require.Equal(ids.Empty, db.getMerkleRoot())
+	_, err = db.Get([]byte("key0"))
+	require.ErrorIs(err, database.ErrNotFound)
+
+	valueIt := db.NewIterator()
+	defer valueIt.Release()
+	require.False(valueIt.Next())
+	require.NoError(valueIt.Error())
+}
+
+func Test_BulkLoad_AfterClear(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	// Deleting every key leaves stale entries in the caches.
+	writeBasicBatch(t, db)
+	batch := db.NewBatch()
+	for i := byte(0); i <= 4; i++ {
+		require.NoError(batch.Delete([]byte{i}))
+	}
+	require.NoError(batch.Write())
+	require.Equal(ids.Empty, db.getMerkleRoot())
+
+	kvs := map[string][]byte{
+		string([]byte{0}): {0},
+		string([]byte{1}): {1},
+	}
+	it := newSortedIterator(t, kvs)
+	defer it.Release()
+	require.NoError(db.BulkLoad(context.Background(), it))
+
+	for key, value := range kvs {
+		gotValue, err := db.Get([]byte(key))
+		require.NoError(err)
+		require.Equal(value, gotValue)
+	}
+}
+
+type unsortedIterator struct {
+	keys  [][]byte
+	index int
+}
+
+func (it *unsortedIterator) Next() bool {
+	it.index++
+	return it.index <= len(it.keys)
+}
+
+func (*unsortedIterator) Error() error {
+	return nil
+}
+
+func (it *unsortedIterator) Key() []byte {
+	return it.keys[it.index-1]
+}
+
+func (it *unsortedIterator) Value() []byte {
+	return it.keys[it.index-1]
+}
+
+func (*unsortedIterator) Release() {}
+
+func Benchmark_BulkLoad(b *testing.B) {
+	require := require.New(b)
+
+	const numKeys = 100_000
+	source := memdb.New()
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+	for i := 0; i < numKeys; i++ {
+		key := make([]byte, 32)
+		_, _ = r.Read(key)
+		require.NoError(source.Put(key, key))
+	}
+
+	b.Run("bulk load", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			db, err := getBasicDB()
+			require.NoError(err)
+
+			it := source.NewIterator()
+			require.NoError(db.BulkLoad(context.Background(), it))
+			it.Release()
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			db, err := getBasicDB()
+			require.NoError(err)
+
+			it := source.NewIterator()
+			batch := db.NewBatch()
+			for it.Next() {
+				require.NoError(batch.Put(it.Key(), it.Value()))
+			}
+			require.NoError(it.Error())
+			require.NoError(batch.Write())
+			it.Release()
+		}
+	})
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/luxfi/database"
 	"github.com/luxfi/ids"
+	"github.com/luxfi/node/cache"
 	"github.com/luxfi/node/utils"
 	"github.com/luxfi/node/utils/maybe"
 	"github.com/luxfi/math/set"
@@ -147,9 +148,19 @@ type Prefetcher interface {
 	PrefetchPaths(keys [][]byte) error
 }
 
+type BulkLoader interface {
+	// BulkLoad populates the database with the key/value pairs of [it], which
+	// must be in strictly increasing key order. This is much faster than
+	// inserting the key/value pairs with batches and results in the same root.
+	//
+	// Returns an error if the database isn't empty.
+	BulkLoad(ctx context.Context, it database.Iterator) error
+}
+
 type MerkleDB interface {
 	database.Database
 	Clearer
+	BulkLoader
 	Trie
 	MerkleRootGetter
 	ProofGetter
@@ -176,15 +187,28 @@ type Config struct {
 	// The number of changes to the database that we store in memory in order to
 	// serve change proofs.
 	HistoryLength uint
+	// The number of the most recent changes to the database that we also
+	// store on disk, so that change proofs can still be served after the
+	// database is reopened.
+	//
+	// Must be at most [HistoryLength]. If 0, the history isn't persisted.
+	PersistedHistoryLength uint
 	// The number of bytes used to cache nodes with values.
 	ValueNodeCacheSize uint
 	// The number of bytes used to cache nodes without values.
 	IntermediateNodeCacheSize uint
+	// The eviction policy of the node caches. If empty, nodes are evicted in
+	// LRU order.
+	CachePolicy cache.Policy
 	// The number of bytes used to store nodes without values in memory before forcing them onto disk.
 	IntermediateWriteBufferSize uint
 	// The number of bytes to write to disk when intermediate nodes are evicted
 	// from the write buffer and written to disk.
 	IntermediateWriteBatchSize uint
+	// The number of nodes of a single type that are deleted before the range
+	// of keys they were stored in is compacted. If 0, merkledb never requests
+	// compactions.
+	CompactionHintThreshold uint
 	// If [Reg] is nil, metrics are collected locally but not exported through
 	// Prometheus.
 	// This may be useful for testing.
@@ -215,6 +239,12 @@ type merkleDB struct {
 	// historical views of the trie.
 	history *trieHistory
 
+	// The number of the most recent change lists that are persisted in
+	// [baseDB].
+	persistedHistoryLength uint64
+	// The number to persist the next change list with.
+	nextHistoryNumber uint64
+
 	// True iff the db has been closed.
 	closed bool
 
@@ -248,6 +278,11 @@ func New(ctx context.Context, db database.Database, config Config) (MerkleDB, er
 	if err != nil {
 		return nil, err
 	}
+	if estimator, ok := db.(DiskUsageEstimator); ok && config.Reg != nil {
+		if err := registerDiskSizeMetrics("merkledb", config.Reg, estimator); err != nil {
+			return nil, err
+		}
+	}
 	return newDatabase(ctx, db, config, metrics)
 }
 
@@ -260,12 +295,43 @@ func newDatabase(
 	if err := config.BranchFactor.Valid(); err != nil {
 		return nil, err
 	}
+	if err := config.CachePolicy.Verify(); err != nil {
+		return nil, err
+	}
+	if config.PersistedHistoryLength > config.HistoryLength {
+		return nil, fmt.Errorf("%w: %d > %d",
+			errInvalidPersistedHistoryLength,
+			config.PersistedHistoryLength,
+			config.HistoryLength,
+		)
+	}
 
 	hasher := config.Hasher
 	if hasher == nil {
 		hasher = DefaultHasher
 	}
 
+	if config.CompactionHintThreshold != 0 {
+		db = newCompactionHintDB(db, config.CompactionHintThreshold)
+	}
+
+	intermediateNodeCache, err := cache.NewSizedCacher(
+		config.CachePolicy,
+		int(config.IntermediateNodeCacheSize),
+		cacheEntrySize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	valueNodeCache, err := cache.NewSizedCacher(
+		config.CachePolicy,
+		int(config.ValueNodeCacheSize),
+		cacheEntrySize,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	rootGenConcurrency := runtime.NumCPU()
 	if config.RootGenConcurrency != 0 {
 		rootGenConcurrency = int(config.RootGenConcurrency)
@@ -282,7 +348,7 @@ func newDatabase(
 			db,
 			bufferPool,
 			metrics,
-			int(config.IntermediateNodeCacheSize),
+			intermediateNodeCache,
 			int(config.IntermediateWriteBufferSize),
 			int(config.IntermediateWriteBatchSize),
 			BranchFactorToTokenSize[config.BranchFactor],
@@ -292,16 +358,17 @@ func newDatabase(
 			db,
 			bufferPool,
 			metrics,
-			int(config.ValueNodeCacheSize),
+			valueNodeCache,
 			hasher,
 		),
-		history:          newTrieHistory(int(config.HistoryLength)),
-		debugTracer:      getTracerIfEnabled(config.TraceLevel, DebugTrace, config.Tracer),
-		infoTracer:       getTracerIfEnabled(config.TraceLevel, InfoTrace, config.Tracer),
-		childViews:       make([]*view, 0, defaultPreallocationSize),
-		hashNodesKeyPool: newBytesPool(rootGenConcurrency),
-		tokenSize:        BranchFactorToTokenSize[config.BranchFactor],
-		hasher:           hasher,
+		history:                newTrieHistory(int(config.HistoryLength)),
+		persistedHistoryLength: uint64(config.PersistedHistoryLength),
+		debugTracer:            getTracerIfEnabled(config.TraceLevel, DebugTrace, config.Tracer),
+		infoTracer:             getTracerIfEnabled(config.TraceLevel, InfoTrace, config.Tracer),
+		childViews:             make([]*view, 0, defaultPreallocationSize),
+		hashNodesKeyPool:       newBytesPool(rootGenConcurrency),
+		tokenSize:              BranchFactorToTokenSize[config.BranchFactor],
+		hasher:                 hasher,
 	}
 
 	shutdownType, err := trieDB.baseDB.Get(cleanShutdownKey)
@@ -324,6 +391,10 @@ func newDatabase(
 		}
 	}
 
+	if err := trieDB.loadHistory(); err != nil {
+		return nil, err
+	}
+
 	// add current root to history (has no changes)
 	trieDB.history.record(&changeSummary{
 		rootID: trieDB.rootID,
@@ -345,7 +416,7 @@ func (db *merkleDB) rebuild(ctx context.Context, cacheSize int) error {
 	db.rootID = ids.Empty
 
 	// Delete intermediate nodes.
-	if err := database.ClearPrefix(db.baseDB, intermediateNodePrefix, rebuildIntermediateDeletionWriteSize); err != nil {
+	if err := clearPrefix(db.baseDB, intermediateNodePrefix, rebuildIntermediateDeletionWriteSize); err != nil {
 		return err
 	}
 
@@ -505,7 +576,16 @@ func (db *merkleDB) Close() error {
 	if err := batch.Put(cleanShutdownKey, hadCleanShutdown); err != nil {
 		return err
 	}
-	return batch.Write()
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	// The caller may close the database once this returns, so compactions
+	// running in the background must finish first.
+	if hintDB, ok := db.baseDB.(*compactionHintDB); ok {
+		return hintDB.awaitCompactions()
+	}
+	return nil
 }
 
 func (db *merkleDB) PrefetchPaths(keys [][]byte) error {
@@ -986,9 +1066,14 @@ func (db *merkleDB) commitView(ctx context.Context, trieToCommit *view) error {
 		return err
 	}
 
+	if err := db.persistChanges(valueNodeBatch, changes); err != nil {
+		return err
+	}
+
 	if err := db.commitValueChanges(ctx, valueNodeBatch); err != nil {
 		return err
 	}
+	db.historyPersisted()
 
 	db.history.record(changes)
 
@@ -1363,6 +1448,10 @@ func (db *merkleDB) Clear() error {
 	db.rootID = ids.Empty
 
 	// Clear history
+	if err := atomicClearPrefix(db.baseDB, historyPrefix); err != nil {
+		return err
+	}
+	db.nextHistoryNumber = 0
 	db.history = newTrieHistory(db.history.maxHistoryLen)
 	db.history.record(&changeSummary{
 		rootID: db.rootID,
@@ -255,6 +255,12 @@ func (th *trieHistory) getChangesToGetToRoot(rootID ids.ID, start maybe.Maybe[[]
 	for i := mostRecentChangeIndex; i > lastRootChangeIndex; i-- {
 		changes, _ := th.history.Index(i)
 
+		// Changes loaded from disk only include the value changes, so they
+		// can't be reverted.
+		if changes.nodes == nil {
+			return nil, fmt.Errorf("%w: node changes resulting in %s were not persisted", ErrInsufficientHistory, changes.rootID)
+		}
+
 		if i == mostRecentChangeIndex {
 			combinedChanges.rootChange.before = changes.rootChange.after
 		}
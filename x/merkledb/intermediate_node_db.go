@@ -40,7 +40,7 @@ func newIntermediateNodeDB(
 	db database.Database,
 	bufferPool *utils.BytesPool,
 	metrics metrics,
-	cacheSize int,
+	nodeCache cache.Cacher[Key, *node],
 	writeBufferSize int,
 	evictionBatchSize int,
 	tokenSize int,
@@ -53,7 +53,7 @@ func newIntermediateNodeDB(
 		evictionBatchSize: evictionBatchSize,
 		tokenSize:         tokenSize,
 		hasher:            hasher,
-		nodeCache:         cache.NewSizedLRU(cacheSize, cacheEntrySize),
+		nodeCache:         nodeCache,
 	}
 	result.writeBuffer = newOnEvictCache(
 		writeBufferSize,
@@ -192,5 +192,5 @@ func (db *intermediateNodeDB) Clear() error {
 		db.writeBuffer.size,
 		db.writeBuffer.onEviction,
 	)
-	return database.AtomicClearPrefix(db.baseDB, db.baseDB, intermediateNodePrefix)
+	return atomicClearPrefix(db.baseDB, intermediateNodePrefix)
 }
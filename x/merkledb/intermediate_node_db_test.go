@@ -11,6 +11,7 @@ import (
 
 	"github.com/luxfi/database"
 	"github.com/luxfi/database/memdb"
+	"github.com/luxfi/node/cache"
 	"github.com/luxfi/node/utils"
 	"github.com/luxfi/node/utils/maybe"
 	"github.com/luxfi/node/utils/units"
@@ -39,7 +40,7 @@ func Test_IntermediateNodeDB(t *testing.T) {
 		baseDB,
 		utils.NewBytesPool(),
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		bufferSize,
 		evictionBatchSize,
 		4,
@@ -152,7 +153,7 @@ func FuzzIntermediateNodeDBConstructDBKey(f *testing.F) {
 				baseDB,
 				utils.NewBytesPool(),
 				&mockMetrics{},
-				cacheSize,
+				cache.NewSizedLRU(cacheSize, cacheEntrySize),
 				bufferSize,
 				evictionBatchSize,
 				tokenSize,
@@ -194,7 +195,7 @@ func Test_IntermediateNodeDB_ConstructDBKey_DirtyBuffer(t *testing.T) {
 		baseDB,
 		utils.NewBytesPool(),
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		bufferSize,
 		evictionBatchSize,
 		4,
@@ -226,7 +227,7 @@ func TestIntermediateNodeDBClear(t *testing.T) {
 		baseDB,
 		utils.NewBytesPool(),
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		bufferSize,
 		evictionBatchSize,
 		4,
@@ -261,7 +262,7 @@ func TestIntermediateNodeDBDeleteEmptyKey(t *testing.T) {
 		baseDB,
 		utils.NewBytesPool(),
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		bufferSize,
 		evictionBatchSize,
 		4,
@@ -293,7 +294,7 @@ func Benchmark_IntermediateNodeDB_ConstructDBKey(b *testing.B) {
 			memdb.New(),
 			utils.NewBytesPool(),
 			&mockMetrics{},
-			units.MiB,
+			cache.NewSizedLRU(units.MiB, cacheEntrySize),
 			units.MiB,
 			units.MiB,
 			tokenSize,
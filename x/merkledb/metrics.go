@@ -24,6 +24,12 @@ const (
 	lookupResult = "result"
 	hitResult    = "hit"
 	missResult   = "miss"
+
+	keySpaceType         = "type"
+	metadataType         = "metadata"
+	valueNodeType        = "valueNode"
+	intermediateNodeType = "intermediateNode"
+	historyType          = "history"
 )
 
 var (
@@ -73,6 +79,49 @@ var (
 	}
 )
 
+// registerDiskSizeMetrics registers a gauge per node type reporting the
+// number of bytes [estimator] uses on disk to store that node type.
+func registerDiskSizeMetrics(
+	namespace string,
+	reg prometheus.Registerer,
+	estimator DiskUsageEstimator,
+) error {
+	keySpaces := []struct {
+		name   string
+		prefix []byte
+	}{
+		{name: metadataType, prefix: metadataPrefix},
+		{name: valueNodeType, prefix: valueNodePrefix},
+		{name: intermediateNodeType, prefix: intermediateNodePrefix},
+		{name: historyType, prefix: historyPrefix},
+	}
+	errs := make([]error, 0, len(keySpaces))
+	for _, keySpace := range keySpaces {
+		var (
+			start = keySpace.prefix
+			end   = prefixUpperBound(keySpace.prefix)
+		)
+		errs = append(errs, reg.Register(prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "disk_size",
+				Help:      "estimated number of bytes used on disk",
+				ConstLabels: prometheus.Labels{
+					keySpaceType: keySpace.name,
+				},
+			},
+			func() float64 {
+				size, err := estimator.EstimateDiskUsage(start, end)
+				if err != nil {
+					return 0
+				}
+				return float64(size)
+			},
+		)))
+	}
+	return errors.Join(errs...)
+}
+
 type metrics interface {
 	HashCalculated()
 	DatabaseNodeRead()
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=x/merkledb/db.go -destination=x/merkledb/mock_db.go -package=merkledb -exclude_interfaces=ChangeProofer,RangeProofer,Clearer,Prefetcher
+//	mockgen -source=x/merkledb/db.go -destination=x/merkledb/mock_db.go -package=merkledb -exclude_interfaces=ChangeProofer,RangeProofer,Clearer,BulkLoader,Prefetcher
 //
 
 // Package merkledb is a generated GoMock package.
@@ -42,6 +42,20 @@ func (m *MockMerkleDB) EXPECT() *MockMerkleDBMockRecorder {
 	return m.recorder
 }
 
+// BulkLoad mocks base method.
+func (m *MockMerkleDB) BulkLoad(ctx context.Context, it database.Iterator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkLoad", ctx, it)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkLoad indicates an expected call of BulkLoad.
+func (mr *MockMerkleDBMockRecorder) BulkLoad(ctx, it any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkLoad", reflect.TypeOf((*MockMerkleDB)(nil).BulkLoad), ctx, it)
+}
+
 // Clear mocks base method.
 func (m *MockMerkleDB) Clear() error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,310 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package pebbledb implements a [database.Database] backed by pebble for
+// storing merkledb tries.
+//
+// Every merkledb node type is stored under its own key prefix, so each node
+// type occupies a contiguous key space, similar to a column family. Unlike the
+// pebble database of [github.com/luxfi/database/pebbledb], the database
+// natively supports deleting a key space without iterating over it, and
+// estimating the disk usage of a key space, which merkledb uses to prune nodes
+// and to report the on-disk size of each node type. The upstream database
+// doesn't expose its pebble handle, so pebble is opened directly.
+package pebbledb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/node/utils/units"
+)
+
+var (
+	_ database.Database = (*Database)(nil)
+
+	errInvalidRange = errors.New("range start must be less than range end")
+
+	// Matches the write options of the upstream database.
+	writeOptions = &pebble.WriteOptions{Sync: true}
+
+	DefaultConfig = Config{
+		CacheSize:    128,
+		MaxOpenFiles: 4096,
+	}
+)
+
+type Config struct {
+	// CacheSize is the number of megabytes of blocks that are cached in
+	// memory.
+	CacheSize int `json:"cacheSize"`
+	// MaxOpenFiles is the maximum number of files that are kept open.
+	MaxOpenFiles int `json:"maxOpenFiles"`
+}
+
+// Database is a persistent key-value store backed by pebble with support for
+// range deletions and disk usage estimates.
+type Database struct {
+	lock          sync.RWMutex
+	pebbleDB      *pebble.DB
+	closed        bool
+	openIterators map[*iter]struct{}
+}
+
+// New opens the pebble database at [path].
+func New(path string, config Config) (*Database, error) {
+	// Matches the options of the upstream database.
+	opts := &pebble.Options{
+		Cache:                       pebble.NewCache(int64(config.CacheSize) * units.MiB),
+		MaxOpenFiles:                config.MaxOpenFiles,
+		MaxConcurrentCompactions:    runtime.NumCPU,
+		L0CompactionThreshold:       2,
+		L0StopWritesThreshold:       1000,
+		LBaseMaxBytes:               64 * units.MiB,
+		MaxManifestFileSize:         128 * units.MiB,
+		MemTableSize:                4 * units.MiB,
+		MemTableStopWritesThreshold: 2,
+		Levels:                      make([]pebble.LevelOptions, 7),
+	}
+	defer opts.Cache.Unref()
+
+	for i := range opts.Levels {
+		level := &opts.Levels[i]
+		level.BlockSize = 32 * units.KiB
+		level.IndexBlockSize = 256 * units.KiB
+		level.FilterPolicy = bloom.FilterPolicy(10)
+		level.FilterType = pebble.TableFilter
+		if i == 0 {
+			level.TargetFileSize = 2 * units.MiB
+		} else {
+			level.TargetFileSize = opts.Levels[i-1].TargetFileSize * 2
+		}
+	}
+
+	pebbleDB, err := pebble.Open(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Database{
+		pebbleDB:      pebbleDB,
+		openIterators: make(map[*iter]struct{}),
+	}, nil
+}
+
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	db.closed = true
+
+	for it := range db.openIterators {
+		it.lock.Lock()
+		it.release()
+		it.lock.Unlock()
+	}
+	clear(db.openIterators)
+	return updateError(db.pebbleDB.Close())
+}
+
+func (db *Database) HealthCheck(context.Context) (interface{}, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	return nil, nil
+}
+
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return false, database.ErrClosed
+	}
+
+	_, closer, err := db.pebbleDB.Get(key)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, updateError(err)
+	}
+	return true, closer.Close()
+}
+
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	value, closer, err := db.pebbleDB.Get(key)
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return bytes.Clone(value), closer.Close()
+}
+
+func (db *Database) Put(key []byte, value []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.pebbleDB.Set(key, value, writeOptions))
+}
+
+func (db *Database) Delete(key []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.pebbleDB.Delete(key, writeOptions))
+}
+
+// DeleteRange deletes every key in [start, end) by writing a single range
+// tombstone, rather than a tombstone for every key.
+func (db *Database) DeleteRange(start, end []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	if bytes.Compare(start, end) >= 0 {
+		return errInvalidRange
+	}
+	return updateError(db.pebbleDB.DeleteRange(start, end, writeOptions))
+}
+
+// Compact compacts the keys in [start, limit). A nil [start] is treated as a
+// key before all keys and a nil [limit] is treated as a key after all keys.
+func (db *Database) Compact(start []byte, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	if limit == nil {
+		// pebble treats a nil limit as a key before all keys, so the last key
+		// in the database is used instead.
+		it, err := db.pebbleDB.NewIter(&pebble.IterOptions{
+			LowerBound: start,
+		})
+		if err != nil {
+			return updateError(err)
+		}
+		if !it.Last() {
+			// There is nothing to compact.
+			return it.Close()
+		}
+		limit = append(bytes.Clone(it.Key()), 0)
+		if err := it.Close(); err != nil {
+			return updateError(err)
+		}
+	}
+	if bytes.Compare(start, limit) >= 0 {
+		return nil
+	}
+	return updateError(db.pebbleDB.Compact(start, limit, true /* parallelize */))
+}
+
+// EstimateDiskUsage returns the approximate number of bytes used on disk to
+// store the keys in [start, end).
+func (db *Database) EstimateDiskUsage(start, end []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return 0, database.ErrClosed
+	}
+	size, err := db.pebbleDB.EstimateDiskUsage(start, end)
+	return size, updateError(err)
+}
+
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+
+	opts := &pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixEnd(prefix),
+	}
+	if bytes.Compare(start, prefix) > 0 {
+		opts.LowerBound = start
+	}
+	pebbleIter, err := db.pebbleDB.NewIter(opts)
+	if err != nil {
+		return &database.IteratorError{
+			Err: updateError(err),
+		}
+	}
+
+	it := &iter{
+		db:   db,
+		iter: pebbleIter,
+	}
+	db.openIterators[it] = struct{}{}
+	return it
+}
+
+// prefixEnd returns the smallest key that is larger than every key with
+// [prefix], or nil if there is no such key.
+func prefixEnd(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			end := bytes.Clone(prefix[:i+1])
+			end[i]++
+			return end
+		}
+	}
+	return nil
+}
+
+func updateError(err error) error {
+	switch {
+	case errors.Is(err, pebble.ErrClosed):
+		return database.ErrClosed
+	case errors.Is(err, pebble.ErrNotFound):
+		return database.ErrNotFound
+	default:
+		return err
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build test
+
+package pebbledb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/database/dbtest"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/units"
+	"github.com/luxfi/node/x/merkledb"
+	"github.com/luxfi/trace"
+)
+
+var (
+	_ merkledb.RangeDeleter       = (*Database)(nil)
+	_ merkledb.DiskUsageEstimator = (*Database)(nil)
+)
+
+func newDB(t testing.TB) *Database {
+	config := DefaultConfig
+	config.CacheSize = 1
+
+	db, err := New(t.TempDir(), config)
+	require.NoError(t, err)
+	return db
+}
+
+func newMerkleDBConfig(reg prometheus.Registerer) merkledb.Config {
+	return merkledb.Config{
+		BranchFactor:                merkledb.BranchFactor16,
+		Hasher:                      merkledb.DefaultHasher,
+		HistoryLength:               100,
+		PersistedHistoryLength:      10,
+		ValueNodeCacheSize:          units.MiB,
+		IntermediateNodeCacheSize:   units.MiB,
+		IntermediateWriteBufferSize: units.KiB,
+		IntermediateWriteBatchSize:  256,
+		CompactionHintThreshold:     64,
+		Reg:                         reg,
+		Tracer:                      trace.Noop,
+	}
+}
+
+func TestInterface(t *testing.T) {
+	for name, test := range dbtest.Tests {
+		t.Run(name, func(t *testing.T) {
+			db := newDB(t)
+			test(t, db)
+			_ = db.Close()
+		})
+	}
+}
+
+func TestDeleteRange(t *testing.T) {
+	require := require.New(t)
+
+	db := newDB(t)
+	defer db.Close()
+
+	for _, key := range []string{"a", "b1", "b2", "c"} {
+		require.NoError(db.Put([]byte(key), []byte(key)))
+	}
+
+	require.ErrorIs(db.DeleteRange([]byte("c"), []byte("b")), errInvalidRange)
+	require.NoError(db.DeleteRange([]byte("b"), []byte("c")))
+
+	for key, expected := range map[string]bool{
+		"a":  true,
+		"b1": false,
+		"b2": false,
+		"c":  true,
+	} {
+		has, err := db.Has([]byte(key))
+		require.NoError(err)
+		require.Equal(expected, has, key)
+	}
+}
+
+func TestEstimateDiskUsage(t *testing.T) {
+	require := require.New(t)
+
+	db := newDB(t)
+	defer db.Close()
+
+	value := make([]byte, units.KiB)
+	for i := 0; i < 1024; i++ {
+		require.NoError(db.Put([]byte(fmt.Sprintf("a%04d", i)), value))
+	}
+	// Flush the memtable so the keys are stored in sstables.
+	require.NoError(db.Compact(nil, nil))
+
+	size, err := db.EstimateDiskUsage([]byte("a"), []byte("b"))
+	require.NoError(err)
+	require.Positive(size)
+
+	size, err = db.EstimateDiskUsage([]byte("b"), []byte("c"))
+	require.NoError(err)
+	require.Zero(size)
+
+	require.NoError(db.DeleteRange([]byte("a"), []byte("b")))
+	require.NoError(db.Compact([]byte("a"), []byte("b")))
+
+	size, err = db.EstimateDiskUsage([]byte("a"), []byte("b"))
+	require.NoError(err)
+	require.Zero(size)
+}
+
+func TestClosed(t *testing.T) {
+	require := require.New(t)
+
+	db := newDB(t)
+	require.NoError(db.Close())
+
+	require.ErrorIs(db.DeleteRange([]byte("a"), []byte("b")), database.ErrClosed)
+	_, err := db.EstimateDiskUsage([]byte("a"), []byte("b"))
+	require.ErrorIs(err, database.ErrClosed)
+}
+
+func TestMerkleDB(t *testing.T) {
+	require := require.New(t)
+
+	db := newDB(t)
+	reg := prometheus.NewRegistry()
+	trie, err := merkledb.New(
+		context.Background(),
+		db,
+		newMerkleDBConfig(reg),
+	)
+	require.NoError(err)
+
+	value := make([]byte, units.KiB)
+	for i := 0; i < 512; i++ {
+		require.NoError(trie.Put([]byte(fmt.Sprintf("key%04d", i)), value))
+	}
+	for i := 0; i < 512; i += 2 {
+		require.NoError(trie.Delete([]byte(fmt.Sprintf("key%04d", i))))
+	}
+	rootID, err := trie.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.NoError(trie.Close())
+
+	metrics, err := reg.Gather()
+	require.NoError(err)
+	var numDiskSizes int
+	for _, metric := range metrics {
+		if metric.GetName() == "merkledb_disk_size" {
+			numDiskSizes = len(metric.GetMetric())
+		}
+	}
+	require.Equal(4, numDiskSizes)
+
+	trie, err = merkledb.New(
+		context.Background(),
+		db,
+		newMerkleDBConfig(nil),
+	)
+	require.NoError(err)
+
+	reopenedRootID, err := trie.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(rootID, reopenedRootID)
+
+	for i := 0; i < 512; i++ {
+		_, err := trie.Get([]byte(fmt.Sprintf("key%04d", i)))
+		if i%2 == 0 {
+			require.ErrorIs(err, database.ErrNotFound)
+		} else {
+			require.NoError(err)
+		}
+	}
+
+	require.NoError(trie.Clear())
+	rootID, err = trie.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(ids.Empty, rootID)
+	require.NoError(trie.Close())
+	require.NoError(db.Close())
+}
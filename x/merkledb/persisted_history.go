@@ -0,0 +1,220 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils"
+	"github.com/luxfi/node/utils/maybe"
+)
+
+const historyNumberLen = 8
+
+var (
+	historyPrefix = []byte{3}
+
+	errInvalidPersistedHistoryLength = errors.New("persisted history length must not exceed history length")
+	errInvalidHistoryKey             = errors.New("invalid history key")
+)
+
+// historyKey returns the database key of the change with the provided number.
+//
+// Numbers are big endian encoded so that iterating over [historyPrefix]
+// returns the changes in the order they were committed.
+func historyKey(number uint64) []byte {
+	key := make([]byte, len(historyPrefix)+historyNumberLen)
+	copy(key, historyPrefix)
+	binary.BigEndian.PutUint64(key[len(historyPrefix):], number)
+	return key
+}
+
+// encodeValueChanges serializes the root ID and the value changes of
+// [changes]. Node changes are not included.
+func encodeValueChanges(changes *changeSummary) []byte {
+	keys := maps.Keys(changes.values)
+	utils.Sort(keys)
+
+	w := codecWriter{
+		b: make([]byte, 0, ids.IDLen+uintSize(uint64(len(keys)))),
+	}
+	w.ID(changes.rootID)
+	w.Uvarint(uint64(len(keys)))
+	for _, key := range keys {
+		valueChange := changes.values[key]
+		w.Key(key)
+		w.MaybeBytes(valueChange.before)
+		w.MaybeBytes(valueChange.after)
+	}
+	return w.b
+}
+
+// decodeValueChanges parses the output of [encodeValueChanges].
+//
+// The returned summary doesn't contain any node changes, so it can be used to
+// serve change proofs but not to construct historical views of the trie.
+func decodeValueChanges(b []byte) (*changeSummary, error) {
+	r := codecReader{
+		b:    b,
+		copy: true,
+	}
+	rootID, err := r.ID()
+	if err != nil {
+		return nil, err
+	}
+	numValues, err := r.Uvarint()
+	if err != nil {
+		return nil, err
+	}
+	// Each value change requires at least 3 bytes.
+	if numValues > uint64(len(r.b)) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	values := make(map[Key]*change[maybe.Maybe[[]byte]], numValues)
+	for i := uint64(0); i < numValues; i++ {
+		key, err := r.Key()
+		if err != nil {
+			return nil, err
+		}
+		before, err := r.MaybeBytes()
+		if err != nil {
+			return nil, err
+		}
+		after, err := r.MaybeBytes()
+		if err != nil {
+			return nil, err
+		}
+		values[key] = &change[maybe.Maybe[[]byte]]{
+			before: before,
+			after:  after,
+		}
+	}
+	if len(r.b) != 0 {
+		return nil, errExtraSpace
+	}
+	return &changeSummary{
+		rootID: rootID,
+		values: values,
+	}, nil
+}
+
+// persistChanges adds [changes] to the persisted history in [batch] and
+// removes the change that falls outside of the persisted window.
+//
+// [db.nextHistoryNumber] isn't incremented, so that a failed write of [batch]
+// doesn't leave a gap in the persisted history. The caller must call
+// [db.historyPersisted] once [batch] has been written.
+//
+// Assumes [db.lock] is held.
+func (db *merkleDB) persistChanges(batch database.KeyValueWriterDeleter, changes *changeSummary) error {
+	if db.persistedHistoryLength == 0 {
+		return nil
+	}
+
+	number := db.nextHistoryNumber
+	if err := batch.Put(historyKey(number), encodeValueChanges(changes)); err != nil {
+		return err
+	}
+
+	if number < db.persistedHistoryLength {
+		return nil
+	}
+	return batch.Delete(historyKey(number - db.persistedHistoryLength))
+}
+
+// historyPersisted marks the changes added by [db.persistChanges] as written.
+//
+// Assumes [db.lock] is held.
+func (db *merkleDB) historyPersisted() {
+	if db.persistedHistoryLength != 0 {
+		db.nextHistoryNumber++
+	}
+}
+
+// loadHistory adds the persisted changes to the history.
+//
+// Persisted changes that fall outside of the persisted window are deleted. If
+// the persisted changes don't end with the current root, for example because
+// persistence was disabled while the db was last open, all of them are
+// deleted.
+//
+// Assumes the root has been initialized.
+func (db *merkleDB) loadHistory() error {
+	var (
+		numbers []uint64
+		changes []*changeSummary
+	)
+	it := db.baseDB.NewIteratorWithPrefix(historyPrefix)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(historyPrefix)+historyNumberLen {
+			it.Release()
+			return fmt.Errorf("%w: %x", errInvalidHistoryKey, key)
+		}
+		number := binary.BigEndian.Uint64(key[len(historyPrefix):])
+
+		// The history must be contiguous, so a gap discards all of the
+		// earlier changes.
+		if len(numbers) > 0 && numbers[len(numbers)-1]+1 != number {
+			numbers = numbers[:0]
+			changes = changes[:0]
+		}
+
+		valueChanges, err := decodeValueChanges(it.Value())
+		if err != nil {
+			it.Release()
+			return fmt.Errorf("failed to decode change %d: %w", number, err)
+		}
+		numbers = append(numbers, number)
+		changes = append(changes, valueChanges)
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+
+	if db.persistedHistoryLength == 0 ||
+		len(changes) == 0 ||
+		changes[len(changes)-1].rootID != db.rootID {
+		db.nextHistoryNumber = 0
+		return clearPrefix(db.baseDB, historyPrefix, rebuildIntermediateDeletionWriteSize)
+	}
+
+	// Only keep the changes within the persisted window.
+	numToDrop := max(len(changes)-int(db.persistedHistoryLength), 0)
+	for _, valueChanges := range changes[numToDrop:] {
+		db.history.record(valueChanges)
+	}
+	db.nextHistoryNumber = numbers[len(numbers)-1] + 1
+
+	var (
+		firstToKeep = numbers[numToDrop]
+		batch       = db.baseDB.NewBatch()
+	)
+	it = db.baseDB.NewIteratorWithPrefix(historyPrefix)
+	defer it.Release()
+	for it.Next() {
+		key := it.Key()
+		if binary.BigEndian.Uint64(key[len(historyPrefix):]) >= firstToKeep {
+			break
+		}
+		if err := batch.Delete(slices.Clone(key)); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
@@ -0,0 +1,275 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build test
+
+package merkledb
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/database/memdb"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/maybe"
+)
+
+func Test_ValueChanges_EncodeDecode(t *testing.T) {
+	require := require.New(t)
+
+	changes := &changeSummary{
+		rootID: ids.GenerateTestID(),
+		values: map[Key]*change[maybe.Maybe[[]byte]]{
+			ToKey([]byte("added")): {
+				before: maybe.Nothing[[]byte](),
+				after:  maybe.Some([]byte("value")),
+			},
+			ToKey([]byte("removed")): {
+				before: maybe.Some([]byte("value")),
+				after:  maybe.Nothing[[]byte](),
+			},
+			ToKey([]byte("updated")): {
+				before: maybe.Some([]byte{}),
+				after:  maybe.Some([]byte("value")),
+			},
+		},
+	}
+
+	b := encodeValueChanges(changes)
+	parsedChanges, err := decodeValueChanges(b)
+	require.NoError(err)
+	require.Equal(changes, parsedChanges)
+
+	_, err = decodeValueChanges(append(b, 0))
+	require.ErrorIs(err, errExtraSpace)
+}
+
+func Test_PersistedHistory_InvalidConfig(t *testing.T) {
+	config := newDefaultConfig()
+	config.HistoryLength = 5
+	config.PersistedHistoryLength = 6
+
+	_, err := newDatabase(
+		context.Background(),
+		memdb.New(),
+		config,
+		&mockMetrics{},
+	)
+	require.ErrorIs(t, err, errInvalidPersistedHistoryLength)
+}
+
+func Test_PersistedHistory_Restart(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		historyLength          = 10
+		persistedHistoryLength = 5
+		numBatches             = 8
+	)
+
+	config := newDefaultConfig()
+	config.HistoryLength = historyLength
+	config.PersistedHistoryLength = persistedHistoryLength
+
+	baseDB := memdb.New()
+	db, err := newDatabase(
+		context.Background(),
+		baseDB,
+		config,
+		&mockMetrics{},
+	)
+	require.NoError(err)
+
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+	roots := []ids.ID{db.getMerkleRoot()}
+	for i := 0; i < numBatches; i++ {
+		batch := db.NewBatch()
+		for j := 0; j < 10; j++ {
+			key := []byte(strconv.Itoa(r.Intn(50)))
+			if r.Intn(4) == 0 {
+				require.NoError(batch.Delete(key))
+				continue
+			}
+			value := make([]byte, r.Intn(16))
+			_, _ = r.Read(value)
+			require.NoError(batch.Put(key, value))
+		}
+		require.NoError(batch.Write())
+		roots = append(roots, db.getMerkleRoot())
+	}
+
+	// Only the changes in the persisted window should be served after the
+	// restart.
+	firstPersistedRoot := roots[len(roots)-persistedHistoryLength]
+	endRoot := roots[len(roots)-1]
+	expectedProof, err := db.GetChangeProof(
+		context.Background(),
+		firstPersistedRoot,
+		endRoot,
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	db, err = newDatabase(
+		context.Background(),
+		baseDB,
+		config,
+		&mockMetrics{},
+	)
+	require.NoError(err)
+	require.Equal(endRoot, db.getMerkleRoot())
+
+	proof, err := db.GetChangeProof(
+		context.Background(),
+		firstPersistedRoot,
+		endRoot,
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.NoError(err)
+	require.Equal(expectedProof, proof)
+	require.NoError(db.VerifyChangeProof(
+		context.Background(),
+		proof,
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		endRoot,
+	))
+
+	_, err = db.GetChangeProof(
+		context.Background(),
+		roots[len(roots)-1-persistedHistoryLength],
+		endRoot,
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	// Historical views require node changes, which aren't persisted.
+	_, err = db.GetRangeProofAtRoot(
+		context.Background(),
+		firstPersistedRoot,
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	// Changes made after the restart should extend the persisted history.
+	batch := db.NewBatch()
+	require.NoError(batch.Put([]byte("new key"), []byte("new value")))
+	require.NoError(batch.Write())
+	newRoot := db.getMerkleRoot()
+
+	_, err = db.GetChangeProof(
+		context.Background(),
+		endRoot,
+		newRoot,
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	// Reopening without persisting history should discard the persisted
+	// changes.
+	config.PersistedHistoryLength = 0
+	db, err = newDatabase(
+		context.Background(),
+		baseDB,
+		config,
+		&mockMetrics{},
+	)
+	require.NoError(err)
+
+	it := baseDB.NewIteratorWithPrefix(historyPrefix)
+	defer it.Release()
+	require.False(it.Next())
+	require.NoError(it.Error())
+
+	_, err = db.GetChangeProof(
+		context.Background(),
+		roots[len(roots)-2],
+		endRoot,
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.ErrorIs(err, ErrInsufficientHistory)
+}
+
+type failingWriteDB struct {
+	database.Database
+	failWrites bool
+}
+
+func (db *failingWriteDB) NewBatch() database.Batch {
+	return &failingWriteBatch{
+		Batch: db.Database.NewBatch(),
+		db:    db,
+	}
+}
+
+type failingWriteBatch struct {
+	database.Batch
+	db *failingWriteDB
+}
+
+func (b *failingWriteBatch) Write() error {
+	if b.db.failWrites {
+		return errTest
+	}
+	return b.Batch.Write()
+}
+
+func Test_PersistedHistory_FailedWrite(t *testing.T) {
+	require := require.New(t)
+
+	config := newDefaultConfig()
+	config.HistoryLength = 10
+	config.PersistedHistoryLength = 5
+
+	baseDB := &failingWriteDB{Database: memdb.New()}
+	db, err := newDatabase(
+		context.Background(),
+		baseDB,
+		config,
+		&mockMetrics{},
+	)
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte("key0"), []byte("value")))
+	require.Equal(uint64(1), db.nextHistoryNumber)
+
+	// A failed write must not consume a history number, otherwise the
+	// persisted history would have a gap.
+	baseDB.failWrites = true
+	err = db.Put([]byte("key1"), []byte("value"))
+	require.ErrorIs(err, errTest)
+	require.Equal(uint64(1), db.nextHistoryNumber)
+
+	baseDB.failWrites = false
+	require.NoError(db.Put([]byte("key1"), []byte("value")))
+	require.Equal(uint64(2), db.nextHistoryNumber)
+
+	it := baseDB.NewIteratorWithPrefix(historyPrefix)
+	defer it.Release()
+
+	var keys [][]byte
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	require.NoError(it.Error())
+	require.Equal([][]byte{historyKey(0), historyKey(1)}, keys)
+}
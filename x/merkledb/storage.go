@@ -0,0 +1,277 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/luxfi/database"
+)
+
+var (
+	errRangeDeletionNotSupported = errors.New("range deletion is not supported")
+
+	_ database.Database = (*compactionHintDB)(nil)
+	_ RangeDeleter      = (*compactionHintDB)(nil)
+	_ database.Batch    = (*compactionHintBatch)(nil)
+)
+
+// RangeDeleter is implemented by databases that can delete every key in a
+// range without iterating over it.
+//
+// If the database passed to [New] implements RangeDeleter, key spaces are
+// cleared with a single range deletion.
+type RangeDeleter interface {
+	// DeleteRange deletes every key in [start, end).
+	DeleteRange(start, end []byte) error
+}
+
+// DiskUsageEstimator is implemented by databases that can estimate the number
+// of bytes used on disk to store a range of keys.
+//
+// If the database passed to [New] implements DiskUsageEstimator, the on-disk
+// size of each node type is reported in the metrics.
+type DiskUsageEstimator interface {
+	// EstimateDiskUsage returns the approximate number of bytes used on disk
+	// to store the keys in [start, end).
+	EstimateDiskUsage(start, end []byte) (uint64, error)
+}
+
+// prefixUpperBound returns the smallest key that is larger than every key
+// with [prefix]. Every merkledb prefix has a last byte less than 0xff.
+func prefixUpperBound(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	end[len(end)-1]++
+	return end
+}
+
+// asRangeDeleter returns [db] as a RangeDeleter if the database it stores
+// keys in supports range deletions.
+func asRangeDeleter(db database.Database) (RangeDeleter, bool) {
+	if hintDB, ok := db.(*compactionHintDB); ok {
+		if _, ok := hintDB.Database.(RangeDeleter); !ok {
+			return nil, false
+		}
+	}
+	rangeDeleter, ok := db.(RangeDeleter)
+	return rangeDeleter, ok
+}
+
+// clearPrefix removes all keys in [db] with [prefix]. If [db] doesn't support
+// range deletions, keys are deleted in batches of [writeSize] bytes.
+func clearPrefix(db database.Database, prefix []byte, writeSize int) error {
+	if rangeDeleter, ok := asRangeDeleter(db); ok {
+		return rangeDeleter.DeleteRange(prefix, prefixUpperBound(prefix))
+	}
+	return database.ClearPrefix(db, prefix, writeSize)
+}
+
+// atomicClearPrefix removes all keys in [db] with [prefix] atomically.
+func atomicClearPrefix(db database.Database, prefix []byte) error {
+	if rangeDeleter, ok := asRangeDeleter(db); ok {
+		return rangeDeleter.DeleteRange(prefix, prefixUpperBound(prefix))
+	}
+	return database.AtomicClearPrefix(db, db, prefix)
+}
+
+// deletedRange is the range of keys in a key space that were deleted since
+// the key space was last compacted.
+type deletedRange struct {
+	start   []byte
+	end     []byte // inclusive
+	numKeys uint
+}
+
+func (r *deletedRange) add(key []byte) {
+	if r.numKeys == 0 || bytes.Compare(key, r.start) < 0 {
+		r.start = key
+	}
+	if r.numKeys == 0 || bytes.Compare(key, r.end) > 0 {
+		r.end = key
+	}
+	r.numKeys++
+}
+
+// compactionHintDB wraps a database and compacts the key space of a node type
+// once [threshold] of its keys have been deleted, so that disk space used by
+// pruned nodes is reclaimed rather than left behind as tombstones.
+//
+// Compactions are run in the background, one at a time, so that they don't
+// delay the write that crossed the threshold.
+type compactionHintDB struct {
+	database.Database
+
+	threshold uint
+
+	lock sync.Mutex
+	// Key space prefix byte --> keys deleted in that key space since it was
+	// last compacted.
+	deleted map[byte]*deletedRange
+	// [start, limit) ranges waiting to be compacted, in the order they were
+	// scheduled.
+	pending [][2][]byte
+	// True while a goroutine is compacting the pending ranges.
+	compacting bool
+	// The first error returned by a background compaction.
+	compactionErr error
+	// Done when no goroutine is compacting the pending ranges.
+	compactions sync.WaitGroup
+}
+
+func newCompactionHintDB(db database.Database, threshold uint) *compactionHintDB {
+	return &compactionHintDB{
+		Database:  db,
+		threshold: threshold,
+		deleted:   make(map[byte]*deletedRange),
+	}
+}
+
+func (db *compactionHintDB) Delete(key []byte) error {
+	if err := db.Database.Delete(key); err != nil {
+		return err
+	}
+	db.recordDeletes([][]byte{bytes.Clone(key)})
+	return nil
+}
+
+// DeleteRange deletes every key in [start, end) if the wrapped database
+// supports range deletions. The key space is compacted right after.
+func (db *compactionHintDB) DeleteRange(start, end []byte) error {
+	rangeDeleter, ok := db.Database.(RangeDeleter)
+	if !ok {
+		return errRangeDeletionNotSupported
+	}
+	if err := rangeDeleter.DeleteRange(start, end); err != nil {
+		return err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.scheduleCompaction(bytes.Clone(start), bytes.Clone(end))
+	return nil
+}
+
+func (db *compactionHintDB) NewBatch() database.Batch {
+	return &compactionHintBatch{
+		Batch: db.Database.NewBatch(),
+		db:    db,
+	}
+}
+
+// recordDeletes records that [keys] were deleted and schedules the compaction
+// of every key space that reached [db.threshold] deleted keys. [keys] must not
+// be modified after this call.
+func (db *compactionHintDB) recordDeletes(keys [][]byte) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	for _, key := range keys {
+		if len(key) == 0 {
+			continue
+		}
+		r, ok := db.deleted[key[0]]
+		if !ok {
+			r = &deletedRange{}
+			db.deleted[key[0]] = r
+		}
+		r.add(key)
+	}
+
+	for prefix, r := range db.deleted {
+		if r.numKeys < db.threshold {
+			continue
+		}
+		delete(db.deleted, prefix)
+
+		// Compact's limit is exclusive, so the smallest key after [r.end] is
+		// used.
+		db.scheduleCompaction(r.start, append(bytes.Clone(r.end), 0))
+	}
+}
+
+// scheduleCompaction compacts [start, limit) in the background.
+//
+// Assumes [db.lock] is held.
+func (db *compactionHintDB) scheduleCompaction(start, limit []byte) {
+	db.pending = append(db.pending, [2][]byte{start, limit})
+	if db.compacting {
+		return
+	}
+	db.compacting = true
+	db.compactions.Add(1)
+	go db.compact()
+}
+
+// compact compacts the pending ranges until there are none left.
+func (db *compactionHintDB) compact() {
+	defer db.compactions.Done()
+
+	for {
+		db.lock.Lock()
+		if len(db.pending) == 0 {
+			db.compacting = false
+			db.lock.Unlock()
+			return
+		}
+		r := db.pending[0]
+		db.pending = db.pending[1:]
+		db.lock.Unlock()
+
+		err := db.Database.Compact(r[0], r[1])
+
+		db.lock.Lock()
+		if db.compactionErr == nil {
+			db.compactionErr = err
+		}
+		db.lock.Unlock()
+	}
+}
+
+// awaitCompactions waits for the scheduled compactions to finish and returns
+// the first error a background compaction returned.
+func (db *compactionHintDB) awaitCompactions() error {
+	db.compactions.Wait()
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.compactionErr
+}
+
+// compactionHintBatch records the keys deleted by a batch so that they are
+// counted towards the compaction threshold once the batch is written.
+type compactionHintBatch struct {
+	database.Batch
+
+	db      *compactionHintDB
+	deleted [][]byte
+}
+
+func (b *compactionHintBatch) Delete(key []byte) error {
+	if err := b.Batch.Delete(key); err != nil {
+		return err
+	}
+	b.deleted = append(b.deleted, bytes.Clone(key))
+	return nil
+}
+
+func (b *compactionHintBatch) Write() error {
+	if err := b.Batch.Write(); err != nil {
+		return err
+	}
+	b.db.recordDeletes(b.deleted)
+	return nil
+}
+
+func (b *compactionHintBatch) Reset() {
+	b.Batch.Reset()
+	b.deleted = nil
+}
+
+func (b *compactionHintBatch) Inner() database.Batch {
+	return b
+}
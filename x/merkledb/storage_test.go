@@ -0,0 +1,237 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database"
+	"github.com/luxfi/database/memdb"
+)
+
+// testStorageDB records the compactions requested of it.
+type testStorageDB struct {
+	database.Database
+
+	compactedRanges [][2][]byte
+}
+
+// testRangeDeleterDB additionally supports range deletions and records them.
+type testRangeDeleterDB struct {
+	*testStorageDB
+
+	deletedRanges [][2][]byte
+}
+
+func (db *testRangeDeleterDB) DeleteRange(start, end []byte) error {
+	db.deletedRanges = append(db.deletedRanges, [2][]byte{start, end})
+
+	it := db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	var keys [][]byte
+	for it.Next() && bytes.Compare(it.Key(), end) < 0 {
+		keys = append(keys, it.Key())
+	}
+	for _, key := range keys {
+		if err := db.Database.Delete(key); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func (db *testStorageDB) Compact(start []byte, limit []byte) error {
+	db.compactedRanges = append(db.compactedRanges, [2][]byte{start, limit})
+	return db.Database.Compact(start, limit)
+}
+
+func (db *testStorageDB) EstimateDiskUsage(start, end []byte) (uint64, error) {
+	it := db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	var size uint64
+	for it.Next() && bytes.Compare(it.Key(), end) < 0 {
+		size += uint64(len(it.Key()) + len(it.Value()))
+	}
+	return size, it.Error()
+}
+
+func TestClearPrefix(t *testing.T) {
+	for _, supportsRangeDeletion := range []bool{false, true} {
+		require := require.New(t)
+
+		baseDB := &testRangeDeleterDB{
+			testStorageDB: &testStorageDB{
+				Database: memdb.New(),
+			},
+		}
+		var db database.Database = baseDB.testStorageDB
+		if supportsRangeDeletion {
+			db = baseDB
+		}
+		for _, key := range [][]byte{{1, 0}, {2, 0}, {2, 1}, {3, 0}} {
+			require.NoError(db.Put(key, key))
+		}
+
+		require.NoError(clearPrefix(db, intermediateNodePrefix, 1))
+		require.NoError(atomicClearPrefix(db, historyPrefix))
+
+		for key, expected := range map[byte]bool{1: true, 2: false, 3: false} {
+			has, err := db.Has([]byte{key, 0})
+			require.NoError(err)
+			require.Equal(expected, has)
+		}
+		if supportsRangeDeletion {
+			require.Equal(
+				[][2][]byte{
+					{intermediateNodePrefix, {3}},
+					{historyPrefix, {4}},
+				},
+				baseDB.deletedRanges,
+			)
+		} else {
+			require.Empty(baseDB.deletedRanges)
+		}
+	}
+}
+
+func TestCompactionHintDB(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := &testRangeDeleterDB{
+		testStorageDB: &testStorageDB{
+			Database: memdb.New(),
+		},
+	}
+	db := newCompactionHintDB(baseDB, 3)
+
+	batch := db.NewBatch()
+	require.NoError(batch.Delete([]byte{2, 5}))
+	require.NoError(batch.Delete([]byte{1, 0}))
+	require.NoError(batch.Delete([]byte{2, 1}))
+	require.NoError(batch.Put([]byte{2, 9}, nil))
+	require.NoError(batch.Write())
+	require.NoError(db.awaitCompactions())
+	require.Empty(baseDB.compactedRanges)
+
+	// The third deletion in the intermediate node key space compacts the
+	// range of deleted intermediate nodes.
+	require.NoError(db.Delete([]byte{2, 3}))
+	require.NoError(db.awaitCompactions())
+	require.Equal([][2][]byte{{{2, 1}, {2, 5, 0}}}, baseDB.compactedRanges)
+
+	// Deletions are counted from the last compaction.
+	batch.Reset()
+	require.NoError(batch.Delete([]byte{2, 7}))
+	require.NoError(batch.Delete([]byte{1, 1}))
+	require.NoError(batch.Write())
+	require.NoError(db.awaitCompactions())
+	require.Len(baseDB.compactedRanges, 1)
+
+	batch.Reset()
+	require.NoError(batch.Delete([]byte{2, 8}))
+	require.NoError(batch.Write())
+	require.NoError(db.awaitCompactions())
+	require.Len(baseDB.compactedRanges, 1)
+
+	// Ranges are compacted as soon as they are deleted.
+	require.NoError(db.DeleteRange(intermediateNodePrefix, []byte{3}))
+	require.NoError(db.awaitCompactions())
+	require.Equal([][2][]byte{{intermediateNodePrefix, {3}}}, baseDB.deletedRanges)
+	require.Equal([2][]byte{intermediateNodePrefix, {3}}, baseDB.compactedRanges[1])
+
+	has, err := db.Has([]byte{2, 9})
+	require.NoError(err)
+	require.False(has)
+}
+
+// blockingCompactDB blocks compactions until [unblock] is closed.
+type blockingCompactDB struct {
+	database.Database
+
+	unblock chan struct{}
+	err     error
+}
+
+func (db *blockingCompactDB) Compact([]byte, []byte) error {
+	<-db.unblock
+	return db.err
+}
+
+func TestCompactionHintDBCompactsInBackground(t *testing.T) {
+	require := require.New(t)
+
+	errCompact := errors.New("compaction failed")
+	baseDB := &blockingCompactDB{
+		Database: memdb.New(),
+		unblock:  make(chan struct{}),
+		err:      errCompact,
+	}
+	db := newCompactionHintDB(baseDB, 1)
+
+	// Writes aren't delayed by the compactions they schedule.
+	require.NoError(db.Delete([]byte{1, 0}))
+	require.NoError(db.Delete([]byte{2, 0}))
+	require.NoError(db.Put([]byte{1, 1}, nil))
+
+	close(baseDB.unblock)
+	require.ErrorIs(db.awaitCompactions(), errCompact)
+}
+
+func TestCompactionHintDBWithoutRangeDeletion(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := &testStorageDB{
+		Database: memdb.New(),
+	}
+	db := newCompactionHintDB(baseDB, 1)
+
+	_, ok := asRangeDeleter(db)
+	require.False(ok)
+	require.ErrorIs(db.DeleteRange([]byte{0}, []byte{1}), errRangeDeletionNotSupported)
+
+	require.NoError(db.Put([]byte{1, 0}, nil))
+	require.NoError(atomicClearPrefix(db, valueNodePrefix))
+	require.NoError(db.awaitCompactions())
+	require.Equal([][2][]byte{{{1, 0}, {1, 0, 0}}}, baseDB.compactedRanges)
+}
+
+func TestDiskSizeMetrics(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := &testStorageDB{
+		Database: memdb.New(),
+	}
+	require.NoError(baseDB.Put([]byte{1, 0}, []byte{0, 0}))
+	require.NoError(baseDB.Put([]byte{2, 0}, []byte{0, 0, 0, 0}))
+
+	reg := prometheus.NewRegistry()
+	require.NoError(registerDiskSizeMetrics("merkledb", reg, baseDB))
+
+	metrics, err := reg.Gather()
+	require.NoError(err)
+	require.Len(metrics, 1)
+
+	sizes := make(map[string]float64)
+	for _, metric := range metrics[0].GetMetric() {
+		label := metric.GetLabel()[0]
+		require.Equal(keySpaceType, label.GetName())
+		sizes[label.GetValue()] = metric.GetGauge().GetValue()
+	}
+	require.Equal(
+		map[string]float64{
+			metadataType:         0,
+			valueNodeType:        4,
+			intermediateNodeType: 6,
+			historyType:          0,
+		},
+		sizes,
+	)
+}
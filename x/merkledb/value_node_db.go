@@ -38,21 +38,26 @@ func newValueNodeDB(
 	db database.Database,
 	bufferPool *utils.BytesPool,
 	metrics metrics,
-	cacheSize int,
+	nodeCache cache.Cacher[Key, *node],
 	hasher Hasher,
 ) *valueNodeDB {
 	return &valueNodeDB{
 		metrics:    metrics,
 		baseDB:     db,
 		bufferPool: bufferPool,
-		nodeCache:  cache.NewSizedLRU(cacheSize, cacheEntrySize),
+		nodeCache:  nodeCache,
 		hasher:     hasher,
 	}
 }
 
 func (db *valueNodeDB) Write(batch database.KeyValueWriterDeleter, key Key, n *node) error {
-	db.metrics.DatabaseNodeWrite()
 	db.nodeCache.Put(key, n)
+	return db.addToBatch(batch, key, n)
+}
+
+// addToBatch writes [n] to [batch] without adding it to the cache.
+func (db *valueNodeDB) addToBatch(batch database.KeyValueWriterDeleter, key Key, n *node) error {
+	db.metrics.DatabaseNodeWrite()
 	prefixedKey := addPrefixToKey(db.bufferPool, valueNodePrefix, key.Bytes())
 	defer db.bufferPool.Put(prefixedKey)
 
@@ -103,7 +108,7 @@ func (db *valueNodeDB) Get(key Key) (*node, error) {
 
 func (db *valueNodeDB) Clear() error {
 	db.nodeCache.Flush()
-	return database.AtomicClearPrefix(db.baseDB, db.baseDB, valueNodePrefix)
+	return atomicClearPrefix(db.baseDB, valueNodePrefix)
 }
 
 type iterator struct {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/luxfi/database"
 	"github.com/luxfi/database/memdb"
+	"github.com/luxfi/node/cache"
 	"github.com/luxfi/node/utils"
 	"github.com/luxfi/node/utils/maybe"
 )
@@ -25,7 +26,7 @@ func TestValueNodeDB(t *testing.T) {
 		baseDB,
 		utils.NewBytesPool(),
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		DefaultHasher,
 	)
 
@@ -119,7 +120,7 @@ func TestValueNodeDBIterator(t *testing.T) {
 		baseDB,
 		utils.NewBytesPool(),
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		DefaultHasher,
 	)
 
@@ -225,7 +226,7 @@ func TestValueNodeDBClear(t *testing.T) {
 		baseDB,
 		utils.NewBytesPool(),
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		DefaultHasher,
 	)
 
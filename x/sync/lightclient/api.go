@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package lightclient fetches merkle proofs from the proof service of a
+// merkledb-backed chain and verifies them against a root the client trusts,
+// such as the state root in a block header, without syncing the chain's state.
+package lightclient
+
+import (
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/formatting"
+)
+
+// ServiceName is the name the proof service is registered under. Methods are
+// called as "merkledb.getProof", "merkledb.getRangeProof" and
+// "merkledb.getChangeProof".
+const ServiceName = "merkledb"
+
+// All byte fields of the arguments and replies are encoded with [Encoding].
+// Start and end keys are omitted if the range is unbounded in that direction.
+
+type GetProofArgs struct {
+	RootID   ids.ID              `json:"rootID"`
+	Key      string              `json:"key"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetProofReply contains a range proof for the range [Key, Key] at RootID,
+// proving either the value of Key or that Key isn't in the trie.
+type GetProofReply struct {
+	// Proof is a marshalled [pb.RangeProof].
+	Proof    string              `json:"proof"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+type GetRangeProofArgs struct {
+	RootID     ids.ID              `json:"rootID"`
+	StartKey   *string             `json:"startKey,omitempty"`
+	EndKey     *string             `json:"endKey,omitempty"`
+	KeyLimit   uint32              `json:"keyLimit"`
+	BytesLimit uint32              `json:"bytesLimit"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+type GetRangeProofReply struct {
+	// Proof is a marshalled [pb.RangeProof].
+	Proof    string              `json:"proof"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+type GetChangeProofArgs struct {
+	StartRootID ids.ID              `json:"startRootID"`
+	EndRootID   ids.ID              `json:"endRootID"`
+	StartKey    *string             `json:"startKey,omitempty"`
+	EndKey      *string             `json:"endKey,omitempty"`
+	KeyLimit    uint32              `json:"keyLimit"`
+	BytesLimit  uint32              `json:"bytesLimit"`
+	Encoding    formatting.Encoding `json:"encoding"`
+}
+
+type GetChangeProofReply struct {
+	// Proof is a marshalled [pb.SyncGetChangeProofResponse]. If the server
+	// didn't have sufficient history to generate a change proof, it contains
+	// a range proof for [EndRootID] instead.
+	Proof    string              `json:"proof"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
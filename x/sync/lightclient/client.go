@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lightclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/formatting"
+	"github.com/luxfi/node/utils/maybe"
+	"github.com/luxfi/node/utils/rpc"
+	"github.com/luxfi/node/x/merkledb"
+
+	pb "github.com/luxfi/node/proto/pb/sync"
+)
+
+var (
+	_ Client = (*client)(nil)
+
+	errUnexpectedChangeProofResponse = errors.New("unexpected response type")
+)
+
+// Client fetches proofs from a proof service. The returned proofs are not
+// verified; use a [Verifier] to check them against a trusted root.
+type Client interface {
+	// GetProof returns a proof of the value of [key] in the trie with root
+	// [rootID], or that [key] isn't in that trie.
+	GetProof(
+		ctx context.Context,
+		rootID ids.ID,
+		key []byte,
+		options ...rpc.Option,
+	) (*merkledb.RangeProof, error)
+	// GetRangeProof returns a proof of the key-value pairs in [start, end] in
+	// the trie with root [rootID]. The proof may only cover a prefix of the
+	// range if the range doesn't fit within the limits.
+	GetRangeProof(
+		ctx context.Context,
+		rootID ids.ID,
+		start maybe.Maybe[[]byte],
+		end maybe.Maybe[[]byte],
+		keyLimit uint32,
+		bytesLimit uint32,
+		options ...rpc.Option,
+	) (*merkledb.RangeProof, error)
+	// GetChangeProof returns a proof of the changes to the key-value pairs in
+	// [start, end] between the tries with roots [startRootID] and
+	// [endRootID], or a range proof for [endRootID] if the server doesn't
+	// have sufficient history.
+	GetChangeProof(
+		ctx context.Context,
+		startRootID ids.ID,
+		endRootID ids.ID,
+		start maybe.Maybe[[]byte],
+		end maybe.Maybe[[]byte],
+		keyLimit uint32,
+		bytesLimit uint32,
+		options ...rpc.Option,
+	) (*merkledb.ChangeOrRangeProof, error)
+}
+
+// NewClient returns a client for the proof service served at [uri], for
+// example "http://localhost:9650/ext/bc/<chainID>/proofs".
+func NewClient(uri string) Client {
+	return &client{
+		req: rpc.NewEndpointRequester(uri),
+	}
+}
+
+type client struct {
+	req rpc.EndpointRequester
+}
+
+func (c *client) GetProof(
+	ctx context.Context,
+	rootID ids.ID,
+	key []byte,
+	options ...rpc.Option,
+) (*merkledb.RangeProof, error) {
+	keyStr, err := formatting.Encode(formatting.HexNC, key)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &GetProofReply{}
+	err = c.req.SendRequest(
+		ctx,
+		ServiceName+".getProof",
+		&GetProofArgs{
+			RootID:   rootID,
+			Key:      keyStr,
+			Encoding: formatting.HexNC,
+		},
+		resp,
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return parseRangeProof(resp.Encoding, resp.Proof)
+}
+
+func (c *client) GetRangeProof(
+	ctx context.Context,
+	rootID ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	keyLimit uint32,
+	bytesLimit uint32,
+	options ...rpc.Option,
+) (*merkledb.RangeProof, error) {
+	startKey, err := encodeMaybeKey(start)
+	if err != nil {
+		return nil, err
+	}
+	endKey, err := encodeMaybeKey(end)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &GetRangeProofReply{}
+	err = c.req.SendRequest(
+		ctx,
+		ServiceName+".getRangeProof",
+		&GetRangeProofArgs{
+			RootID:     rootID,
+			StartKey:   startKey,
+			EndKey:     endKey,
+			KeyLimit:   keyLimit,
+			BytesLimit: bytesLimit,
+			Encoding:   formatting.HexNC,
+		},
+		resp,
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return parseRangeProof(resp.Encoding, resp.Proof)
+}
+
+func (c *client) GetChangeProof(
+	ctx context.Context,
+	startRootID ids.ID,
+	endRootID ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	keyLimit uint32,
+	bytesLimit uint32,
+	options ...rpc.Option,
+) (*merkledb.ChangeOrRangeProof, error) {
+	startKey, err := encodeMaybeKey(start)
+	if err != nil {
+		return nil, err
+	}
+	endKey, err := encodeMaybeKey(end)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &GetChangeProofReply{}
+	err = c.req.SendRequest(
+		ctx,
+		ServiceName+".getChangeProof",
+		&GetChangeProofArgs{
+			StartRootID: startRootID,
+			EndRootID:   endRootID,
+			StartKey:    startKey,
+			EndKey:      endKey,
+			KeyLimit:    keyLimit,
+			BytesLimit:  bytesLimit,
+			Encoding:    formatting.HexNC,
+		},
+		resp,
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	proofBytes, err := formatting.Decode(resp.Encoding, resp.Proof)
+	if err != nil {
+		return nil, err
+	}
+	var changeProofResp pb.SyncGetChangeProofResponse
+	if err := proto.Unmarshal(proofBytes, &changeProofResp); err != nil {
+		return nil, err
+	}
+
+	switch changeProofResp := changeProofResp.Response.(type) {
+	case *pb.SyncGetChangeProofResponse_ChangeProof:
+		var changeProof merkledb.ChangeProof
+		if err := changeProof.UnmarshalProto(changeProofResp.ChangeProof); err != nil {
+			return nil, err
+		}
+		return &merkledb.ChangeOrRangeProof{
+			ChangeProof: &changeProof,
+		}, nil
+	case *pb.SyncGetChangeProofResponse_RangeProof:
+		var rangeProof merkledb.RangeProof
+		if err := rangeProof.UnmarshalProto(changeProofResp.RangeProof); err != nil {
+			return nil, err
+		}
+		return &merkledb.ChangeOrRangeProof{
+			RangeProof: &rangeProof,
+		}, nil
+	default:
+		return nil, fmt.Errorf(
+			"%w: %T",
+			errUnexpectedChangeProofResponse, changeProofResp,
+		)
+	}
+}
+
+func encodeMaybeKey(key maybe.Maybe[[]byte]) (*string, error) {
+	if key.IsNothing() {
+		return nil, nil
+	}
+	keyStr, err := formatting.Encode(formatting.HexNC, key.Value())
+	return &keyStr, err
+}
+
+func parseRangeProof(encoding formatting.Encoding, proofStr string) (*merkledb.RangeProof, error) {
+	proofBytes, err := formatting.Decode(encoding, proofStr)
+	if err != nil {
+		return nil, err
+	}
+	var pbProof pb.RangeProof
+	if err := proto.Unmarshal(proofBytes, &pbProof); err != nil {
+		return nil, err
+	}
+	var proof merkledb.RangeProof
+	return &proof, proof.UnmarshalProto(&pbProof)
+}
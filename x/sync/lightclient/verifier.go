@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lightclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/maybe"
+	"github.com/luxfi/node/x/merkledb"
+)
+
+var (
+	ErrInvalidProof = errors.New("invalid proof")
+
+	errTooManyKeys      = errors.New("response contains more than requested keys")
+	errEmptyChangeProof = errors.New("response contains neither a change proof nor a range proof")
+	errNoChangeProofer  = errors.New("verifying a change proof requires the trie at the start root")
+	errInvalidKeyLimit  = errors.New("key limit must be greater than 0")
+	errUnexpectedKey    = errors.New("proof contains an unexpected key")
+)
+
+// Verifier checks proofs against roots the client trusts. It must be
+// configured with the same branch factor and hasher as the trie the proofs
+// were generated from.
+type Verifier struct {
+	tokenSize int
+	hasher    merkledb.Hasher
+}
+
+// NewVerifier returns a verifier for tries with [branchFactor] and [hasher].
+// If [hasher] is nil, [merkledb.DefaultHasher] is used.
+func NewVerifier(branchFactor merkledb.BranchFactor, hasher merkledb.Hasher) (*Verifier, error) {
+	if err := branchFactor.Valid(); err != nil {
+		return nil, err
+	}
+	if hasher == nil {
+		hasher = merkledb.DefaultHasher
+	}
+	return &Verifier{
+		tokenSize: merkledb.BranchFactorToTokenSize[branchFactor],
+		hasher:    hasher,
+	}, nil
+}
+
+// VerifyValue verifies that [proof], as returned by [Client.GetProof], proves
+// the value of [key] in the trie with root [rootID]. Returns the value of
+// [key], or Nothing if [key] isn't in the trie.
+func (v *Verifier) VerifyValue(
+	ctx context.Context,
+	rootID ids.ID,
+	key []byte,
+	proof *merkledb.RangeProof,
+) (maybe.Maybe[[]byte], error) {
+	keyBound := maybe.Some(key)
+	if err := v.VerifyRange(ctx, rootID, keyBound, keyBound, 1, proof); err != nil {
+		return maybe.Nothing[[]byte](), err
+	}
+	if len(proof.KeyValues) == 0 {
+		return maybe.Nothing[[]byte](), nil
+	}
+
+	// [VerifyRange] guarantees the only key is in [key, key].
+	kv := proof.KeyValues[0]
+	if !bytes.Equal(kv.Key, key) {
+		return maybe.Nothing[[]byte](), fmt.Errorf("%w: %w", ErrInvalidProof, errUnexpectedKey)
+	}
+	return maybe.Some(kv.Value), nil
+}
+
+// VerifyRange verifies that [proof] proves the key-value pairs it contains are
+// all of the key-value pairs in the trie with root [rootID] from [start] up to
+// its largest key, or [end] if it covers the whole range, and that it
+// contains at most [keyLimit] key-value pairs.
+func (v *Verifier) VerifyRange(
+	ctx context.Context,
+	rootID ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	keyLimit int,
+	proof *merkledb.RangeProof,
+) error {
+	if keyLimit <= 0 {
+		return errInvalidKeyLimit
+	}
+	if len(proof.KeyValues) > keyLimit {
+		return fmt.Errorf(
+			"%w: %w: (%d) > %d",
+			ErrInvalidProof, errTooManyKeys, len(proof.KeyValues), keyLimit,
+		)
+	}
+	if err := proof.Verify(ctx, start, end, rootID, v.tokenSize, v.hasher); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	return nil
+}
+
+// VerifyChange verifies [proof], as returned by [Client.GetChangeProof].
+//
+// A range proof is verified against [endRootID]. A change proof can only be
+// verified by applying it to the trie at the start root, so [db] must have
+// that root and may only be nil if the proof is a range proof.
+func (v *Verifier) VerifyChange(
+	ctx context.Context,
+	db merkledb.ChangeProofer,
+	endRootID ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	keyLimit int,
+	proof *merkledb.ChangeOrRangeProof,
+) error {
+	switch {
+	case proof.RangeProof != nil:
+		return v.VerifyRange(ctx, endRootID, start, end, keyLimit, proof.RangeProof)
+	case proof.ChangeProof == nil:
+		return fmt.Errorf("%w: %w", ErrInvalidProof, errEmptyChangeProof)
+	case db == nil:
+		return errNoChangeProofer
+	case keyLimit <= 0:
+		return errInvalidKeyLimit
+	case len(proof.ChangeProof.KeyChanges) > keyLimit:
+		return fmt.Errorf(
+			"%w: %w: (%d) > %d",
+			ErrInvalidProof, errTooManyKeys, len(proof.ChangeProof.KeyChanges), keyLimit,
+		)
+	}
+	if err := db.VerifyChangeProof(ctx, proof.ChangeProof, start, end, endRootID); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lightclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database/memdb"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/maybe"
+	"github.com/luxfi/node/x/merkledb"
+	"github.com/luxfi/trace"
+)
+
+func newTestDB(t *testing.T) merkledb.MerkleDB {
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		merkledb.Config{
+			BranchFactor:                merkledb.BranchFactor16,
+			Hasher:                      merkledb.DefaultHasher,
+			HistoryLength:               100,
+			ValueNodeCacheSize:          1024,
+			IntermediateNodeCacheSize:   1024,
+			IntermediateWriteBufferSize: 1024,
+			IntermediateWriteBatchSize:  100,
+			Tracer:                      trace.Noop,
+		},
+	)
+	require.NoError(t, err)
+	return db
+}
+
+func TestVerifyValue(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db := newTestDB(t)
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+	require.NoError(db.Put([]byte("key2"), []byte("value2")))
+	rootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	verifier, err := NewVerifier(merkledb.BranchFactor16, nil)
+	require.NoError(err)
+
+	getProof := func(key []byte) *merkledb.RangeProof {
+		proof, err := db.GetRangeProofAtRoot(ctx, rootID, maybe.Some(key), maybe.Some(key), 1)
+		require.NoError(err)
+		return proof
+	}
+
+	proof := getProof([]byte("key1"))
+	value, err := verifier.VerifyValue(ctx, rootID, []byte("key1"), proof)
+	require.NoError(err)
+	require.Equal(maybe.Some([]byte("value1")), value)
+
+	proof = getProof([]byte("key3"))
+	value, err = verifier.VerifyValue(ctx, rootID, []byte("key3"), proof)
+	require.NoError(err)
+	require.True(value.IsNothing())
+
+	// A proof for one key doesn't prove the value of another key.
+	proof = getProof([]byte("key1"))
+	_, err = verifier.VerifyValue(ctx, rootID, []byte("key2"), proof)
+	require.ErrorIs(err, ErrInvalidProof)
+
+	// A proof can't be verified against a different root.
+	_, err = verifier.VerifyValue(ctx, ids.GenerateTestID(), []byte("key1"), proof)
+	require.ErrorIs(err, ErrInvalidProof)
+
+	// A tampered value fails verification.
+	proof.KeyValues[0].Value = []byte("value2")
+	_, err = verifier.VerifyValue(ctx, rootID, []byte("key1"), proof)
+	require.ErrorIs(err, ErrInvalidProof)
+}
+
+func TestVerifyValueAtHistoricalRoot(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db := newTestDB(t)
+	require.NoError(db.Put([]byte("key"), []byte("old")))
+	oldRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("new")))
+
+	verifier, err := NewVerifier(merkledb.BranchFactor16, merkledb.DefaultHasher)
+	require.NoError(err)
+
+	key := maybe.Some([]byte("key"))
+	proof, err := db.GetRangeProofAtRoot(ctx, oldRootID, key, key, 1)
+	require.NoError(err)
+
+	value, err := verifier.VerifyValue(ctx, oldRootID, []byte("key"), proof)
+	require.NoError(err)
+	require.Equal(maybe.Some([]byte("old")), value)
+}
+
+func TestVerifyRange(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db := newTestDB(t)
+	for _, key := range []string{"a", "b", "c", "d"} {
+		require.NoError(db.Put([]byte(key), []byte(key)))
+	}
+	rootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	verifier, err := NewVerifier(merkledb.BranchFactor16, nil)
+	require.NoError(err)
+
+	start := maybe.Some([]byte("b"))
+	end := maybe.Nothing[[]byte]()
+	proof, err := db.GetRangeProofAtRoot(ctx, rootID, start, end, 2)
+	require.NoError(err)
+	require.Len(proof.KeyValues, 2)
+
+	require.NoError(verifier.VerifyRange(ctx, rootID, start, end, 2, proof))
+	require.ErrorIs(verifier.VerifyRange(ctx, rootID, start, end, 1, proof), ErrInvalidProof)
+	require.ErrorIs(verifier.VerifyRange(ctx, rootID, start, end, 0, proof), errInvalidKeyLimit)
+
+	// Dropping a key from the proof fails verification.
+	proof.KeyValues = proof.KeyValues[1:]
+	require.ErrorIs(verifier.VerifyRange(ctx, rootID, start, end, 2, proof), ErrInvalidProof)
+}
+
+func TestVerifyChange(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db := newTestDB(t)
+	require.NoError(db.Put([]byte("a"), []byte("a")))
+	startRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	// [clientDB] has the trie at [startRootID].
+	clientDB := newTestDB(t)
+	require.NoError(clientDB.Put([]byte("a"), []byte("a")))
+
+	require.NoError(db.Put([]byte("b"), []byte("b")))
+	require.NoError(db.Delete([]byte("a")))
+	endRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	verifier, err := NewVerifier(merkledb.BranchFactor16, nil)
+	require.NoError(err)
+
+	var (
+		start = maybe.Nothing[[]byte]()
+		end   = maybe.Nothing[[]byte]()
+	)
+	changeProof, err := db.GetChangeProof(ctx, startRootID, endRootID, start, end, 10)
+	require.NoError(err)
+	proof := &merkledb.ChangeOrRangeProof{
+		ChangeProof: changeProof,
+	}
+	require.NoError(verifier.VerifyChange(ctx, clientDB, endRootID, start, end, 10, proof))
+	require.ErrorIs(verifier.VerifyChange(ctx, nil, endRootID, start, end, 10, proof), errNoChangeProofer)
+	require.ErrorIs(verifier.VerifyChange(ctx, clientDB, endRootID, start, end, 1, proof), ErrInvalidProof)
+	require.ErrorIs(verifier.VerifyChange(ctx, clientDB, ids.GenerateTestID(), start, end, 10, proof), ErrInvalidProof)
+
+	// Range proofs sent in place of change proofs don't require the trie at
+	// the start root.
+	rangeProof, err := db.GetRangeProofAtRoot(ctx, endRootID, start, end, 10)
+	require.NoError(err)
+	proof = &merkledb.ChangeOrRangeProof{
+		RangeProof: rangeProof,
+	}
+	require.NoError(verifier.VerifyChange(ctx, nil, endRootID, start, end, 10, proof))
+
+	err = verifier.VerifyChange(ctx, nil, endRootID, start, end, 10, &merkledb.ChangeOrRangeProof{})
+	require.ErrorIs(err, ErrInvalidProof)
+}
+
+func TestNewVerifierInvalidBranchFactor(t *testing.T) {
+	_, err := NewVerifier(merkledb.BranchFactor(3), nil)
+	require.ErrorIs(t, err, merkledb.ErrInvalidBranchFactor)
+}
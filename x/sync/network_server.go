@@ -174,42 +174,79 @@ func (s *NetworkServer) HandleChangeProofRequest(
 	}
 
 	// override limits if they exceed caps
+	req.KeyLimit = min(req.KeyLimit, maxKeyValuesLimit)
+	req.BytesLimit = min(req.BytesLimit, maxByteSizeLimit)
+
+	proofBytes, err := getChangeProof(ctx, s.db, req)
+	if err != nil {
+		if errors.Is(err, merkledb.ErrNoEndRoot) {
+			// [s.db] doesn't have [endRoot] in its history.
+			// We can't generate a change/range proof. Drop this request.
+			return nil
+		}
+		return err
+	}
+	if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
+		s.log.Error(
+			"failed to send app response",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Int("responseLen", len(proofBytes)),
+			zap.Error(err),
+		)
+		return fmt.Errorf("%w: %w", errAppSendFailed, err)
+	}
+	return nil
+}
+
+// Get the change proof specified by [req], marshalled as a
+// [pb.SyncGetChangeProofResponse].
+// If [db] doesn't have sufficient history to generate the change proof, a
+// range proof for the end root is returned instead.
+// If the generated proof is too large, the key limit is reduced
+// and the proof is regenerated. This process is repeated until
+// the proof is smaller than [req.BytesLimit].
+// Returns [merkledb.ErrNoEndRoot] if [db] doesn't have the end root in its
+// history.
+// If no sufficiently small proof can be generated, returns [ErrMinProofSizeIsTooLarge].
+func getChangeProof(
+	ctx context.Context,
+	db DB,
+	req *pb.SyncGetChangeProofRequest,
+) ([]byte, error) {
 	var (
-		keyLimit   = min(req.KeyLimit, maxKeyValuesLimit)
-		bytesLimit = min(int(req.BytesLimit), maxByteSizeLimit)
-		start      = maybeBytesToMaybe(req.StartKey)
-		end        = maybeBytesToMaybe(req.EndKey)
+		keyLimit = req.KeyLimit
+		start    = maybeBytesToMaybe(req.StartKey)
+		end      = maybeBytesToMaybe(req.EndKey)
 	)
 
 	startRoot, err := ids.ToID(req.StartRootHash)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	endRoot, err := ids.ToID(req.EndRootHash)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for keyLimit > 0 {
-		changeProof, err := s.db.GetChangeProof(ctx, startRoot, endRoot, start, end, int(keyLimit))
+		changeProof, err := db.GetChangeProof(ctx, startRoot, endRoot, start, end, int(keyLimit))
 		if err != nil {
 			if !errors.Is(err, merkledb.ErrInsufficientHistory) {
 				// We should only fail to get a change proof if we have insufficient history.
 				// Other errors are unexpected.
-				return err
+				return nil, err
 			}
 			if errors.Is(err, merkledb.ErrNoEndRoot) {
-				// [s.db] doesn't have [endRoot] in its history.
-				// We can't generate a change/range proof. Drop this request.
-				return nil
+				return nil, err
 			}
 
-			// [s.db] doesn't have sufficient history to generate change proof.
+			// [db] doesn't have sufficient history to generate change proof.
 			// Generate a range proof for the end root ID instead.
-			proofBytes, err := getRangeProof(
+			return getRangeProof(
 				ctx,
-				s.db,
+				db,
 				&pb.SyncGetRangeProofRequest{
 					RootHash:   req.EndRootHash,
 					StartKey:   req.StartKey,
@@ -225,21 +262,6 @@ func (s *NetworkServer) HandleChangeProofRequest(
 					})
 				},
 			)
-			if err != nil {
-				return err
-			}
-
-			if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
-				s.log.Error(
-					"failed to send app response",
-					zap.Stringer("nodeID", nodeID),
-					zap.Uint32("requestID", requestID),
-					zap.Int("responseLen", len(proofBytes)),
-					zap.Error(err),
-				)
-				return fmt.Errorf("%w: %w", errAppSendFailed, err)
-			}
-			return nil
 		}
 
 		// We generated a change proof. See if it's small enough.
@@ -249,27 +271,17 @@ func (s *NetworkServer) HandleChangeProofRequest(
 			},
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		if len(proofBytes) < bytesLimit {
-			if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
-				s.log.Error(
-					"failed to send app response",
-					zap.Stringer("nodeID", nodeID),
-					zap.Uint32("requestID", requestID),
-					zap.Int("responseLen", len(proofBytes)),
-					zap.Error(err),
-				)
-				return fmt.Errorf("%w: %w", errAppSendFailed, err)
-			}
-			return nil
+		if len(proofBytes) < int(req.BytesLimit) {
+			return proofBytes, nil
 		}
 
 		// The proof was too large. Try to shrink it.
 		keyLimit = uint32(len(changeProof.KeyChanges)) / 2
 	}
-	return ErrMinProofSizeIsTooLarge
+	return nil, ErrMinProofSizeIsTooLarge
 }
 
 // Generates a range proof and sends it to [nodeID].
@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"net/http"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/luxfi/node/utils/formatting"
+	"github.com/luxfi/node/utils/json"
+	"github.com/luxfi/node/x/merkledb"
+	"github.com/luxfi/node/x/sync/lightclient"
+
+	pb "github.com/luxfi/node/proto/pb/sync"
+)
+
+// ProofService serves merkle proofs over HTTP, so that light clients can
+// verify values against a root they obtained elsewhere, such as from a block
+// header. Requests are subject to the same limits as [NetworkServer].
+//
+// A VM backed by merkledb can serve proofs by including the handler returned
+// by [NewProofHandler] in the result of CreateHandlers:
+//
+//	handler, err := sync.NewProofHandler(vm.db)
+//	if err != nil {
+//		return nil, err
+//	}
+//	return map[string]http.Handler{"/proofs": handler}, nil
+//
+// [lightclient.Client] can be used to query the service.
+type ProofService struct {
+	db DB
+}
+
+// NewProofHandler returns an HTTP handler serving the [ProofService] for [db].
+func NewProofHandler(db DB) (http.Handler, error) {
+	server := json.NewServer()
+	server.RegisterCodec(json.NewCodec(), "application/json")
+	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
+	return server, server.RegisterService(
+		&ProofService{db: db},
+		lightclient.ServiceName,
+	)
+}
+
+// GetProof returns a proof of the value of a key, or that the key isn't in
+// the trie, at the requested root.
+//
+// The proof is a range proof bounded by the key rather than a
+// [merkledb.Proof], so that it can be generated at any root in the history of
+// the trie rather than only the current root.
+func (s *ProofService) GetProof(r *http.Request, args *lightclient.GetProofArgs, reply *lightclient.GetProofReply) error {
+	key, err := formatting.Decode(args.Encoding, args.Key)
+	if err != nil {
+		return err
+	}
+
+	keyBound := &pb.MaybeBytes{Value: key}
+	req := &pb.SyncGetRangeProofRequest{
+		RootHash:   args.RootID[:],
+		StartKey:   keyBound,
+		EndKey:     keyBound,
+		KeyLimit:   1,
+		BytesLimit: maxByteSizeLimit,
+	}
+	if err := validateRangeProofRequest(req); err != nil {
+		return err
+	}
+
+	proofBytes, err := getRangeProof(r.Context(), s.db, req, marshalRangeProof)
+	if err != nil {
+		return err
+	}
+	if proofBytes == nil {
+		return merkledb.ErrInsufficientHistory
+	}
+
+	reply.Encoding = args.Encoding
+	reply.Proof, err = formatting.Encode(args.Encoding, proofBytes)
+	return err
+}
+
+// GetRangeProof returns a proof of the key-value pairs in a range at the
+// requested root.
+func (s *ProofService) GetRangeProof(r *http.Request, args *lightclient.GetRangeProofArgs, reply *lightclient.GetRangeProofReply) error {
+	startKey, err := decodeMaybeKey(args.Encoding, args.StartKey)
+	if err != nil {
+		return err
+	}
+	endKey, err := decodeMaybeKey(args.Encoding, args.EndKey)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.SyncGetRangeProofRequest{
+		RootHash:   args.RootID[:],
+		StartKey:   startKey,
+		EndKey:     endKey,
+		KeyLimit:   args.KeyLimit,
+		BytesLimit: args.BytesLimit,
+	}
+	if err := validateRangeProofRequest(req); err != nil {
+		return err
+	}
+
+	// override limits if they exceed caps
+	req.KeyLimit = min(req.KeyLimit, maxKeyValuesLimit)
+	req.BytesLimit = min(req.BytesLimit, maxByteSizeLimit)
+
+	proofBytes, err := getRangeProof(r.Context(), s.db, req, marshalRangeProof)
+	if err != nil {
+		return err
+	}
+	if proofBytes == nil {
+		return merkledb.ErrInsufficientHistory
+	}
+
+	reply.Encoding = args.Encoding
+	reply.Proof, err = formatting.Encode(args.Encoding, proofBytes)
+	return err
+}
+
+// GetChangeProof returns a proof of the changes to the key-value pairs in a
+// range between two roots. If there is insufficient history to generate a
+// change proof, a range proof for the end root is returned instead.
+func (s *ProofService) GetChangeProof(r *http.Request, args *lightclient.GetChangeProofArgs, reply *lightclient.GetChangeProofReply) error {
+	startKey, err := decodeMaybeKey(args.Encoding, args.StartKey)
+	if err != nil {
+		return err
+	}
+	endKey, err := decodeMaybeKey(args.Encoding, args.EndKey)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.SyncGetChangeProofRequest{
+		StartRootHash: args.StartRootID[:],
+		EndRootHash:   args.EndRootID[:],
+		StartKey:      startKey,
+		EndKey:        endKey,
+		KeyLimit:      args.KeyLimit,
+		BytesLimit:    args.BytesLimit,
+	}
+	if err := validateChangeProofRequest(req); err != nil {
+		return err
+	}
+
+	// override limits if they exceed caps
+	req.KeyLimit = min(req.KeyLimit, maxKeyValuesLimit)
+	req.BytesLimit = min(req.BytesLimit, maxByteSizeLimit)
+
+	proofBytes, err := getChangeProof(r.Context(), s.db, req)
+	if err != nil {
+		return err
+	}
+	if proofBytes == nil {
+		return merkledb.ErrInsufficientHistory
+	}
+
+	reply.Encoding = args.Encoding
+	reply.Proof, err = formatting.Encode(args.Encoding, proofBytes)
+	return err
+}
+
+func marshalRangeProof(rangeProof *merkledb.RangeProof) ([]byte, error) {
+	return proto.Marshal(rangeProof.ToProto())
+}
+
+// decodeMaybeKey returns nil, which is treated as Nothing, if [key] is nil.
+func decodeMaybeKey(encoding formatting.Encoding, key *string) (*pb.MaybeBytes, error) {
+	if key == nil {
+		return nil, nil
+	}
+	keyBytes, err := formatting.Decode(encoding, *key)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.MaybeBytes{Value: keyBytes}, nil
+}
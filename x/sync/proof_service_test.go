@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2024, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/database/memdb"
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/maybe"
+	"github.com/luxfi/node/x/merkledb"
+	"github.com/luxfi/node/x/sync/lightclient"
+)
+
+func newProofServiceClient(t *testing.T, db DB) lightclient.Client {
+	handler, err := NewProofHandler(db)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return lightclient.NewClient(server.URL)
+}
+
+func TestProofServiceGetProof(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db, err := merkledb.New(ctx, memdb.New(), newDefaultDBConfig())
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("old")))
+	oldRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("new")))
+	newRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	client := newProofServiceClient(t, db)
+	verifier, err := lightclient.NewVerifier(merkledb.BranchFactor16, merkledb.DefaultHasher)
+	require.NoError(err)
+
+	for rootID, expectedValue := range map[ids.ID]string{
+		oldRootID: "old",
+		newRootID: "new",
+	} {
+		proof, err := client.GetProof(ctx, rootID, []byte("key"))
+		require.NoError(err)
+
+		value, err := verifier.VerifyValue(ctx, rootID, []byte("key"), proof)
+		require.NoError(err)
+		require.Equal(maybe.Some([]byte(expectedValue)), value)
+	}
+
+	proof, err := client.GetProof(ctx, newRootID, []byte("missing"))
+	require.NoError(err)
+	value, err := verifier.VerifyValue(ctx, newRootID, []byte("missing"), proof)
+	require.NoError(err)
+	require.True(value.IsNothing())
+
+	_, err = client.GetProof(ctx, ids.GenerateTestID(), []byte("key"))
+	require.ErrorContains(err, merkledb.ErrInsufficientHistory.Error())
+
+	_, err = client.GetProof(ctx, ids.Empty, []byte("key"))
+	require.ErrorContains(err, merkledb.ErrEmptyProof.Error())
+}
+
+func TestProofServiceGetRangeProof(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db, err := merkledb.New(ctx, memdb.New(), newDefaultDBConfig())
+	require.NoError(err)
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(db.Put([]byte(key), []byte(key)))
+	}
+	rootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	client := newProofServiceClient(t, db)
+	verifier, err := lightclient.NewVerifier(merkledb.BranchFactor16, nil)
+	require.NoError(err)
+
+	var (
+		start = maybe.Some([]byte("b"))
+		end   = maybe.Nothing[[]byte]()
+	)
+	proof, err := client.GetRangeProof(ctx, rootID, start, end, 10, 1024)
+	require.NoError(err)
+	require.Len(proof.KeyValues, 2)
+	require.NoError(verifier.VerifyRange(ctx, rootID, start, end, 10, proof))
+
+	// Limits are capped at the limits of [NetworkServer].
+	proof, err = client.GetRangeProof(ctx, rootID, maybe.Nothing[[]byte](), end, 2*maxKeyValuesLimit, 2*maxByteSizeLimit)
+	require.NoError(err)
+	require.Len(proof.KeyValues, 3)
+
+	_, err = client.GetRangeProof(ctx, rootID, maybe.Some([]byte("c")), maybe.Some([]byte("a")), 10, 1024)
+	require.ErrorContains(err, errInvalidBounds.Error())
+
+	_, err = client.GetRangeProof(ctx, rootID, start, end, 0, 1024)
+	require.ErrorContains(err, errInvalidKeyLimit.Error())
+}
+
+func TestProofServiceGetChangeProof(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db, err := merkledb.New(ctx, memdb.New(), newDefaultDBConfig())
+	require.NoError(err)
+	require.NoError(db.Put([]byte("a"), []byte("a")))
+	startRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	clientDB, err := merkledb.New(ctx, memdb.New(), newDefaultDBConfig())
+	require.NoError(err)
+	require.NoError(clientDB.Put([]byte("a"), []byte("a")))
+
+	require.NoError(db.Put([]byte("b"), []byte("b")))
+	endRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	client := newProofServiceClient(t, db)
+	verifier, err := lightclient.NewVerifier(merkledb.BranchFactor16, nil)
+	require.NoError(err)
+
+	var (
+		start = maybe.Nothing[[]byte]()
+		end   = maybe.Nothing[[]byte]()
+	)
+	proof, err := client.GetChangeProof(ctx, startRootID, endRootID, start, end, 10, 1024)
+	require.NoError(err)
+	require.NotNil(proof.ChangeProof)
+	require.NoError(verifier.VerifyChange(ctx, clientDB, endRootID, start, end, 10, proof))
+
+	// Without history for the start root, a range proof is returned.
+	proof, err = client.GetChangeProof(ctx, ids.GenerateTestID(), endRootID, start, end, 10, 1024)
+	require.NoError(err)
+	require.NotNil(proof.RangeProof)
+	require.NoError(verifier.VerifyChange(ctx, nil, endRootID, start, end, 10, proof))
+
+	_, err = client.GetChangeProof(ctx, startRootID, ids.GenerateTestID(), start, end, 10, 1024)
+	require.ErrorContains(err, merkledb.ErrNoEndRoot.Error())
+}